@@ -1,6 +1,7 @@
 package arrutil_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/gookit/goutil/arrutil"
@@ -88,7 +89,42 @@ func TestUnique(t *testing.T) {
 	assert.Eq(t, []int{2, 3, 4}, arrutil.Unique[int]([]int{2, 3, 2, 4}))
 	assert.Eq(t, []uint{2, 3, 4}, arrutil.Unique([]uint{2, 3, 2, 4}))
 	assert.Eq(t, []string{"ab", "bc", "cd"}, arrutil.Unique([]string{"ab", "bc", "ab", "cd"}))
+	assert.Eq(t, []bool{true, false}, arrutil.Unique([]bool{true, false, true}))
+
+	type point struct{ X, Y int }
+	assert.Eq(t, []point{{1, 2}, {2, 1}}, arrutil.Unique([]point{{1, 2}, {2, 1}, {1, 2}}))
 
 	assert.Eq(t, 1, arrutil.IndexOf(3, []int{2, 3, 4}))
 	assert.Eq(t, -1, arrutil.IndexOf(5, []int{2, 3, 4}))
 }
+
+func TestUniqueBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	users := []user{{1, "tom"}, {2, "john"}, {1, "tom2"}, {3, "tom"}}
+	assert.Eq(t, []user{{1, "tom"}, {2, "john"}, {3, "tom"}}, arrutil.UniqueBy(users, func(u user) int { return u.ID }))
+	assert.Eq(t, []user{{1, "tom"}, {2, "john"}, {1, "tom2"}}, arrutil.UniqueBy(users, func(u user) string { return u.Name }))
+	assert.Empty(t, arrutil.UniqueBy(nil, func(u user) int { return u.ID }))
+}
+
+func TestLastIndexOf(t *testing.T) {
+	assert.Eq(t, 3, arrutil.LastIndexOf(3, []int{2, 3, 4, 3}))
+	assert.Eq(t, -1, arrutil.LastIndexOf("a", []string{"b"}))
+}
+
+func TestIndexOfFunc(t *testing.T) {
+	ls := []string{"ab", "cd", "abc", "d"}
+	hasA := func(s string) bool { return strings.Contains(s, "a") }
+
+	assert.Eq(t, 0, arrutil.IndexOfFunc(ls, hasA))
+	assert.Eq(t, 2, arrutil.LastIndexOfFunc(ls, hasA))
+	assert.True(t, arrutil.ContainsFunc(ls, hasA))
+
+	isEmpty := func(s string) bool { return s == "" }
+	assert.Eq(t, -1, arrutil.IndexOfFunc(ls, isEmpty))
+	assert.Eq(t, -1, arrutil.LastIndexOfFunc(ls, isEmpty))
+	assert.False(t, arrutil.ContainsFunc(ls, isEmpty))
+}
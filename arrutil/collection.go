@@ -336,3 +336,32 @@ func ExceptWhile[T any](data []T, fn Predicate[T]) []T {
 	}
 	return result
 }
+
+// GroupBy group the elements by the key selector, the order of elements in each group is kept.
+//
+// Usage:
+//
+//	// output: map[string][]User{"admin": {...}, "guest": {...}}
+//	groups := arrutil.GroupBy(users, func(u User) string { return u.Role })
+func GroupBy[T any, K comparable](list []T, key func(v T) K) map[K][]T {
+	mp := make(map[K][]T)
+	for _, v := range list {
+		k := key(v)
+		mp[k] = append(mp[k], v)
+	}
+	return mp
+}
+
+// KeyBy index the elements by the key selector, the later element will overwrite the earlier one on key is duplicated.
+//
+// Usage:
+//
+//	// output: map[int]User{1: {...}, 2: {...}}
+//	userMap := arrutil.KeyBy(users, func(u User) int { return u.ID })
+func KeyBy[T any, K comparable](list []T, key func(v T) K) map[K]T {
+	mp := make(map[K]T, len(list))
+	for _, v := range list {
+		mp[key(v)] = v
+	}
+	return mp
+}
@@ -194,3 +194,24 @@ func TestMap(t *testing.T) {
 	assert.Len(t, flatArr, 2)
 	assert.Eq(t, 34, flatArr[1])
 }
+
+func TestGroupBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Role string
+	}
+
+	users := []user{{1, "admin"}, {2, "guest"}, {3, "admin"}, {1, "guest"}}
+	groups := arrutil.GroupBy(users, func(u user) string { return u.Role })
+	assert.Len(t, groups, 2)
+	assert.Eq(t, []user{{1, "admin"}, {3, "admin"}}, groups["admin"])
+	assert.Eq(t, []user{{2, "guest"}, {1, "guest"}}, groups["guest"])
+
+	userMap := arrutil.KeyBy(users, func(u user) int { return u.ID })
+	assert.Len(t, userMap, 3)
+	assert.Eq(t, user{1, "guest"}, userMap[1])
+	assert.Eq(t, user{3, "admin"}, userMap[3])
+
+	assert.Empty(t, arrutil.GroupBy(nil, func(u user) int { return u.ID }))
+	assert.Empty(t, arrutil.KeyBy(nil, func(u user) int { return u.ID }))
+}
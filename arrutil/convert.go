@@ -2,6 +2,8 @@ package arrutil
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -125,6 +127,178 @@ func SliceToInt64s(arr []any) []int64 {
 	return i64s
 }
 
+/*************************************************************
+ * convert func for numbers
+ *************************************************************/
+
+// ErrFraction error on convert a float value with fractional part to integer
+var ErrFraction = errors.New("the value has fractional part")
+
+// ToNumbers convert the numeric or numeric string slice to any int, uint, float type slice.
+//
+// Will not silently truncate or overflow: on the value out of range for R, or a float has
+// fractional part convert to integer, will return *ConvError with the failing index.
+//
+// Usage:
+//
+//	u8s, err := arrutil.ToNumbers[uint8]([]string{"1", "255"}) // []uint8{1, 255}
+//	_, err = arrutil.ToNumbers[uint8]([]int{256}) // error: value out of range
+func ToNumbers[R comdef.XintOrFloat, T ~string | comdef.XintOrFloat](ls []T) ([]R, error) {
+	ret := make([]R, len(ls))
+	for i, v := range ls {
+		dst := reflect.ValueOf(&ret[i]).Elem()
+		if err := setNumber(dst, reflect.ValueOf(v)); err != nil {
+			return nil, &ConvError{Index: i, Value: v, Err: err}
+		}
+	}
+	return ret, nil
+}
+
+// ToInts convert the numeric or numeric string slice to []int. see ToNumbers()
+//
+// Usage:
+//
+//	ints, err := arrutil.ToInts([]string{"1", "2"}) // []int{1, 2}
+//	ints, err = arrutil.ToInts([]float64{1, 2.0}) // []int{1, 2}
+func ToInts[T ~string | comdef.XintOrFloat](ls []T) ([]int, error) {
+	return ToNumbers[int](ls)
+}
+
+// ToFloats convert the numeric or numeric string slice to []float64. see ToNumbers()
+func ToFloats[T ~string | comdef.XintOrFloat](ls []T) ([]float64, error) {
+	return ToNumbers[float64](ls)
+}
+
+// NumbersToStrings convert the numeric slice to []string.
+// The float is formatted by the minimal digits. eg: 1.50 -> "1.5"
+func NumbersToStrings[T comdef.XintOrFloat](ls []T) []string {
+	ss := make([]string, len(ls))
+	for i, v := range ls {
+		ss[i] = formatNumber(reflect.ValueOf(v))
+	}
+	return ss
+}
+
+// JoinNumbers join the numbers to string by sep.
+//
+// Usage:
+//
+//	arrutil.JoinNumbers(",", 1, 2, 3) // "1,2,3"
+//	arrutil.JoinNumbers(",", []float64{1.5, 2}...) // "1.5,2"
+func JoinNumbers[T comdef.XintOrFloat](sep string, nums ...T) string {
+	var sb strings.Builder
+	for i, v := range nums {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(formatNumber(reflect.ValueOf(v)))
+	}
+	return sb.String()
+}
+
+func formatNumber(rv reflect.Value) string {
+	switch {
+	case rv.CanInt():
+		return strconv.FormatInt(rv.Int(), 10)
+	case rv.CanUint():
+		return strconv.FormatUint(rv.Uint(), 10)
+	default: // float
+		return strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits())
+	}
+}
+
+// setNumber set the src numeric or numeric string value to the dst numeric value, check the range.
+func setNumber(dst, src reflect.Value) error {
+	if src.Kind() == reflect.String {
+		s := strings.TrimSpace(src.String())
+		bits := dst.Type().Bits()
+
+		switch {
+		case dst.CanInt():
+			i64, err := strconv.ParseInt(s, 10, bits)
+			if err != nil {
+				return err
+			}
+			dst.SetInt(i64)
+		case dst.CanUint():
+			u64, err := strconv.ParseUint(s, 10, bits)
+			if err != nil {
+				return err
+			}
+			dst.SetUint(u64)
+		default:
+			f64, err := strconv.ParseFloat(s, bits)
+			if err != nil {
+				return err
+			}
+			dst.SetFloat(f64)
+		}
+		return nil
+	}
+
+	switch {
+	case src.CanInt():
+		i64 := src.Int()
+		switch {
+		case dst.CanInt():
+			if dst.OverflowInt(i64) {
+				return strconv.ErrRange
+			}
+			dst.SetInt(i64)
+		case dst.CanUint():
+			if i64 < 0 || dst.OverflowUint(uint64(i64)) {
+				return strconv.ErrRange
+			}
+			dst.SetUint(uint64(i64))
+		default:
+			dst.SetFloat(float64(i64))
+		}
+	case src.CanUint():
+		u64 := src.Uint()
+		switch {
+		case dst.CanInt():
+			if u64 > math.MaxInt64 || dst.OverflowInt(int64(u64)) {
+				return strconv.ErrRange
+			}
+			dst.SetInt(int64(u64))
+		case dst.CanUint():
+			if dst.OverflowUint(u64) {
+				return strconv.ErrRange
+			}
+			dst.SetUint(u64)
+		default:
+			dst.SetFloat(float64(u64))
+		}
+	default: // float
+		f64 := src.Float()
+		if dst.CanFloat() {
+			if dst.OverflowFloat(f64) {
+				return strconv.ErrRange
+			}
+			dst.SetFloat(f64)
+			return nil
+		}
+
+		if f64 != math.Trunc(f64) {
+			return ErrFraction
+		}
+
+		if dst.CanInt() {
+			// float64(math.MaxInt64) is 2^63
+			if f64 < math.MinInt64 || f64 >= math.MaxInt64 || dst.OverflowInt(int64(f64)) {
+				return strconv.ErrRange
+			}
+			dst.SetInt(int64(f64))
+		} else {
+			if f64 < 0 || f64 >= math.MaxUint64 || dst.OverflowUint(uint64(f64)) {
+				return strconv.ErrRange
+			}
+			dst.SetUint(uint64(f64))
+		}
+	}
+	return nil
+}
+
 /*************************************************************
  * convert func for anys
  *************************************************************/
@@ -223,6 +397,77 @@ func ConvType[T any, R any](arr []T, newElemTyp R) ([]R, error) {
 	return newArr, nil
 }
 
+// ConvError the error on convert slice element, contains the failing index.
+type ConvError struct {
+	// Index of the failing element
+	Index int
+	// Value of the failing element
+	Value any
+	Err   error
+}
+
+// Error string
+func (e *ConvError) Error() string {
+	return fmt.Sprintf("convert element #%d(%T: %v) error: %v", e.Index, e.Value, e.Value, e.Err)
+}
+
+// Unwrap the cause error
+func (e *ConvError) Unwrap() error { return e.Err }
+
+// AnysTo convert []any to []T, eg: the decoded JSON array.
+//
+// Will try type assertion on each element, then coercion for the basic types(string, bool, intX, uintX, floatX).
+// On failure, will return *ConvError with the failing index.
+//
+// Usage:
+//
+//	ints, err := arrutil.AnysTo[int]([]any{1, "2", 3.0}) // []int{1, 2, 3}
+func AnysTo[T any](arr []any) ([]T, error) {
+	ret := make([]T, len(arr))
+
+	var typ reflect.Type
+	for i, v := range arr {
+		if tv, ok := v.(T); ok {
+			ret[i] = tv
+			continue
+		}
+
+		if typ == nil {
+			typ = reflect.TypeOf((*T)(nil)).Elem()
+		}
+		if v == nil || typ.Kind() == reflect.Interface {
+			return nil, &ConvError{Index: i, Value: v, Err: comdef.ErrConvType}
+		}
+
+		rv, err := reflects.ValueByType(v, typ)
+		if err != nil {
+			return nil, &ConvError{Index: i, Value: v, Err: err}
+		}
+
+		// eg: type MyInt int
+		if rv.Type() != typ {
+			if rv.Kind() != typ.Kind() {
+				return nil, &ConvError{Index: i, Value: v, Err: comdef.ErrConvType}
+			}
+			rv = rv.Convert(typ)
+		}
+		ret[i] = rv.Interface().(T)
+	}
+	return ret, nil
+}
+
+// AnysToStrings convert []any to []string, will return *ConvError on failure.
+//
+// Unlike the QuietStrings(), it will not ignore the failing element.
+func AnysToStrings(arr []any) ([]string, error) {
+	return AnysTo[string](arr)
+}
+
+// AnysToInts convert []any to []int, will return *ConvError on failure.
+func AnysToInts(arr []any) ([]int, error) {
+	return AnysTo[int](arr)
+}
+
 // AnyToString simple and quickly convert any array, slice to string
 func AnyToString(arr any) string {
 	return NewFormatter(arr).Format()
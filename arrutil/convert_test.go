@@ -1,10 +1,13 @@
 package arrutil_test
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/comdef"
 	"github.com/gookit/goutil/testutil/assert"
 )
 
@@ -28,6 +31,85 @@ func TestToInt64s(t *testing.T) {
 	is.Err(err)
 }
 
+type myNumStr string
+
+func TestToNumbers(t *testing.T) {
+	ints, err := arrutil.ToInts([]string{"1", " 2", "-3"})
+	assert.NoErr(t, err)
+	assert.Eq(t, []int{1, 2, -3}, ints)
+
+	ints, err = arrutil.ToInts([]myNumStr{"23"})
+	assert.NoErr(t, err)
+	assert.Eq(t, []int{23}, ints)
+
+	ints, err = arrutil.ToInts([]float64{1, 2.0})
+	assert.NoErr(t, err)
+	assert.Eq(t, []int{1, 2}, ints)
+
+	ints, err = arrutil.ToInts([]uint16{1, 65535})
+	assert.NoErr(t, err)
+	assert.Eq(t, []int{1, 65535}, ints)
+
+	// error with index
+	_, err = arrutil.ToInts([]string{"1", "abc"})
+	var ce *arrutil.ConvError
+	assert.True(t, errors.As(err, &ce))
+	assert.Eq(t, 1, ce.Index)
+	assert.Eq(t, "abc", ce.Value)
+
+	_, err = arrutil.ToInts([]float64{1.5})
+	assert.ErrIs(t, err, arrutil.ErrFraction)
+	_, err = arrutil.ToInts([]uint64{1 << 63})
+	assert.ErrIs(t, err, strconv.ErrRange)
+
+	// other widths
+	u8s, err := arrutil.ToNumbers[uint8]([]string{"1", "255"})
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint8{1, 255}, u8s)
+
+	_, err = arrutil.ToNumbers[uint8]([]int{256})
+	assert.ErrIs(t, err, strconv.ErrRange)
+	_, err = arrutil.ToNumbers[uint8]([]string{"256"})
+	assert.ErrIs(t, err, strconv.ErrRange)
+	_, err = arrutil.ToNumbers[uint]([]int{-1})
+	assert.ErrIs(t, err, strconv.ErrRange)
+	_, err = arrutil.ToNumbers[int8]([]float32{-129})
+	assert.ErrIs(t, err, strconv.ErrRange)
+
+	i8s, err := arrutil.ToNumbers[int8]([]float32{-128, 127})
+	assert.NoErr(t, err)
+	assert.Eq(t, []int8{-128, 127}, i8s)
+
+	f32s, err := arrutil.ToNumbers[float32]([]int64{1, -2})
+	assert.NoErr(t, err)
+	assert.Eq(t, []float32{1, -2}, f32s)
+	_, err = arrutil.ToNumbers[float32]([]float64{1e300})
+	assert.ErrIs(t, err, strconv.ErrRange)
+
+	fs, err := arrutil.ToFloats([]string{"1.5", "2"})
+	assert.NoErr(t, err)
+	assert.Eq(t, []float64{1.5, 2}, fs)
+
+	fs, err = arrutil.ToFloats([]uint{3})
+	assert.NoErr(t, err)
+	assert.Eq(t, []float64{3}, fs)
+
+	fs, err = arrutil.ToFloats([]string{})
+	assert.NoErr(t, err)
+	assert.Empty(t, fs)
+}
+
+func TestNumbersToStrings(t *testing.T) {
+	assert.Eq(t, []string{"1", "-2"}, arrutil.NumbersToStrings([]int8{1, -2}))
+	assert.Eq(t, []string{"18446744073709551615"}, arrutil.NumbersToStrings([]uint64{1<<64 - 1}))
+	assert.Eq(t, []string{"1.5", "2", "0.1"}, arrutil.NumbersToStrings([]float32{1.50, 2, 0.1}))
+	assert.Empty(t, arrutil.NumbersToStrings([]int{}))
+
+	assert.Eq(t, "1,2,3", arrutil.JoinNumbers(",", 1, 2, 3))
+	assert.Eq(t, "1.5 | 2", arrutil.JoinNumbers(" | ", []float64{1.5, 2}...))
+	assert.Eq(t, "", arrutil.JoinNumbers[int](","))
+}
+
 func TestToStrings(t *testing.T) {
 	is := assert.New(t)
 
@@ -165,3 +247,53 @@ func TestCombineToSMap(t *testing.T) {
 	assert.Eq(t, "val0", mp["key0"])
 	assert.Eq(t, "", mp["key1"])
 }
+
+func TestAnysTo(t *testing.T) {
+	ints, err := arrutil.AnysTo[int]([]any{1, "2", 3.0, int64(4)})
+	assert.NoErr(t, err)
+	assert.Eq(t, []int{1, 2, 3, 4}, ints)
+
+	ints, err = arrutil.AnysToInts([]any{})
+	assert.NoErr(t, err)
+	assert.Empty(t, ints)
+
+	ss, err := arrutil.AnysToStrings([]any{"a", 2, 3.5, true})
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"a", "2", "3.5", "true"}, ss)
+
+	// decoded JSON array
+	fs, err := arrutil.AnysTo[float64]([]any{float64(1), 2.5})
+	assert.NoErr(t, err)
+	assert.Eq(t, []float64{1, 2.5}, fs)
+
+	// named type
+	type myInt int
+	mis, err := arrutil.AnysTo[myInt]([]any{1, "2"})
+	assert.NoErr(t, err)
+	assert.Eq(t, []myInt{1, 2}, mis)
+
+	// any value
+	mps, err := arrutil.AnysTo[map[string]any]([]any{map[string]any{"a": 1}})
+	assert.NoErr(t, err)
+	assert.Len(t, mps, 1)
+
+	// error
+	_, err = arrutil.AnysToInts([]any{1, "abc", 3})
+	assert.Err(t, err)
+	var ce *arrutil.ConvError
+	assert.True(t, errors.As(err, &ce))
+	assert.Eq(t, 1, ce.Index)
+	assert.Eq(t, "abc", ce.Value)
+	assert.StrContains(t, err.Error(), "convert element #1(string: abc)")
+
+	_, err = arrutil.AnysTo[string]([]any{"a", nil})
+	assert.True(t, errors.As(err, &ce))
+	assert.Eq(t, 1, ce.Index)
+	assert.ErrIs(t, err, comdef.ErrConvType)
+
+	_, err = arrutil.AnysTo[fmt.Stringer]([]any{1})
+	assert.ErrIs(t, err, comdef.ErrConvType)
+
+	_, err = arrutil.AnysTo[map[string]any]([]any{map[string]int{"a": 1}})
+	assert.Err(t, err)
+}
@@ -0,0 +1,90 @@
+package arrutil
+
+import "fmt"
+
+// Mismatch the first mismatch info of the slice compare, it implements the error.
+type Mismatch[T any] struct {
+	// Index of the mismatched element in the checked slice
+	Index int
+	// Value the mismatched element
+	Value T
+	// Reason the mismatch description
+	Reason string
+}
+
+// Error string
+func (m *Mismatch[T]) Error() string {
+	return fmt.Sprintf("mismatch at index %d (value: %v): %s", m.Index, m.Value, m.Reason)
+}
+
+// EqualOrdered check the two slices have same elements in same order.
+// returns nil on equal, otherwise returns the first mismatch in a, or in b on b is longer.
+//
+// Usage:
+//
+//	if m := arrutil.EqualOrdered(want, got); m != nil {
+//		t.Errorf("not equal: %s", m) // mismatch at index 2 (value: 3): not equal to 4
+//	}
+func EqualOrdered[T comparable](a, b []T) *Mismatch[T] {
+	for i, v := range a {
+		if i >= len(b) {
+			return &Mismatch[T]{Index: i, Value: v, Reason: fmt.Sprintf("not exists in the other, length %d != %d", len(a), len(b))}
+		}
+		if v != b[i] {
+			return &Mismatch[T]{Index: i, Value: v, Reason: fmt.Sprintf("not equal to %v", b[i])}
+		}
+	}
+
+	if len(b) > len(a) {
+		i := len(a)
+		return &Mismatch[T]{Index: i, Value: b[i], Reason: fmt.Sprintf("extra element in the other, length %d != %d", len(a), len(b))}
+	}
+	return nil
+}
+
+// EqualUnordered check the two slices have same elements in any order, the duplicate elements are counted.
+// returns nil on equal, otherwise returns the first element in a which missing in b, or the extra element in b.
+func EqualUnordered[T comparable](a, b []T) *Mismatch[T] {
+	counts := make(map[T]int, len(b))
+	for _, v := range b {
+		counts[v]++
+	}
+
+	for i, v := range a {
+		if counts[v] == 0 {
+			return &Mismatch[T]{Index: i, Value: v, Reason: "not exists in the other or the count not match"}
+		}
+		counts[v]--
+	}
+
+	for i, v := range b {
+		if counts[v] > 0 {
+			return &Mismatch[T]{Index: i, Value: v, Reason: "extra element in the other"}
+		}
+	}
+	return nil
+}
+
+// IsSubset check all elements of sub are in the set. returns nil on is subset,
+// otherwise returns the first element of sub which not in the set.
+//
+// TIP: it's the detailed version of ContainsAll(set, sub)
+//
+// Usage:
+//
+//	if m := arrutil.IsSubset(input, allowed); m != nil {
+//		return fmt.Errorf("invalid value %q", m.Value)
+//	}
+func IsSubset[T comparable](sub, set []T) *Mismatch[T] {
+	valMap := make(map[T]struct{}, len(set))
+	for _, v := range set {
+		valMap[v] = struct{}{}
+	}
+
+	for i, v := range sub {
+		if _, ok := valMap[v]; !ok {
+			return &Mismatch[T]{Index: i, Value: v, Reason: "not exists in the set"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package arrutil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestEqualOrdered(t *testing.T) {
+	assert.Nil(t, arrutil.EqualOrdered([]int{1, 2, 3}, []int{1, 2, 3}))
+	assert.Nil(t, arrutil.EqualOrdered([]int{}, nil))
+
+	m := arrutil.EqualOrdered([]int{1, 2, 3}, []int{1, 2, 4})
+	assert.NotNil(t, m)
+	assert.Eq(t, 2, m.Index)
+	assert.Eq(t, 3, m.Value)
+	assert.Eq(t, "mismatch at index 2 (value: 3): not equal to 4", m.Error())
+
+	m = arrutil.EqualOrdered([]int{1, 2, 3}, []int{1, 2})
+	assert.Eq(t, 2, m.Index)
+	assert.Eq(t, 3, m.Value)
+	assert.StrContains(t, m.Reason, "length 3 != 2")
+
+	ms := arrutil.EqualOrdered([]string{"a"}, []string{"a", "b"})
+	assert.Eq(t, 1, ms.Index)
+	assert.Eq(t, "b", ms.Value)
+	assert.StrContains(t, ms.Reason, "extra element")
+}
+
+func TestEqualUnordered(t *testing.T) {
+	assert.Nil(t, arrutil.EqualUnordered([]int{1, 2, 2, 3}, []int{2, 3, 1, 2}))
+
+	m := arrutil.EqualUnordered([]int{1, 2, 2}, []int{2, 1, 1})
+	assert.Eq(t, 2, m.Index)
+	assert.Eq(t, 2, m.Value)
+
+	m = arrutil.EqualUnordered([]int{1, 2}, []int{2, 1, 3})
+	assert.Eq(t, 2, m.Index)
+	assert.Eq(t, 3, m.Value)
+	assert.Eq(t, "extra element in the other", m.Reason)
+}
+
+func TestIsSubset(t *testing.T) {
+	assert.Nil(t, arrutil.IsSubset([]string{"a", "c"}, []string{"a", "b", "c"}))
+	assert.Nil(t, arrutil.IsSubset(nil, []string{"a"}))
+
+	m := arrutil.IsSubset([]string{"a", "d", "e"}, []string{"a", "b", "c"})
+	assert.Eq(t, 1, m.Index)
+	assert.Eq(t, "d", m.Value)
+	assert.ErrMsg(t, m, "mismatch at index 1 (value: d): not exists in the set")
+}
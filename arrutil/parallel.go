@@ -0,0 +1,83 @@
+package arrutil
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gookit/goutil/comdef"
+)
+
+// ParallelMap map each element of the slice by the fn with a pool of workers, the results keep the same order as input.
+//
+// workers <= 0 will use runtime.NumCPU(). It does not stop on error, all errors are collected
+// with the element index, and returned as comdef.Errors in order of the index.
+// The result of the failed element is zero value.
+//
+// Usage:
+//
+//	bodies, err := arrutil.ParallelMap(urls, 8, func(url string) ([]byte, error) {
+//		return fetch(url)
+//	})
+func ParallelMap[T any, R any](ls []T, workers int, fn func(v T) (R, error)) ([]R, error) {
+	return ParallelMapCtx(context.Background(), ls, workers, func(_ context.Context, v T) (R, error) {
+		return fn(v)
+	})
+}
+
+// ParallelMapCtx like ParallelMap(), but support cancel by the context.
+// The ctx is passed to the fn, on ctx is done, the not started elements will be skipped,
+// and the ctx.Err() will be appended to the returned errors.
+func ParallelMapCtx[T any, R any](ctx context.Context, ls []T, workers int, fn func(ctx context.Context, v T) (R, error)) ([]R, error) {
+	n := len(ls)
+	results := make([]R, n)
+	if n == 0 {
+		return results, nil
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > n {
+		workers = n
+	}
+
+	// each index is only written by one worker, so no lock is needed.
+	errs := make([]error, n)
+	var next int64
+	var skipped atomic.Bool
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1) - 1)
+				if i >= n {
+					return
+				}
+				if ctx.Err() != nil {
+					skipped.Store(true)
+					return
+				}
+
+				results[i], errs[i] = fn(ctx, ls[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	var es comdef.Errors
+	for i, err := range errs {
+		if err != nil {
+			es = append(es, fmt.Errorf("index %d: %w", i, err))
+		}
+	}
+	if skipped.Load() {
+		es = append(es, ctx.Err())
+	}
+	return results, es.ErrOrNil()
+}
@@ -0,0 +1,94 @@
+package arrutil_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestParallelMap(t *testing.T) {
+	ls := make([]int, 50)
+	for i := range ls {
+		ls[i] = i
+	}
+
+	var running, maxRunning int32
+	rs, err := arrutil.ParallelMap(ls, 4, func(v int) (string, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			old := atomic.LoadInt32(&maxRunning)
+			if n <= old || atomic.CompareAndSwapInt32(&maxRunning, old, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		return strconv.Itoa(v * 2), nil
+	})
+	assert.NoErr(t, err)
+	assert.Len(t, rs, 50)
+	assert.Eq(t, "0", rs[0])
+	assert.Eq(t, "98", rs[49])
+	assert.True(t, maxRunning <= 4)
+
+	// empty
+	rs, err = arrutil.ParallelMap([]int{}, 4, func(v int) (string, error) {
+		return "", nil
+	})
+	assert.NoErr(t, err)
+	assert.Empty(t, rs)
+
+	// workers <= 0
+	ints, err := arrutil.ParallelMap([]int{1, 2, 3}, 0, func(v int) (int, error) {
+		return v * v, nil
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, []int{1, 4, 9}, ints)
+}
+
+func TestParallelMap_error(t *testing.T) {
+	errOdd := errors.New("odd value")
+	ints, err := arrutil.ParallelMap([]int{1, 2, 3, 4}, 2, func(v int) (int, error) {
+		if v%2 == 1 {
+			return 0, errOdd
+		}
+		return v * 10, nil
+	})
+
+	assert.Err(t, err)
+	assert.Eq(t, []int{0, 20, 0, 40}, ints)
+	assert.Eq(t, "index 0: odd value\nindex 2: odd value\n", err.Error())
+}
+
+func TestParallelMapCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var called int32
+	ints, err := arrutil.ParallelMapCtx(ctx, []int{1, 2, 3, 4, 5, 6}, 1, func(ctx context.Context, v int) (int, error) {
+		atomic.AddInt32(&called, 1)
+		if v == 2 {
+			cancel()
+		}
+		return v, nil
+	})
+
+	assert.Err(t, err)
+	assert.ErrSubMsg(t, err, context.Canceled.Error())
+	assert.Eq(t, int32(2), called)
+	assert.Eq(t, []int{1, 2, 0, 0, 0, 0}, ints)
+
+	// not canceled
+	ints, err = arrutil.ParallelMapCtx(context.Background(), []int{1, 2}, 2, func(ctx context.Context, v int) (int, error) {
+		return v + 1, ctx.Err()
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, []int{2, 3}, ints)
+}
@@ -68,13 +68,239 @@ func Map[T any, V any](list []T, mapFn MapFn[T, V]) []V {
 	return flatArr
 }
 
+// MapTo map each element of the list to new list by the mapFn. unlike Map(), it keeps all elements.
+//
+// Usage:
+//
+//	// output: [2, 4, 6]
+//	ints := arrutil.MapTo([]int{1, 2, 3}, func(v int) int { return v * 2 })
+func MapTo[T any, V any](list []T, mapFn func(v T) V) []V {
+	newLs := make([]V, len(list))
+	for i, v := range list {
+		newLs[i] = mapFn(v)
+	}
+	return newLs
+}
+
+// MapIdx map each element and its index of the list to new list by the mapFn.
+func MapIdx[T any, V any](list []T, mapFn func(i int, v T) V) []V {
+	newLs := make([]V, len(list))
+	for i, v := range list {
+		newLs[i] = mapFn(i, v)
+	}
+	return newLs
+}
+
+// FilterIdx filter the list by the element and its index.
+//
+// Usage:
+//
+//	// output: [a, c]
+//	ss := arrutil.FilterIdx([]string{"a", "b", "c"}, func(i int, _ string) bool { return i%2 == 0 })
+func FilterIdx[T any](ls []T, fn func(i int, v T) bool) []T {
+	newLs := make([]T, 0, len(ls))
+	for i, v := range ls {
+		if fn(i, v) {
+			newLs = append(newLs, v)
+		}
+	}
+	return newLs
+}
+
+// Reduce the list to a single value by the fn, init is the initial value of accumulator.
+//
+// Usage:
+//
+//	// output: 6
+//	sum := arrutil.Reduce([]int{1, 2, 3}, 0, func(sum, v int) int { return sum + v })
+func Reduce[T any, R any](ls []T, init R, fn func(acc R, v T) R) R {
+	acc := init
+	for _, v := range ls {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// ReduceIdx reduce the list to a single value by the fn, fn can access the element index.
+func ReduceIdx[T any, R any](ls []T, init R, fn func(acc R, i int, v T) R) R {
+	acc := init
+	for i, v := range ls {
+		acc = fn(acc, i, v)
+	}
+	return acc
+}
+
+// Each call the fn for each element of the list.
+func Each[T any](ls []T, fn func(v T)) {
+	for _, v := range ls {
+		fn(v)
+	}
+}
+
+// EachIdx call the fn for each element and its index of the list.
+func EachIdx[T any](ls []T, fn func(i int, v T)) {
+	for i, v := range ls {
+		fn(i, v)
+	}
+}
+
 // Column alias of Map func
 func Column[T any, V any](list []T, mapFn func(obj T) (val V, find bool)) []V {
 	return Map(list, mapFn)
 }
 
-// Unique value in the given slice data.
-func Unique[T ~string | comdef.XintOrFloat](list []T) []T {
+// Chunk split the slice into chunks of the given size, the last chunk may be smaller.
+// The chunks share the memory with the input slice, will panic if size <= 0.
+//
+// Usage:
+//
+//	// output: [[1 2] [3 4] [5]]
+//	chunks := arrutil.Chunk([]int{1, 2, 3, 4, 5}, 2)
+func Chunk[T any](ls []T, size int) [][]T {
+	if size <= 0 {
+		panic("chunk size must be greater than 0")
+	}
+
+	chunks := make([][]T, 0, (len(ls)+size-1)/size)
+	for i := 0; i < len(ls); i += size {
+		end := i + size
+		if end > len(ls) {
+			end = len(ls)
+		}
+		// limit the cap, avoid append to a chunk overwrite the next chunk
+		chunks = append(chunks, ls[i:end:end])
+	}
+	return chunks
+}
+
+// Windows get the sliding windows of the given size, move the window by step each time.
+// Only the full windows are returned, and the windows share the memory with the input slice.
+// will panic if size <= 0 or step <= 0.
+//
+// Usage:
+//
+//	// output: [[1 2 3] [2 3 4] [3 4 5]]
+//	ws := arrutil.Windows([]int{1, 2, 3, 4, 5}, 3, 1)
+//	// output: [[1 2] [3 4]]
+//	ws = arrutil.Windows([]int{1, 2, 3, 4, 5}, 2, 2)
+func Windows[T any](ls []T, size, step int) [][]T {
+	if size <= 0 || step <= 0 {
+		panic("window size and step must be greater than 0")
+	}
+	if len(ls) < size {
+		return [][]T{}
+	}
+
+	ws := make([][]T, 0, (len(ls)-size)/step+1)
+	for i := 0; i+size <= len(ls); i += step {
+		ws = append(ws, ls[i:i+size:i+size])
+	}
+	return ws
+}
+
+// Pairwise get the adjacent element pairs of the slice.
+//
+// Usage:
+//
+//	// output: [{1 2} {2 3} {3 4}]
+//	ps := arrutil.Pairwise([]int{1, 2, 3, 4})
+func Pairwise[T any](ls []T) []Pair[T, T] {
+	if len(ls) < 2 {
+		return []Pair[T, T]{}
+	}
+
+	ps := make([]Pair[T, T], len(ls)-1)
+	for i := 1; i < len(ls); i++ {
+		ps[i-1] = Pair[T, T]{First: ls[i-1], Second: ls[i]}
+	}
+	return ps
+}
+
+// Partition split the slice into two slices by the predicate: match and rest. the order of elements is kept.
+//
+// Usage:
+//
+//	// output: [2 4] [1 3 5]
+//	even, odd := arrutil.Partition([]int{1, 2, 3, 4, 5}, func(v int) bool { return v%2 == 0 })
+func Partition[T any](ls []T, pred func(v T) bool) (match, rest []T) {
+	for _, v := range ls {
+		if pred(v) {
+			match = append(match, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return
+}
+
+// Flatten the two-dimensional slice to one-dimensional slice.
+//
+// Usage:
+//
+//	// output: [1 2 3 4 5]
+//	ints := arrutil.Flatten([][]int{{1, 2}, {3}, {4, 5}})
+func Flatten[T any](ss [][]T) []T {
+	var n int
+	for _, ls := range ss {
+		n += len(ls)
+	}
+
+	newLs := make([]T, 0, n)
+	for _, ls := range ss {
+		newLs = append(newLs, ls...)
+	}
+	return newLs
+}
+
+// FlattenDeep flatten the arbitrarily nested slice or array to one-dimensional []any.
+// non-slice value will be returned as a single element list.
+//
+// Usage:
+//
+//	// output: [1 2 3 a 4]
+//	vs := arrutil.FlattenDeep([]any{1, []any{2, []int{3}}, "a", [1]int{4}})
+func FlattenDeep(v any) []any {
+	return flattenDeep(make([]any, 0), v)
+}
+
+func flattenDeep(dst []any, v any) []any {
+	switch typVal := v.(type) {
+	case []any:
+		for _, el := range typVal {
+			dst = flattenDeep(dst, el)
+		}
+		return dst
+	case nil:
+		return append(dst, v)
+	}
+
+	rv := reflect.ValueOf(v)
+	if kind := rv.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return append(dst, v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		dst = flattenDeep(dst, rv.Index(i).Interface())
+	}
+	return dst
+}
+
+// FlatMap map each element of the list to a slice by the mapFn, then flatten the results to one list.
+//
+// Usage:
+//
+//	// output: [a b c d]
+//	ss := arrutil.FlatMap([]string{"a b", "c d"}, strings.Fields)
+func FlatMap[T any, R any](list []T, mapFn func(v T) []R) []R {
+	newLs := make([]R, 0, len(list))
+	for _, v := range list {
+		newLs = append(newLs, mapFn(v)...)
+	}
+	return newLs
+}
+
+// Unique value in the given slice data, the order of elements is kept.
+func Unique[T comparable](list []T) []T {
 	if len(list) < 2 {
 		return list
 	}
@@ -91,6 +317,26 @@ func Unique[T ~string | comdef.XintOrFloat](list []T) []T {
 	return uniArr
 }
 
+// UniqueBy remove the duplicate elements by the key selector, keep the first one and the order of elements.
+//
+// Usage:
+//
+//	// output: [{1 tom} {2 john}]
+//	users = arrutil.UniqueBy(users, func(u User) int { return u.ID })
+func UniqueBy[T any, K comparable](list []T, key func(v T) K) []T {
+	keyMap := make(map[K]struct{}, len(list))
+	uniArr := make([]T, 0, len(list))
+
+	for _, v := range list {
+		k := key(v)
+		if _, ok := keyMap[k]; !ok {
+			keyMap[k] = struct{}{}
+			uniArr = append(uniArr, v)
+		}
+	}
+	return uniArr
+}
+
 // IndexOf value in given slice.
 func IndexOf[T ~string | comdef.XintOrFloat](val T, list []T) int {
 	for i, v := range list {
@@ -100,3 +346,42 @@ func IndexOf[T ~string | comdef.XintOrFloat](val T, list []T) int {
 	}
 	return -1
 }
+
+// LastIndexOf get the last index of the value in given slice, returns -1 if not found.
+func LastIndexOf[T ~string | comdef.XintOrFloat](val T, list []T) int {
+	for i := len(list) - 1; i >= 0; i-- {
+		if list[i] == val {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexOfFunc get the index of the first element which match the fn, returns -1 if not found.
+//
+// Usage:
+//
+//	idx := arrutil.IndexOfFunc(users, func(u *User) bool { return u.Name == "inhere" })
+func IndexOfFunc[T any](list []T, fn func(v T) bool) int {
+	for i, v := range list {
+		if fn(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexOfFunc get the index of the last element which match the fn, returns -1 if not found.
+func LastIndexOfFunc[T any](list []T, fn func(v T) bool) int {
+	for i := len(list) - 1; i >= 0; i-- {
+		if fn(list[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ContainsFunc check the slice has any element which match the fn.
+func ContainsFunc[T any](list []T, fn func(v T) bool) bool {
+	return IndexOfFunc(list, fn) >= 0
+}
@@ -1,6 +1,8 @@
 package arrutil_test
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/gookit/goutil/arrutil"
@@ -31,4 +33,115 @@ func TestFilter(t *testing.T) {
 	is := assert.New(t)
 	ss := arrutil.Filter([]string{"a", "", "b", ""})
 	is.Eq([]string{"a", "b"}, ss)
+
+	ss = arrutil.FilterIdx([]string{"a", "b", "c"}, func(i int, _ string) bool {
+		return i%2 == 0
+	})
+	is.Eq([]string{"a", "c"}, ss)
+	is.Empty(arrutil.FilterIdx(nil, func(i int, v int) bool { return true }))
+}
+
+func TestMapTo(t *testing.T) {
+	ints := arrutil.MapTo([]int{1, 2, 3}, func(v int) int { return v * 2 })
+	assert.Eq(t, []int{2, 4, 6}, ints)
+
+	ss := arrutil.MapTo([]int{1, 2}, strconv.Itoa)
+	assert.Eq(t, []string{"1", "2"}, ss)
+
+	ss = arrutil.MapIdx([]string{"a", "b"}, func(i int, v string) string {
+		return v + strconv.Itoa(i)
+	})
+	assert.Eq(t, []string{"a0", "b1"}, ss)
+	assert.Empty(t, arrutil.MapTo(nil, strconv.Itoa))
+}
+
+func TestReduce(t *testing.T) {
+	sum := arrutil.Reduce([]int{1, 2, 3}, 0, func(sum, v int) int { return sum + v })
+	assert.Eq(t, 6, sum)
+
+	str := arrutil.Reduce([]int{1, 2, 3}, "", func(s string, v int) string { return s + strconv.Itoa(v) })
+	assert.Eq(t, "123", str)
+
+	str = arrutil.ReduceIdx([]string{"a", "b"}, "", func(s string, i int, v string) string {
+		return s + strconv.Itoa(i) + v
+	})
+	assert.Eq(t, "0a1b", str)
+	assert.Eq(t, 10, arrutil.Reduce(nil, 10, func(acc, v int) int { return acc + v }))
+}
+
+func TestEach(t *testing.T) {
+	var sum int
+	arrutil.Each([]int{1, 2, 3}, func(v int) { sum += v })
+	assert.Eq(t, 6, sum)
+
+	var idx []int
+	arrutil.EachIdx([]string{"a", "b"}, func(i int, _ string) { idx = append(idx, i) })
+	assert.Eq(t, []int{0, 1}, idx)
+}
+
+func TestChunk(t *testing.T) {
+	ints := []int{1, 2, 3, 4, 5}
+	chunks := arrutil.Chunk(ints, 2)
+	assert.Eq(t, [][]int{{1, 2}, {3, 4}, {5}}, chunks)
+	assert.Eq(t, [][]int{{1, 2, 3, 4, 5}}, arrutil.Chunk(ints, 10))
+	assert.Len(t, arrutil.Chunk(ints, 1), 5)
+	assert.Empty(t, arrutil.Chunk([]string{}, 3))
+
+	// append to chunk not overwrite the next chunk
+	chunks[0] = append(chunks[0], 10)
+	assert.Eq(t, []int{3, 4}, chunks[1])
+
+	assert.Panics(t, func() {
+		arrutil.Chunk(ints, 0)
+	})
+}
+
+func TestPartition(t *testing.T) {
+	even, odd := arrutil.Partition([]int{1, 2, 3, 4, 5}, func(v int) bool { return v%2 == 0 })
+	assert.Eq(t, []int{2, 4}, even)
+	assert.Eq(t, []int{1, 3, 5}, odd)
+
+	match, rest := arrutil.Partition([]string{"a", "b"}, func(s string) bool { return s == "c" })
+	assert.Empty(t, match)
+	assert.Eq(t, []string{"a", "b"}, rest)
+}
+
+func TestFlatten(t *testing.T) {
+	assert.Eq(t, []int{1, 2, 3, 4, 5}, arrutil.Flatten([][]int{{1, 2}, {3}, nil, {4, 5}}))
+	assert.Empty(t, arrutil.Flatten[string](nil))
+
+	vs := arrutil.FlattenDeep([]any{1, []any{2, []int{3}, []any{}}, "a", [1]int{4}, nil})
+	assert.Eq(t, []any{1, 2, 3, "a", 4, nil}, vs)
+	assert.Eq(t, []any{"abc"}, arrutil.FlattenDeep("abc"))
+
+	ss := arrutil.FlatMap([]string{"a b", "c d"}, strings.Fields)
+	assert.Eq(t, []string{"a", "b", "c", "d"}, ss)
+}
+
+func TestWindows(t *testing.T) {
+	ls := []int{1, 2, 3, 4, 5}
+	assert.Eq(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, arrutil.Windows(ls, 3, 1))
+	assert.Eq(t, [][]int{{1, 2}, {3, 4}}, arrutil.Windows(ls, 2, 2))
+	assert.Eq(t, [][]int{{1}, {4}}, arrutil.Windows(ls, 1, 3))
+	assert.Empty(t, arrutil.Windows(ls, 6, 1))
+
+	// moving average
+	avg := arrutil.MapTo(arrutil.Windows(ls, 2, 1), func(w []int) float64 {
+		return float64(w[0]+w[1]) / 2
+	})
+	assert.Eq(t, []float64{1.5, 2.5, 3.5, 4.5}, avg)
+
+	assert.Panics(t, func() {
+		arrutil.Windows(ls, 0, 1)
+	})
+	assert.Panics(t, func() {
+		arrutil.Windows(ls, 2, 0)
+	})
+}
+
+func TestPairwise(t *testing.T) {
+	ps := arrutil.Pairwise([]int{1, 2, 3, 4})
+	assert.Eq(t, []arrutil.Pair[int, int]{{1, 2}, {2, 3}, {3, 4}}, ps)
+	assert.Empty(t, arrutil.Pairwise([]int{1}))
+	assert.Empty(t, arrutil.Pairwise[string](nil))
 }
@@ -0,0 +1,80 @@
+package arrutil
+
+import "github.com/gookit/goutil/mathutil"
+
+// Shuffle the slice elements in place.
+//
+// It uses the random source of mathutil, can be set a seeded source by mathutil.SetRandSource() or testutil.SeedRandom() in tests.
+func Shuffle[T any](ls []T) {
+	for i := len(ls) - 1; i > 0; i-- {
+		j := mathutil.RandIntn(i + 1)
+		ls[i], ls[j] = ls[j], ls[i]
+	}
+}
+
+// Sample returns n random elements from the slice without replacement, the given slice will not be modified.
+// If n is greater than the slice length, all elements are returned in random order.
+//
+// Usage:
+//
+//	winners := arrutil.Sample(users, 3)
+func Sample[T any](ls []T, n int) []T {
+	if n > len(ls) {
+		n = len(ls)
+	}
+	if n <= 0 {
+		return make([]T, 0)
+	}
+
+	// partial Fisher-Yates shuffle on a copy
+	cp := CloneSlice(ls)
+	for i := 0; i < n; i++ {
+		j := i + mathutil.RandIntn(len(cp)-i)
+		cp[i], cp[j] = cp[j], cp[i]
+	}
+	return cp[:n:n]
+}
+
+// WeightedPick pick a random element by the weights, the probability of each element is weight/total.
+//
+// Will panic on the items is empty, the length of weights is not equal to items, or the total weight <= 0.
+// The negative weight is treated as 0.
+//
+// Usage:
+//
+//	// "a": 10%, "b": 30%, "c": 60%
+//	val := arrutil.WeightedPick([]string{"a", "b", "c"}, []float64{1, 3, 6})
+func WeightedPick[T any](items []T, weights []float64) T {
+	if len(items) == 0 {
+		panic("cannot pick value from nil or empty slice")
+	}
+	if len(items) != len(weights) {
+		panic("the length of items and weights must be equal")
+	}
+
+	var total float64
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+	if total <= 0 {
+		panic("the total weight must be greater than 0")
+	}
+
+	r := mathutil.RandFloat64() * total
+	last := 0
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		if r < w {
+			return items[i]
+		}
+		r -= w
+		last = i
+	}
+
+	// float rounding, return the last positive weight item
+	return items[last]
+}
@@ -0,0 +1,81 @@
+package arrutil_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestShuffle(t *testing.T) {
+	ls := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	arrutil.Shuffle(ls)
+	assert.Len(t, ls, 8)
+
+	sorted := arrutil.CloneSlice(ls)
+	sort.Ints(sorted)
+	assert.Eq(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, sorted)
+
+	// deterministic by seed
+	var r1, r2 []int
+	t.Run("seed1", func(t *testing.T) {
+		testutil.SeedRandom(t, 23)
+		r1 = []int{1, 2, 3, 4, 5, 6, 7, 8}
+		arrutil.Shuffle(r1)
+	})
+	t.Run("seed2", func(t *testing.T) {
+		testutil.SeedRandom(t, 23)
+		r2 = []int{1, 2, 3, 4, 5, 6, 7, 8}
+		arrutil.Shuffle(r2)
+	})
+	assert.Eq(t, r1, r2)
+
+	arrutil.Shuffle([]string{})
+}
+
+func TestSample(t *testing.T) {
+	ls := []string{"a", "b", "c", "d", "e"}
+
+	sp := arrutil.Sample(ls, 3)
+	assert.Len(t, sp, 3)
+	assert.Len(t, arrutil.Unique(sp), 3)
+	for _, v := range sp {
+		assert.Contains(t, ls, v)
+	}
+	// not modify the input
+	assert.Eq(t, []string{"a", "b", "c", "d", "e"}, ls)
+
+	sp = arrutil.Sample(ls, 10)
+	assert.Len(t, sp, 5)
+	sort.Strings(sp)
+	assert.Eq(t, ls, sp)
+
+	assert.Empty(t, arrutil.Sample(ls, 0))
+	assert.Empty(t, arrutil.Sample([]int(nil), 2))
+}
+
+func TestWeightedPick(t *testing.T) {
+	testutil.SeedRandom(t, 42)
+
+	items := []string{"a", "b", "c"}
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		counts[arrutil.WeightedPick(items, []float64{1, 3, 0})]++
+	}
+	assert.Eq(t, 0, counts["c"])
+	assert.Gt(t, counts["b"], counts["a"]*2)
+
+	assert.Eq(t, "b", arrutil.WeightedPick(items, []float64{0, 1, -1}))
+
+	assert.PanicsMsg(t, func() {
+		arrutil.WeightedPick([]int{}, nil)
+	}, "cannot pick value from nil or empty slice")
+	assert.PanicsMsg(t, func() {
+		arrutil.WeightedPick(items, []float64{1})
+	}, "the length of items and weights must be equal")
+	assert.PanicsMsg(t, func() {
+		arrutil.WeightedPick(items, []float64{0, 0, -1})
+	}, "the total weight must be greater than 0")
+}
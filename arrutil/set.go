@@ -0,0 +1,121 @@
+package arrutil
+
+// SetIntersect returns the unique elements that exist in both a and b, the order of a is kept.
+//
+// Example:
+//
+//	// Output: []int{2, 3}
+//	SetIntersect([]int{1, 2, 3, 2}, []int{3, 2, 4})
+func SetIntersect[T comparable](a, b []T) []T {
+	return IntersectBy(a, b, identity[T])
+}
+
+// SetUnion returns the unique elements of a and b, the elements of a first, then the new elements of b.
+//
+// Example:
+//
+//	// Output: []int{1, 2, 3, 4}
+//	SetUnion([]int{1, 2, 3, 2}, []int{3, 4})
+func SetUnion[T comparable](a, b []T) []T {
+	return UnionBy(a, b, identity[T])
+}
+
+// SetDiff returns the unique elements of a that not exist in b, the order of a is kept.
+//
+// Example:
+//
+//	// Output: []int{1}
+//	SetDiff([]int{1, 2, 3, 1}, []int{2, 3, 4})
+func SetDiff[T comparable](a, b []T) []T {
+	return DiffBy(a, b, identity[T])
+}
+
+// SymmetricDiff returns the unique elements only exist in one of a and b.
+// The elements of a first, then the elements of b.
+//
+// Example:
+//
+//	// Output: []int{1, 4}
+//	SymmetricDiff([]int{1, 2, 3}, []int{2, 3, 4})
+func SymmetricDiff[T comparable](a, b []T) []T {
+	return SymmetricDiffBy(a, b, identity[T])
+}
+
+// IntersectBy like SetIntersect, but compare the elements by the key selector.
+// The element of a is kept on the key exists in both.
+//
+// Example:
+//
+//	users := arrutil.IntersectBy(users1, users2, func(u User) int { return u.ID })
+func IntersectBy[T any, K comparable](a, b []T, key func(v T) K) []T {
+	bKeys := keySet(b, key)
+	seen := make(map[K]struct{}, len(a))
+	result := make([]T, 0)
+
+	for _, v := range a {
+		k := key(v)
+		if _, ok := bKeys[k]; !ok {
+			continue
+		}
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// UnionBy like SetUnion, but compare the elements by the key selector.
+// The first element is kept on the key is duplicated.
+func UnionBy[T any, K comparable](a, b []T, key func(v T) K) []T {
+	seen := make(map[K]struct{}, len(a)+len(b))
+	result := make([]T, 0, len(a)+len(b))
+
+	for _, ls := range [2][]T{a, b} {
+		for _, v := range ls {
+			k := key(v)
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
+
+// DiffBy like SetDiff, but compare the elements by the key selector.
+func DiffBy[T any, K comparable](a, b []T, key func(v T) K) []T {
+	return appendDiff(make([]T, 0), a, keySet(b, key), key)
+}
+
+// SymmetricDiffBy like SymmetricDiff, but compare the elements by the key selector.
+func SymmetricDiffBy[T any, K comparable](a, b []T, key func(v T) K) []T {
+	result := appendDiff(make([]T, 0), a, keySet(b, key), key)
+	return appendDiff(result, b, keySet(a, key), key)
+}
+
+// append the unique elements of ls that key not exist in excludes.
+func appendDiff[T any, K comparable](result, ls []T, excludes map[K]struct{}, key func(v T) K) []T {
+	seen := make(map[K]struct{}, len(ls))
+	for _, v := range ls {
+		k := key(v)
+		if _, ok := excludes[k]; ok {
+			continue
+		}
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func keySet[T any, K comparable](ls []T, key func(v T) K) map[K]struct{} {
+	set := make(map[K]struct{}, len(ls))
+	for _, v := range ls {
+		set[key(v)] = struct{}{}
+	}
+	return set
+}
+
+func identity[T any](v T) T { return v }
@@ -0,0 +1,42 @@
+package arrutil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestSetOperations(t *testing.T) {
+	a := []int{1, 2, 3, 2}
+	b := []int{3, 2, 4, 4}
+
+	assert.Eq(t, []int{2, 3}, arrutil.SetIntersect(a, b))
+	assert.Eq(t, []int{1, 2, 3, 4}, arrutil.SetUnion(a, b))
+	assert.Eq(t, []int{1}, arrutil.SetDiff(a, b))
+	assert.Eq(t, []int{4}, arrutil.SetDiff(b, a))
+	assert.Eq(t, []int{1, 4}, arrutil.SymmetricDiff(a, b))
+
+	ss := []string{"c", "a"}
+	assert.Eq(t, []string{"c", "a"}, arrutil.SetUnion(ss, nil))
+	assert.Eq(t, []string{"c", "a"}, arrutil.SetDiff(ss, nil))
+	assert.Empty(t, arrutil.SetIntersect(ss, nil))
+	assert.Empty(t, arrutil.SymmetricDiff(ss, ss))
+	assert.Eq(t, []string{"a"}, arrutil.SymmetricDiff(nil, []string{"a", "a"}))
+}
+
+func TestSetOperations_byKey(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	a := []user{{1, "tom"}, {2, "john"}, {2, "john2"}}
+	b := []user{{2, "JOHN"}, {3, "lucy"}}
+	byID := func(u user) int { return u.ID }
+
+	assert.Eq(t, []user{{2, "john"}}, arrutil.IntersectBy(a, b, byID))
+	assert.Eq(t, []user{{1, "tom"}, {2, "john"}, {3, "lucy"}}, arrutil.UnionBy(a, b, byID))
+	assert.Eq(t, []user{{1, "tom"}}, arrutil.DiffBy(a, b, byID))
+	assert.Eq(t, []user{{1, "tom"}, {3, "lucy"}}, arrutil.SymmetricDiffBy(a, b, byID))
+}
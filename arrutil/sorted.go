@@ -0,0 +1,220 @@
+package arrutil
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/gookit/goutil/comdef"
+)
+
+// LessFn the less func for compare two elements. same as the less func of sort.Slice()
+type LessFn[T any] func(a, b T) bool
+
+// CompareFn the compare func for two elements.
+// returns negative number on a < b, 0 on a == b, positive number on a > b.
+type CompareFn[T any] func(a, b T) int
+
+// SortBy sort the slice in place by the key in ascending order. the sort is stable.
+//
+// Usage:
+//
+//	arrutil.SortBy(users, func(u *User) int { return u.Age })
+func SortBy[T any, K comdef.SortedType](ls []T, key func(v T) K) {
+	sort.SliceStable(ls, func(i, j int) bool {
+		return key(ls[i]) < key(ls[j])
+	})
+}
+
+// SortByDesc sort the slice in place by the key in descending order. the sort is stable.
+func SortByDesc[T any, K comdef.SortedType](ls []T, key func(v T) K) {
+	sort.SliceStable(ls, func(i, j int) bool {
+		return key(ls[i]) > key(ls[j])
+	})
+}
+
+// SortByKeys sort the slice in place by multi compare funcs, the next func is used on the previous is equal.
+// the sort is stable.
+//
+// Usage:
+//
+//	// sort by name asc, then by age desc
+//	arrutil.SortByKeys(users,
+//		arrutil.CompareBy(func(u *User) string { return u.Name }),
+//		arrutil.CompareByDesc(func(u *User) int { return u.Age }),
+//	)
+func SortByKeys[T any](ls []T, cmps ...CompareFn[T]) {
+	sort.SliceStable(ls, func(i, j int) bool {
+		for _, cmp := range cmps {
+			if r := cmp(ls[i], ls[j]); r != 0 {
+				return r < 0
+			}
+		}
+		return false
+	})
+}
+
+// CompareBy create a CompareFn by the key in ascending order. see SortByKeys()
+func CompareBy[T any, K comdef.SortedType](key func(v T) K) CompareFn[T] {
+	return func(a, b T) int {
+		ka, kb := key(a), key(b)
+		if ka < kb {
+			return -1
+		}
+		if ka > kb {
+			return 1
+		}
+		return 0
+	}
+}
+
+// CompareByDesc create a CompareFn by the key in descending order. see SortByKeys()
+func CompareByDesc[T any, K comdef.SortedType](key func(v T) K) CompareFn[T] {
+	asc := CompareBy(key)
+	return func(a, b T) int {
+		return asc(b, a)
+	}
+}
+
+// BinarySearchBy search the target key in the slice sorted by the key in ascending order.
+// returns the index of the target found, or the position where the target would be inserted, and whether is found.
+//
+// Usage:
+//
+//	// users sorted by age
+//	idx, found := arrutil.BinarySearchBy(users, 23, func(u *User) int { return u.Age })
+func BinarySearchBy[T any, K comdef.SortedType](ls []T, target K, key func(v T) K) (int, bool) {
+	idx := sort.Search(len(ls), func(i int) bool {
+		return key(ls[i]) >= target
+	})
+	return idx, idx < len(ls) && key(ls[idx]) == target
+}
+
+// MergeSorted merge two sorted slices to a new sorted slice, by O(n).
+// the order of equal elements is kept, and elements of a are before b.
+//
+// Usage:
+//
+//	// output: [1, 2, 3, 4, 5]
+//	arrutil.MergeSorted([]int{1, 3, 5}, []int{2, 4}, func(a, b int) bool { return a < b })
+func MergeSorted[T any](a, b []T, less LessFn[T]) []T {
+	ls := make([]T, 0, len(a)+len(b))
+
+	var i, j int
+	for i < len(a) && j < len(b) {
+		// take from b only if b[j] < a[i], keep the order of equal elements.
+		if less(b[j], a[i]) {
+			ls = append(ls, b[j])
+			j++
+		} else {
+			ls = append(ls, a[i])
+			i++
+		}
+	}
+
+	ls = append(ls, a[i:]...)
+	return append(ls, b[j:]...)
+}
+
+// MergeSortedAll k-way merge multi sorted slices to a new sorted slice, by O(n*log(k)).
+// the order of equal elements is kept by the slices order.
+//
+// Usage:
+//
+//	// merge pre-sorted log shards by time
+//	logs := arrutil.MergeSortedAll(shards, func(a, b *LogEntry) bool {
+//		return a.Time.Before(b.Time)
+//	})
+func MergeSortedAll[T any](slices [][]T, less LessFn[T]) []T {
+	switch len(slices) {
+	case 0:
+		return []T{}
+	case 1:
+		return append(make([]T, 0, len(slices[0])), slices[0]...)
+	case 2:
+		return MergeSorted(slices[0], slices[1], less)
+	}
+
+	var total int
+	mh := &mergeHeap[T]{less: less, items: make([]mergeItem, 0, len(slices))}
+	for _, s := range slices {
+		if len(s) > 0 {
+			total += len(s)
+			mh.items = append(mh.items, mergeItem{sIdx: len(mh.slices)})
+			mh.slices = append(mh.slices, s)
+		}
+	}
+	heap.Init(mh)
+
+	ls := make([]T, 0, total)
+	for len(mh.items) > 0 {
+		top := &mh.items[0]
+		ls = append(ls, mh.slices[top.sIdx][top.eIdx])
+
+		if top.eIdx++; top.eIdx < len(mh.slices[top.sIdx]) {
+			heap.Fix(mh, 0)
+		} else {
+			heap.Pop(mh)
+		}
+	}
+	return ls
+}
+
+// MergeSortedUnique k-way merge multi sorted slices to a new sorted slice, and remove the duplicate elements.
+// the elements a, b are equal if !less(a, b) && !less(b, a)
+func MergeSortedUnique[T any](slices [][]T, less LessFn[T]) []T {
+	return UniqueSorted(MergeSortedAll(slices, less), less)
+}
+
+// UniqueSorted remove the duplicate elements of the sorted slice, will modify the given slice.
+func UniqueSorted[T any](ls []T, less LessFn[T]) []T {
+	if len(ls) < 2 {
+		return ls
+	}
+
+	n := 1
+	for i := 1; i < len(ls); i++ {
+		if less(ls[n-1], ls[i]) {
+			ls[n] = ls[i]
+			n++
+		}
+	}
+	return ls[:n]
+}
+
+type mergeItem struct {
+	// sIdx index of the slice, eIdx index of the element in slice
+	sIdx, eIdx int
+}
+
+// mergeHeap implements heap.Interface for k-way merge
+type mergeHeap[T any] struct {
+	less   LessFn[T]
+	slices [][]T
+	items  []mergeItem
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.items) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	va, vb := h.slices[a.sIdx][a.eIdx], h.slices[b.sIdx][b.eIdx]
+	if h.less(va, vb) {
+		return true
+	}
+	if h.less(vb, va) {
+		return false
+	}
+	// keep the order of equal elements by the slices order
+	return a.sIdx < b.sIdx
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *mergeHeap[T]) Push(x any) { h.items = append(h.items, x.(mergeItem)) }
+
+func (h *mergeHeap[T]) Pop() any {
+	old := h.items
+	it := old[len(old)-1]
+	h.items = old[:len(old)-1]
+	return it
+}
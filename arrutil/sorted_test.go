@@ -0,0 +1,114 @@
+package arrutil_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func TestMergeSorted(t *testing.T) {
+	assert.Eq(t, []int{1, 2, 3, 4, 5, 7, 9}, arrutil.MergeSorted([]int{1, 3, 5}, []int{2, 4, 7, 9}, intLess))
+	assert.Eq(t, []int{1, 2}, arrutil.MergeSorted(nil, []int{1, 2}, intLess))
+	assert.Eq(t, []int{}, arrutil.MergeSorted([]int{}, nil, intLess))
+
+	// keep order of equal elements
+	type item struct {
+		key int
+		src string
+	}
+	a := []item{{1, "a"}, {2, "a"}}
+	b := []item{{1, "b"}, {2, "b"}}
+	ls := arrutil.MergeSorted(a, b, func(x, y item) bool { return x.key < y.key })
+	assert.Eq(t, []item{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}, ls)
+}
+
+func TestMergeSortedAll(t *testing.T) {
+	assert.Eq(t, []int{}, arrutil.MergeSortedAll(nil, intLess))
+
+	src := []int{1, 2}
+	ls := arrutil.MergeSortedAll([][]int{src}, intLess)
+	ls[0] = 10
+	assert.Eq(t, 1, src[0])
+
+	ls = arrutil.MergeSortedAll([][]int{{1, 4, 7}, {}, {2, 5, 8}, {3, 6, 9, 10}, nil}, intLess)
+	assert.Eq(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, ls)
+
+	// stable
+	type item struct {
+		key int
+		src int
+	}
+	less := func(x, y item) bool { return x.key < y.key }
+	items := arrutil.MergeSortedAll([][]item{{{1, 0}, {3, 0}}, {{1, 1}, {2, 1}}, {{1, 2}, {3, 2}}}, less)
+	assert.Eq(t, []item{{1, 0}, {1, 1}, {1, 2}, {2, 1}, {3, 0}, {3, 2}}, items)
+
+	// compare with sort
+	shards := [][]int{{5, 9, 20, 21}, {1, 1, 30}, {2, 9, 9, 12}, {0}}
+	var want []int
+	for _, s := range shards {
+		want = append(want, s...)
+	}
+	sort.Ints(want)
+	assert.Eq(t, want, arrutil.MergeSortedAll(shards, intLess))
+}
+
+func TestMergeSortedUnique(t *testing.T) {
+	ls := arrutil.MergeSortedUnique([][]int{{1, 2, 2, 5}, {2, 3}, {1, 5, 6}}, intLess)
+	assert.Eq(t, []int{1, 2, 3, 5, 6}, ls)
+
+	assert.Eq(t, []string{"a", "b"}, arrutil.UniqueSorted([]string{"a", "a", "b", "b"}, func(a, b string) bool {
+		return a < b
+	}))
+	assert.Eq(t, []int{1}, arrutil.UniqueSorted([]int{1}, intLess))
+}
+
+type sortUser struct {
+	Name string
+	Age  int
+}
+
+func TestSortBy(t *testing.T) {
+	users := []sortUser{{"tom", 23}, {"amy", 18}, {"bob", 23}, {"amy", 30}}
+
+	arrutil.SortBy(users, func(u sortUser) int { return u.Age })
+	assert.Eq(t, []sortUser{{"amy", 18}, {"tom", 23}, {"bob", 23}, {"amy", 30}}, users)
+
+	// stable
+	arrutil.SortByDesc(users, func(u sortUser) int { return u.Age })
+	assert.Eq(t, []sortUser{{"amy", 30}, {"tom", 23}, {"bob", 23}, {"amy", 18}}, users)
+
+	arrutil.SortByKeys(users,
+		arrutil.CompareBy(func(u sortUser) string { return u.Name }),
+		arrutil.CompareByDesc(func(u sortUser) int { return u.Age }),
+	)
+	assert.Eq(t, []sortUser{{"amy", 30}, {"amy", 18}, {"bob", 23}, {"tom", 23}}, users)
+
+	// no compare funcs, keep the order
+	arrutil.SortByKeys(users)
+	assert.Eq(t, "amy", users[0].Name)
+}
+
+func TestBinarySearchBy(t *testing.T) {
+	users := []sortUser{{"amy", 18}, {"tom", 23}, {"bob", 23}, {"ken", 30}}
+	age := func(u sortUser) int { return u.Age }
+
+	idx, ok := arrutil.BinarySearchBy(users, 23, age)
+	assert.True(t, ok)
+	assert.Eq(t, 1, idx)
+
+	idx, ok = arrutil.BinarySearchBy(users, 25, age)
+	assert.False(t, ok)
+	assert.Eq(t, 3, idx)
+
+	idx, ok = arrutil.BinarySearchBy(users, 40, age)
+	assert.False(t, ok)
+	assert.Eq(t, 4, idx)
+
+	idx, ok = arrutil.BinarySearchBy(nil, 1, age)
+	assert.False(t, ok)
+	assert.Eq(t, 0, idx)
+}
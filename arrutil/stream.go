@@ -0,0 +1,142 @@
+package arrutil
+
+// Stream a lazy pipeline for process the elements one by one, without materializing the intermediate slices.
+//
+// The stream can only be consumed once. Type-changing operations are provided as functions: StreamMap(), StreamDistinct()
+//
+// Usage:
+//
+//	// output: [4 8]
+//	ints := arrutil.StreamOf([]int{1, 2, 3, 4, 5}).
+//		Filter(func(v int) bool { return v%2 == 0 }).
+//		Take(2).
+//		Collect()
+//	ls := arrutil.StreamMap(arrutil.StreamOf(ints), func(v int) int { return v * 2 }).Collect()
+type Stream[T any] struct {
+	next func() (T, bool)
+}
+
+// StreamFunc create a stream by the next func, next returns false on no more elements.
+func StreamFunc[T any](next func() (T, bool)) *Stream[T] {
+	return &Stream[T]{next: next}
+}
+
+// StreamOf create a stream from the slice
+func StreamOf[T any](ls []T) *Stream[T] {
+	var i int
+	return StreamFunc(func() (v T, ok bool) {
+		if i >= len(ls) {
+			return
+		}
+		i++
+		return ls[i-1], true
+	})
+}
+
+// StreamChan create a stream from the channel, it ends on the channel closed.
+func StreamChan[T any](ch <-chan T) *Stream[T] {
+	return StreamFunc(func() (T, bool) {
+		v, ok := <-ch
+		return v, ok
+	})
+}
+
+// Next get the next element, returns false on no more elements.
+func (s *Stream[T]) Next() (T, bool) { return s.next() }
+
+// Filter keep the elements which match the fn
+func (s *Stream[T]) Filter(fn func(v T) bool) *Stream[T] {
+	return StreamFunc(func() (v T, ok bool) {
+		for v, ok = s.next(); ok; v, ok = s.next() {
+			if fn(v) {
+				return v, true
+			}
+		}
+		return
+	})
+}
+
+// Take the first n elements, the source will not be consumed after taken n elements.
+func (s *Stream[T]) Take(n int) *Stream[T] {
+	return StreamFunc(func() (v T, ok bool) {
+		if n <= 0 {
+			return
+		}
+		n--
+		return s.next()
+	})
+}
+
+// Skip the first n elements
+func (s *Stream[T]) Skip(n int) *Stream[T] {
+	return StreamFunc(func() (v T, ok bool) {
+		for ; n > 0; n-- {
+			if _, ok = s.next(); !ok {
+				return
+			}
+		}
+		return s.next()
+	})
+}
+
+// Peek call the fn for each element when it passes through, useful for debug.
+func (s *Stream[T]) Peek(fn func(v T)) *Stream[T] {
+	return StreamFunc(func() (T, bool) {
+		v, ok := s.next()
+		if ok {
+			fn(v)
+		}
+		return v, ok
+	})
+}
+
+// Each consume the stream and call the fn for each element
+func (s *Stream[T]) Each(fn func(v T)) {
+	for v, ok := s.next(); ok; v, ok = s.next() {
+		fn(v)
+	}
+}
+
+// Collect consume the stream and collect the elements to a slice
+func (s *Stream[T]) Collect() []T {
+	ls := make([]T, 0)
+	for v, ok := s.next(); ok; v, ok = s.next() {
+		ls = append(ls, v)
+	}
+	return ls
+}
+
+// Count consume the stream and returns the number of elements
+func (s *Stream[T]) Count() int {
+	var n int
+	for _, ok := s.next(); ok; _, ok = s.next() {
+		n++
+	}
+	return n
+}
+
+// First get the first element, returns false on stream is empty.
+func (s *Stream[T]) First() (T, bool) { return s.next() }
+
+// StreamMap map each element of the stream to a new stream by the fn
+func StreamMap[T, R any](s *Stream[T], fn func(v T) R) *Stream[R] {
+	return StreamFunc(func() (r R, ok bool) {
+		v, ok := s.next()
+		if !ok {
+			return
+		}
+		return fn(v), true
+	})
+}
+
+// StreamDistinct remove the duplicate elements of the stream, the first one is kept.
+func StreamDistinct[T comparable](s *Stream[T]) *Stream[T] {
+	seen := make(map[T]struct{})
+	return s.Filter(func(v T) bool {
+		if _, ok := seen[v]; ok {
+			return false
+		}
+		seen[v] = struct{}{}
+		return true
+	})
+}
@@ -0,0 +1,55 @@
+package arrutil_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestStream(t *testing.T) {
+	var visited int
+	ints := arrutil.StreamOf([]int{1, 2, 3, 4, 5, 6, 7, 8}).
+		Peek(func(v int) { visited++ }).
+		Filter(func(v int) bool { return v%2 == 0 }).
+		Skip(1).
+		Take(2).
+		Collect()
+	assert.Eq(t, []int{4, 6}, ints)
+	// lazy: stop read the source after taken
+	assert.Eq(t, 6, visited)
+
+	ss := arrutil.StreamMap(arrutil.StreamOf([]int{1, 2, 2, 3, 1}), strconv.Itoa)
+	assert.Eq(t, []string{"1", "2", "3"}, arrutil.StreamDistinct(ss).Collect())
+
+	assert.Eq(t, 3, arrutil.StreamOf([]int{1, 2, 3}).Count())
+	assert.Empty(t, arrutil.StreamOf([]int{1, 2}).Skip(3).Collect())
+	assert.Empty(t, arrutil.StreamOf([]int{1, 2}).Take(0).Collect())
+
+	v, ok := arrutil.StreamOf([]string{"a", "b"}).First()
+	assert.True(t, ok)
+	assert.Eq(t, "a", v)
+	_, ok = arrutil.StreamOf([]string{}).First()
+	assert.False(t, ok)
+
+	var sum int
+	arrutil.StreamOf([]int{1, 2, 3}).Each(func(v int) { sum += v })
+	assert.Eq(t, 6, sum)
+}
+
+func TestStreamChan(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 10; i++ {
+			ch <- i
+		}
+	}()
+
+	s := arrutil.StreamChan(ch).Filter(func(v int) bool { return v > 5 })
+	v, ok := s.Next()
+	assert.True(t, ok)
+	assert.Eq(t, 6, v)
+	assert.Eq(t, []int{7, 8, 9, 10}, s.Collect())
+}
@@ -1,7 +1,6 @@
 package arrutil
 
 import (
-	"strconv"
 	"strings"
 
 	"github.com/gookit/goutil/comdef"
@@ -23,37 +22,26 @@ func StringsToSlice(ss []string) []any {
 
 // StringsAsInts convert and ignore error
 func StringsAsInts(ss []string) []int {
-	ints, _ := StringsTryInts(ss)
+	ints, _ := ToInts(ss)
 	return ints
 }
 
-// StringsToInts string slice to int slice
-func StringsToInts(ss []string) (ints []int, err error) {
-	return StringsTryInts(ss)
-}
-
-// StringsTryInts string slice to int slice
-func StringsTryInts(ss []string) (ints []int, err error) {
-	for _, str := range ss {
-		iVal, err := strconv.Atoi(str)
-		if err != nil {
-			return nil, err
-		}
+// StringsToInts string slice to int slice.
+//
+// Deprecated: please use ToInts()
+func StringsToInts(ss []string) (ints []int, err error) { return ToInts(ss) }
 
-		ints = append(ints, iVal)
-	}
-	return
-}
+// StringsTryInts string slice to int slice.
+//
+// Deprecated: please use ToInts()
+func StringsTryInts(ss []string) (ints []int, err error) { return ToInts(ss) }
 
-// StringsUnique unique string slice
+// StringsUnique unique string slice. always returns a new slice, nil on ss is empty.
 func StringsUnique(ss []string) []string {
-	var unique []string
-	for _, s := range ss {
-		if !StringsContains(unique, s) {
-			unique = append(unique, s)
-		}
+	if len(ss) == 0 {
+		return nil
 	}
-	return unique
+	return UniqueBy(ss, func(s string) string { return s })
 }
 
 // StringsContains check string slice contains string
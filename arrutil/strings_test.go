@@ -24,6 +24,11 @@ func TestStringsToInts(t *testing.T) {
 
 	_, err = arrutil.StringsToInts([]string{"a", "b"})
 	is.Err(err)
+	is.Eq(`convert element #0(string: a) error: strconv.ParseInt: parsing "a": invalid syntax`, err.Error())
+
+	ints, err = arrutil.StringsTryInts([]string{})
+	is.Nil(err)
+	is.Empty(ints)
 
 	ints = arrutil.StringsAsInts([]string{"1", "2"})
 	is.Eq("[]int{1, 2}", fmt.Sprintf("%#v", ints))
@@ -39,6 +44,17 @@ func TestStringsRemove(t *testing.T) {
 	assert.Len(t, ns, 2)
 }
 
+func TestStringsUnique(t *testing.T) {
+	assert.Eq(t, []string{"a", "b"}, arrutil.StringsUnique([]string{"a", "b", "a"}))
+	assert.Nil(t, arrutil.StringsUnique(nil))
+
+	// returns a new slice
+	ss := []string{"a"}
+	uni := arrutil.StringsUnique(ss)
+	uni[0] = "b"
+	assert.Eq(t, "a", ss[0])
+}
+
 func TestStringsFilter(t *testing.T) {
 	is := assert.New(t)
 
@@ -0,0 +1,91 @@
+package arrutil
+
+// Pair of two values, the result element of Zip()
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// ZipOpt options for Zip() and ZipWith()
+type ZipOpt struct {
+	// Pad the shorter slice with zero value to the longer length.
+	// default will truncate to the shorter length.
+	Pad bool
+}
+
+// ZipOptFn zip option func
+type ZipOptFn func(opt *ZipOpt)
+
+// WithZipPad pad the shorter slice with zero value on zip, instead of truncate.
+func WithZipPad() ZipOptFn {
+	return func(opt *ZipOpt) {
+		opt.Pad = true
+	}
+}
+
+// Zip combine two slices to a pair list by the index.
+// default will truncate to the shorter length, use WithZipPad() to pad with zero value.
+//
+// Usage:
+//
+//	// output: [{1 a} {2 b}]
+//	ps := arrutil.Zip([]int{1, 2, 3}, []string{"a", "b"})
+//	// output: [{1 a} {2 b} {3 }]
+//	ps = arrutil.Zip([]int{1, 2, 3}, []string{"a", "b"}, arrutil.WithZipPad())
+func Zip[A, B any](as []A, bs []B, optFns ...ZipOptFn) []Pair[A, B] {
+	return ZipWith(as, bs, func(a A, b B) Pair[A, B] {
+		return Pair[A, B]{First: a, Second: b}
+	}, optFns...)
+}
+
+// ZipWith combine two slices to a new list by the combiner func.
+// default will truncate to the shorter length, use WithZipPad() to pad with zero value.
+//
+// Usage:
+//
+//	// output: [a=1 b=2]
+//	ss := arrutil.ZipWith([]string{"a", "b"}, []int{1, 2}, func(k string, v int) string {
+//		return k + "=" + strconv.Itoa(v)
+//	})
+func ZipWith[A, B, R any](as []A, bs []B, fn func(a A, b B) R, optFns ...ZipOptFn) []R {
+	opt := &ZipOpt{}
+	for _, optFn := range optFns {
+		optFn(opt)
+	}
+
+	ln := len(as)
+	if opt.Pad == (len(bs) > ln) {
+		ln = len(bs)
+	}
+
+	var zeroA A
+	var zeroB B
+	newLs := make([]R, ln)
+	for i := 0; i < ln; i++ {
+		a, b := zeroA, zeroB
+		if i < len(as) {
+			a = as[i]
+		}
+		if i < len(bs) {
+			b = bs[i]
+		}
+		newLs[i] = fn(a, b)
+	}
+	return newLs
+}
+
+// Unzip split the pair list to two slices. it's the reverse of Zip()
+//
+// Usage:
+//
+//	// output: [1 2] [a b]
+//	ints, strs := arrutil.Unzip([]arrutil.Pair[int, string]{{1, "a"}, {2, "b"}})
+func Unzip[A, B any](ps []Pair[A, B]) ([]A, []B) {
+	as := make([]A, len(ps))
+	bs := make([]B, len(ps))
+	for i, p := range ps {
+		as[i] = p.First
+		bs[i] = p.Second
+	}
+	return as, bs
+}
@@ -0,0 +1,37 @@
+package arrutil_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestZip(t *testing.T) {
+	ps := arrutil.Zip([]int{1, 2, 3}, []string{"a", "b"})
+	assert.Eq(t, []arrutil.Pair[int, string]{{1, "a"}, {2, "b"}}, ps)
+
+	ints, strs := arrutil.Unzip(ps)
+	assert.Eq(t, []int{1, 2}, ints)
+	assert.Eq(t, []string{"a", "b"}, strs)
+
+	ps = arrutil.Zip([]int{1, 2, 3}, []string{"a", "b"}, arrutil.WithZipPad())
+	assert.Eq(t, []arrutil.Pair[int, string]{{1, "a"}, {2, "b"}, {3, ""}}, ps)
+	ps = arrutil.Zip([]int{1}, []string{"a", "b"}, arrutil.WithZipPad())
+	assert.Eq(t, []arrutil.Pair[int, string]{{1, "a"}, {0, "b"}}, ps)
+
+	assert.Empty(t, arrutil.Zip([]int{1}, []string{}))
+	ints, strs = arrutil.Unzip[int, string](nil)
+	assert.Empty(t, ints)
+	assert.Empty(t, strs)
+}
+
+func TestZipWith(t *testing.T) {
+	fn := func(k string, v int) string { return k + "=" + strconv.Itoa(v) }
+
+	ss := arrutil.ZipWith([]string{"a", "b"}, []int{1, 2, 3}, fn)
+	assert.Eq(t, []string{"a=1", "b=2"}, ss)
+	ss = arrutil.ZipWith([]string{"a", "b"}, []int{1, 2, 3}, fn, arrutil.WithZipPad())
+	assert.Eq(t, []string{"a=1", "b=2", "=3"}, ss)
+}
@@ -1,8 +1,8 @@
 package cflag
 
 import (
+	"errors"
 	"flag"
-	"fmt"
 	"io"
 	"os"
 	"path"
@@ -11,6 +11,7 @@ import (
 	"github.com/gookit/color"
 	"github.com/gookit/goutil"
 	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/errorx"
 	"github.com/gookit/goutil/mathutil"
 	"github.com/gookit/goutil/strutil"
 )
@@ -25,11 +26,23 @@ type App struct {
 	// NameWidth max width for command name
 	NameWidth  int
 	HelpWriter io.Writer
+	// ErrWriter for output the porcelain messages on NonInteractive mode. default is os.Stderr
+	ErrWriter io.Writer
 	// Version for app
 	Version string
 
 	// AfterHelpBuild hook
 	AfterHelpBuild func(buf *strutil.Buffer)
+	// ExitFunc for exit app on Run() failed. default is os.Exit
+	ExitFunc func(code int)
+	// NonInteractive mode, all errors and outputs from the framework itself are written
+	// as single-line JSON to the ErrWriter, for reliable scripting. see PorcelainMsg
+	//
+	// It can also be enabled by the global option PorcelainFlag on run.
+	NonInteractive bool
+
+	// custom mapping error to exit code
+	exitCodes []exitCodeMap
 }
 
 // NewApp instance
@@ -41,6 +54,8 @@ func NewApp(fns ...func(app *App)) *App {
 		// NameWidth default value
 		NameWidth:  12,
 		HelpWriter: os.Stdout,
+		ErrWriter:  os.Stderr,
+		ExitFunc:   os.Exit,
 	}
 
 	for _, fn := range fns {
@@ -82,51 +97,170 @@ func (a *App) addCmd(c *Cmd) {
 	}
 }
 
-// Run app by os.Args
+// AddExitCode add custom mapping error to exit code. will check by errors.Is()
+//
+// Usage:
+//
+//	app.AddExitCode(ErrNotFound, 4)
+func (a *App) AddExitCode(err error, code int) {
+	a.exitCodes = append(a.exitCodes, exitCodeMap{err: err, code: code})
+}
+
+// ExitCode get exit code by error. will check custom mapping first, then fallback to ExitCodeOf()
+func (a *App) ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	for _, item := range a.exitCodes {
+		if errors.Is(err, item.err) {
+			return item.code
+		}
+	}
+	return ExitCodeOf(err)
+}
+
+// Run app by os.Args, will call ExitFunc with exit code on error.
+//
+// The error message is got by errorx.UserMsg(), and the full error detail will be printed on Debug mode.
+// On NonInteractive mode, the error is written as a single-line JSON. see PorcelainMsg
 func (a *App) Run() {
-	err := a.RunWithArgs(os.Args[1:])
-	if err != nil {
-		cliutil.Errorln("ERROR:", err)
+	args := os.Args[1:]
+	if err := a.RunWithArgs(args); err != nil {
+		code := a.ReportError(err, args)
+		if a.ExitFunc != nil {
+			a.ExitFunc(code)
+		}
 	}
 }
 
+// ReportError print the error returned by run the args, and returns the exit code. it is used by Run().
+//
+// On NonInteractive mode or the args start with PorcelainFlag, the error is written as a single-line JSON.
+// Otherwise, will print the error by PrintError().
+func (a *App) ReportError(err error, args []string) int {
+	code := a.ExitCode(err)
+	if a.porcelainMode(args) {
+		writePorcelain(a.errWriter(), newErrorMsg(err, code))
+	} else {
+		PrintError(err)
+	}
+	return code
+}
+
+// PrintError print the error message got by errorx.UserMsg(), and the full error detail on Debug mode.
+func PrintError(err error) {
+	cliutil.Errorln("ERROR:", errorx.UserMsg(err))
+	if Debug && errorx.HasUserMsg(err) {
+		cliutil.Errorln("DETAIL:", err)
+	}
+}
+
+// check the run is on porcelain mode, by App.NonInteractive or the global option PorcelainFlag.
+func (a *App) porcelainMode(args []string) bool {
+	return a.NonInteractive || len(args) > 0 && args[0] == PorcelainFlag
+}
+
 // RunWithArgs run app by input args
 func (a *App) RunWithArgs(args []string) error {
 	a.init()
 
+	// the global option for enable non-interactive mode, only for current run.
+	porcelain := a.porcelainMode(args)
+	if len(args) > 0 && args[0] == PorcelainFlag {
+		args = args[1:]
+	}
+
 	if len(args) == 0 || args[0] == "" {
-		return a.showHelp()
+		return a.showHelp(porcelain)
 	}
 
 	name := args[0]
 	if name == "help" || name == "--help" || name == "-h" {
-		return a.showHelp()
+		return a.showHelp(porcelain)
 	}
 
 	if name[0] == '-' {
-		return fmt.Errorf("provide undefined flag option %q", name)
+		return usageErrorf("provide undefined flag option %q", name)
 	}
 
 	cmd, ok := a.findCmd(name)
 	if !ok {
-		return fmt.Errorf("input not exists command %q", name)
+		return usageErrorf("input not exists command %q", name)
 	}
 
+	// the remembered values are saved under the app config dir
+	cmd.appName = a.Name
+	cmd.porcelainW = nil
+	if porcelain {
+		cmd.porcelainW = a.errWriter()
+	}
 	return cmd.Parse(args[1:])
 }
 
+// AddForgetCmd add the command "forget" for clear the remembered flag values. see CFlags.Persist
+//
+// Usage:
+//
+//	app forget          // clear for all commands
+//	app forget COMMAND  // clear for the command
+func (a *App) AddForgetCmd() {
+	cmd := NewCmd("forget", "Clear the remembered flag values")
+	cmd.AddArg("command", "The command name, default clear for all commands", false, nil)
+
+	cmd.Func = func(c *Cmd) error {
+		name := c.Arg("command").String()
+		if name != "" {
+			if _, ok := a.cmds[name]; !ok {
+				return usageErrorf("input not exists command %q", name)
+			}
+		}
+
+		for cname, sub := range a.cmds {
+			if len(sub.persistNames) == 0 || (name != "" && name != cname) {
+				continue
+			}
+
+			sub.appName = a.Name
+			if err := sub.ClearRemembered(); err != nil {
+				return err
+			}
+		}
+
+		if c.porcelainW != nil {
+			writePorcelain(c.porcelainW, &PorcelainMsg{Type: "info", Message: "Cleared the remembered flag values"})
+		} else {
+			cliutil.Infoln("Cleared the remembered flag values")
+		}
+		return nil
+	}
+	a.Add(cmd)
+}
+
 func (a *App) init() {
 	if a.Name == "" {
 		a.Name = path.Base(os.Args[0])
 	}
 }
 
+func (a *App) errWriter() io.Writer {
+	if a.ErrWriter == nil {
+		return os.Stderr
+	}
+	return a.ErrWriter
+}
+
 func (a *App) findCmd(name string) (*Cmd, bool) {
 	cmd, ok := a.cmds[name]
 	return cmd, ok
 }
 
-func (a *App) showHelp() error {
+func (a *App) showHelp(porcelain bool) error {
+	if porcelain {
+		writePorcelain(a.errWriter(), a.porcelainHelp())
+		return nil
+	}
+
 	bin := a.Name
 	buf := strutil.NewBuffer()
 	buf.Printf("<cyan>%s</> - %s", bin, a.Desc)
@@ -137,19 +271,23 @@ func (a *App) showHelp() error {
 
 	buf.Printf("\n\n<comment>Usage:</> %s <green>COMMAND</> [--Options...] [...Arguments]\n", bin)
 
-	buf.WriteStr1Nl("<comment>Options:</>")
-	buf.WriteStr1Nl("  <green>-h, --help</>     Display application help")
-	buf.WriteStr1Nl("\n<comment>Commands:</>")
-
 	sort.Strings(a.names)
+	cmdRows := make([]cliutil.UsageRow, 0, len(a.names)+1)
 	for _, name := range a.names {
 		c := a.cmds[name]
 		name := strutil.PadRight(name, " ", a.NameWidth)
-		buf.Printf("  <green>%s</>  %s\n", name, strutil.UpperFirst(c.Desc))
+		cmdRows = append(cmdRows, cliutil.UsageRow{Name: "<green>" + name + "</>", Desc: strutil.UpperFirst(c.Desc)})
 	}
 
 	name := strutil.PadRight("help", " ", a.NameWidth)
-	buf.Printf("  <green>%s</>  Display application help\n", name)
+	cmdRows = append(cmdRows, cliutil.UsageRow{Name: "<green>" + name + "</>", Desc: "Display application help"})
+
+	buf.WriteStr(cliutil.RenderUsage([]cliutil.UsageBlock{
+		{Title: "<comment>Options</>", Rows: []cliutil.UsageRow{
+			{Name: "<green>-h, --help</>", Desc: "Display application help"},
+		}},
+		{Title: "<comment>Commands</>", Rows: cmdRows},
+	}, 0))
 	buf.Printf("\nUse \"<cyan>%s COMMAND --help</>\" for about a command\n", bin)
 
 	if a.AfterHelpBuild != nil {
@@ -164,6 +302,17 @@ func (a *App) showHelp() error {
 	return nil
 }
 
+// porcelainHelp build the porcelain help message for the app
+func (a *App) porcelainHelp() *PorcelainMsg {
+	msg := &PorcelainMsg{Type: "help", Name: a.Name, Desc: a.Desc, Version: a.Version}
+
+	sort.Strings(a.names)
+	for _, name := range a.names {
+		msg.Commands = append(msg.Commands, PorcelainItem{Name: name, Desc: strutil.UpperFirst(a.cmds[name].Desc)})
+	}
+	return msg
+}
+
 // Cmd struct
 type Cmd struct {
 	*CFlags
@@ -2,11 +2,17 @@ package cflag_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/gookit/color"
 	"github.com/gookit/goutil/cflag"
 	"github.com/gookit/goutil/dump"
+	"github.com/gookit/goutil/errorx"
 	"github.com/gookit/goutil/strutil"
 	"github.com/gookit/goutil/testutil/assert"
 )
@@ -133,3 +139,198 @@ func TestApp_Run(t *testing.T) {
 	assert.Eq(t, "inhere", c1Opts.name)
 	assert.Eq(t, "val1", c1.Arg("arg1").String())
 }
+
+type notFoundErr struct{}
+
+func (e notFoundErr) Error() string { return "not found" }
+
+func (e notFoundErr) ExitCode() int { return 4 }
+
+func TestApp_ExitCode(t *testing.T) {
+	errCustom := errors.New("custom error")
+
+	var exitCode int
+	app := cflag.NewApp(func(app *cflag.App) {
+		app.Name = "myapp"
+		app.ExitFunc = func(code int) {
+			exitCode = code
+		}
+	})
+	app.AddExitCode(errCustom, 5)
+
+	c1 := cflag.NewCmd("demo", "this is a demo command")
+	c1.Func = func(c *cflag.Cmd) error {
+		return fmt.Errorf("wrap error: %w", errCustom)
+	}
+	var name string
+	c2 := cflag.NewCmd("other", "this is another command")
+	c2.StringVar(&name, "name", "", "the name option;true")
+	c2.Func = func(c *cflag.Cmd) error {
+		return notFoundErr{}
+	}
+	app.Add(c1, c2)
+
+	assert.Eq(t, cflag.ExitOK, app.ExitCode(nil))
+	assert.Eq(t, cflag.ExitError, app.ExitCode(errors.New("error")))
+
+	err := app.RunWithArgs([]string{"notExists"})
+	assert.ErrIs(t, err, cflag.ErrUsage)
+	assert.Eq(t, cflag.ExitUsage, app.ExitCode(err))
+
+	err = app.RunWithArgs([]string{"other"})
+	assert.ErrMsg(t, err, "flag option 'name' is required")
+	assert.Eq(t, cflag.ExitUsage, app.ExitCode(err))
+
+	err = app.RunWithArgs([]string{"other", "--invalid"})
+	assert.ErrIs(t, err, cflag.ErrUsage)
+
+	err = app.RunWithArgs([]string{"other", "--name", "inhere"})
+	assert.Eq(t, 4, app.ExitCode(err))
+
+	err = app.RunWithArgs([]string{"demo"})
+	assert.Eq(t, 5, app.ExitCode(err))
+
+	// run by os.Args
+	osArgs := os.Args
+	os.Args = []string{"./myapp", "demo"}
+	app.Run()
+	os.Args = osArgs
+	assert.Eq(t, 5, exitCode)
+}
+
+func TestApp_Run_userMsg(t *testing.T) {
+	var exitCode int
+	app := cflag.NewApp(func(app *cflag.App) {
+		app.Name = "myapp"
+		app.ExitFunc = func(code int) {
+			exitCode = code
+		}
+	})
+
+	cmd := cflag.NewCmd("demo", "this is a demo command")
+	cmd.Func = func(c *cflag.Cmd) error {
+		err := fmt.Errorf("dial tcp 127.0.0.1:80: %w", errors.New("connection refused"))
+		return errorx.WithUserMsg(err, "cannot connect to the server")
+	}
+	app.Add(cmd)
+
+	buf := new(bytes.Buffer)
+	color.SetOutput(buf)
+	defer color.ResetOutput()
+
+	osArgs := os.Args
+	defer func() { os.Args = osArgs }()
+	os.Args = []string{"./myapp", "demo"}
+
+	app.Run()
+	assert.Eq(t, cflag.ExitError, exitCode)
+	assert.StrContains(t, buf.String(), "ERROR: cannot connect to the server")
+	assert.NotContains(t, buf.String(), "connection refused")
+
+	// show detail on debug mode
+	buf.Reset()
+	cflag.SetDebug(true)
+	defer cflag.SetDebug(false)
+
+	app.Run()
+	assert.StrContains(t, buf.String(), "ERROR: cannot connect to the server")
+	assert.StrContains(t, buf.String(), "DETAIL: dial tcp 127.0.0.1:80: connection refused")
+}
+
+func TestApp_NonInteractive(t *testing.T) {
+	var exitCode int
+	errBuf := new(bytes.Buffer)
+	app := cflag.NewApp(func(app *cflag.App) {
+		app.Name = "myapp"
+		app.Desc = "my cli app"
+		app.ErrWriter = errBuf
+		app.ExitFunc = func(code int) {
+			exitCode = code
+		}
+	})
+
+	var age int
+	cmd := cflag.NewCmd("demo", "this is a demo command")
+	cmd.IntVar(&age, "age", 0, "the age option;true;a")
+	cmd.AddArg("name", "the name argument", false, "inhere")
+	cmd.Func = func(c *cflag.Cmd) error {
+		err := errors.New("connection refused")
+		return errorx.WithUserMsg(err, "cannot connect to the server")
+	}
+	app.Add(cmd)
+
+	buf := new(bytes.Buffer)
+	color.SetOutput(buf)
+	defer color.ResetOutput()
+
+	osArgs := os.Args
+	defer func() { os.Args = osArgs }()
+
+	// usage error
+	os.Args = []string{"./myapp", cflag.PorcelainFlag, "notExists"}
+	app.Run()
+	assert.False(t, app.NonInteractive)
+	assert.Eq(t, cflag.ExitUsage, exitCode)
+	assert.Eq(t, `{"type":"error","code":2,"kind":"usage","message":"input not exists command \"notExists\""}`+"\n", errBuf.String())
+	assert.Empty(t, buf.String())
+
+	// error with user message
+	errBuf.Reset()
+	os.Args = []string{"./myapp", cflag.PorcelainFlag, "demo", "--age", "23"}
+	app.Run()
+	assert.Eq(t, cflag.ExitError, exitCode)
+	assert.Eq(t, `{"type":"error","code":1,"kind":"error","message":"cannot connect to the server","detail":"connection refused"}`+"\n", errBuf.String())
+
+	// app help
+	errBuf.Reset()
+	assert.NoErr(t, app.RunWithArgs([]string{cflag.PorcelainFlag, "help"}))
+	assert.Eq(t, `{"type":"help","name":"myapp","desc":"my cli app","version":"0.0.1","commands":[{"name":"demo","desc":"This is a demo command"}]}`+"\n", errBuf.String())
+
+	// command help
+	errBuf.Reset()
+	assert.NoErr(t, app.RunWithArgs([]string{cflag.PorcelainFlag, "demo", "-h"}))
+	assert.Eq(t, 1, strings.Count(errBuf.String(), "\n"))
+
+	msg := new(cflag.PorcelainMsg)
+	assert.NoErr(t, json.Unmarshal(errBuf.Bytes(), msg))
+	assert.Eq(t, "help", msg.Type)
+	assert.Eq(t, "demo", msg.Name)
+	assert.Len(t, msg.Options, 1)
+	assert.Eq(t, cflag.PorcelainItem{Name: "age", Desc: "The age option", Shorts: []string{"a"}, Required: true, Default: "0"}, msg.Options[0])
+	assert.Eq(t, []cflag.PorcelainItem{{Name: "name", Desc: "The name argument", Default: "inhere"}}, msg.Arguments)
+	assert.Empty(t, buf.String())
+}
+
+func TestApp_porcelainPerRun(t *testing.T) {
+	var exitCode int
+	errBuf := new(bytes.Buffer)
+	app := cflag.NewApp(func(app *cflag.App) {
+		app.Name = "myapp"
+		app.ErrWriter = errBuf
+		app.ExitFunc = func(code int) {
+			exitCode = code
+		}
+	})
+
+	buf := new(bytes.Buffer)
+	color.SetOutput(buf)
+	defer color.ResetOutput()
+
+	osArgs := os.Args
+	defer func() { os.Args = osArgs }()
+
+	// run with porcelain flag
+	os.Args = []string{"./myapp", cflag.PorcelainFlag, "notExists"}
+	app.Run()
+	assert.Eq(t, cflag.ExitUsage, exitCode)
+	assert.StrContains(t, errBuf.String(), `{"type":"error","code":2,"kind":"usage"`)
+	assert.Empty(t, buf.String())
+
+	// run again without porcelain flag
+	errBuf.Reset()
+	os.Args = []string{"./myapp", "notExists"}
+	app.Run()
+	assert.Eq(t, cflag.ExitUsage, exitCode)
+	assert.Empty(t, errBuf.String())
+	assert.StrContains(t, buf.String(), `ERROR: input not exists command "notExists"`)
+}
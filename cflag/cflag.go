@@ -13,6 +13,7 @@ package cflag
 
 import (
 	"flag"
+	"fmt"
 	"io"
 	"os"
 	"path"
@@ -24,8 +25,6 @@ import (
 	"github.com/gookit/goutil/cliutil"
 	"github.com/gookit/goutil/envutil"
 	"github.com/gookit/goutil/errorx"
-	"github.com/gookit/goutil/mathutil"
-	"github.com/gookit/goutil/stdio"
 	"github.com/gookit/goutil/structs"
 	"github.com/gookit/goutil/strutil"
 )
@@ -54,8 +53,6 @@ type CFlags struct {
 	// shortcuts map for options. eg: n -> name
 	shortcuts map[string]string
 
-	// argWidth max width value
-	argWidth int
 	// bound arguments.
 	bindArgs []*FlagArg
 	// the argument name to index map.
@@ -63,6 +60,24 @@ type CFlags struct {
 	// remainArgs after binding args
 	remainArgs []string
 
+	// flagNames the declaration order of options
+	flagNames []string
+	// group names of options, keep the add order.
+	groupNames []string
+	// flagGroups map of option name to group name
+	flagGroups map[string]string
+
+	// persistNames the flag names for remember the last-used value. see Persist
+	persistNames []string
+	// persistFile the file path for save the remembered values
+	persistFile string
+	// appName for get the config dir. default is the command name
+	appName string
+	// noRemember value of the flag --no-remember
+	noRemember bool
+	// porcelainW output the porcelain messages, set by App on NonInteractive mode.
+	porcelainW io.Writer
+
 	// Desc command description
 	Desc string
 	// Version command version number
@@ -71,6 +86,8 @@ type CFlags struct {
 	Example string
 	// LongHelp custom help
 	LongHelp string
+	// FlagSort the sort mode of options on render help. default is FlagSortAlpha
+	FlagSort FlagSortMode
 	// Func handler for the command
 	Func func(c *CFlags) error
 }
@@ -96,7 +113,6 @@ func New(fns ...func(c *CFlags)) *CFlags {
 // NewEmpty instance.
 func NewEmpty(fns ...func(c *CFlags)) *CFlags {
 	c := &CFlags{
-		argWidth:  12,
 		shortcuts: make(map[string]string),
 		bindOpts:  make(map[string]*FlagOpt),
 		argNames:  make(map[string]int, 4),
@@ -171,6 +187,27 @@ func (c *CFlags) addShortcuts(name string, shorts []string) {
 	}
 }
 
+// AddOutputFlag add the --output/-o option for select output format. see cliutil.NewFormatter()
+//
+// Usage:
+//
+//	var format string
+//	c.AddOutputFlag(&format, "table")
+//
+//	// in command func
+//	err := cliutil.NewFormatter(format).Print(data)
+func (c *CFlags) AddOutputFlag(p *string, defFormat string) {
+	desc := "Output format, allow: " + strings.Join(cliutil.OutputFormats, ",") + ";false;o"
+	c.StringVar(p, "output", defFormat, desc)
+
+	c.AddValidator("output", func(val any) error {
+		if format, ok := val.(string); ok && !cliutil.IsValidFormat(format) {
+			return errorx.Rawf("invalid format %q", format)
+		}
+		return nil
+	})
+}
+
 // AddArg binding for command
 func (c *CFlags) AddArg(name, desc string, required bool, value any) {
 	arg := &FlagArg{
@@ -197,7 +234,6 @@ func (c *CFlags) BindArg(arg *FlagArg) {
 
 	// register
 	c.bindArgs = append(c.bindArgs, arg)
-	c.argWidth = mathutil.MaxInt(c.argWidth, len(arg.Name))
 	c.argNames[arg.Name] = arg.Index
 }
 
@@ -233,6 +269,11 @@ func (c *CFlags) Parse(args []string) error {
 
 	defer func() {
 		if err := recover(); err != nil {
+			if c.porcelainW != nil {
+				writePorcelain(c.porcelainW, &PorcelainMsg{Type: "error", Code: ExitError, Kind: "error", Message: fmt.Sprint(err)})
+				return
+			}
+
 			if Debug {
 				cliutil.Errorln("recover error on run parse")
 			}
@@ -263,6 +304,7 @@ func (c *CFlags) Parse(args []string) error {
 func (c *CFlags) prepare() error {
 	// dont use flag output.
 	c.SetOutput(io.Discard)
+	c.preparePersist()
 
 	// parse flag usage string
 	c.VisitAll(func(f *flag.Flag) {
@@ -320,6 +362,14 @@ func (c *CFlags) doParse(args []string) error {
 
 	// do parsing
 	if err := c.FlagSet.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return wrapUsageErr(err)
+	}
+
+	// use the remembered values
+	if err := c.applyPersisted(); err != nil {
 		return err
 	}
 
@@ -328,7 +378,12 @@ func (c *CFlags) doParse(args []string) error {
 		return err
 	}
 
-	return c.bindParsedArgs()
+	if err := c.bindParsedArgs(); err != nil {
+		return err
+	}
+
+	c.savePersisted()
+	return nil
 }
 
 // check bind option flags
@@ -336,7 +391,7 @@ func (c *CFlags) checkBindOpts() error {
 	for name, opt := range c.bindOpts {
 		fv := c.Lookup(name).Value
 		if opt.Required && fv.String() == "" {
-			return errorx.Rawf("flag option '%s' is required", name)
+			return usageErrorf("flag option '%s' is required", name)
 		}
 
 		if opt.Validator == nil {
@@ -347,7 +402,7 @@ func (c *CFlags) checkBindOpts() error {
 		if fg, ok := fv.(flag.Getter); ok {
 			err := opt.Validator(fg.Get())
 			if err != nil {
-				return errorx.Rawf("flag option '%s': %s", name, err.Error())
+				return usageErrorf("flag option '%s': %s", name, err.Error())
 			}
 		}
 	}
@@ -364,7 +419,7 @@ func (c *CFlags) bindParsedArgs() error {
 		name := arg.Name
 		if arg.Index > argN {
 			if arg.Required {
-				return errorx.Rawf("argument '%s'(#%d) is required", name, arg.Index)
+				return usageErrorf("argument '%s'(#%d) is required", name, arg.Index)
 			}
 			break
 		}
@@ -372,7 +427,7 @@ func (c *CFlags) bindParsedArgs() error {
 		lastIdx++
 		val := args[arg.Index]
 		if arg.Required && val == "" {
-			return errorx.Rawf("argument '%s'(#%d) is required", name, arg.Index)
+			return usageErrorf("argument '%s'(#%d) is required", name, arg.Index)
 		}
 
 		arg.V = val
@@ -426,6 +481,11 @@ func (c *CFlags) ShowHelp() {
 
 // show help for command
 func (c *CFlags) showHelp(err error) {
+	if c.porcelainW != nil {
+		writePorcelain(c.porcelainW, c.porcelainHelp())
+		return
+	}
+
 	binName := c.Name()
 	helpVars := map[string]string{
 		"{{cmd}}":     binName,
@@ -443,21 +503,17 @@ func (c *CFlags) showHelp(err error) {
 	}
 
 	buf.Printf("<comment>Usage:</> %s [--Options...] [...CliArgs]\n", binName)
-	buf.WriteStr("<comment>Options:</>\n")
-
-	// render options help
-	c.renderOptionsHelp(buf)
 
+	// render options and arguments help
+	blocks := c.optionUsageBlocks()
 	if len(c.bindArgs) > 0 {
-		buf.WriteStr1("\n<comment>CliArgs:</>\n")
+		rows := make([]cliutil.UsageRow, 0, len(c.bindArgs))
 		for _, arg := range c.bindArgs {
-			buf.Printf(
-				"  <green>%s</>   %s\n",
-				strutil.PadRight(arg.Name, " ", c.argWidth),
-				arg.HelpDesc(),
-			)
+			rows = append(rows, cliutil.UsageRow{Name: "<green>" + arg.Name + "</>", Desc: arg.HelpDesc()})
 		}
+		blocks = append(blocks, cliutil.UsageBlock{Title: "<comment>CliArgs</>", Rows: rows})
 	}
+	buf.WriteStr(cliutil.RenderUsage(blocks, 0))
 
 	if c.LongHelp != "" {
 		buf.WriteStr1Nl("\n<comment>Help:</>")
@@ -472,44 +528,53 @@ func (c *CFlags) showHelp(err error) {
 	color.Println(strutil.Replaces(buf.String(), helpVars))
 }
 
-// ShowOptionsHelp prints, to standard error unless configured otherwise, the
-// default values of all defined command-line flags in the set. See the
-// documentation for the global function PrintDefaults for more information.
-//
-// from flag.PrintDefaults
-func (c *CFlags) renderOptionsHelp(buf *strutil.Buffer) {
-	c.VisitAll(func(opt *flag.Flag) {
-		var b strings.Builder
+// optionUsageBlocks build the options usage blocks. the grouped options will be rendered on its group section.
+func (c *CFlags) optionUsageBlocks() []cliutil.UsageBlock {
+	var ungrouped []*flag.Flag
+	grouped := make([][]*flag.Flag, len(c.groupNames))
+
+	for _, opt := range c.sortedFlags() {
+		if idx, ok := c.groupIndex(c.flagGroups[opt.Name]); ok {
+			grouped[idx] = append(grouped[idx], opt)
+		} else {
+			ungrouped = append(ungrouped, opt)
+		}
+	}
 
+	var blocks []cliutil.UsageBlock
+	if len(ungrouped) > 0 || len(grouped) == 0 {
+		blocks = append(blocks, cliutil.UsageBlock{Title: "<comment>Options</>", Rows: c.flagUsageRows(ungrouped)})
+	}
+
+	for i, flags := range grouped {
+		if len(flags) > 0 {
+			blocks = append(blocks, cliutil.UsageBlock{Title: "<comment>" + c.groupNames[i] + "</>", Rows: c.flagUsageRows(flags)})
+		}
+	}
+	return blocks
+}
+
+// flagUsageRows build the usage rows of flags, like flag.PrintDefaults
+func (c *CFlags) flagUsageRows(flags []*flag.Flag) []cliutil.UsageRow {
+	rows := make([]cliutil.UsageRow, 0, len(flags))
+	for _, opt := range flags {
 		mate := c.bindOpts[opt.Name]
-		stdio.QuietFprintf(&b, "  <info>%s</>", mate.HelpName(opt.Name))
+		name := "<info>" + mate.HelpName(opt.Name) + "</>"
 
 		typName, usage := flag.UnquoteUsage(opt)
 		if len(typName) > 0 {
-			b.WriteString(" ")
-			b.WriteString(typName)
+			name += " " + typName
 		}
 
-		// Boolean flags of one ASCII letter are so common we
-		// treat them specially, putting their usage on the same line.
-		if b.Len() <= 20 { // space, space, '-', 'x'.
-			b.WriteString("\t")
-		} else {
-			// Four spaces before the tab triggers good alignment
-			// for both 4- and 8-space tab stops.
-			b.WriteString("\n    \t")
-		}
-		b.WriteString(strings.ReplaceAll(usage, "\n", "\n    \t"))
-
 		// put quotes on the string value
 		if isZero, isStr := IsZeroValue(opt, opt.DefValue); !isZero {
 			if isStr {
-				stdio.QuietFprintf(&b, " (default <magentaB>%q</>)", opt.DefValue)
+				usage += fmt.Sprintf(" (default <magentaB>%q</>)", opt.DefValue)
 			} else {
-				stdio.QuietFprintf(&b, " (default <magentaB>%v</>)", opt.DefValue)
+				usage += fmt.Sprintf(" (default <magentaB>%v</>)", opt.DefValue)
 			}
 		}
-
-		buf.WriteStr1(b.String())
-	})
+		rows = append(rows, cliutil.UsageRow{Name: name, Desc: usage})
+	}
+	return rows
 }
@@ -3,9 +3,11 @@ package cflag_test
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/gookit/goutil/cflag"
+	"github.com/gookit/goutil/cflag/cflagtest"
 	"github.com/gookit/goutil/cliutil"
 	"github.com/gookit/goutil/errorx"
 	"github.com/gookit/goutil/testutil/assert"
@@ -129,3 +131,60 @@ func TestCFlags_Parse(t *testing.T) {
 
 	os.Args = osArgs
 }
+
+func TestCFlags_AddOutputFlag(t *testing.T) {
+	var format string
+	c := cflag.New()
+	c.AddOutputFlag(&format, "table")
+
+	assert.NoErr(t, c.Parse([]string{}))
+	assert.Eq(t, "table", format)
+
+	assert.NoErr(t, c.Parse([]string{"-o", "json"}))
+	assert.Eq(t, "json", format)
+
+	err := c.Parse([]string{"--output", "xml"})
+	assert.ErrSubMsg(t, err, `invalid format "xml"`)
+}
+
+func TestCFlags_SetFlagGroup(t *testing.T) {
+	c := cflag.New(cflag.WithDesc("group test"), cflag.WithFlagSort(cflag.FlagSortDeclare))
+	c.StringVar(new(string), "name", "", "the name")
+	c.StringVar(new(string), "format", "", "output format")
+	c.BoolVar(new(bool), "quiet", false, "quiet mode")
+	c.Int("age", 0, "the age")
+	c.FlagSet.Bool("zz", false, "added by FlagSet")
+	c.Bool("debug", false, "debug mode")
+	c.SetFlagGroup("Output", "format", "quiet")
+	c.SetFlagGroup("Dev", "debug")
+
+	assert.Eq(t, "Output", c.FlagGroup("quiet"))
+	assert.Eq(t, "", c.FlagGroup("name"))
+
+	res := cflagtest.RunCmd(c, "--help")
+	assert.NoErr(t, res.Err)
+	out := res.PlainStdout()
+
+	// declaration order, FlagSet added at end
+	iName, iAge, iZz := strings.Index(out, "--name"), strings.Index(out, "--age"), strings.Index(out, "--zz")
+	assert.True(t, iName < iAge && iAge < iZz)
+
+	// group sections
+	iOpts, iOutput, iDev := strings.Index(out, "Options:"), strings.Index(out, "Output:"), strings.Index(out, "Dev:")
+	assert.True(t, iOpts > 0 && iOpts < iOutput && iOutput < iDev)
+	iFormat, iQuiet, iDebug := strings.Index(out, "--format"), strings.Index(out, "--quiet"), strings.Index(out, "--debug")
+	assert.True(t, iZz < iOutput && iOutput < iFormat && iFormat < iQuiet && iQuiet < iDev && iDev < iDebug)
+}
+
+func TestCFlags_FlagSort(t *testing.T) {
+	c := cflag.New()
+	c.String("name", "", "the name")
+	c.Float64("age", 0, "the age")
+	c.SetFlagGroup("All", "name", "age")
+
+	out := cflagtest.RunCmd(c, "-h").PlainStdout()
+	assert.StrNotContains(t, out, "Options:")
+	assert.StrContains(t, out, "All:")
+	// default sort by name
+	assert.True(t, strings.Index(out, "--age") < strings.Index(out, "--name"))
+}
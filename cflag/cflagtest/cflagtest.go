@@ -0,0 +1,173 @@
+// Package cflagtest provide some test helpers for run and assert the cflag.App, cflag.CFlags
+package cflagtest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/goutil/cflag"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+// Result of run an app or command
+type Result struct {
+	// Args input args for run
+	Args []string
+	// Err returned error on run
+	Err error
+	// ExitCode of run. 0 on success, see cflag.App.ExitCode(), cflag.ExitCodeOf()
+	ExitCode int
+	// Stdout captured output. contains the help and error messages
+	Stdout string
+	// Stderr captured output
+	Stderr string
+}
+
+// Success check run is success
+func (r *Result) Success() bool { return r.ExitCode == 0 }
+
+// PlainStdout get stdout contents without color codes
+func (r *Result) PlainStdout() string { return color.ClearCode(r.Stdout) }
+
+// PlainStderr get stderr contents without color codes
+func (r *Result) PlainStderr() string { return color.ClearCode(r.Stderr) }
+
+// RunApp run the cflag.App with input args, and capture the output and exit status.
+//
+// Usage:
+//
+//	res := cflagtest.RunApp(app, "demo", "--name", "inhere")
+//	assert.Eq(t, 0, res.ExitCode)
+//	assert.StrContains(t, res.Stdout, "...")
+func RunApp(app *cflag.App, args ...string) *Result {
+	return capture(args, func(w io.Writer) (int, error) {
+		oldW, oldErrW := app.HelpWriter, app.ErrWriter
+		// os.Stderr is replaced on call fn()
+		app.HelpWriter, app.ErrWriter = w, os.Stderr
+		defer func() {
+			app.HelpWriter, app.ErrWriter = oldW, oldErrW
+		}()
+
+		if err := app.RunWithArgs(args); err != nil {
+			// same as App.Run()
+			return app.ReportError(err, args), err
+		}
+		return 0, nil
+	})
+}
+
+// RunCmd run the cflag.CFlags command with input args, and capture the output and exit status.
+//
+// Usage:
+//
+//	res := cflagtest.RunCmd(cmd.CFlags, "--name", "inhere")
+func RunCmd(c *cflag.CFlags, args ...string) *Result {
+	if args == nil {
+		args = []string{} // dont fallback to os.Args
+	}
+
+	return capture(args, func(_ io.Writer) (int, error) {
+		if err := c.Parse(args); err != nil {
+			cflag.PrintError(err)
+			return cflag.ExitCodeOf(err), err
+		}
+		return 0, nil
+	})
+}
+
+// capture the color output, os.Stdout and os.Stderr on call fn(). fn should report the error and returns the exit code.
+func capture(args []string, fn func(w io.Writer) (int, error)) *Result {
+	res := &Result{Args: args}
+
+	outFile, err := os.CreateTemp("", "cflagtest-stdout-*")
+	if err != nil {
+		panic(err)
+	}
+	errFile, err := os.CreateTemp("", "cflagtest-stderr-*")
+	if err != nil {
+		panic(err)
+	}
+
+	defer func() {
+		_ = outFile.Close()
+		_ = errFile.Close()
+		_ = os.Remove(outFile.Name())
+		_ = os.Remove(errFile.Name())
+	}()
+
+	oldOut, oldErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outFile, errFile
+	color.SetOutput(outFile)
+
+	func() {
+		defer func() {
+			os.Stdout, os.Stderr = oldOut, oldErr
+			color.ResetOutput()
+		}()
+
+		res.ExitCode, res.Err = fn(outFile)
+	}()
+
+	res.Stdout = readFile(outFile)
+	res.Stderr = readFile(errFile)
+	return res
+}
+
+func readFile(f *os.File) string {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(f)
+	return buf.String()
+}
+
+// CmdOK run the app with input args, and assert it is success. returns the run result for more checks.
+//
+// Usage:
+//
+//	res := cflagtest.CmdOK(t, app, "demo", "--name", "inhere")
+//	assert.StrContains(t, res.Stdout, "hello, inhere")
+func CmdOK(t assert.TestingT, app *cflag.App, args ...string) *Result {
+	t.Helper()
+	res := RunApp(app, args...)
+	if !res.Success() {
+		assert.Fail(t, fmt.Sprintf("Run app with args %q should be success, but exit code is %d\nError: %v\nOutput:\n%s",
+			args, res.ExitCode, res.Err, res.PlainStdout()+res.PlainStderr()))
+	}
+	return res
+}
+
+// CmdFails run the app with input args, and assert it is failed with the exit code,
+// and the error output contains wantErrSub. empty wantErrSub will skip check the output.
+//
+// NOTE: the error message is printed to the color output(stdout) by default, so will check both stdout and stderr.
+//
+// Usage:
+//
+//	cflagtest.CmdFails(t, app, []string{"demo"}, cflag.ExitUsage, "flag option 'name' is required")
+func CmdFails(t assert.TestingT, app *cflag.App, args []string, wantCode int, wantErrSub string) *Result {
+	t.Helper()
+	res := RunApp(app, args...)
+	if res.Success() {
+		assert.Fail(t, fmt.Sprintf("Run app with args %q should be failed, but it is success", args))
+		return res
+	}
+
+	if res.ExitCode != wantCode {
+		assert.Fail(t, fmt.Sprintf("Run app with args %q exit code not equal:\nexpect: %d\nactual: %d\nError: %v",
+			args, wantCode, res.ExitCode, res.Err))
+		return res
+	}
+
+	if output := res.PlainStderr() + res.PlainStdout(); wantErrSub != "" && !strings.Contains(output, wantErrSub) {
+		assert.Fail(t, fmt.Sprintf("Run app with args %q error output check fail:\noutput: %q\nshould contains: %q",
+			args, output, wantErrSub))
+	}
+	return res
+}
@@ -0,0 +1,125 @@
+package cflagtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gookit/goutil/cflag"
+	"github.com/gookit/goutil/cflag/cflagtest"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func newTestApp() *cflag.App {
+	app := cflag.NewApp(func(app *cflag.App) {
+		app.Name = "myapp"
+		app.Desc = "this is my cli application"
+	})
+
+	var name string
+	cmd := cflag.NewCmd("demo", "this is a demo command")
+	cmd.StringVar(&name, "name", "", "the name option;true")
+	cmd.Func = func(c *cflag.Cmd) error {
+		fmt.Println("hello,", name)
+		return nil
+	}
+
+	app.Add(cmd)
+	return app
+}
+
+func TestRunApp(t *testing.T) {
+	app := newTestApp()
+
+	res := cflagtest.RunApp(app)
+	assert.True(t, res.Success())
+	assert.StrContains(t, res.PlainStdout(), "myapp - this is my cli application")
+	assert.StrContains(t, res.PlainStdout(), "demo")
+
+	res = cflagtest.RunApp(app, "demo", "--name", "inhere")
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, 0, res.ExitCode)
+	assert.Eq(t, "hello, inhere\n", res.Stdout)
+	assert.Empty(t, res.Stderr)
+
+	res = cflagtest.RunApp(app, "not-exist")
+	assert.Err(t, res.Err)
+	assert.Eq(t, cflag.ExitUsage, res.ExitCode)
+	assert.StrContains(t, res.PlainStdout(), `ERROR: input not exists command "not-exist"`)
+}
+
+func TestRunApp_porcelain(t *testing.T) {
+	app := newTestApp()
+
+	res := cflagtest.RunApp(app, cflag.PorcelainFlag, "help")
+	assert.True(t, res.Success())
+	assert.Empty(t, res.Stdout)
+	assert.StrContains(t, res.Stderr, `{"type":"help","name":"myapp"`)
+
+	// error is reported as JSON, same as App.Run()
+	res = cflagtest.RunApp(app, cflag.PorcelainFlag, "not-exist")
+	assert.Eq(t, cflag.ExitUsage, res.ExitCode)
+	assert.NotContains(t, res.PlainStdout(), "ERROR:")
+	assert.Eq(t, `{"type":"error","code":2,"kind":"usage","message":"input not exists command \"not-exist\""}`+"\n", res.Stderr)
+}
+
+func TestRunCmd(t *testing.T) {
+	var age int
+	c := cflag.New(func(c *cflag.CFlags) {
+		c.Desc = "this is a demo command"
+	})
+	c.IntVar(&age, "age", 0, "your age;true;a")
+	c.Func = func(c *cflag.CFlags) error {
+		fmt.Println("age:", age)
+		return nil
+	}
+
+	res := cflagtest.RunCmd(c, "-a", "23")
+	assert.True(t, res.Success())
+	assert.Eq(t, "age: 23\n", res.Stdout)
+
+	res = cflagtest.RunCmd(c, "--help")
+	assert.NoErr(t, res.Err)
+	assert.StrContains(t, res.PlainStdout(), "This is a demo command")
+	assert.StrContains(t, res.PlainStdout(), "--age")
+}
+
+func TestRunApp_exitCode(t *testing.T) {
+	app := newTestApp()
+
+	res := cflagtest.RunApp(app, "demo")
+	assert.ErrIs(t, res.Err, cflag.ErrUsage)
+	assert.Eq(t, cflag.ExitUsage, res.ExitCode)
+	assert.StrContains(t, res.PlainStdout(), "ERROR: flag option 'name' is required")
+}
+
+type fakeT struct {
+	*testing.T
+	errs []string
+}
+
+func (f *fakeT) Error(args ...any) { f.errs = append(f.errs, fmt.Sprint(args...)) }
+
+func TestCmdOK_CmdFails(t *testing.T) {
+	res := cflagtest.CmdOK(t, newTestApp(), "demo", "--name", "inhere")
+	assert.Eq(t, "hello, inhere\n", res.Stdout)
+	cflagtest.CmdFails(t, newTestApp(), []string{"demo"}, cflag.ExitUsage, "flag option 'name' is required")
+	cflagtest.CmdFails(t, newTestApp(), []string{"not-exist"}, cflag.ExitUsage, "")
+
+	// fails
+	ft := &fakeT{T: t}
+	cflagtest.CmdOK(ft, newTestApp(), "demo")
+	assert.Len(t, ft.errs, 1)
+	assert.StrContains(t, ft.errs[0], "should be success, but exit code is 2")
+
+	ft.errs = nil
+	cflagtest.CmdFails(ft, newTestApp(), []string{"demo", "--name", "inhere"}, cflag.ExitUsage, "")
+	assert.StrContains(t, ft.errs[0], "should be failed, but it is success")
+
+	ft.errs = nil
+	cflagtest.CmdFails(ft, newTestApp(), []string{"demo"}, cflag.ExitError, "")
+	assert.StrContains(t, ft.errs[0], "exit code not equal")
+
+	ft.errs = nil
+	cflagtest.CmdFails(ft, newTestApp(), []string{"demo"}, cflag.ExitUsage, "not-contains")
+	assert.StrContains(t, ft.errs[0], "error output check fail")
+}
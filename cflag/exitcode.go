@@ -0,0 +1,76 @@
+package cflag
+
+import (
+	"errors"
+	"fmt"
+)
+
+// exit codes for run application
+const (
+	ExitOK    = 0
+	ExitError = 1
+	// ExitUsage invalid usage. eg: unknown command or option, missing required argument
+	ExitUsage = 2
+)
+
+// ErrUsage sentinel error for invalid command usage.
+//
+// Can use errors.Is(err, cflag.ErrUsage) to check it.
+var ErrUsage = errors.New("invalid usage")
+
+// ExitCoder interface. an error implements it can custom the exit code.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// usageError wrap the usage error message
+type usageError struct {
+	msg string
+	err error
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+// Unwrap get the raw error
+func (e *usageError) Unwrap() error { return e.err }
+
+// Is check target is ErrUsage
+func (e *usageError) Is(target error) bool { return target == ErrUsage }
+
+// usageErrorf create new usage error
+func usageErrorf(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// wrapUsageErr wrap an error as usage error
+func wrapUsageErr(err error) error {
+	return &usageError{msg: err.Error(), err: err}
+}
+
+// exitCodeMap item for mapping error to exit code
+type exitCodeMap struct {
+	err  error
+	code int
+}
+
+// ExitCodeOf get exit code by error. will use default mapping:
+//
+//   - nil: ExitOK
+//   - implements ExitCoder: err.ExitCode()
+//   - errors.Is(err, ErrUsage): ExitUsage
+//   - others: ExitError
+func ExitCodeOf(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var ec ExitCoder
+	if errors.As(err, &ec) {
+		return ec.ExitCode()
+	}
+
+	if errors.Is(err, ErrUsage) {
+		return ExitUsage
+	}
+	return ExitError
+}
@@ -0,0 +1,215 @@
+package cflag
+
+import (
+	"encoding"
+	"flag"
+	"sort"
+	"time"
+)
+
+// FlagSortMode the sort mode of options on render help
+type FlagSortMode uint8
+
+// flag sort modes
+const (
+	// FlagSortAlpha sort options by name, is default mode
+	FlagSortAlpha FlagSortMode = iota
+	// FlagSortDeclare keep the declaration order of options
+	FlagSortDeclare
+)
+
+// WithFlagSort set the sort mode of options on render help
+func WithFlagSort(mode FlagSortMode) func(c *CFlags) {
+	return func(c *CFlags) {
+		c.FlagSort = mode
+	}
+}
+
+// SetFlagGroup add options to a named group, the group will be rendered
+// as a section with header on help. group order is by first call order.
+//
+// Usage:
+//
+//	c.SetFlagGroup("Output", "format", "quiet")
+func (c *CFlags) SetFlagGroup(group string, names ...string) {
+	if c.flagGroups == nil {
+		c.flagGroups = make(map[string]string, len(names))
+	}
+
+	if _, ok := c.groupIndex(group); !ok {
+		c.groupNames = append(c.groupNames, group)
+	}
+	for _, name := range names {
+		c.flagGroups[name] = group
+	}
+}
+
+// FlagGroup get the group name of the option, return empty if not grouped.
+func (c *CFlags) FlagGroup(name string) string {
+	return c.flagGroups[name]
+}
+
+func (c *CFlags) groupIndex(group string) (int, bool) {
+	for i, name := range c.groupNames {
+		if name == group {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// record the declaration order of the option
+func (c *CFlags) recordFlag(name string) {
+	c.flagNames = append(c.flagNames, name)
+}
+
+// sortedFlags get all flags by the FlagSort mode
+func (c *CFlags) sortedFlags() []*flag.Flag {
+	var flags []*flag.Flag
+	c.VisitAll(func(f *flag.Flag) {
+		flags = append(flags, f)
+	})
+
+	if c.FlagSort != FlagSortDeclare || len(c.flagNames) == 0 {
+		return flags
+	}
+
+	// NOTE: the options added by FlagSet directly, will be placed at the end by name.
+	orders := make(map[string]int, len(c.flagNames))
+	for i, name := range c.flagNames {
+		orders[name] = i
+	}
+
+	sort.SliceStable(flags, func(i, j int) bool {
+		oi, iok := orders[flags[i].Name]
+		oj, jok := orders[flags[j].Name]
+		if iok && jok {
+			return oi < oj
+		}
+		return iok && !jok
+	})
+	return flags
+}
+
+/*************************************************************
+ * define options. wrap the flag.FlagSet methods for record the declaration order.
+ *
+ * NOTE: flag.FlagSet.Func is shadowed by the field CFlags.Func, use Var() instead.
+ *************************************************************/
+
+// Var defines a flag with the specified name and usage string. see flag.FlagSet.Var
+func (c *CFlags) Var(value flag.Value, name, usage string) {
+	c.FlagSet.Var(value, name, usage)
+	c.recordFlag(name)
+}
+
+// TextVar defines a flag with the specified name, default value, and usage string.
+// see flag.FlagSet.TextVar
+func (c *CFlags) TextVar(p encoding.TextUnmarshaler, name string, value encoding.TextMarshaler, usage string) {
+	c.FlagSet.TextVar(p, name, value, usage)
+	c.recordFlag(name)
+}
+
+// BoolVar defines a bool flag. see flag.FlagSet.BoolVar
+func (c *CFlags) BoolVar(p *bool, name string, value bool, usage string) {
+	c.FlagSet.BoolVar(p, name, value, usage)
+	c.recordFlag(name)
+}
+
+// Bool defines a bool flag. see flag.FlagSet.Bool
+func (c *CFlags) Bool(name string, value bool, usage string) *bool {
+	p := c.FlagSet.Bool(name, value, usage)
+	c.recordFlag(name)
+	return p
+}
+
+// StringVar defines a string flag. see flag.FlagSet.StringVar
+func (c *CFlags) StringVar(p *string, name string, value string, usage string) {
+	c.FlagSet.StringVar(p, name, value, usage)
+	c.recordFlag(name)
+}
+
+// String defines a string flag. see flag.FlagSet.String
+func (c *CFlags) String(name string, value string, usage string) *string {
+	p := c.FlagSet.String(name, value, usage)
+	c.recordFlag(name)
+	return p
+}
+
+// IntVar defines an int flag. see flag.FlagSet.IntVar
+func (c *CFlags) IntVar(p *int, name string, value int, usage string) {
+	c.FlagSet.IntVar(p, name, value, usage)
+	c.recordFlag(name)
+}
+
+// Int defines an int flag. see flag.FlagSet.Int
+func (c *CFlags) Int(name string, value int, usage string) *int {
+	p := c.FlagSet.Int(name, value, usage)
+	c.recordFlag(name)
+	return p
+}
+
+// Int64Var defines an int64 flag. see flag.FlagSet.Int64Var
+func (c *CFlags) Int64Var(p *int64, name string, value int64, usage string) {
+	c.FlagSet.Int64Var(p, name, value, usage)
+	c.recordFlag(name)
+}
+
+// Int64 defines an int64 flag. see flag.FlagSet.Int64
+func (c *CFlags) Int64(name string, value int64, usage string) *int64 {
+	p := c.FlagSet.Int64(name, value, usage)
+	c.recordFlag(name)
+	return p
+}
+
+// UintVar defines a uint flag. see flag.FlagSet.UintVar
+func (c *CFlags) UintVar(p *uint, name string, value uint, usage string) {
+	c.FlagSet.UintVar(p, name, value, usage)
+	c.recordFlag(name)
+}
+
+// Uint defines a uint flag. see flag.FlagSet.Uint
+func (c *CFlags) Uint(name string, value uint, usage string) *uint {
+	p := c.FlagSet.Uint(name, value, usage)
+	c.recordFlag(name)
+	return p
+}
+
+// Uint64Var defines a uint64 flag. see flag.FlagSet.Uint64Var
+func (c *CFlags) Uint64Var(p *uint64, name string, value uint64, usage string) {
+	c.FlagSet.Uint64Var(p, name, value, usage)
+	c.recordFlag(name)
+}
+
+// Uint64 defines a uint64 flag. see flag.FlagSet.Uint64
+func (c *CFlags) Uint64(name string, value uint64, usage string) *uint64 {
+	p := c.FlagSet.Uint64(name, value, usage)
+	c.recordFlag(name)
+	return p
+}
+
+// Float64Var defines a float64 flag. see flag.FlagSet.Float64Var
+func (c *CFlags) Float64Var(p *float64, name string, value float64, usage string) {
+	c.FlagSet.Float64Var(p, name, value, usage)
+	c.recordFlag(name)
+}
+
+// Float64 defines a float64 flag. see flag.FlagSet.Float64
+func (c *CFlags) Float64(name string, value float64, usage string) *float64 {
+	p := c.FlagSet.Float64(name, value, usage)
+	c.recordFlag(name)
+	return p
+}
+
+// DurationVar defines a time.Duration flag. see flag.FlagSet.DurationVar
+func (c *CFlags) DurationVar(p *time.Duration, name string, value time.Duration, usage string) {
+	c.FlagSet.DurationVar(p, name, value, usage)
+	c.recordFlag(name)
+}
+
+// Duration defines a time.Duration flag. see flag.FlagSet.Duration
+func (c *CFlags) Duration(name string, value time.Duration, usage string) *time.Duration {
+	p := c.FlagSet.Duration(name, value, usage)
+	c.recordFlag(name)
+	return p
+}
@@ -0,0 +1,182 @@
+package cflag
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/sysutil"
+)
+
+// NoRememberFlag the flag name for skip use and save the remembered flag values on current run.
+const NoRememberFlag = "no-remember"
+
+// PersistFileName the default file name for save the remembered flag values, under the app config dir.
+const PersistFileName = "flags.json"
+
+// Persist mark the flags to remember the last-used value. the value will be saved under the
+// app config dir on parse success, and used as the default value on next run.
+//
+// Will add the flag --no-remember for skip use and save the remembered values on current run.
+//
+// Usage:
+//
+//	c.StringVar(&opts.region, "region", "", "the region name")
+//	c.Persist("region")
+func (c *CFlags) Persist(names ...string) *CFlags {
+	c.persistNames = append(c.persistNames, names...)
+	return c
+}
+
+// SetPersistFile set the file path for save the remembered flag values.
+// default is {ConfigDir(app)}/flags.json, see sysutil.ConfigDir
+func (c *CFlags) SetPersistFile(fpath string) *CFlags {
+	c.persistFile = fpath
+	return c
+}
+
+// PersistFile get the file path for save the remembered flag values.
+func (c *CFlags) PersistFile() string {
+	if c.persistFile == "" {
+		dir, err := sysutil.ConfigDir(c.persistApp())
+		if err != nil {
+			return ""
+		}
+		c.persistFile = filepath.Join(dir, PersistFileName)
+	}
+	return c.persistFile
+}
+
+// the app name for config dir
+func (c *CFlags) persistApp() string {
+	if c.appName != "" {
+		return c.appName
+	}
+	return c.Name()
+}
+
+// Remembered get the remembered flag values of the command.
+func (c *CFlags) Remembered() map[string]string {
+	return loadPersisted(c.PersistFile())[c.Name()]
+}
+
+// ClearRemembered clear the remembered flag values of the command.
+func (c *CFlags) ClearRemembered() error {
+	fpath := c.PersistFile()
+	data := loadPersisted(fpath)
+	if _, ok := data[c.Name()]; !ok {
+		return nil
+	}
+
+	delete(data, c.Name())
+	return savePersisted(fpath, data)
+}
+
+// add the --no-remember flag
+func (c *CFlags) preparePersist() {
+	if len(c.persistNames) > 0 && c.Lookup(NoRememberFlag) == nil {
+		c.BoolVar(&c.noRemember, NoRememberFlag, false, "Do not use and save the remembered flag values")
+	}
+}
+
+// use the remembered values for the flags not set on command line
+func (c *CFlags) applyPersisted() error {
+	if len(c.persistNames) == 0 || c.noRemember {
+		return nil
+	}
+
+	setFlags := c.setFlagNames()
+	values := c.Remembered()
+	for _, name := range c.persistNames {
+		val, ok := values[name]
+		if !ok || setFlags[name] || c.Lookup(name) == nil {
+			continue
+		}
+
+		if err := c.Set(name, val); err != nil {
+			return usageErrorf("invalid remembered value %q of the flag option '%s': %s", val, name, err.Error())
+		}
+	}
+	return nil
+}
+
+// save the flag values that set on command line
+func (c *CFlags) savePersisted() {
+	if len(c.persistNames) == 0 || c.noRemember {
+		return
+	}
+
+	setFlags := c.setFlagNames()
+	values := make(map[string]string)
+	for _, name := range c.persistNames {
+		if setFlags[name] {
+			values[name] = c.Lookup(name).Value.String()
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	fpath := c.PersistFile()
+	data := loadPersisted(fpath)
+	if data[c.Name()] == nil {
+		data[c.Name()] = values
+	} else {
+		for name, val := range values {
+			data[c.Name()][name] = val
+		}
+	}
+
+	if err := savePersisted(fpath, data); err != nil && Debug {
+		cliutil.Errorln("cflag: save remembered flag values error:", err)
+	}
+}
+
+// the flag names that set on command line
+func (c *CFlags) setFlagNames() map[string]bool {
+	names := make(map[string]bool)
+	c.Visit(func(f *flag.Flag) {
+		names[f.Name] = true
+	})
+	return names
+}
+
+// load the persisted data. format: {command: {flag: value}}
+func loadPersisted(fpath string) map[string]map[string]string {
+	data := make(map[string]map[string]string)
+	if fpath == "" {
+		return data
+	}
+
+	bs, err := os.ReadFile(fpath)
+	if err == nil {
+		err = json.Unmarshal(bs, &data)
+	}
+
+	if err != nil && !os.IsNotExist(err) && Debug {
+		cliutil.Errorln("cflag: load remembered flag values error:", err)
+	}
+	return data
+}
+
+func savePersisted(fpath string, data map[string]map[string]string) error {
+	if len(data) == 0 {
+		err := os.Remove(fpath)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	bs, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(fpath), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(fpath, bs, 0600)
+}
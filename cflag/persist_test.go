@@ -0,0 +1,101 @@
+package cflag_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/cflag"
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestCFlags_Persist(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "sub", "flags.json")
+
+	var region, name string
+	newCmd := func() *cflag.CFlags {
+		region, name = "", ""
+		c := cflag.New(func(c *cflag.CFlags) {
+			c.Desc = "persist test"
+		})
+		c.StringVar(&region, "region", "", "the region name;true")
+		c.StringVar(&name, "name", "", "the name")
+		return c.Persist("region").SetPersistFile(fpath)
+	}
+
+	// first run: save the value
+	c := newCmd()
+	assert.NoErr(t, c.Parse([]string{"--region", "us-east", "--name", "inhere"}))
+	assert.Eq(t, "us-east", region)
+	assert.True(t, fsutil.IsFile(fpath))
+	assert.Eq(t, map[string]string{"region": "us-east"}, c.Remembered())
+
+	// second run: use the remembered value, satisfied the required check
+	c = newCmd()
+	assert.NoErr(t, c.Parse([]string{}))
+	assert.Eq(t, "us-east", region)
+	assert.Eq(t, "", name)
+
+	// override and remember the new value
+	c = newCmd()
+	assert.NoErr(t, c.Parse([]string{"--region", "eu-west"}))
+	c = newCmd()
+	assert.NoErr(t, c.Parse([]string{}))
+	assert.Eq(t, "eu-west", region)
+
+	// --no-remember: not use and not save
+	c = newCmd()
+	err := c.Parse([]string{"--no-remember"})
+	assert.ErrSubMsg(t, err, "flag option 'region' is required")
+
+	c = newCmd()
+	assert.NoErr(t, c.Parse([]string{"--no-remember", "--region", "ap-south"}))
+	assert.Eq(t, "ap-south", region)
+	assert.Eq(t, "eu-west", c.Remembered()["region"])
+
+	// clear
+	assert.NoErr(t, c.ClearRemembered())
+	assert.Empty(t, c.Remembered())
+	assert.False(t, fsutil.IsFile(fpath))
+	assert.NoErr(t, c.ClearRemembered())
+}
+
+func TestApp_AddForgetCmd(t *testing.T) {
+	cfgDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", cfgDir)
+	t.Setenv("APPDATA", cfgDir)
+
+	var region string
+	var cmd *cflag.Cmd
+	newApp := func() *cflag.App {
+		region = ""
+		cmd = cflag.NewCmd("deploy", "deploy the app")
+		cmd.StringVar(&region, "region", "", "the region name")
+		cmd.Persist("region")
+		cmd.Func = func(c *cflag.Cmd) error { return nil }
+
+		app := cflag.NewApp(func(app *cflag.App) {
+			app.Name = "myapp"
+		})
+		app.Add(cmd)
+		app.AddForgetCmd()
+		return app
+	}
+
+	assert.NoErr(t, newApp().RunWithArgs([]string{"deploy", "--region", "us"}))
+	fpath := filepath.Join(cfgDir, "myapp", cflag.PersistFileName)
+	assert.Eq(t, fpath, cmd.PersistFile())
+	assert.True(t, fsutil.IsFile(fpath))
+
+	assert.NoErr(t, newApp().RunWithArgs([]string{"deploy"}))
+	assert.Eq(t, "us", region)
+
+	err := newApp().RunWithArgs([]string{"forget", "not-exists"})
+	assert.ErrSubMsg(t, err, `input not exists command "not-exists"`)
+
+	assert.NoErr(t, newApp().RunWithArgs([]string{"forget", "deploy"}))
+	assert.False(t, fsutil.IsFile(fpath))
+
+	assert.NoErr(t, newApp().RunWithArgs([]string{"deploy"}))
+	assert.Eq(t, "", region)
+}
@@ -0,0 +1,113 @@
+package cflag
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/goutil/errorx"
+	"github.com/gookit/goutil/strutil"
+)
+
+// PorcelainFlag the global option for enable the App.NonInteractive mode.
+//
+// It must be placed before the command name. eg: app --porcelain COMMAND ...
+const PorcelainFlag = "--porcelain"
+
+// PorcelainMsg the single-line JSON message for output on App.NonInteractive mode.
+type PorcelainMsg struct {
+	// Type of the message. allow: error, help, info
+	Type string `json:"type"`
+	// Code the exit code on Type is error
+	Code int `json:"code,omitempty"`
+	// Kind of the error, allow: usage, error
+	Kind string `json:"kind,omitempty"`
+	// Message for the error or info
+	Message string `json:"message,omitempty"`
+	// Detail the raw error message, only set on the error has different user message.
+	Detail string `json:"detail,omitempty"`
+
+	// Name of the app or command, on Type is help
+	Name string `json:"name,omitempty"`
+	// Desc of the app or command, on Type is help
+	Desc    string `json:"desc,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Commands of the app, on Type is help
+	Commands []PorcelainItem `json:"commands,omitempty"`
+	// Options of the command, on Type is help
+	Options []PorcelainItem `json:"options,omitempty"`
+	// Arguments of the command, on Type is help
+	Arguments []PorcelainItem `json:"arguments,omitempty"`
+}
+
+// PorcelainItem the command, option or argument info in the PorcelainMsg
+type PorcelainItem struct {
+	Name     string   `json:"name"`
+	Desc     string   `json:"desc"`
+	Shorts   []string `json:"shorts,omitempty"`
+	Required bool     `json:"required,omitempty"`
+	Default  string   `json:"default,omitempty"`
+}
+
+// writePorcelain write the message as a single-line JSON
+func writePorcelain(w io.Writer, msg *PorcelainMsg) {
+	bs, err := json.Marshal(msg)
+	if err != nil {
+		bs, _ = json.Marshal(&PorcelainMsg{Type: "error", Code: ExitError, Kind: "error", Message: err.Error()})
+	}
+	_, _ = w.Write(append(bs, '\n'))
+}
+
+// newErrorMsg build the porcelain message for the error
+func newErrorMsg(err error, code int) *PorcelainMsg {
+	msg := &PorcelainMsg{
+		Type:    "error",
+		Code:    code,
+		Kind:    "error",
+		Message: errorx.UserMsg(err),
+	}
+
+	if errors.Is(err, ErrUsage) {
+		msg.Kind = "usage"
+	}
+	if errorx.HasUserMsg(err) {
+		msg.Detail = err.Error()
+	}
+	return msg
+}
+
+// porcelainHelp build the porcelain help message for the command
+func (c *CFlags) porcelainHelp() *PorcelainMsg {
+	msg := &PorcelainMsg{
+		Type:    "help",
+		Name:    c.Name(),
+		Desc:    strutil.UpperFirst(c.Desc),
+		Version: c.Version,
+	}
+
+	c.VisitAll(func(f *flag.Flag) {
+		item := PorcelainItem{Name: f.Name, Desc: color.ClearTag(f.Usage), Default: f.DefValue}
+		if opt, ok := c.bindOpts[f.Name]; ok {
+			item.Shorts = opt.Shortcuts
+			item.Required = opt.Required
+		}
+		if item.Required {
+			// remove the required mark added by parseFlagUsage()
+			item.Desc = strings.TrimPrefix(item.Desc, "*")
+		}
+		msg.Options = append(msg.Options, item)
+	})
+
+	for _, arg := range c.bindArgs {
+		msg.Arguments = append(msg.Arguments, PorcelainItem{
+			Name:     arg.Name,
+			Desc:     strutil.UpperFirst(arg.Desc),
+			Required: arg.Required,
+			Default:  arg.defVal,
+		})
+	}
+	return msg
+}
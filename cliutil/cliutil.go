@@ -60,12 +60,12 @@ func ExecLine(cmdLine string, workDir ...string) (string, error) {
 //
 //	ExecCmd("ls", []string{"-al"})
 func ExecCmd(binName string, args []string, workDir ...string) (string, error) {
-	return comfunc.ExecCmd(binName, args, workDir...)
+	return sysutil.ExecCmd(binName, args, workDir...)
 }
 
 // ExecCommand alias of the ExecCmd()
 func ExecCommand(binName string, args []string, workDir ...string) (string, error) {
-	return comfunc.ExecCmd(binName, args, workDir...)
+	return sysutil.ExecCmd(binName, args, workDir...)
 }
 
 // ShellExec exec command by shell
@@ -73,7 +73,7 @@ func ExecCommand(binName string, args []string, workDir ...string) (string, erro
 // Usage:
 // ret, err := cliutil.ShellExec("ls -al")
 func ShellExec(cmdLine string, shells ...string) (string, error) {
-	return comfunc.ShellExec(cmdLine, shells...)
+	return sysutil.ShellExec(cmdLine, shells...)
 }
 
 // CurrentShell get current used shell env file. eg "/bin/zsh" "/bin/bash"
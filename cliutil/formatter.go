@@ -0,0 +1,405 @@
+package cliutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/gookit/goutil/strutil"
+)
+
+// output formats for the Formatter
+const (
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatTable = "table"
+	FormatPlain = "plain"
+	// FormatTemplate use go text/template render. format: "template=TEXT"
+	FormatTemplate = "template"
+)
+
+// OutputFormats all supported output format names
+var OutputFormats = []string{FormatJSON, FormatYAML, FormatTable, FormatPlain, FormatTemplate}
+
+// IsValidFormat check the output format is valid
+func IsValidFormat(format string) bool {
+	name, _ := splitFormat(format)
+	for _, f := range OutputFormats {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Formatter for switch output format of the data. eg: json, yaml, table, plain, template
+type Formatter struct {
+	// Format name. see OutputFormats
+	Format string
+	// Template text for the template format
+	Template string
+	// Indent for json format. default is 2 spaces
+	Indent string
+	// Out writer, default is os.Stdout
+	Out io.Writer
+}
+
+// NewFormatter create a new Formatter.
+//
+// Usage:
+//
+//	cliutil.NewFormatter("json").Print(data)
+//	cliutil.NewFormatter("template={{.Name}}").Print(user)
+func NewFormatter(format string) *Formatter {
+	name, tpl := splitFormat(format)
+	if name == "" {
+		name = FormatPlain
+	}
+
+	return &Formatter{
+		Format:   name,
+		Template: tpl,
+		Indent:   "  ",
+		Out:      os.Stdout,
+	}
+}
+
+// split format name and template text. eg: "template={{.Name}}"
+func splitFormat(format string) (name, tpl string) {
+	format = strings.TrimSpace(format)
+	if idx := strings.IndexAny(format, "=:"); idx > 0 {
+		return strings.ToLower(format[:idx]), format[idx+1:]
+	}
+	return strings.ToLower(format), ""
+}
+
+// Print the data by format to the Out writer
+func (f *Formatter) Print(v any) error {
+	s, err := f.Render(v)
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	_, err = io.WriteString(f.Out, s)
+	return err
+}
+
+// Render the data to string by format
+func (f *Formatter) Render(v any) (string, error) {
+	switch f.Format {
+	case FormatJSON:
+		bs, err := json.MarshalIndent(v, "", f.Indent)
+		return string(bs), err
+	case FormatYAML:
+		gv, err := toGenericValue(v)
+		if err != nil {
+			return "", err
+		}
+
+		var buf bytes.Buffer
+		writeYAML(&buf, gv, 0)
+		return buf.String(), nil
+	case FormatTable:
+		return renderTable(v)
+	case FormatPlain:
+		return renderPlain(v), nil
+	case FormatTemplate:
+		tpl, err := template.New("output").Parse(f.Template)
+		if err != nil {
+			return "", err
+		}
+
+		var buf bytes.Buffer
+		err = tpl.Execute(&buf, v)
+		return buf.String(), err
+	}
+	return "", fmt.Errorf("cliutil: invalid output format %q, allow: %v", f.Format, OutputFormats)
+}
+
+// convert value to generic value(map[string]any, []any, scalar) by JSON
+func toGenericValue(v any) (any, error) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var gv any
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+	err = dec.Decode(&gv)
+	return gv, err
+}
+
+func renderPlain(v any) string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprint(v)
+	}
+
+	var sb strings.Builder
+	for i := 0; i < rv.Len(); i++ {
+		sb.WriteString(fmt.Sprint(rv.Index(i).Interface()))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+/*************************************************************
+ * render yaml
+ *************************************************************/
+
+func writeYAML(buf *bytes.Buffer, v any, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	switch tv := v.(type) {
+	case map[string]any:
+		if len(tv) == 0 {
+			buf.WriteString(pad + "{}\n")
+			return
+		}
+
+		for _, key := range sortedKeys(tv) {
+			buf.WriteString(pad + yamlScalar(key) + ":")
+			writeYAMLValue(buf, tv[key], indent)
+		}
+	case []any:
+		if len(tv) == 0 {
+			buf.WriteString(pad + "[]\n")
+			return
+		}
+
+		for _, item := range tv {
+			if !isYAMLCollection(item) {
+				buf.WriteString(pad + "-")
+				writeYAMLValue(buf, item, indent)
+				continue
+			}
+
+			// render the collection item inline. eg: "- key: value"
+			var sub bytes.Buffer
+			writeYAML(&sub, item, indent+1)
+			buf.WriteString(pad + "- ")
+			buf.Write(sub.Bytes()[len(pad)+2:])
+		}
+	default:
+		buf.WriteString(pad + yamlScalar(v) + "\n")
+	}
+}
+
+// check value is non-empty map or slice
+func isYAMLCollection(v any) bool {
+	switch tv := v.(type) {
+	case map[string]any:
+		return len(tv) > 0
+	case []any:
+		return len(tv) > 0
+	}
+	return false
+}
+
+// write the value after "key:" or "-"
+func writeYAMLValue(buf *bytes.Buffer, v any, indent int) {
+	switch tv := v.(type) {
+	case map[string]any:
+		if len(tv) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+	case []any:
+		if len(tv) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+	default:
+		buf.WriteString(" " + yamlScalar(v) + "\n")
+		return
+	}
+
+	buf.WriteByte('\n')
+	writeYAML(buf, v, indent+1)
+}
+
+func yamlScalar(v any) string {
+	switch tv := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(tv)
+	case json.Number:
+		return tv.String()
+	case string:
+		if yamlNeedQuote(tv) {
+			return strconv.Quote(tv)
+		}
+		return tv
+	}
+	return fmt.Sprint(v)
+}
+
+func yamlNeedQuote(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off":
+		return true
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	return strings.ContainsAny(s, "\n\t") || strings.Contains(s, ": ") || strings.Contains(s, " #")
+}
+
+func sortedKeys(mp map[string]any) []string {
+	keys := make([]string, 0, len(mp))
+	for key := range mp {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+/*************************************************************
+ * render table
+ *************************************************************/
+
+func renderTable(v any) (string, error) {
+	gv, err := toGenericValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	var cols []string
+	var rows [][]string
+	switch tv := gv.(type) {
+	case []any:
+		cols = tableColumns(v, tv)
+		for _, item := range tv {
+			if mp, ok := item.(map[string]any); ok {
+				row := make([]string, len(cols))
+				for i, col := range cols {
+					row[i] = tableCell(mp[col])
+				}
+				rows = append(rows, row)
+			} else {
+				rows = append(rows, []string{tableCell(item)})
+			}
+		}
+	case map[string]any:
+		cols = []string{"key", "value"}
+		for _, key := range sortedKeys(tv) {
+			rows = append(rows, []string{key, tableCell(tv[key])})
+		}
+	default:
+		cols = []string{"value"}
+		rows = append(rows, []string{tableCell(tv)})
+	}
+
+	// calc column widths
+	widths := make([]int, len(cols))
+	for i, col := range cols {
+		widths[i] = strutil.Utf8Width(col)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := strutil.Utf8Width(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	var sb strings.Builder
+	header := make([]string, len(cols))
+	for i, col := range cols {
+		header[i] = strings.ToUpper(col)
+	}
+
+	writeTableRow(&sb, header, widths)
+	for _, row := range rows {
+		writeTableRow(&sb, row, widths)
+	}
+	return sb.String(), nil
+}
+
+func writeTableRow(sb *strings.Builder, row []string, widths []int) {
+	for i, cell := range row {
+		if i == len(row)-1 {
+			sb.WriteString(cell)
+			break
+		}
+
+		sb.WriteString(cell)
+		sb.WriteString(strings.Repeat(" ", widths[i]-strutil.Utf8Width(cell)+2))
+	}
+	sb.WriteByte('\n')
+}
+
+// get table columns. for struct elements will keep the fields order
+func tableColumns(raw any, items []any) []string {
+	rt := reflect.TypeOf(raw)
+	for rt != nil && (rt.Kind() == reflect.Ptr || rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array) {
+		rt = rt.Elem()
+	}
+
+	var cols []string
+	if rt != nil && rt.Kind() == reflect.Struct {
+		for i := 0; i < rt.NumField(); i++ {
+			sf := rt.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			name := sf.Name
+			if tag := sf.Tag.Get("json"); tag != "" {
+				if tag = strings.Split(tag, ",")[0]; tag == "-" {
+					continue
+				} else if tag != "" {
+					name = tag
+				}
+			}
+			cols = append(cols, name)
+		}
+		return cols
+	}
+
+	// collect all keys in map elements
+	keySet := make(map[string]any)
+	for _, item := range items {
+		if mp, ok := item.(map[string]any); ok {
+			for key := range mp {
+				keySet[key] = true
+			}
+		}
+	}
+
+	if len(keySet) == 0 {
+		return []string{"value"}
+	}
+	return sortedKeys(keySet)
+}
+
+func tableCell(v any) string {
+	switch v.(type) {
+	case map[string]any, []any:
+		bs, _ := json.Marshal(v)
+		return string(bs)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,78 @@
+package cliutil_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+type fmtUser struct {
+	Name string   `json:"name"`
+	Age  int      `json:"age"`
+	Tags []string `json:"tags,omitempty"`
+	pwd  string
+}
+
+func TestFormatter_Render(t *testing.T) {
+	users := []fmtUser{
+		{Name: "inhere", Age: 23, Tags: []string{"go", "php"}},
+		{Name: "tom", Age: 3},
+	}
+
+	s, err := cliutil.NewFormatter("json").Render(users[1])
+	assert.NoErr(t, err)
+	assert.Eq(t, "{\n  \"name\": \"tom\",\n  \"age\": 3\n}", s)
+
+	s, err = cliutil.NewFormatter("yaml").Render(users)
+	assert.NoErr(t, err)
+	assert.Eq(t, `- age: 23
+  name: inhere
+  tags:
+    - go
+    - php
+- age: 3
+  name: tom
+`, s)
+
+	s, err = cliutil.NewFormatter("YAML").Render(map[string]any{"empty": "", "num": "12", "list": []int{}})
+	assert.NoErr(t, err)
+	assert.Eq(t, "empty: \"\"\nlist: []\nnum: \"12\"\n", s)
+
+	s, err = cliutil.NewFormatter("table").Render(users)
+	assert.NoErr(t, err)
+	assert.Eq(t, `NAME    AGE  TAGS
+inhere  23   ["go","php"]
+tom     3    
+`, s)
+
+	s, err = cliutil.NewFormatter("table").Render(map[string]int{"a": 1, "b": 2})
+	assert.NoErr(t, err)
+	assert.Eq(t, "KEY  VALUE\na    1\nb    2\n", s)
+
+	s, err = cliutil.NewFormatter("plain").Render([]string{"a", "b"})
+	assert.NoErr(t, err)
+	assert.Eq(t, "a\nb\n", s)
+
+	s, err = cliutil.NewFormatter("template={{.Name}}:{{.Age}}").Render(users[0])
+	assert.NoErr(t, err)
+	assert.Eq(t, "inhere:23", s)
+
+	_, err = cliutil.NewFormatter("invalid").Render(users)
+	assert.ErrSubMsg(t, err, "invalid output format")
+}
+
+func TestFormatter_Print(t *testing.T) {
+	buf := new(bytes.Buffer)
+	f := cliutil.NewFormatter("")
+	f.Out = buf
+
+	assert.Eq(t, cliutil.FormatPlain, f.Format)
+	assert.NoErr(t, f.Print("hello"))
+	assert.Eq(t, "hello\n", buf.String())
+
+	assert.True(t, cliutil.IsValidFormat("json"))
+	assert.True(t, cliutil.IsValidFormat("template={{.}}"))
+	assert.False(t, cliutil.IsValidFormat("xml"))
+}
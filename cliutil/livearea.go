@@ -0,0 +1,251 @@
+package cliutil
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/sysutil"
+	"golang.org/x/term"
+)
+
+// LiveArea owns the last N lines of the terminal, and re-render them in place on change.
+// useful for show the multi-line progress of concurrent tasks.
+//
+// On the output is not a terminal(pipe, file, TERM=dumb), it will degrade to print the changed line only.
+//
+// Usage:
+//
+//	la := cliutil.NewLiveArea(os.Stdout)
+//	defer la.Stop()
+//
+//	la.Add("task1", "task1: downloading...")
+//	la.Add("task2", "task2: waiting")
+//	la.Update("task1", "task1: done")
+//	la.Remove("task2")
+type LiveArea struct {
+	mu  sync.Mutex
+	w   io.Writer
+	tty bool
+	// ordered line ids and the line texts
+	ids   []string
+	lines map[string]string
+	// the fd of the terminal, -1 if w is not a file
+	fd int
+	// max width of each line, 0 for use the terminal width
+	width int
+	// the number of lines rendered last time
+	rendered int
+	stopped  bool
+	// cursor is hidden by render, must restore on stop
+	cursorHidden bool
+}
+
+// NewLiveArea create a new LiveArea for the writer
+func NewLiveArea(w io.Writer) *LiveArea {
+	fd, tty := -1, false
+	if f, ok := w.(interface{ Fd() uintptr }); ok {
+		fd = int(f.Fd())
+		tty = sysutil.IsTerminal(f.Fd()) && os.Getenv("TERM") != "dumb"
+	}
+
+	return &LiveArea{w: w, fd: fd, tty: tty, lines: make(map[string]string)}
+}
+
+// SetTTY set the render mode manually. false: only print the changed line
+func (la *LiveArea) SetTTY(tty bool) *LiveArea {
+	la.mu.Lock()
+	la.tty = tty
+	la.mu.Unlock()
+	return la
+}
+
+// SetWidth set the max width of each line manually, the long line will be truncated.
+// default will use the terminal width, <= 0 for restore the default.
+func (la *LiveArea) SetWidth(width int) *LiveArea {
+	la.mu.Lock()
+	la.width = width
+	la.mu.Unlock()
+	return la
+}
+
+// Add a line to the end of the area, will update the text if id exists.
+func (la *LiveArea) Add(id, text string) error {
+	la.mu.Lock()
+	defer la.mu.Unlock()
+
+	if _, ok := la.lines[id]; !ok {
+		la.ids = append(la.ids, id)
+	}
+	return la.setLine(id, text)
+}
+
+// Update the line text by id, will add the line if not exists.
+func (la *LiveArea) Update(id, text string) error { return la.Add(id, text) }
+
+// Remove the line by id
+func (la *LiveArea) Remove(id string) error {
+	la.mu.Lock()
+	defer la.mu.Unlock()
+
+	if _, ok := la.lines[id]; !ok || la.stopped {
+		return nil
+	}
+
+	delete(la.lines, id)
+	for i, v := range la.ids {
+		if v == id {
+			la.ids = append(la.ids[:i], la.ids[i+1:]...)
+			break
+		}
+	}
+
+	if !la.tty {
+		return nil
+	}
+	return la.render()
+}
+
+// Lines get the current lines in order
+func (la *LiveArea) Lines() []string {
+	la.mu.Lock()
+	defer la.mu.Unlock()
+
+	ls := make([]string, len(la.ids))
+	for i, id := range la.ids {
+		ls[i] = la.lines[id]
+	}
+	return ls
+}
+
+// Len get the number of lines
+func (la *LiveArea) Len() int {
+	la.mu.Lock()
+	defer la.mu.Unlock()
+	return len(la.ids)
+}
+
+// Stop the live area, the lines are kept on the screen and the cursor is restored.
+// All operations after stop will be ignored.
+func (la *LiveArea) Stop() error {
+	la.mu.Lock()
+	defer la.mu.Unlock()
+
+	if la.stopped {
+		return nil
+	}
+
+	la.stopped = true
+	if !la.cursorHidden {
+		return nil
+	}
+
+	la.cursorHidden = false
+	_, err := io.WriteString(la.w, "\x1b[?25h")
+	return err
+}
+
+func (la *LiveArea) setLine(id, text string) error {
+	if la.stopped {
+		return nil
+	}
+
+	// only keep the first line
+	if pos := strings.IndexAny(text, "\r\n"); pos >= 0 {
+		text = text[:pos]
+	}
+
+	old, ok := la.lines[id]
+	la.lines[id] = text
+	if ok && old == text {
+		return nil
+	}
+
+	if !la.tty {
+		_, err := io.WriteString(la.w, text+"\n")
+		return err
+	}
+	return la.render()
+}
+
+// re-render all lines: move the cursor up to the area start, rewrite each line and clear the rest.
+func (la *LiveArea) render() error {
+	var sb strings.Builder
+	if la.rendered > 0 {
+		sb.WriteString("\r\x1b[" + strconv.Itoa(la.rendered) + "A")
+	}
+	if !la.cursorHidden {
+		sb.WriteString("\x1b[?25l")
+		la.cursorHidden = true
+	}
+
+	// a line longer than the terminal width will wrap to multi rows, and break the cursor moving.
+	width := la.lineWidth()
+	for _, id := range la.ids {
+		sb.WriteString("\x1b[2K")
+		sb.WriteString(truncateVisible(la.lines[id], width))
+		sb.WriteByte('\n')
+	}
+
+	// clear the stale lines after removed
+	if len(la.ids) < la.rendered {
+		sb.WriteString("\x1b[0J")
+	}
+
+	la.rendered = len(la.ids)
+	_, err := io.WriteString(la.w, sb.String())
+	return err
+}
+
+// get the max width of each line, 0 for no limit.
+func (la *LiveArea) lineWidth() int {
+	if la.width > 0 {
+		return la.width
+	}
+
+	if la.fd >= 0 {
+		if w, _, err := term.GetSize(la.fd); err == nil && w > 0 {
+			return w
+		}
+	}
+	return 0
+}
+
+// truncate s to the visible width, the ANSI escape sequences are kept and not counted.
+func truncateVisible(s string, width int) string {
+	if width <= 0 || plainWidth(s) <= width {
+		return s
+	}
+
+	var w int
+	var hasEsc bool
+	for i := 0; i < len(s); {
+		// skip the CSI sequence. eg: "\x1b[32m"
+		if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[' {
+			j := i + 2
+			for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+				j++
+			}
+			i, hasEsc = j+1, true
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		rw := strutil.RuneWidth(r)
+		if w+rw > width {
+			s = s[:i]
+			break
+		}
+		w += rw
+		i += size
+	}
+
+	if hasEsc {
+		return s + "\x1b[0m"
+	}
+	return s
+}
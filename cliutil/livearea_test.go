@@ -0,0 +1,92 @@
+package cliutil_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestLiveArea_tty(t *testing.T) {
+	buf := new(bytes.Buffer)
+	la := cliutil.NewLiveArea(buf).SetTTY(true)
+
+	assert.NoErr(t, la.Add("t1", "task1: running"))
+	assert.Eq(t, "\x1b[?25l\x1b[2Ktask1: running\n", buf.String())
+
+	buf.Reset()
+	assert.NoErr(t, la.Add("t2", "task2: waiting"))
+	assert.Eq(t, "\r\x1b[1A\x1b[2Ktask1: running\n\x1b[2Ktask2: waiting\n", buf.String())
+
+	buf.Reset()
+	assert.NoErr(t, la.Update("t1", "task1: done\nextra"))
+	assert.Eq(t, "\r\x1b[2A\x1b[2Ktask1: done\n\x1b[2Ktask2: waiting\n", buf.String())
+	assert.Eq(t, []string{"task1: done", "task2: waiting"}, la.Lines())
+
+	// no change, not render
+	buf.Reset()
+	assert.NoErr(t, la.Update("t1", "task1: done"))
+	assert.Eq(t, "", buf.String())
+
+	// remove and clear the stale line
+	assert.NoErr(t, la.Remove("t1"))
+	assert.NoErr(t, la.Remove("not-exists"))
+	assert.Eq(t, "\r\x1b[2A\x1b[2Ktask2: waiting\n\x1b[0J", buf.String())
+	assert.Eq(t, 1, la.Len())
+
+	buf.Reset()
+	assert.NoErr(t, la.Stop())
+	assert.Eq(t, "\x1b[?25h", buf.String())
+
+	// ignored after stop
+	buf.Reset()
+	assert.NoErr(t, la.Add("t3", "task3"))
+	assert.NoErr(t, la.Remove("t2"))
+	assert.NoErr(t, la.Stop())
+	assert.Eq(t, "", buf.String())
+}
+
+func TestLiveArea_noTTY(t *testing.T) {
+	buf := new(bytes.Buffer)
+	la := cliutil.NewLiveArea(buf)
+
+	assert.NoErr(t, la.Add("t1", "task1: running"))
+	assert.NoErr(t, la.Add("t2", "task2: running"))
+	assert.NoErr(t, la.Update("t1", "task1: running"))
+	assert.NoErr(t, la.Update("t1", "task1: done"))
+	assert.NoErr(t, la.Remove("t2"))
+	assert.NoErr(t, la.Stop())
+
+	assert.Eq(t, "task1: running\ntask2: running\ntask1: done\n", buf.String())
+	assert.Eq(t, []string{"task1: done"}, la.Lines())
+}
+
+func TestLiveArea_restoreCursor(t *testing.T) {
+	buf := new(bytes.Buffer)
+	la := cliutil.NewLiveArea(buf).SetTTY(true)
+
+	assert.NoErr(t, la.Add("t1", "task1"))
+	assert.NoErr(t, la.Remove("t1"))
+
+	// all lines removed, but the cursor still need restore
+	buf.Reset()
+	assert.NoErr(t, la.Stop())
+	assert.Eq(t, "\x1b[?25h", buf.String())
+}
+
+func TestLiveArea_SetWidth(t *testing.T) {
+	buf := new(bytes.Buffer)
+	la := cliutil.NewLiveArea(buf).SetTTY(true).SetWidth(8)
+
+	assert.NoErr(t, la.Add("t1", "task1: downloading"))
+	assert.Eq(t, "\x1b[?25l\x1b[2Ktask1: d\n", buf.String())
+
+	// keep the color codes and reset on truncated
+	buf.Reset()
+	assert.NoErr(t, la.Add("t1", "\x1b[32mtask1\x1b[0m: 中文字"))
+	assert.Eq(t, "\r\x1b[1A\x1b[2K\x1b[32mtask1\x1b[0m: \x1b[0m\n", buf.String())
+
+	// the full text is kept
+	assert.Eq(t, []string{"\x1b[32mtask1\x1b[0m: 中文字"}, la.Lines())
+}
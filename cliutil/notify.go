@@ -0,0 +1,89 @@
+package cliutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/gookit/color"
+)
+
+// Bell ring the terminal bell by print the BEL char.
+func Bell() {
+	_, _ = fmt.Fprint(os.Stdout, "\a")
+}
+
+// Notify show a desktop notification, useful for alert on long-running command completion.
+//
+// will fall back to ring the bell and print a colored message on console,
+// if the desktop notification is not supported or failed.
+//
+// Usage:
+//
+//	cliutil.Notify("Build", "build completed")
+func Notify(title, msg string) {
+	if err := DesktopNotify(title, msg); err != nil {
+		Bell()
+		color.Printf("<info>%s</>: %s\n", title, msg)
+	}
+}
+
+// DesktopNotify show a desktop notification, will return error if not supported or failed.
+//
+//   - darwin: use osascript
+//   - windows: use powershell toast
+//   - linux and others: use notify-send
+func DesktopNotify(title, msg string) error {
+	binName, args := NotifyCommand(title, msg)
+	if _, err := exec.LookPath(binName); err != nil {
+		return err
+	}
+	return exec.Command(binName, args...).Run()
+}
+
+// NotifyCommand build the command for show desktop notification on current OS.
+func NotifyCommand(title, msg string) (binName string, args []string) {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptQuote(msg), appleScriptQuote(title))
+		return "osascript", []string{"-e", script}
+	case "windows":
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", winToastScript(title, msg)}
+	default:
+		// "--" for avoid the title starts with "-" parsed as option
+		return "notify-send", []string{"--", title, msg}
+	}
+}
+
+func appleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+const winToastTpl = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$tpl = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$nodes = $tpl.GetElementsByTagName('text')
+$nodes.Item(0).AppendChild($tpl.CreateTextNode(%s)) > $null
+$nodes.Item(1).AppendChild($tpl.CreateTextNode(%s)) > $null
+$appId = '{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe'
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($appId).Show([Windows.UI.Notifications.ToastNotification]::new($tpl))`
+
+func winToastScript(title, msg string) string {
+	return fmt.Sprintf(winToastTpl, psQuote(title), psQuote(msg))
+}
+
+// powershell treats the U+2018-U+201B also as single quote, must escape them by doubled.
+var psQuoteReplacer = strings.NewReplacer(
+	"'", "''",
+	"\u2018", "\u2018\u2018",
+	"\u2019", "\u2019\u2019",
+	"\u201a", "\u201a\u201a",
+	"\u201b", "\u201b\u201b",
+)
+
+// quote string for powershell single-quoted string
+func psQuote(s string) string {
+	return "'" + psQuoteReplacer.Replace(s) + "'"
+}
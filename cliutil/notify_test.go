@@ -0,0 +1,35 @@
+package cliutil_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestBell(t *testing.T) {
+	out := testutil.CaptureStdout(cliutil.Bell)
+	assert.Eq(t, "\a", out)
+}
+
+func TestNotifyCommand(t *testing.T) {
+	bin, args := cliutil.NotifyCommand(`Build "app"`, "it's done")
+
+	switch runtime.GOOS {
+	case "darwin":
+		assert.Eq(t, "osascript", bin)
+		assert.Eq(t, []string{"-e", `display notification "it's done" with title "Build \"app\""`}, args)
+	case "windows":
+		assert.Eq(t, "powershell", bin)
+		assert.StrContains(t, args[len(args)-1], `CreateTextNode('it''s done')`)
+
+		// the smart quotes are also single quote in powershell
+		_, args = cliutil.NotifyCommand("title", "it\u2019s done")
+		assert.StrContains(t, args[len(args)-1], "CreateTextNode('it\u2019\u2019s done')")
+	default:
+		assert.Eq(t, "notify-send", bin)
+		assert.Eq(t, []string{"--", `Build "app"`, "it's done"}, args)
+	}
+}
@@ -0,0 +1,235 @@
+package cliutil
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gookit/goutil/strutil"
+	"golang.org/x/term"
+)
+
+// DefaultUsageWidth the usage width when output is not a terminal
+const DefaultUsageWidth = 80
+
+// the min width for render usage description
+const minDescWidth = 20
+
+// UsageRow a row of the usage block. eg: an option, a command
+type UsageRow struct {
+	// Name of the row. eg: "-n, --name string". allow color tags
+	Name string
+	// Desc of the row, will be wrapped to the usage width. allow color tags
+	Desc string
+}
+
+// UsageBlock a block of the usage text. eg: "Options:", "Commands:"
+type UsageBlock struct {
+	// Title of the block, will render as "Title:". allow color tags
+	Title string
+	// Text the paragraph text, will be wrapped to the usage width.
+	Text string
+	// Rows the aligned name and description rows. render after Text
+	Rows []UsageRow
+	// MaxNameWidth limit the name column width, the longer name will put desc on next line.
+	// default is 1/3 of the usage width.
+	MaxNameWidth int
+}
+
+// UsageWidth get the width for render usage. will use the terminal width if stdout is
+// a terminal, otherwise return DefaultUsageWidth.
+func UsageWidth() int {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return DefaultUsageWidth
+	}
+
+	if w, _, err := term.GetSize(fd); err == nil && w > 0 {
+		return w
+	}
+	return DefaultUsageWidth
+}
+
+// RenderUsage render the usage blocks to text, the descriptions are wrapped to width and
+// the name columns are aligned by each block. if width <= 0, will use UsageWidth().
+//
+// Usage:
+//
+//	text := cliutil.RenderUsage([]cliutil.UsageBlock{
+//		{Title: "Usage", Text: "app [options] <file>"},
+//		{Title: "Options", Rows: []cliutil.UsageRow{
+//			{Name: "-v, --verbose", Desc: "show verbose output"},
+//			{Name: "-o, --output string", Desc: "the output file path"},
+//		}},
+//	}, 0)
+//	color.Print(text)
+func RenderUsage(blocks []UsageBlock, width int) string {
+	if width <= 0 {
+		width = UsageWidth()
+	}
+
+	var sb strings.Builder
+	for i, block := range blocks {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		renderUsageBlock(&sb, block, width)
+	}
+	return sb.String()
+}
+
+// the indent of the block content
+const usageIndent = "  "
+
+func renderUsageBlock(sb *strings.Builder, block UsageBlock, width int) {
+	if block.Title != "" {
+		sb.WriteString(block.Title)
+		sb.WriteString(":\n")
+	}
+
+	if block.Text != "" {
+		for _, line := range wrapUsageText(block.Text, width-len(usageIndent)) {
+			writeUsageLine(sb, usageIndent, line)
+		}
+	}
+
+	if len(block.Rows) == 0 {
+		return
+	}
+
+	maxName := block.MaxNameWidth
+	if maxName <= 0 {
+		maxName = width / 3
+	}
+
+	// calc the name column width
+	var nameWidth int
+	for _, row := range block.Rows {
+		if w := plainWidth(row.Name); w > nameWidth && w <= maxName {
+			nameWidth = w
+		}
+	}
+
+	// 2 spaces between name and desc
+	descIndent := strings.Repeat(" ", len(usageIndent)+nameWidth+2)
+	descWidth := width - len(descIndent)
+	if descWidth < minDescWidth {
+		descWidth = minDescWidth
+	}
+
+	for _, row := range block.Rows {
+		lines := wrapUsageText(row.Desc, descWidth)
+		nw := plainWidth(row.Name)
+
+		sb.WriteString(usageIndent)
+		sb.WriteString(row.Name)
+		if len(lines) == 0 {
+			sb.WriteByte('\n')
+			continue
+		}
+
+		if nw > nameWidth { // too long name, desc on next line
+			sb.WriteByte('\n')
+			sb.WriteString(descIndent)
+		} else {
+			sb.WriteString(strings.Repeat(" ", nameWidth-nw+2))
+		}
+
+		writeUsageLine(sb, "", lines[0])
+		for _, line := range lines[1:] {
+			writeUsageLine(sb, descIndent, line)
+		}
+	}
+}
+
+func writeUsageLine(sb *strings.Builder, indent, line string) {
+	if line != "" {
+		sb.WriteString(indent)
+		sb.WriteString(line)
+	}
+	sb.WriteByte('\n')
+}
+
+// get the display width, exclude color tags and codes
+func plainWidth(s string) int {
+	return strutil.Utf8Width(color.ClearCode(color.ClearTag(s)))
+}
+
+// wrap the text by words to lines, keep the exists newlines.
+func wrapUsageText(text string, width int) []string {
+	if text == "" {
+		return nil
+	}
+
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		lines = append(lines, wrapWords(para, width)...)
+	}
+	return lines
+}
+
+func wrapWords(para string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+
+	words := strings.Fields(para)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var line strings.Builder
+	lineW := 0
+	for _, word := range words {
+		ww := plainWidth(word)
+
+		// too long word, split it
+		for ww > width && !strings.ContainsRune(word, '<') {
+			if lineW > 0 {
+				lines = append(lines, line.String())
+				line.Reset()
+				lineW = 0
+			}
+
+			var head string
+			head, word = cutByWidth(word, width)
+			lines = append(lines, head)
+			ww = plainWidth(word)
+		}
+		if word == "" {
+			continue
+		}
+
+		if lineW > 0 && lineW+1+ww > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			lineW = 0
+		}
+		if lineW > 0 {
+			line.WriteByte(' ')
+			lineW++
+		}
+
+		line.WriteString(word)
+		lineW += ww
+	}
+
+	if lineW > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// cut the head of s within the width, at least one rune will be cut.
+func cutByWidth(s string, width int) (head, rest string) {
+	var w int
+	for i, r := range s {
+		rw := strutil.RuneWidth(r)
+		if i > 0 && w+rw > width {
+			return s[:i], s[i:]
+		}
+		w += rw
+	}
+	return s, ""
+}
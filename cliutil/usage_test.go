@@ -0,0 +1,69 @@
+package cliutil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestRenderUsage(t *testing.T) {
+	blocks := []cliutil.UsageBlock{
+		{Title: "Usage", Text: "app [options] <file>"},
+		{Title: "Options", Rows: []cliutil.UsageRow{
+			{Name: "-v, --verbose", Desc: "show verbose output"},
+			{Name: "<info>-o, --output</> string", Desc: "the output file path, default will write to the stdout"},
+			{Name: "--a-very-long-option-name string", Desc: "long name option"},
+			{Name: "--no-desc"},
+		}},
+	}
+
+	want := `Usage:
+  app [options] <file>
+
+Options:
+  -v, --verbose        show verbose output
+  <info>-o, --output</> string  the output file path, default will
+                       write to the stdout
+  --a-very-long-option-name string
+                       long name option
+  --no-desc
+`
+	assert.Eq(t, want, cliutil.RenderUsage(blocks, 60))
+
+	// wide width
+	out := cliutil.RenderUsage(blocks[1:], 120)
+	assert.StrContains(t, out, "  <info>-o, --output</> string               the output file path, default will write to the stdout\n")
+	assert.StrContains(t, out, "  --a-very-long-option-name string  long name option\n")
+
+	// not terminal on testing
+	assert.Eq(t, cliutil.DefaultUsageWidth, cliutil.UsageWidth())
+	assert.NotEmpty(t, cliutil.RenderUsage(blocks, 0))
+}
+
+func TestRenderUsage_wrapText(t *testing.T) {
+	blocks := []cliutil.UsageBlock{
+		{Text: "line one is long enough\n\nhttps://example.com/a/very/long/url"},
+	}
+
+	want := `  line one is
+  long enough
+
+  https://exampl
+  e.com/a/very/l
+  ong/url
+`
+	assert.Eq(t, want, cliutil.RenderUsage(blocks, 16))
+}
+
+func TestRenderUsage_narrow(t *testing.T) {
+	blocks := []cliutil.UsageBlock{
+		{Text: "ab 中文字"},
+	}
+
+	// text width is 0, clamp to 1
+	assert.Eq(t, "  a\n  b\n  中\n  文\n  字\n", cliutil.RenderUsage(blocks, 2))
+	// the wide rune is wider than the width
+	assert.Eq(t, "  a\n  b\n  中\n  文\n  字\n", cliutil.RenderUsage(blocks, 3))
+	assert.Eq(t, "  ab\n  中\n  文\n  字\n", cliutil.RenderUsage(blocks, 4))
+}
@@ -1,12 +1,14 @@
 package dump
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"reflect"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -110,13 +112,36 @@ func (d *Dumper) dump(vs ...any) {
 		d.ColorTheme = make(Theme)
 	}
 
-	// show print position
+	// get the print position
+	var pc uintptr
+	var file string
+	var line int
+	var ok bool
 	if d.ShowFlag != Fnopos {
-		// get the print position
-		pc, file, line, ok := runtime.Caller(d.CallerSkip)
-		if ok {
-			d.printCaller(pc, file, line)
-		}
+		pc, file, line, ok = runtime.Caller(d.CallerSkip)
+	}
+
+	if d.Ring == nil {
+		d.printAll(vs, ok, pc, file, line)
+		return
+	}
+
+	// record to ring buffer. render by a copied dumper, dont change the shared d.Output
+	buf := new(bytes.Buffer)
+	opts := *d.Options
+	opts.Output = buf
+
+	rd := &Dumper{Options: &opts, visited: make(map[visit]int)}
+	rd.printAll(vs, ok, pc, file, line)
+
+	_, _ = d.Output.Write(buf.Bytes())
+	d.Ring.Add(color.ClearCode(buf.String()))
+}
+
+func (d *Dumper) printAll(vs []any, showCaller bool, pc uintptr, file string, line int) {
+	// show print position
+	if showCaller {
+		d.printCaller(pc, file, line)
 	}
 
 	// print var data
@@ -318,7 +343,12 @@ func (d *Dumper) printRValue(t reflect.Type, v reflect.Value) {
 		d.write(!isPtr, d.ColorTheme.msType(t.String()), " { ", lenTip, "\n")
 		d.msValue = false
 
-		for _, key := range v.MapKeys() {
+		keys := v.MapKeys()
+		if d.SortMapKeys {
+			sortMapKeys(keys)
+		}
+
+		for _, key := range keys {
 			mv := v.MapIndex(key)
 			if d.SkipNilField && isNilOrInvalid(mv) {
 				continue
@@ -446,3 +476,21 @@ func (d *Dumper) write(indent bool, v ...any) {
 func (d *Dumper) indentPrint(v ...any) {
 	d.write(true, v...)
 }
+
+// sort the map keys, numbers by value, others by the formatted string.
+func sortMapKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		}
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	})
+}
@@ -31,6 +31,10 @@ type Options struct {
 	SkipPrivate bool
 	// BytesAsString dump handle.
 	BytesAsString bool
+	// SortMapKeys sort the map keys on dump, make the output stable.
+	SortMapKeys bool
+	// Ring record the dumps to the ring buffer, without color codes.
+	Ring *RingBuffer
 	// MoreLenNL array/slice elements length > MoreLenNL, will wrap new line
 	// MoreLenNL int
 }
@@ -79,6 +83,13 @@ func BytesAsString() OptionFunc {
 	}
 }
 
+// SortMapKeys setting. sort the map keys on dump, make the output stable.
+func SortMapKeys() OptionFunc {
+	return func(opt *Options) {
+		opt.SortMapKeys = true
+	}
+}
+
 // WithCallerSkip on print caller position information.
 func WithCallerSkip(skip int) OptionFunc {
 	return func(opt *Options) {
@@ -107,6 +118,13 @@ func WithoutColor() OptionFunc {
 	}
 }
 
+// WithRing setting. record the dumps to the ring buffer
+func WithRing(rb *RingBuffer) OptionFunc {
+	return func(opt *Options) {
+		opt.Ring = rb
+	}
+}
+
 // WithoutType setting.
 func WithoutType() OptionFunc {
 	return func(opt *Options) {
@@ -2,6 +2,7 @@ package dump
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/gookit/color"
@@ -103,3 +104,18 @@ func TestBytesAsString(t *testing.T) {
 	[]byte("hello"), #len=5,cap=5
 	*/
 }
+
+func TestSortMapKeys(t *testing.T) {
+	buf := newBuffer()
+	dumper := newStd().WithOptions(WithoutOutput(buf), WithoutPosition(), WithoutColor(), SortMapKeys())
+
+	dumper.Print(map[string]int{"c": 3, "a": 1, "b": 2})
+	assert.Eq(t, "map[string]int { #len=3\n  \"a\": int(1),\n  \"b\": int(2),\n  \"c\": int(3),\n},\n", buf.String())
+
+	buf.Reset()
+	dumper.Print(map[int]string{10: "ten", 2: "two", -1: "neg"})
+	str := buf.String()
+	fmt.Print(str)
+	assert.True(t, strings.Index(str, "-1:") < strings.Index(str, "2:"))
+	assert.True(t, strings.Index(str, "2:") < strings.Index(str, "10:"))
+}
@@ -0,0 +1,184 @@
+package dump
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gookit/goutil/errorx"
+)
+
+var (
+	sinkMu sync.Mutex
+	// the NoColor setting of std dumpers before disabled by SetSink()
+	sinkNoColorBak = map[*Dumper]bool{}
+)
+
+// SetSink set the output writer of std dumpers. eg: a log file, bytes.Buffer
+//
+// NOTE: will disable color if w is not os.Stdout or os.Stderr,
+// and restore the color setting on set back to os.Stdout or os.Stderr.
+func SetSink(w io.Writer) {
+	noColor := w != os.Stdout && w != os.Stderr
+
+	sinkMu.Lock()
+	defer sinkMu.Unlock()
+	for _, d := range []*Dumper{std, std2} {
+		d.Output = w
+		if noColor {
+			if _, ok := sinkNoColorBak[d]; !ok {
+				sinkNoColorBak[d] = d.NoColor
+			}
+			d.NoColor = true
+		} else if old, ok := sinkNoColorBak[d]; ok {
+			d.NoColor = old
+			delete(sinkNoColorBak, d)
+		}
+	}
+}
+
+// ToFile dump vars to the file, will append to the file and without color.
+func ToFile(path string, vs ...any) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0664)
+	if err != nil {
+		return err
+	}
+
+	d := NewDumper(f, defaultSkip).WithoutColor()
+	d.Ring = std.Ring
+	d.Dump(vs...)
+	return f.Close()
+}
+
+// RingBuffer a bounded buffer for keep the recent dumps. it is safe for concurrent use.
+type RingBuffer struct {
+	mu    sync.Mutex
+	items []string
+	// next write index
+	next int
+	full bool
+}
+
+// NewRingBuffer create a ring buffer with the max size
+func NewRingBuffer(size int) *RingBuffer {
+	if size <= 0 {
+		panic("dump: ring buffer size must be greater than 0")
+	}
+	return &RingBuffer{items: make([]string, size)}
+}
+
+// Add a dump text to the buffer, the oldest one will be dropped on full.
+func (rb *RingBuffer) Add(s string) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	rb.items[rb.next] = s
+	rb.next = (rb.next + 1) % len(rb.items)
+	if rb.next == 0 {
+		rb.full = true
+	}
+}
+
+// Len get the number of dumps in the buffer
+func (rb *RingBuffer) Len() int {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if rb.full {
+		return len(rb.items)
+	}
+	return rb.next
+}
+
+// Items get all dumps, sorted from oldest to newest.
+func (rb *RingBuffer) Items() []string {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if !rb.full {
+		return append([]string(nil), rb.items[:rb.next]...)
+	}
+
+	ss := make([]string, 0, len(rb.items))
+	ss = append(ss, rb.items[rb.next:]...)
+	return append(ss, rb.items[:rb.next]...)
+}
+
+// Reset clear the buffer
+func (rb *RingBuffer) Reset() {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	rb.items = make([]string, len(rb.items))
+	rb.next, rb.full = 0, false
+}
+
+// WriteTo write all dumps to w, sorted from oldest to newest.
+func (rb *RingBuffer) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, strings.Join(rb.Items(), ""))
+	return int64(n), err
+}
+
+// EnableRing keep the recent size dumps of std dumpers in memory, for post-mortem debugging.
+// size <= 0 to disable it.
+//
+// Usage:
+//
+//	dump.EnableRing(20)
+//	dump.SetSink(io.Discard) // optional, not print to stdout
+//	defer dump.FlushOnPanic(os.Stderr)
+func EnableRing(size int) {
+	var rb *RingBuffer
+	if size > 0 {
+		rb = NewRingBuffer(size)
+	}
+
+	std.Ring = rb
+	std2.Ring = rb
+}
+
+// Recent get the recent dumps of std dumper. will return nil if ring is not enabled.
+func Recent() []string {
+	if std.Ring == nil {
+		return nil
+	}
+	return std.Ring.Items()
+}
+
+// FlushOnPanic write the recent dumps to w on panic, then re-panic. must call it with defer.
+//
+// Usage:
+//
+//	defer dump.FlushOnPanic(os.Stderr)
+func FlushOnPanic(w io.Writer) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	flushRecent(w)
+	panic(r)
+}
+
+// HookPanic register the FlushOnPanic behavior as a panic hook of errorx,
+// the recent dumps will be written to w on errorx.Recover() recovered a panic.
+//
+// Usage:
+//
+//	dump.EnableRing(20)
+//	dump.HookPanic(os.Stderr)
+//	defer errorx.Recover()
+func HookPanic(w io.Writer) {
+	errorx.OnPanic(func(_ any) {
+		flushRecent(w)
+	})
+}
+
+func flushRecent(w io.Writer) {
+	if rb := std.Ring; rb != nil && rb.Len() > 0 {
+		_, _ = fmt.Fprintf(w, "---- recent dumps(%d) before panic ----\n", rb.Len())
+		_, _ = rb.WriteTo(w)
+	}
+}
@@ -0,0 +1,146 @@
+package dump
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/errorx"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestSetSink(t *testing.T) {
+	Reset()
+	Reset2()
+	defer Reset()
+	defer Reset2()
+
+	buf := new(bytes.Buffer)
+	SetSink(buf)
+	P("hello")
+	NoLoc(23)
+
+	out := buf.String()
+	assert.StrContains(t, out, "PRINT AT github.com/gookit/goutil/dump.TestSetSink(sink_test.go:")
+	assert.StrContains(t, out, `string("hello"), #len=5`)
+	assert.StrContains(t, out, "int(23)")
+	assert.StrNotContains(t, out, "\x1b[")
+
+	// restore the color setting
+	assert.True(t, Std().NoColor)
+	SetSink(os.Stdout)
+	assert.False(t, Std().NoColor)
+	assert.False(t, Std2().NoColor)
+
+	// keep the setting by user
+	Std().NoColor = true
+	SetSink(buf)
+	SetSink(os.Stderr)
+	assert.True(t, Std().NoColor)
+	assert.False(t, Std2().NoColor)
+}
+
+func TestToFile(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "dump.log")
+
+	assert.NoErr(t, ToFile(fpath, "first"))
+	assert.NoErr(t, ToFile(fpath, 123))
+
+	bs, err := os.ReadFile(fpath)
+	assert.NoErr(t, err)
+	out := string(bs)
+	assert.StrContains(t, out, "dump.TestToFile(sink_test.go:")
+	assert.StrContains(t, out, `string("first")`)
+	assert.StrContains(t, out, "int(123)")
+	assert.StrNotContains(t, out, "\x1b[")
+
+	assert.Err(t, ToFile(filepath.Join(fpath, "not-dir", "a.log"), 1))
+}
+
+func TestRingBuffer(t *testing.T) {
+	rb := NewRingBuffer(3)
+	assert.Eq(t, 0, rb.Len())
+	assert.Empty(t, rb.Items())
+
+	rb.Add("a")
+	rb.Add("b")
+	assert.Eq(t, []string{"a", "b"}, rb.Items())
+
+	rb.Add("c")
+	rb.Add("d")
+	assert.Eq(t, 3, rb.Len())
+	assert.Eq(t, []string{"b", "c", "d"}, rb.Items())
+
+	buf := new(bytes.Buffer)
+	n, err := rb.WriteTo(buf)
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(3), n)
+	assert.Eq(t, "bcd", buf.String())
+
+	rb.Reset()
+	assert.Eq(t, 0, rb.Len())
+	assert.Panics(t, func() {
+		NewRingBuffer(0)
+	})
+}
+
+func TestEnableRing(t *testing.T) {
+	defer Reset()
+	defer Reset2()
+
+	assert.Nil(t, Recent())
+	EnableRing(2)
+	SetSink(new(bytes.Buffer))
+
+	P("one")
+	P("two")
+	NoLoc("three")
+
+	items := Recent()
+	assert.Len(t, items, 2)
+	assert.StrContains(t, items[0], `string("two")`)
+	assert.StrContains(t, items[1], `string("three")`)
+
+	// flush on panic
+	buf := new(bytes.Buffer)
+	assert.Panics(t, func() {
+		defer FlushOnPanic(buf)
+		panic("oops")
+	})
+	assert.StrContains(t, buf.String(), "recent dumps(2) before panic")
+	assert.StrContains(t, buf.String(), `string("three")`)
+
+	// no panic
+	buf.Reset()
+	func() {
+		defer FlushOnPanic(buf)
+	}()
+	assert.Empty(t, buf.String())
+
+	// hook to errorx.Recover()
+	HookPanic(buf)
+	defer errorx.ResetPanicHooks()
+	assert.Panics(t, func() {
+		defer errorx.Recover()
+		panic("oops")
+	})
+	assert.StrContains(t, buf.String(), "recent dumps(2) before panic")
+	assert.StrContains(t, buf.String(), `string("three")`)
+
+	EnableRing(0)
+	assert.Nil(t, Recent())
+}
+
+func TestDumper_Ring(t *testing.T) {
+	buf := new(bytes.Buffer)
+	d := NewDumper(buf, defaultSkip).WithoutColor()
+	d.Ring = NewRingBuffer(2)
+
+	d.Dump("hi")
+	d.Dump("hello")
+	assert.Same(t, buf, d.Output)
+	assert.StrContains(t, buf.String(), `string("hello")`)
+	assert.Len(t, d.Ring.Items(), 2)
+	assert.StrContains(t, d.Ring.Items()[1], `string("hello")`)
+}
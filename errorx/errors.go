@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // ErrorCoder interface
@@ -152,3 +153,145 @@ func (es Errors) First() error {
 	}
 	return nil
 }
+
+// LimitErrors a concurrent safe multi error collector with limited capacity.
+//
+// It keeps the first max errors, and only counts the rest. Can also sample the
+// duplicate errors by message, only keep the first one and count the repeats.
+//
+// Useful for batch operations over huge items, avoid blow memory on build error list.
+//
+// Usage:
+//
+//	es := errorx.NewLimitErrors(100, true)
+//	for _, item := range items {
+//		es.Add(handle(item))
+//	}
+//	return es.ErrorOrNil()
+type LimitErrors struct {
+	mu  sync.Mutex
+	max int
+	// sample the duplicate errors by message
+	sampleDup bool
+	errs      Errors
+	// dups message -> repeat count of the stored errors
+	dups    map[string]int
+	dropped int
+	total   int
+}
+
+// NewLimitErrors create a LimitErrors with max capacity. max <= 0 means no limit.
+func NewLimitErrors(max int, sampleDup ...bool) *LimitErrors {
+	return &LimitErrors{
+		max:       max,
+		sampleDup: len(sampleDup) > 0 && sampleDup[0],
+		dups:      make(map[string]int),
+	}
+}
+
+// Add an error, nil will be ignored. return true if the error is stored.
+func (es *LimitErrors) Add(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.total++
+
+	var msg string
+	if es.sampleDup {
+		msg = err.Error()
+		if _, ok := es.dups[msg]; ok {
+			es.dups[msg]++
+			return false
+		}
+	}
+
+	if es.max > 0 && len(es.errs) >= es.max {
+		es.dropped++
+		return false
+	}
+
+	es.errs = append(es.errs, err)
+	if es.sampleDup {
+		es.dups[msg] = 1
+	}
+	return true
+}
+
+// Len get the stored errors count
+func (es *LimitErrors) Len() int {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return len(es.errs)
+}
+
+// Total get the count of all added errors, include dropped and sampled.
+func (es *LimitErrors) Total() int {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.total
+}
+
+// Dropped get the count of errors dropped by exceed the capacity
+func (es *LimitErrors) Dropped() int {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.dropped
+}
+
+// RepeatCount get the count of the stored error message on sample duplicates. return 0 if not found.
+func (es *LimitErrors) RepeatCount(msg string) int {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.dups[msg]
+}
+
+// Errors get a copy of the stored errors
+func (es *LimitErrors) Errors() Errors {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return append(Errors(nil), es.errs...)
+}
+
+// First error
+func (es *LimitErrors) First() error {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.errs.First()
+}
+
+// IsEmpty check
+func (es *LimitErrors) IsEmpty() bool {
+	return es.Len() == 0
+}
+
+// ErrorOrNil error
+func (es *LimitErrors) ErrorOrNil() error {
+	if es.IsEmpty() {
+		return nil
+	}
+	return es
+}
+
+// Error string. will append the repeat count and the dropped count.
+func (es *LimitErrors) Error() string {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	var sb strings.Builder
+	for _, err := range es.errs {
+		msg := err.Error()
+		sb.WriteString(msg)
+		if n := es.dups[msg]; n > 1 {
+			sb.WriteString(" (repeated " + strconv.Itoa(n) + " times)")
+		}
+		sb.WriteByte('\n')
+	}
+
+	if es.dropped > 0 {
+		sb.WriteString("... and " + strconv.Itoa(es.dropped) + " more errors\n")
+	}
+	return sb.String()
+}
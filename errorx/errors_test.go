@@ -63,3 +63,49 @@ func TestErrors_usage(t *testing.T) {
 	assert.Err(t, es.ErrorOrNil())
 	assert.Err(t, es.First())
 }
+
+func TestLimitErrors(t *testing.T) {
+	es := errorx.NewLimitErrors(2)
+	assert.True(t, es.IsEmpty())
+	assert.Nil(t, es.ErrorOrNil())
+	assert.Nil(t, es.First())
+	assert.False(t, es.Add(nil))
+
+	assert.True(t, es.Add(errorx.Raw("error1")))
+	assert.True(t, es.Add(errorx.Raw("error1")))
+	assert.False(t, es.Add(errorx.Raw("error2")))
+	assert.False(t, es.Add(errorx.Raw("error3")))
+
+	assert.Eq(t, 2, es.Len())
+	assert.Eq(t, 4, es.Total())
+	assert.Eq(t, 2, es.Dropped())
+	assert.Eq(t, "error1", es.First().Error())
+	assert.Len(t, es.Errors(), 2)
+	assert.Err(t, es.ErrorOrNil())
+	assert.Eq(t, "error1\nerror1\n... and 2 more errors\n", es.Error())
+}
+
+func TestLimitErrors_sampleDup(t *testing.T) {
+	es := errorx.NewLimitErrors(2, true)
+
+	for i := 0; i < 1000; i++ {
+		es.Add(errorx.Raw("timeout"))
+		es.Add(errorx.Rawf("invalid item %d", i))
+	}
+
+	assert.Eq(t, 2, es.Len())
+	assert.Eq(t, 2000, es.Total())
+	assert.Eq(t, 999, es.Dropped())
+	assert.Eq(t, 1000, es.RepeatCount("timeout"))
+	assert.Eq(t, 1, es.RepeatCount("invalid item 0"))
+	assert.Eq(t, 0, es.RepeatCount("invalid item 1"))
+	assert.Eq(t, "timeout (repeated 1000 times)\ninvalid item 0\n... and 999 more errors\n", es.Error())
+
+	// no limit
+	es = errorx.NewLimitErrors(0)
+	for i := 0; i < 10; i++ {
+		es.Add(errorx.Raw("timeout"))
+	}
+	assert.Eq(t, 10, es.Len())
+	assert.Eq(t, 0, es.Dropped())
+}
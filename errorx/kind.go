@@ -0,0 +1,267 @@
+package errorx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+)
+
+// Kind the error classification. can be convert to HTTP status code and gRPC code.
+type Kind uint8
+
+// built-in error kinds. refer the gRPC codes
+const (
+	KindUnknown Kind = iota
+	KindCanceled
+	KindInvalidArgument
+	KindDeadlineExceeded
+	KindNotFound
+	KindAlreadyExists
+	KindPermissionDenied
+	KindResourceExhausted
+	KindFailedPrecondition
+	KindAborted
+	KindOutOfRange
+	KindUnimplemented
+	KindInternal
+	KindUnavailable
+	KindDataLoss
+	KindUnauthenticated
+)
+
+// gRPC codes value, same as the google.golang.org/grpc/codes
+const (
+	GRPCCodeOK                 = 0
+	GRPCCodeCanceled           = 1
+	GRPCCodeUnknown            = 2
+	GRPCCodeInvalidArgument    = 3
+	GRPCCodeDeadlineExceeded   = 4
+	GRPCCodeNotFound           = 5
+	GRPCCodeAlreadyExists      = 6
+	GRPCCodePermissionDenied   = 7
+	GRPCCodeResourceExhausted  = 8
+	GRPCCodeFailedPrecondition = 9
+	GRPCCodeAborted            = 10
+	GRPCCodeOutOfRange         = 11
+	GRPCCodeUnimplemented      = 12
+	GRPCCodeInternal           = 13
+	GRPCCodeUnavailable        = 14
+	GRPCCodeDataLoss           = 15
+	GRPCCodeUnauthenticated    = 16
+)
+
+type kindInfo struct {
+	name     string
+	status   int
+	grpcCode int
+}
+
+var kindInfos = map[Kind]kindInfo{
+	KindUnknown:            {"unknown", http.StatusInternalServerError, GRPCCodeUnknown},
+	KindCanceled:           {"canceled", 499, GRPCCodeCanceled}, // 499: client closed request
+	KindInvalidArgument:    {"invalid_argument", http.StatusBadRequest, GRPCCodeInvalidArgument},
+	KindDeadlineExceeded:   {"deadline_exceeded", http.StatusGatewayTimeout, GRPCCodeDeadlineExceeded},
+	KindNotFound:           {"not_found", http.StatusNotFound, GRPCCodeNotFound},
+	KindAlreadyExists:      {"already_exists", http.StatusConflict, GRPCCodeAlreadyExists},
+	KindPermissionDenied:   {"permission_denied", http.StatusForbidden, GRPCCodePermissionDenied},
+	KindResourceExhausted:  {"resource_exhausted", http.StatusTooManyRequests, GRPCCodeResourceExhausted},
+	KindFailedPrecondition: {"failed_precondition", http.StatusPreconditionFailed, GRPCCodeFailedPrecondition},
+	KindAborted:            {"aborted", http.StatusConflict, GRPCCodeAborted},
+	KindOutOfRange:         {"out_of_range", http.StatusBadRequest, GRPCCodeOutOfRange},
+	KindUnimplemented:      {"unimplemented", http.StatusNotImplemented, GRPCCodeUnimplemented},
+	KindInternal:           {"internal", http.StatusInternalServerError, GRPCCodeInternal},
+	KindUnavailable:        {"unavailable", http.StatusServiceUnavailable, GRPCCodeUnavailable},
+	KindDataLoss:           {"data_loss", http.StatusInternalServerError, GRPCCodeDataLoss},
+	KindUnauthenticated:    {"unauthenticated", http.StatusUnauthorized, GRPCCodeUnauthenticated},
+}
+
+// String get kind name. eg: "not_found"
+func (k Kind) String() string {
+	if info, ok := kindInfos[k]; ok {
+		return info.name
+	}
+	return "unknown"
+}
+
+// HTTPStatus get the HTTP status code of the kind
+func (k Kind) HTTPStatus() int {
+	if info, ok := kindInfos[k]; ok {
+		return info.status
+	}
+	return http.StatusInternalServerError
+}
+
+// GRPCCode get the gRPC code of the kind
+func (k Kind) GRPCCode() int {
+	if info, ok := kindInfos[k]; ok {
+		return info.grpcCode
+	}
+	return GRPCCodeUnknown
+}
+
+// KindFromHTTPStatus get error kind by HTTP status code
+func KindFromHTTPStatus(status int) Kind {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return KindInvalidArgument
+	case http.StatusUnauthorized:
+		return KindUnauthenticated
+	case http.StatusForbidden:
+		return KindPermissionDenied
+	case http.StatusNotFound:
+		return KindNotFound
+	case http.StatusConflict:
+		return KindAlreadyExists
+	case http.StatusPreconditionFailed:
+		return KindFailedPrecondition
+	case http.StatusRequestedRangeNotSatisfiable:
+		return KindOutOfRange
+	case http.StatusTooManyRequests:
+		return KindResourceExhausted
+	case 499:
+		return KindCanceled
+	case http.StatusNotImplemented:
+		return KindUnimplemented
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return KindUnavailable
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return KindDeadlineExceeded
+	case http.StatusInternalServerError:
+		return KindInternal
+	}
+	return KindUnknown
+}
+
+// KindFromGRPCCode get error kind by gRPC code
+func KindFromGRPCCode(code int) Kind {
+	for kind, info := range kindInfos {
+		if info.grpcCode == code {
+			return kind
+		}
+	}
+	return KindUnknown
+}
+
+// Kinder interface for get the error kind
+type Kinder interface {
+	Kind() Kind
+}
+
+// KindError an error with kind and fields info.
+type KindError struct {
+	kind Kind
+	msg  string
+	err  error
+	// Fields extra error fields. eg: validate failed fields
+	Fields map[string]any
+}
+
+// NewKind create a new KindError
+func NewKind(kind Kind, msg string) *KindError {
+	return &KindError{kind: kind, msg: msg}
+}
+
+// NewKindf create a new KindError with format message
+func NewKindf(kind Kind, tpl string, vars ...any) *KindError {
+	return &KindError{kind: kind, msg: fmt.Sprintf(tpl, vars...)}
+}
+
+// WithKind wrap an error with kind. If err is nil, will return nil.
+func WithKind(err error, kind Kind) error {
+	if err == nil {
+		return nil
+	}
+	return &KindError{kind: kind, err: err}
+}
+
+// WithField set a field info
+func (e *KindError) WithField(name string, val any) *KindError {
+	if e.Fields == nil {
+		e.Fields = make(map[string]any)
+	}
+	e.Fields[name] = val
+	return e
+}
+
+// WithFields set multi fields info
+func (e *KindError) WithFields(fields map[string]any) *KindError {
+	for name, val := range fields {
+		e.WithField(name, val)
+	}
+	return e
+}
+
+// Kind get error kind
+func (e *KindError) Kind() Kind { return e.kind }
+
+// Unwrap get the wrapped error
+func (e *KindError) Unwrap() error { return e.err }
+
+// Error string
+func (e *KindError) Error() string {
+	if e.err == nil {
+		return e.msg
+	}
+	if e.msg == "" {
+		return e.err.Error()
+	}
+	return e.msg + ": " + e.err.Error()
+}
+
+// KindOf get the error kind. will check:
+//
+//   - nil: KindUnknown
+//   - implements Kinder: err.Kind()
+//   - context.Canceled, context.DeadlineExceeded
+//   - fs.ErrNotExist, fs.ErrExist, fs.ErrPermission, fs.ErrInvalid
+//
+// otherwise, return KindUnknown
+func KindOf(err error) Kind {
+	if err == nil {
+		return KindUnknown
+	}
+
+	var ke Kinder
+	if errors.As(err, &ke) {
+		return ke.Kind()
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return KindCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return KindDeadlineExceeded
+	case errors.Is(err, fs.ErrNotExist):
+		return KindNotFound
+	case errors.Is(err, fs.ErrExist):
+		return KindAlreadyExists
+	case errors.Is(err, fs.ErrPermission):
+		return KindPermissionDenied
+	case errors.Is(err, fs.ErrInvalid):
+		return KindInvalidArgument
+	}
+	return KindUnknown
+}
+
+// IsKind check the error kind is equals to the kind
+func IsKind(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
+
+// HTTPStatus get the HTTP status code by error. nil error will return 200
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	return KindOf(err).HTTPStatus()
+}
+
+// GRPCCode get the gRPC code by error. nil error will return GRPCCodeOK
+func GRPCCode(err error) int {
+	if err == nil {
+		return GRPCCodeOK
+	}
+	return KindOf(err).GRPCCode()
+}
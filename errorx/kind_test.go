@@ -0,0 +1,83 @@
+package errorx_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gookit/goutil/errorx"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestKind_convert(t *testing.T) {
+	assert.Eq(t, "not_found", errorx.KindNotFound.String())
+	assert.Eq(t, http.StatusNotFound, errorx.KindNotFound.HTTPStatus())
+	assert.Eq(t, errorx.GRPCCodeNotFound, errorx.KindNotFound.GRPCCode())
+	assert.Eq(t, "unknown", errorx.Kind(200).String())
+	assert.Eq(t, http.StatusInternalServerError, errorx.Kind(200).HTTPStatus())
+
+	assert.Eq(t, errorx.KindUnauthenticated, errorx.KindFromHTTPStatus(http.StatusUnauthorized))
+	assert.Eq(t, errorx.KindUnknown, errorx.KindFromHTTPStatus(http.StatusTeapot))
+	assert.Eq(t, errorx.KindInternal, errorx.KindFromGRPCCode(errorx.GRPCCodeInternal))
+	assert.Eq(t, errorx.KindUnknown, errorx.KindFromGRPCCode(99))
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Eq(t, errorx.KindUnknown, errorx.KindOf(nil))
+	assert.Eq(t, errorx.KindUnknown, errorx.KindOf(errors.New("error")))
+	assert.Eq(t, errorx.KindCanceled, errorx.KindOf(context.Canceled))
+	assert.Eq(t, errorx.KindNotFound, errorx.KindOf(os.ErrNotExist))
+
+	err := errorx.NewKind(errorx.KindInvalidArgument, "invalid name")
+	assert.Eq(t, "invalid name", err.Error())
+	assert.True(t, errorx.IsKind(err, errorx.KindInvalidArgument))
+	assert.True(t, errorx.IsKind(errorx.With(err, "wrap"), errorx.KindInvalidArgument))
+
+	assert.Nil(t, errorx.WithKind(nil, errorx.KindNotFound))
+	err2 := errorx.WithKind(os.ErrPermission, errorx.KindNotFound)
+	assert.Eq(t, errorx.KindNotFound, errorx.KindOf(err2))
+	assert.ErrIs(t, err2, os.ErrPermission)
+
+	assert.Eq(t, http.StatusOK, errorx.HTTPStatus(nil))
+	assert.Eq(t, http.StatusBadRequest, errorx.HTTPStatus(err))
+	assert.Eq(t, errorx.GRPCCodeOK, errorx.GRPCCode(nil))
+	assert.Eq(t, errorx.GRPCCodeInvalidArgument, errorx.GRPCCode(err))
+	assert.Eq(t, "not found", errorx.NewKindf(errorx.KindNotFound, "not %s", "found").Error())
+}
+
+func TestNewPayload(t *testing.T) {
+	p := errorx.NewPayload(nil)
+	assert.Eq(t, http.StatusOK, p.Code)
+
+	err := errorx.NewKind(errorx.KindNotFound, "user not found").WithField("id", 23)
+	assert.Eq(t, `{"code":404,"message":"user not found","fields":{"id":23}}`, string(errorx.RenderJSON(err)))
+
+	// ErrorCoder
+	p = errorx.NewPayload(errorx.Fail(1001, "custom error"))
+	assert.Eq(t, 1001, p.Code)
+	assert.Eq(t, "custom error", p.Error())
+
+	// ErrorM
+	p = errorx.NewPayload(errorx.WithKind(errorx.ErrorM{"name": errors.New("name is required")}, errorx.KindInvalidArgument))
+	assert.Eq(t, 400, p.Code)
+	assert.Eq(t, "name is required", p.Fields["name"])
+
+	w := httptest.NewRecorder()
+	assert.NoErr(t, errorx.WriteHTTPError(w, err))
+	assert.Eq(t, 404, w.Code)
+	assert.StrContains(t, w.Header().Get("Content-Type"), "application/json")
+
+	// from response
+	ke := errorx.FromHTTPResponse(w.Code, w.Body.Bytes())
+	assert.Eq(t, errorx.KindNotFound, ke.Kind())
+	assert.Eq(t, "user not found", ke.Error())
+	assert.Eq(t, float64(23), ke.Fields["id"])
+
+	ke = errorx.FromHTTPResponse(503, []byte("service down"))
+	assert.Eq(t, errorx.KindUnavailable, ke.Kind())
+	assert.Eq(t, "service down", ke.Error())
+	assert.Eq(t, "Bad Gateway", errorx.FromHTTPResponse(502, nil).Error())
+}
@@ -0,0 +1,95 @@
+package errorx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ErrorPayload the JSON error response payload.
+//
+// eg: {"code": 404, "message": "user not found", "fields": {"id": 23}}
+type ErrorPayload struct {
+	// Code custom error code on err implements ErrorCoder, otherwise is HTTP status code
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+// NewPayload create error payload from the error
+//
+//   - Code: err.Code() on err implements ErrorCoder, otherwise is HTTPStatus(err)
+//   - Fields: will collect from KindError.Fields or ErrorM
+func NewPayload(err error) *ErrorPayload {
+	p := &ErrorPayload{Code: HTTPStatus(err)}
+	if err == nil {
+		return p
+	}
+
+	p.Message = err.Error()
+
+	var ec ErrorCoder
+	if errors.As(err, &ec) {
+		p.Code = ec.Code()
+	}
+
+	var ke *KindError
+	if errors.As(err, &ke) && len(ke.Fields) > 0 {
+		p.Fields = ke.Fields
+	}
+
+	var em ErrorM
+	if errors.As(err, &em) {
+		if p.Fields == nil {
+			p.Fields = make(map[string]any, len(em))
+		}
+		for name, e := range em {
+			p.Fields[name] = e.Error()
+		}
+	}
+	return p
+}
+
+// JSON encode the payload to JSON bytes
+func (p *ErrorPayload) JSON() []byte {
+	bs, _ := json.Marshal(p)
+	return bs
+}
+
+// Error implements the error
+func (p *ErrorPayload) Error() string { return p.Message }
+
+// ToError convert payload to *KindError, kind will be parsed from the HTTP status.
+func (p *ErrorPayload) ToError(status int) *KindError {
+	ke := NewKind(KindFromHTTPStatus(status), p.Message)
+	if len(p.Fields) > 0 {
+		ke.Fields = p.Fields
+	}
+	return ke
+}
+
+// RenderJSON render error as JSON payload bytes. see NewPayload()
+func RenderJSON(err error) []byte {
+	return NewPayload(err).JSON()
+}
+
+// WriteHTTPError write error as JSON payload to the http.ResponseWriter, status code by HTTPStatus(err)
+func WriteHTTPError(w http.ResponseWriter, err error) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(HTTPStatus(err))
+	_, werr := w.Write(RenderJSON(err))
+	return werr
+}
+
+// FromHTTPResponse parse the HTTP error response to *KindError.
+// If body is not a valid JSON payload, will use the body as message.
+func FromHTTPResponse(status int, body []byte) *KindError {
+	p := &ErrorPayload{}
+	if err := json.Unmarshal(body, p); err != nil || p.Message == "" {
+		p.Message = string(body)
+		if p.Message == "" {
+			p.Message = http.StatusText(status)
+		}
+	}
+	return p.ToError(status)
+}
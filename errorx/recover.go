@@ -0,0 +1,54 @@
+package errorx
+
+import "sync"
+
+var (
+	panicMu    sync.RWMutex
+	panicHooks []func(r any)
+)
+
+// OnPanic register a hook func, it will be called by Recover() with the recovered value.
+//
+// Usage:
+//
+//	errorx.OnPanic(func(r any) {
+//		log.Println("panic:", r)
+//	})
+func OnPanic(fn func(r any)) {
+	panicMu.Lock()
+	panicHooks = append(panicHooks, fn)
+	panicMu.Unlock()
+}
+
+// ResetPanicHooks remove all registered panic hooks
+func ResetPanicHooks() {
+	panicMu.Lock()
+	panicHooks = nil
+	panicMu.Unlock()
+}
+
+// Recover the panic and call the registered hooks by OnPanic(), then re-panic with the value.
+// must call it with defer, at the top of main() or the goroutines.
+//
+// Usage:
+//
+//	func main() {
+//		defer errorx.Recover()
+//		// ...
+//	}
+func Recover() {
+	if r := recover(); r != nil {
+		firePanicHooks(r)
+		panic(r)
+	}
+}
+
+func firePanicHooks(r any) {
+	panicMu.RLock()
+	hooks := panicHooks
+	panicMu.RUnlock()
+
+	for _, fn := range hooks {
+		fn(r)
+	}
+}
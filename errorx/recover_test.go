@@ -0,0 +1,37 @@
+package errorx_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/errorx"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestRecover(t *testing.T) {
+	defer errorx.ResetPanicHooks()
+
+	var got []any
+	errorx.OnPanic(func(r any) { got = append(got, r) })
+	errorx.OnPanic(func(r any) { got = append(got, "second") })
+
+	// no panic
+	func() {
+		defer errorx.Recover()
+	}()
+	assert.Empty(t, got)
+
+	assert.PanicsMsg(t, func() {
+		defer errorx.Recover()
+		panic("oops")
+	}, "oops")
+	assert.Eq(t, []any{"oops", "second"}, got)
+
+	// reset hooks
+	got = nil
+	errorx.ResetPanicHooks()
+	assert.Panics(t, func() {
+		defer errorx.Recover()
+		panic("oops")
+	})
+	assert.Empty(t, got)
+}
@@ -0,0 +1,70 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UserMessager interface for get the friendly message, which can be shown to end users.
+type UserMessager interface {
+	UserMsg() string
+}
+
+// userMsgError wrap an error with the friendly message for end users.
+type userMsgError struct {
+	msg string
+	err error
+}
+
+// WithUserMsg wrap an error with the friendly message for end users.
+// The Error() will keep the full detail of the wrapped error. If err is nil, will return nil.
+//
+// Usage:
+//
+//	err = errorx.WithUserMsg(err, "cannot connect to the server, please check your network")
+//
+//	fmt.Println(errorx.UserMsg(err)) // show to end users
+//	log.Println(err) // full detail for debug
+func WithUserMsg(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &userMsgError{msg: msg, err: err}
+}
+
+// WithUserMsgf wrap an error with the format friendly message for end users.
+func WithUserMsgf(err error, tpl string, vars ...any) error {
+	if err == nil {
+		return nil
+	}
+	return &userMsgError{msg: fmt.Sprintf(tpl, vars...), err: err}
+}
+
+// UserMsg get the friendly message for end users
+func (e *userMsgError) UserMsg() string { return e.msg }
+
+// Unwrap get the wrapped error
+func (e *userMsgError) Unwrap() error { return e.err }
+
+// Error get the full error detail
+func (e *userMsgError) Error() string { return e.err.Error() }
+
+// HasUserMsg check the error chain has the friendly message for end users
+func HasUserMsg(err error) bool {
+	var um UserMessager
+	return errors.As(err, &um)
+}
+
+// UserMsg get the friendly message for end users from the error chain.
+// The outermost message is used, and will fall back to err.Error() if not found.
+func UserMsg(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var um UserMessager
+	if errors.As(err, &um) {
+		return um.UserMsg()
+	}
+	return err.Error()
+}
@@ -0,0 +1,35 @@
+package errorx_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gookit/goutil/errorx"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestWithUserMsg(t *testing.T) {
+	assert.Nil(t, errorx.WithUserMsg(nil, "msg"))
+	assert.Nil(t, errorx.WithUserMsgf(nil, "msg %d", 1))
+	assert.Eq(t, "", errorx.UserMsg(nil))
+	assert.False(t, errorx.HasUserMsg(nil))
+
+	raw := fmt.Errorf("open config: %w", os.ErrNotExist)
+	assert.Eq(t, raw.Error(), errorx.UserMsg(raw))
+	assert.False(t, errorx.HasUserMsg(raw))
+
+	err := errorx.WithUserMsg(raw, "config file not found")
+	assert.True(t, errorx.HasUserMsg(err))
+	assert.Eq(t, "config file not found", errorx.UserMsg(err))
+	assert.Eq(t, raw.Error(), err.Error())
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	// wrapped again, use the outermost message
+	err = fmt.Errorf("load app: %w", err)
+	assert.Eq(t, "config file not found", errorx.UserMsg(err))
+	err = errorx.WithUserMsgf(err, "cannot start %s", "app")
+	assert.Eq(t, "cannot start app", errorx.UserMsg(err))
+	assert.Eq(t, "load app: open config: file does not exist", err.Error())
+}
@@ -0,0 +1,307 @@
+package fsutil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// CacheDirOption for NewCacheDir()
+type CacheDirOption struct {
+	// BaseDir for create the cache dir. default is the platform user cache dir:
+	//
+	//  - Linux: $XDG_CACHE_HOME or $HOME/.cache
+	//  - macOS: $HOME/Library/Caches
+	//  - Windows: %LocalAppData%
+	BaseDir string
+	// TTL of the cache file, expired file will be ignored on Get and removed by Clean. 0 is never expire.
+	TTL time.Duration
+	// MaxSize max total size(bytes) of the cache files. 0 is no limit.
+	// will evict the least recently used files on Put, on TTL mode is the oldest written files.
+	MaxSize int64
+}
+
+// CacheDirOptionFunc for NewCacheDir()
+type CacheDirOptionFunc func(opt *CacheDirOption)
+
+// WithCacheBaseDir set the base dir for NewCacheDir()
+func WithCacheBaseDir(dir string) CacheDirOptionFunc {
+	return func(opt *CacheDirOption) {
+		opt.BaseDir = dir
+	}
+}
+
+// WithCacheTTL set the TTL of cache files for NewCacheDir()
+func WithCacheTTL(ttl time.Duration) CacheDirOptionFunc {
+	return func(opt *CacheDirOption) {
+		opt.TTL = ttl
+	}
+}
+
+// WithCacheMaxSize set the max total size of cache files for NewCacheDir()
+func WithCacheMaxSize(size int64) CacheDirOptionFunc {
+	return func(opt *CacheDirOption) {
+		opt.MaxSize = size
+	}
+}
+
+// CacheDir a content-addressed cache dir, the file path is by hash of the key.
+//
+// Usage:
+//
+//	cd, err := fsutil.NewCacheDir("my-cli", fsutil.WithCacheTTL(24*time.Hour))
+//	if bs, ok := cd.Get(url); ok {
+//		return bs
+//	}
+//	err = cd.Put(url, downloaded)
+type CacheDir struct {
+	CacheDirOption
+	// Dir the cache dir path
+	Dir string
+}
+
+// NewCacheDir create a cache dir by name under the platform user cache dir.
+func NewCacheDir(name string, optFns ...CacheDirOptionFunc) (*CacheDir, error) {
+	c := &CacheDir{}
+	for _, fn := range optFns {
+		fn(&c.CacheDirOption)
+	}
+
+	if c.BaseDir == "" {
+		baseDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		c.BaseDir = baseDir
+	}
+
+	c.Dir = filepath.Join(c.BaseDir, name)
+	if err := os.MkdirAll(c.Dir, DefaultDirPerm); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Path get the cache file path of the key. eg: "{Dir}/ab/abcdef..."
+func (c *CacheDir) Path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	hash := hex.EncodeToString(sum[:])
+	return filepath.Join(c.Dir, hash[:2], hash)
+}
+
+// Has check the key is cached and not expired
+func (c *CacheDir) Has(key string) bool {
+	_, ok := c.stat(key)
+	return ok
+}
+
+// Get the cached contents by key. will return false if not exists or expired.
+func (c *CacheDir) Get(key string) ([]byte, bool) {
+	fPath, ok := c.File(key)
+	if !ok {
+		return nil, false
+	}
+
+	bs, err := os.ReadFile(fPath)
+	if err != nil {
+		return nil, false
+	}
+	return bs, true
+}
+
+// File get the cached file path by key. will return false if not exists or expired.
+//
+// NOTE: if TTL is not set, will update the modify time as last access time for LRU eviction.
+func (c *CacheDir) File(key string) (string, bool) {
+	if _, ok := c.stat(key); !ok {
+		return "", false
+	}
+
+	fPath := c.Path(key)
+	// use the mtime as last access time. on TTL mode, must keep it for expire check.
+	if c.TTL <= 0 {
+		now := time.Now()
+		_ = os.Chtimes(fPath, now, now)
+	}
+	return fPath, true
+}
+
+func (c *CacheDir) stat(key string) (fs.FileInfo, bool) {
+	fPath := c.Path(key)
+	fi, err := os.Stat(fPath)
+	if err != nil || fi.IsDir() {
+		return nil, false
+	}
+
+	if c.expired(fi) {
+		_ = os.Remove(fPath)
+		return nil, false
+	}
+	return fi, true
+}
+
+func (c *CacheDir) expired(fi fs.FileInfo) bool {
+	return c.TTL > 0 && time.Since(fi.ModTime()) > c.TTL
+}
+
+// Put save contents to the cache by key
+func (c *CacheDir) Put(key string, data []byte) error {
+	return c.PutReader(key, bytes.NewReader(data))
+}
+
+// PutReader save contents from reader to the cache by key.
+// will write to temp file then rename it, so readers never see a partial file.
+func (c *CacheDir) PutReader(key string, r io.Reader) error {
+	fPath := c.Path(key)
+	if err := os.MkdirAll(filepath.Dir(fPath), DefaultDirPerm); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fPath), ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, r)
+	if cErr := tmp.Close(); err == nil {
+		err = cErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), fPath)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if c.MaxSize > 0 {
+		_, err = c.Evict()
+	}
+	return err
+}
+
+// Delete the cache by key
+func (c *CacheDir) Delete(key string) error {
+	return DeleteIfFileExist(c.Path(key))
+}
+
+// Clear remove all cache files
+func (c *CacheDir) Clear() error {
+	if err := os.RemoveAll(c.Dir); err != nil {
+		return err
+	}
+	return os.MkdirAll(c.Dir, DefaultDirPerm)
+}
+
+type cacheFile struct {
+	path string
+	size int64
+	// last access time. see File()
+	atime time.Time
+}
+
+func (c *CacheDir) files() ([]*cacheFile, error) {
+	var files []*cacheFile
+	err := filepath.WalkDir(c.Dir, func(fPath string, ent fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ent.IsDir() {
+			return nil
+		}
+
+		fi, err := ent.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, &cacheFile{path: fPath, size: fi.Size(), atime: fi.ModTime()})
+		return nil
+	})
+	return files, err
+}
+
+// Size get total size of the cache files
+func (c *CacheDir) Size() (int64, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, f := range files {
+		size += f.size
+	}
+	return size, nil
+}
+
+// Clean remove the expired cache files by TTL. returns the removed number.
+func (c *CacheDir) Clean() (int, error) {
+	if c.TTL <= 0 {
+		return 0, nil
+	}
+
+	var num int
+	err := filepath.WalkDir(c.Dir, func(fPath string, ent fs.DirEntry, err error) error {
+		if err != nil || ent.IsDir() {
+			return err
+		}
+
+		fi, err := ent.Info()
+		if err == nil && c.expired(fi) {
+			if err = os.Remove(fPath); err != nil {
+				return err
+			}
+			num++
+		}
+		return nil
+	})
+	return num, err
+}
+
+// Evict remove the least recently used files until the total size <= MaxSize.
+// returns the removed number.
+func (c *CacheDir) Evict() (int, error) {
+	if c.MaxSize <= 0 {
+		return 0, nil
+	}
+
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	if total <= c.MaxSize {
+		return 0, nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].atime.Before(files[j].atime)
+	})
+
+	var num int
+	for _, f := range files {
+		if total <= c.MaxSize {
+			break
+		}
+		if err = os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return num, err
+		}
+		total -= f.size
+		num++
+	}
+	return num, nil
+}
@@ -0,0 +1,94 @@
+package fsutil_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestNewCacheDir(t *testing.T) {
+	base := t.TempDir()
+	cd, err := fsutil.NewCacheDir("my-cli", fsutil.WithCacheBaseDir(base))
+	assert.NoErr(t, err)
+	assert.True(t, fsutil.IsDir(cd.Dir))
+	assert.True(t, strings.HasPrefix(cd.Path("key"), cd.Dir))
+	assert.Eq(t, cd.Path("key"), cd.Path("key"))
+	assert.NotEq(t, cd.Path("key"), cd.Path("key1"))
+
+	_, ok := cd.Get("key")
+	assert.False(t, ok)
+	assert.False(t, cd.Has("key"))
+
+	assert.NoErr(t, cd.Put("key", []byte("hello")))
+	assert.True(t, cd.Has("key"))
+	bs, ok := cd.Get("key")
+	assert.True(t, ok)
+	assert.Eq(t, "hello", string(bs))
+
+	fPath, ok := cd.File("key")
+	assert.True(t, ok)
+	assert.Eq(t, cd.Path("key"), fPath)
+
+	assert.NoErr(t, cd.PutReader("key2", strings.NewReader("world")))
+	size, err := cd.Size()
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(10), size)
+
+	assert.NoErr(t, cd.Delete("key"))
+	assert.False(t, cd.Has("key"))
+	assert.NoErr(t, cd.Delete("key"))
+
+	assert.NoErr(t, cd.Clear())
+	assert.False(t, cd.Has("key2"))
+	assert.True(t, fsutil.IsDir(cd.Dir))
+}
+
+func TestCacheDir_TTL(t *testing.T) {
+	cd, err := fsutil.NewCacheDir("ttl", fsutil.WithCacheBaseDir(t.TempDir()), fsutil.WithCacheTTL(time.Hour))
+	assert.NoErr(t, err)
+
+	assert.NoErr(t, cd.Put("old", []byte("old")))
+	assert.NoErr(t, cd.Put("new", []byte("new")))
+	past := time.Now().Add(-2 * time.Hour)
+	assert.NoErr(t, os.Chtimes(cd.Path("old"), past, past))
+
+	n, err := cd.Clean()
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, n)
+	assert.False(t, cd.Has("old"))
+	assert.True(t, cd.Has("new"))
+
+	// expired on get
+	assert.NoErr(t, os.Chtimes(cd.Path("new"), past, past))
+	_, ok := cd.Get("new")
+	assert.False(t, ok)
+	assert.False(t, fsutil.IsFile(cd.Path("new")))
+}
+
+func TestCacheDir_Evict(t *testing.T) {
+	cd, err := fsutil.NewCacheDir("lru", fsutil.WithCacheBaseDir(t.TempDir()), fsutil.WithCacheMaxSize(10))
+	assert.NoErr(t, err)
+
+	assert.NoErr(t, cd.Put("a", []byte("aaaa")))
+	assert.NoErr(t, cd.Put("b", []byte("bbbb")))
+	// make "a" is older, then access it.
+	past := time.Now().Add(-time.Hour)
+	assert.NoErr(t, os.Chtimes(cd.Path("a"), past, past))
+	assert.NoErr(t, os.Chtimes(cd.Path("b"), past.Add(time.Minute), past.Add(time.Minute)))
+	_, ok := cd.Get("a")
+	assert.True(t, ok)
+
+	// total 12 > 10, evict the least recently used "b"
+	assert.NoErr(t, cd.Put("c", []byte("cccc")))
+	assert.True(t, cd.Has("a"))
+	assert.False(t, cd.Has("b"))
+	assert.True(t, cd.Has("c"))
+
+	size, err := cd.Size()
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(8), size)
+}
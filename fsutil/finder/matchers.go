@@ -2,6 +2,7 @@ package finder
 
 import (
 	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -203,6 +204,23 @@ func NameLikes(patterns []string) MatcherFunc {
 	}
 }
 
+// MatchIgnored match file/dir path by the gitignore-style matcher. root is the dir of ignore file.
+//
+// Usage:
+//
+//	im, err := fsutil.LoadIgnoreFile("path/to/dir/.gitignore")
+//	f := NewFinder("path/to/dir")
+//	f.Exclude(MatchIgnored("path/to/dir", im))
+func MatchIgnored(root string, im *fsutil.IgnoreMatcher) MatcherFunc {
+	return func(el Elem) bool {
+		rel, err := filepath.Rel(root, el.Path())
+		if err != nil {
+			return false
+		}
+		return im.Match(rel, el.IsDir())
+	}
+}
+
 //
 // ----------------- built in file info filters -----------------
 //
@@ -3,6 +3,7 @@ package finder_test
 import (
 	"testing"
 
+	"github.com/gookit/goutil/fsutil"
 	"github.com/gookit/goutil/fsutil/finder"
 	"github.com/gookit/goutil/testutil"
 	"github.com/gookit/goutil/testutil/assert"
@@ -115,3 +116,19 @@ func TestMatchDotDir(t *testing.T) {
 		assert.Contains(t, f.FindNames(), dirName)
 	})
 }
+
+func TestMatchIgnored(t *testing.T) {
+	im, err := fsutil.NewIgnoreMatcher("*.jpg\n/sub/")
+	assert.NoErr(t, err)
+
+	fn := finder.MatchIgnored("path/to", im)
+	assert.True(t, fn(newMockElem("path/to/some.jpg")))
+	assert.True(t, fn(newMockElem("path/to/sub", true)))
+	assert.False(t, fn(newMockElem("path/to/some.txt")))
+	assert.False(t, fn(newMockElem("path/to/dir/sub", true)))
+
+	f := finder.NewFinder("./testdata").Exclude(finder.MatchIgnored("./testdata", im))
+	names := f.FindNames()
+	assert.Contains(t, names, "test.txt")
+	assert.NotContains(t, names, "test.jpg")
+}
@@ -0,0 +1,116 @@
+package fsutil
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// FS the file system interface for read and write files.
+// use the OSFS for real disk, or an in-memory implementation for testing. eg: testutil.MemFS
+type FS interface {
+	fs.FS
+	// Stat get the file info
+	Stat(name string) (fs.FileInfo, error)
+	// ReadFile read the file contents
+	ReadFile(name string) ([]byte, error)
+	// ReadDir read the dir entries, sorted by filename
+	ReadDir(name string) ([]fs.DirEntry, error)
+	// WriteFile write data to the file, will create it if not exists.
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	// MkdirAll create the dir and all parents
+	MkdirAll(dirPath string, perm fs.FileMode) error
+	// Remove the file or empty dir
+	Remove(name string) error
+}
+
+// OSFS is the FS implementation of the os file system, the name is the os file path.
+var OSFS FS = osFS{}
+
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error)               { return os.Open(name) }
+func (osFS) Stat(name string) (fs.FileInfo, error)           { return os.Stat(name) }
+func (osFS) ReadFile(name string) ([]byte, error)            { return os.ReadFile(name) }
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error)      { return os.ReadDir(name) }
+func (osFS) MkdirAll(dirPath string, perm fs.FileMode) error { return os.MkdirAll(dirPath, perm) }
+func (osFS) Remove(name string) error                        { return os.Remove(name) }
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+// PathExistsFS reports whether the named file or directory exists in the fsys.
+func PathExistsFS(fsys FS, fPath string) bool {
+	if fPath == "" {
+		return false
+	}
+
+	_, err := fsys.Stat(fPath)
+	return err == nil
+}
+
+// IsDirFS reports whether the named directory exists in the fsys.
+func IsDirFS(fsys FS, dirPath string) bool {
+	if dirPath == "" {
+		return false
+	}
+
+	fi, err := fsys.Stat(dirPath)
+	return err == nil && fi.IsDir()
+}
+
+// IsFileFS reports whether the named file exists in the fsys.
+func IsFileFS(fsys FS, fPath string) bool {
+	if fPath == "" {
+		return false
+	}
+
+	fi, err := fsys.Stat(fPath)
+	return err == nil && !fi.IsDir()
+}
+
+// SaveFileFS write data to the file in the fsys, will auto create the parent dir.
+func SaveFileFS(fsys FS, fPath string, data []byte, perm ...fs.FileMode) error {
+	if err := fsys.MkdirAll(fsDir(fsys, fPath), DefaultDirPerm); err != nil {
+		return err
+	}
+
+	filePerm := DefaultFilePerm
+	if len(perm) > 0 {
+		filePerm = perm[0]
+	}
+	return fsys.WriteFile(fPath, data, filePerm)
+}
+
+// CopyFileFS copy a file to another path in the fsys, will auto create the parent dir.
+func CopyFileFS(fsys FS, srcPath, dstPath string) error {
+	fi, err := fsys.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+
+	bs, err := fsys.ReadFile(srcPath)
+	if err != nil {
+		return err
+	}
+	return SaveFileFS(fsys, dstPath, bs, fi.Mode().Perm())
+}
+
+// RemoveIfExistFS remove the file or empty dir in the fsys if it exists.
+func RemoveIfExistFS(fsys FS, fPath string) error {
+	err := fsys.Remove(fPath)
+	if err != nil && errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// get parent dir, the OSFS use os path separator
+func fsDir(fsys FS, fPath string) string {
+	if _, ok := fsys.(osFS); ok {
+		return filepath.Dir(fPath)
+	}
+	return path.Dir(fPath)
+}
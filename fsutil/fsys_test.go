@@ -0,0 +1,51 @@
+package fsutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestOSFS(t *testing.T) {
+	dir := t.TempDir()
+	fpath := filepath.Join(dir, "sub", "a.txt")
+
+	assert.NoErr(t, fsutil.SaveFileFS(fsutil.OSFS, fpath, []byte("hello")))
+	assert.True(t, fsutil.IsFileFS(fsutil.OSFS, fpath))
+	assert.True(t, fsutil.IsDirFS(fsutil.OSFS, filepath.Dir(fpath)))
+	assert.True(t, fsutil.PathExistsFS(fsutil.OSFS, fpath))
+	assert.False(t, fsutil.IsFileFS(fsutil.OSFS, ""))
+	assert.False(t, fsutil.IsDirFS(fsutil.OSFS, ""))
+	assert.False(t, fsutil.PathExistsFS(fsutil.OSFS, ""))
+
+	dst := filepath.Join(dir, "bak", "a.txt")
+	assert.NoErr(t, fsutil.CopyFileFS(fsutil.OSFS, fpath, dst))
+	bs, err := os.ReadFile(dst)
+	assert.NoErr(t, err)
+	assert.Eq(t, "hello", string(bs))
+
+	ents, err := fsutil.OSFS.ReadDir(dir)
+	assert.NoErr(t, err)
+	assert.Len(t, ents, 2)
+
+	f, err := fsutil.OSFS.Open(dst)
+	assert.NoErr(t, err)
+	assert.NoErr(t, f.Close())
+
+	assert.NoErr(t, fsutil.RemoveIfExistFS(fsutil.OSFS, dst))
+	assert.NoErr(t, fsutil.RemoveIfExistFS(fsutil.OSFS, dst))
+	assert.False(t, fsutil.PathExistsFS(fsutil.OSFS, dst))
+	assert.Err(t, fsutil.CopyFileFS(fsutil.OSFS, dst, fpath))
+}
+
+func TestMemFS_fsutil(t *testing.T) {
+	mfs := testutil.NewMemFS(map[string]string{"conf/app.ini": "name=app"})
+
+	assert.True(t, fsutil.IsFileFS(mfs, "conf/app.ini"))
+	assert.NoErr(t, fsutil.CopyFileFS(mfs, "conf/app.ini", "bak/app.ini"))
+	assert.Eq(t, []string{"bak/app.ini", "conf/app.ini"}, mfs.Files())
+}
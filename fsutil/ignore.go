@@ -0,0 +1,221 @@
+package fsutil
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreMatcher match the path by gitignore-style patterns. eg: .gitignore, .dockerignore
+//
+// Supported syntax:
+//
+//   - blank line and line start with "#" are ignored. use "\#" for escape
+//   - "!" prefix negate the pattern, re-include the path. use "\!" for escape
+//   - pattern end with "/" only match dir
+//   - pattern contains "/" at the beginning or middle is relative to the root dir, otherwise match at any level
+//   - "*", "?", "[a-z]" glob syntax, "**" match any levels of dirs
+//
+// NOTE: the patterns in the sub dir ignore files are not supported.
+type IgnoreMatcher struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	pattern string
+	negate  bool
+	dirOnly bool
+	re      *regexp.Regexp
+}
+
+// NewIgnoreMatcher create a matcher by the gitignore-style patterns, each one can also be a multi-line text.
+// use LoadIgnoreFile() for create from the ignore file.
+//
+// Usage:
+//
+//	m, err := fsutil.NewIgnoreMatcher("*.log", "build/", "!keep.log")
+//	m, err := fsutil.NewIgnoreMatcher("*.log\nbuild/\n!keep.log")
+//
+//	m.Match("logs/app.log", false) // true
+func NewIgnoreMatcher(patterns ...string) (*IgnoreMatcher, error) {
+	m := &IgnoreMatcher{}
+	for _, text := range patterns {
+		if err := m.AddPatterns(strings.Split(text, "\n")...); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// LoadIgnoreFile create a matcher by the ignore file. eg: .gitignore, .dockerignore
+//
+// Usage:
+//
+//	m, err := fsutil.LoadIgnoreFile(".gitignore")
+func LoadIgnoreFile(filePath string) (*IgnoreMatcher, error) {
+	bs, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return NewIgnoreMatcher(string(bs))
+}
+
+// AddPatterns add gitignore-style pattern lines, the later pattern has higher priority.
+func (m *IgnoreMatcher) AddPatterns(lines ...string) error {
+	for _, line := range lines {
+		rule, ok, err := parseIgnoreRule(line)
+		if err != nil {
+			return err
+		}
+		if ok {
+			m.rules = append(m.rules, rule)
+		}
+	}
+	return nil
+}
+
+// Len get the pattern rules number
+func (m *IgnoreMatcher) Len() int {
+	return len(m.rules)
+}
+
+// Match check the path is ignored. the path should be relative to the root dir of ignore file.
+//
+// The path is ignored if its parent dir is ignored, same as git.
+func (m *IgnoreMatcher) Match(relPath string, isDir bool) bool {
+	relPath = strings.Trim(path.Clean(filepath.ToSlash(relPath)), "/")
+	if relPath == "." || relPath == "" {
+		return false
+	}
+
+	// check the parent dirs
+	for i := 0; i < len(relPath); i++ {
+		if relPath[i] == '/' && m.matchOne(relPath[:i], true) {
+			return true
+		}
+	}
+	return m.matchOne(relPath, isDir)
+}
+
+func (m *IgnoreMatcher) matchOne(relPath string, isDir bool) bool {
+	var ignored bool
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		// only negate rule can change the ignored status
+		if ignored != rule.negate {
+			continue
+		}
+		if rule.re.MatchString(relPath) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// FilterFunc create a FilterFunc for FindInDir, will skip the ignored paths in root dir.
+func (m *IgnoreMatcher) FilterFunc(root string) FilterFunc {
+	return func(fPath string, ent fs.DirEntry) bool {
+		rel, err := filepath.Rel(root, fPath)
+		if err != nil {
+			return true
+		}
+		return !m.Match(rel, ent.IsDir())
+	}
+}
+
+// parse the pattern line to rule. ok=false for blank or comment line.
+func parseIgnoreRule(line string) (rule ignoreRule, ok bool, err error) {
+	line = strings.TrimSuffix(line, "\r")
+	// trailing spaces are ignored unless they are escaped with backslash
+	if trimmed := strings.TrimRight(line, " "); strings.HasSuffix(trimmed, `\`) && len(trimmed) < len(line) {
+		line = trimmed + " "
+	} else {
+		line = trimmed
+	}
+
+	if line == "" || line[0] == '#' {
+		return
+	}
+
+	rule.pattern = line
+	if line[0] == '!' {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return
+	}
+
+	// contains "/" at the beginning or middle: relative to the root dir
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := "^" + globToRegex(line) + "$"
+	if !anchored {
+		expr = "^(?:.*/)?" + globToRegex(line) + "$"
+	}
+
+	rule.re, err = regexp.Compile(expr)
+	return rule, err == nil, err
+}
+
+// convert the gitignore glob pattern to regex expr
+func globToRegex(pattern string) string {
+	var sb strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				// "**/" match zero or more dirs
+				if i+2 < len(pattern) && pattern[i+2] == '/' && (i == 0 || pattern[i-1] == '/') {
+					sb.WriteString("(?:.*/)?")
+					i += 2
+					continue
+				}
+				// "/**" at end match everything inside
+				if i+2 == len(pattern) && (i == 0 || pattern[i-1] == '/') {
+					sb.WriteString(".*")
+					i++
+					continue
+				}
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,128 @@
+package fsutil_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestIgnoreMatcher_Match(t *testing.T) {
+	m, err := fsutil.NewIgnoreMatcher(`
+# comment line
+*.log
+!keep.log
+build/
+/vendor
+docs/*.md
+!docs/README.md
+**/tmp/**
+a/**/z.txt
+\#hash.txt
+file[0-9].txt
+space\ 
+`)
+	assert.NoErr(t, err)
+	assert.Eq(t, 11, m.Len())
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		// match at any level
+		{"app.log", false, true},
+		{"logs/app.log", false, true},
+		{"logs/keep.log", false, false},
+		{"app.go", false, false},
+		// dir only
+		{"build", true, true},
+		{"build", false, false},
+		{"src/build", true, true},
+		{"build/main.o", false, true},
+		// anchored
+		{"vendor", true, true},
+		{"vendor/pkg/a.go", false, true},
+		{"src/vendor", true, false},
+		{"docs/intro.md", false, true},
+		{"docs/README.md", false, false},
+		{"docs/sub/intro.md", false, false},
+		// double star
+		{"tmp/a.txt", false, true},
+		{"x/y/tmp/a.txt", false, true},
+		{"a/z.txt", false, true},
+		{"a/b/c/z.txt", false, true},
+		{"b/a/z.txt", false, false},
+		// escape and class
+		{"#hash.txt", false, true},
+		{"file1.txt", false, true},
+		{"fileA.txt", false, false},
+		{"space ", false, true},
+		{".", true, false},
+	}
+
+	for _, tt := range tests {
+		assert.Eq(t, tt.want, m.Match(tt.path, tt.isDir), tt.path)
+	}
+}
+
+func TestIgnoreMatcher_parentExcluded(t *testing.T) {
+	m, err := fsutil.NewIgnoreMatcher("dist/\n!dist/keep.txt\ncache/*\n!cache/keep.txt")
+	assert.NoErr(t, err)
+
+	// can not re-include a file if a parent dir of that file is excluded
+	assert.True(t, m.Match("dist/keep.txt", false))
+	assert.True(t, m.Match("cache/data.bin", false))
+	assert.False(t, m.Match("cache/keep.txt", false))
+}
+
+func TestLoadIgnoreFile(t *testing.T) {
+	fpath := "testdata/ignore-file.txt"
+	_, err := fsutil.PutContents(fpath, "*.tmp\n# comment\n\n!need.tmp\n")
+	assert.NoErr(t, err)
+	defer fsutil.MustRemove(fpath)
+
+	m, err := fsutil.LoadIgnoreFile(fpath)
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, m.Len())
+	assert.True(t, m.Match("sub/a.tmp", false))
+	assert.False(t, m.Match("sub/need.tmp", false))
+
+	_, err = fsutil.LoadIgnoreFile("testdata/not-exists.txt")
+	assert.Err(t, err)
+
+	// the existing file path is a pattern for NewIgnoreMatcher
+	m, err = fsutil.NewIgnoreMatcher(fpath, "*.bak")
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, m.Len())
+	assert.True(t, m.Match(fpath, false))
+	assert.True(t, m.Match("a.bak", false))
+	assert.False(t, m.Match("sub/a.tmp", false))
+
+	_, err = fsutil.NewIgnoreMatcher("[a-")
+	assert.NoErr(t, err)
+	_, err = fsutil.NewIgnoreMatcher("[z-a]")
+	assert.Err(t, err)
+}
+
+func TestIgnoreMatcher_FilterFunc(t *testing.T) {
+	m, err := fsutil.NewIgnoreMatcher("*.jpg\nsub/")
+	assert.NoErr(t, err)
+
+	fn := m.FilterFunc("testdata")
+	assert.False(t, fn("testdata/test.jpg", testutil.NewDirEnt("testdata/test.jpg")))
+	assert.False(t, fn("testdata/sub", testutil.NewDirEnt("testdata/sub", true)))
+	assert.True(t, fn("testdata/get-contents.txt", testutil.NewDirEnt("testdata/get-contents.txt")))
+
+	files := make([]string, 0, 8)
+	err = fsutil.FindInDir("testdata", func(fPath string, de fs.DirEntry) error {
+		files = append(files, de.Name())
+		return nil
+	}, fn)
+	assert.NoErr(t, err)
+	assert.NotEmpty(t, files)
+	assert.NotContains(t, files, "test.jpg")
+	assert.NotContains(t, files, "sub")
+}
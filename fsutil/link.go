@@ -0,0 +1,79 @@
+package fsutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// DefaultMaxLinkDepth the default max depth for resolve symlinks
+const DefaultMaxLinkDepth = 32
+
+// ErrTooManyLinks too many levels of symbolic links on resolve
+var ErrTooManyLinks = errors.New("too many levels of symbolic links")
+
+// SameFile reports whether the two paths describe the same file. eg: hardlinks, symlink to the file.
+// returns false if any path not exists.
+func SameFile(a, b string) bool {
+	fa, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+
+	fb, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(fa, fb)
+}
+
+// IsSymlink reports whether the path is a symbolic link. the link target can be not exists.
+func IsSymlink(path string) bool {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeSymlink != 0
+}
+
+// ResolveSymlinks follow the symlink chain of the path, return the final target path.
+// if the path is not a symlink, will return the cleaned path.
+//
+// Will return ErrTooManyLinks if the chain depth exceeds maxDepth, maxDepth <= 0 use DefaultMaxLinkDepth.
+//
+// NOTE: only follow the last path element, the symlinks in parent dirs are not resolved.
+// the final target can be not exists.
+func ResolveSymlinks(path string, maxDepth int) (string, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxLinkDepth
+	}
+
+	path = filepath.Clean(path)
+	for depth := 0; ; depth++ {
+		fi, err := os.Lstat(path)
+		if err != nil {
+			// the final target not exists
+			if depth > 0 && os.IsNotExist(err) {
+				return path, nil
+			}
+			return "", err
+		}
+
+		if fi.Mode()&os.ModeSymlink == 0 {
+			return path, nil
+		}
+		if depth >= maxDepth {
+			return "", ErrTooManyLinks
+		}
+
+		target, err := os.Readlink(path)
+		if err != nil {
+			return "", err
+		}
+
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		path = filepath.Clean(target)
+	}
+}
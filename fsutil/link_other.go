@@ -0,0 +1,12 @@
+//go:build !unix && !windows
+
+package fsutil
+
+import "errors"
+
+// HardlinkCount get the number of hard links to the file
+//
+// NOTE: not supported on the platform, will always return error.
+func HardlinkCount(path string) (uint64, error) {
+	return 0, errors.New("fsutil: cannot get the hardlink count on the platform")
+}
@@ -0,0 +1,78 @@
+package fsutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestSameFile_links(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	assert.NoErr(t, os.WriteFile(file, []byte("hello"), 0644))
+
+	n, err := fsutil.HardlinkCount(file)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), n)
+	_, err = fsutil.HardlinkCount(filepath.Join(dir, "not-exists"))
+	assert.Err(t, err)
+
+	// hardlink
+	hard := filepath.Join(dir, "b.txt")
+	assert.NoErr(t, os.Link(file, hard))
+	assert.True(t, fsutil.SameFile(file, hard))
+	assert.False(t, fsutil.IsSymlink(hard))
+	n, err = fsutil.HardlinkCount(file)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), n)
+
+	other := filepath.Join(dir, "c.txt")
+	assert.NoErr(t, os.WriteFile(other, []byte("hello"), 0644))
+	assert.False(t, fsutil.SameFile(file, other))
+	assert.False(t, fsutil.SameFile(file, filepath.Join(dir, "not-exists")))
+
+	// symlink: link2 -> link1 -> a.txt
+	link1 := filepath.Join(dir, "link1")
+	if err := os.Symlink("a.txt", link1); err != nil {
+		t.Skip("create symlink failed:", err)
+	}
+	link2 := filepath.Join(dir, "link2")
+	assert.NoErr(t, os.Symlink(link1, link2))
+
+	assert.True(t, fsutil.IsSymlink(link2))
+	assert.False(t, fsutil.IsSymlink(file))
+	assert.False(t, fsutil.IsSymlink(filepath.Join(dir, "not-exists")))
+	assert.True(t, fsutil.SameFile(file, link2))
+
+	path, err := fsutil.ResolveSymlinks(link2, 0)
+	assert.NoErr(t, err)
+	assert.Eq(t, file, path)
+
+	path, err = fsutil.ResolveSymlinks(file, 0)
+	assert.NoErr(t, err)
+	assert.Eq(t, file, path)
+
+	_, err = fsutil.ResolveSymlinks(link2, 1)
+	assert.ErrIs(t, err, fsutil.ErrTooManyLinks)
+
+	// dangling link
+	dangling := filepath.Join(dir, "dangling")
+	assert.NoErr(t, os.Symlink("not-exists", dangling))
+	assert.True(t, fsutil.IsSymlink(dangling))
+	path, err = fsutil.ResolveSymlinks(dangling, 0)
+	assert.NoErr(t, err)
+	assert.Eq(t, filepath.Join(dir, "not-exists"), path)
+
+	// loop links
+	loop1, loop2 := filepath.Join(dir, "loop1"), filepath.Join(dir, "loop2")
+	assert.NoErr(t, os.Symlink("loop2", loop1))
+	assert.NoErr(t, os.Symlink("loop1", loop2))
+	_, err = fsutil.ResolveSymlinks(loop1, 0)
+	assert.ErrIs(t, err, fsutil.ErrTooManyLinks)
+
+	_, err = fsutil.ResolveSymlinks(filepath.Join(dir, "not-exists"), 0)
+	assert.Err(t, err)
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package fsutil
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// HardlinkCount get the number of hard links to the file
+func HardlinkCount(path string) (uint64, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, errors.New("fsutil: cannot get the hardlink count on the platform")
+	}
+	return uint64(st.Nlink), nil
+}
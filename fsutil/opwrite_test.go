@@ -71,6 +71,7 @@ func TestUpdateContents(t *testing.T) {
 
 	of, err := fsutil.TempFile("testdata", "test-update-contents-*.txt")
 	assert.NoErr(t, err)
+	defer fsutil.QuietRemove(of.Name())
 
 	dump.P(of.Name())
 	_, err = of.WriteString("hello")
@@ -0,0 +1,508 @@
+package fsutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// SnapshotEntry info of a dir or file in the DirSnapshot
+type SnapshotEntry struct {
+	// Path relative path to the snapshot dir, use slash as separator. eg: "sub/file.txt"
+	Path string
+	Mode fs.FileMode
+	Size int64
+	// ModTime last modify time
+	ModTime time.Time
+	// Hash sha256 hex string of the file contents. empty for dir and symlink.
+	Hash string
+	// Link target path for symlink
+	Link string
+}
+
+// IsDir check entry is dir
+func (e *SnapshotEntry) IsDir() bool { return e.Mode.IsDir() }
+
+// IsSymlink check entry is symlink
+func (e *SnapshotEntry) IsSymlink() bool { return e.Mode&fs.ModeSymlink != 0 }
+
+// SnapshotOption for SnapshotDir()
+type SnapshotOption struct {
+	// WithContents save file contents to the StoreDir, required by RestoreSnapshot()
+	WithContents bool
+	// StoreDir dir for save file contents. default will create temp dir on os.TempDir()
+	StoreDir string
+	// Filters for skip some dir or file. return false will skip it.
+	Filters []FilterFunc
+}
+
+// SnapshotOptionFunc for SnapshotDir()
+type SnapshotOptionFunc func(opt *SnapshotOption)
+
+// WithSnapshotContents set save file contents to store dir on snapshot
+func WithSnapshotContents(storeDir ...string) SnapshotOptionFunc {
+	return func(opt *SnapshotOption) {
+		opt.WithContents = true
+		if len(storeDir) > 0 {
+			opt.StoreDir = storeDir[0]
+		}
+	}
+}
+
+// WithSnapshotFilters add filters for SnapshotDir()
+func WithSnapshotFilters(filters ...FilterFunc) SnapshotOptionFunc {
+	return func(opt *SnapshotOption) {
+		opt.Filters = append(opt.Filters, filters...)
+	}
+}
+
+// DirSnapshot the snapshot of a directory tree
+type DirSnapshot struct {
+	// Dir the snapshot dir path
+	Dir string
+	// Entries map. key is relative path of the entry.
+	Entries map[string]*SnapshotEntry
+	// StoreDir the file contents store dir. is empty if not save contents
+	StoreDir string
+	// CreatedAt time of the snapshot
+	CreatedAt time.Time
+
+	filters []FilterFunc
+}
+
+// HasContents check snapshot has saved file contents
+func (s *DirSnapshot) HasContents() bool { return s.StoreDir != "" }
+
+// Paths get all sorted entry paths
+func (s *DirSnapshot) Paths() []string {
+	paths := make([]string, 0, len(s.Entries))
+	for p := range s.Entries {
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+	return paths
+}
+
+// Cleanup remove the file contents store dir.
+func (s *DirSnapshot) Cleanup() error {
+	if s.StoreDir == "" {
+		return nil
+	}
+
+	err := os.RemoveAll(s.StoreDir)
+	s.StoreDir = ""
+	return err
+}
+
+// Restore the dir to the snapshot state. alias of RestoreSnapshot()
+func (s *DirSnapshot) Restore() error { return RestoreSnapshot(s) }
+
+// SnapshotDir capture the metadata and contents hash of all dirs and files in the dir.
+// If WithContents is true, will save file contents to the store dir, then can be use RestoreSnapshot() to revert changes.
+//
+// Usage:
+//
+//	snap, err := fsutil.SnapshotDir("path/to/dir", fsutil.WithSnapshotContents())
+//	defer snap.Cleanup()
+//
+//	// do something ...
+//	if err != nil {
+//		err = fsutil.RestoreSnapshot(snap)
+//	}
+func SnapshotDir(dir string, optFns ...SnapshotOptionFunc) (*DirSnapshot, error) {
+	opt := &SnapshotOption{}
+	for _, fn := range optFns {
+		fn(opt)
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !IsDir(dir) {
+		return nil, errors.New("fsutil: snapshot path is not a dir: " + dir)
+	}
+
+	snap := &DirSnapshot{
+		Dir:       dir,
+		Entries:   make(map[string]*SnapshotEntry),
+		CreatedAt: time.Now(),
+		filters:   opt.Filters,
+	}
+
+	if opt.WithContents {
+		if opt.StoreDir == "" {
+			if opt.StoreDir, err = OSTempDir("dir-snapshot-*"); err != nil {
+				return nil, err
+			}
+		} else if err = os.MkdirAll(opt.StoreDir, DefaultDirPerm); err != nil {
+			return nil, err
+		}
+		snap.StoreDir = opt.StoreDir
+	}
+
+	err = snap.walk(func(fPath, relPath string, ent fs.DirEntry) error {
+		info, err := ent.Info()
+		if err != nil {
+			return err
+		}
+
+		se, err := snap.newEntry(fPath, relPath, info)
+		snap.Entries[relPath] = se
+		return err
+	})
+
+	if err != nil {
+		_ = snap.Cleanup()
+		return nil, err
+	}
+	return snap, nil
+}
+
+// RestoreSnapshot restore the dir to the snapshot state.
+//
+//   - will remove new added dirs and files after snapshot.
+//   - will restore removed or changed dirs and files.
+//
+// NOTE: the snapshot must be created with contents. see WithSnapshotContents()
+func RestoreSnapshot(snap *DirSnapshot) error {
+	if !snap.HasContents() {
+		return errors.New("fsutil: the snapshot not saved file contents, cannot restore")
+	}
+
+	if err := os.MkdirAll(snap.Dir, DefaultDirPerm); err != nil {
+		return err
+	}
+
+	// remove new added entries
+	var newPaths []string
+	err := snap.walk(func(fPath, relPath string, ent fs.DirEntry) error {
+		if _, ok := snap.Entries[relPath]; !ok {
+			newPaths = append(newPaths, fPath)
+			if ent.IsDir() {
+				return fs.SkipDir
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, fPath := range newPaths {
+		if err := os.RemoveAll(fPath); err != nil {
+			return err
+		}
+	}
+
+	// restore entries, parent dir always before sub entries
+	paths := snap.Paths()
+	for _, relPath := range paths {
+		if err := snap.restoreEntry(snap.Entries[relPath]); err != nil {
+			return err
+		}
+	}
+
+	// restore dir modes and mod times at last, deepest first. because restore sub entries will change
+	// the mod time, and the read-only dir mode will break restore its sub entries.
+	for i := len(paths) - 1; i >= 0; i-- {
+		se := snap.Entries[paths[i]]
+		if se.IsDir() {
+			fPath := filepath.Join(snap.Dir, filepath.FromSlash(se.Path))
+			if err := os.Chmod(fPath, se.Mode.Perm()); err != nil {
+				return err
+			}
+			if err := os.Chtimes(fPath, se.ModTime, se.ModTime); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SnapshotChange a changed path between two snapshots
+type SnapshotChange struct {
+	// Path relative path to the snapshot dir, use slash as separator.
+	Path string
+	// OldHash the file hash in the old snapshot. empty on created or it's not a regular file.
+	OldHash string
+	// NewHash the file hash in the new snapshot. empty on deleted or it's not a regular file.
+	NewHash string
+}
+
+// SnapshotDiff the changes between two snapshots, each list is sorted by path.
+type SnapshotDiff struct {
+	Created  []SnapshotChange
+	Modified []SnapshotChange
+	Deleted  []SnapshotChange
+}
+
+// IsEmpty check there is no changes
+func (d *SnapshotDiff) IsEmpty() bool { return d.Len() == 0 }
+
+// Len get the number of all changes
+func (d *SnapshotDiff) Len() int {
+	return len(d.Created) + len(d.Modified) + len(d.Deleted)
+}
+
+// DiffSnapshots compare the old snapshot a with the new snapshot b, get the created, modified and deleted paths.
+//
+// An entry is modified on the file contents hash, symlink target, mode or type changed.
+// The mod time is ignored, so touch a file will not be reported.
+//
+// Usage:
+//
+//	old, err := fsutil.SnapshotDir("path/to/src")
+//	// ... later
+//	cur, err := fsutil.SnapshotDir("path/to/src")
+//
+//	diff := fsutil.DiffSnapshots(old, cur)
+//	for _, c := range diff.Modified {
+//		rebuild(c.Path)
+//	}
+func DiffSnapshots(a, b *DirSnapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{}
+	for _, relPath := range a.Paths() {
+		old := a.Entries[relPath]
+		cur, ok := b.Entries[relPath]
+		if !ok {
+			diff.Deleted = append(diff.Deleted, SnapshotChange{Path: relPath, OldHash: old.Hash})
+			continue
+		}
+
+		if old.Mode != cur.Mode || old.Hash != cur.Hash || old.Link != cur.Link {
+			diff.Modified = append(diff.Modified, SnapshotChange{Path: relPath, OldHash: old.Hash, NewHash: cur.Hash})
+		}
+	}
+
+	for _, relPath := range b.Paths() {
+		if _, ok := a.Entries[relPath]; !ok {
+			diff.Created = append(diff.Created, SnapshotChange{Path: relPath, NewHash: b.Entries[relPath].Hash})
+		}
+	}
+	return diff
+}
+
+// Changes take a new snapshot of the dir with same filters, and get the changes since the snapshot. see DiffSnapshots()
+func (s *DirSnapshot) Changes() (*SnapshotDiff, error) {
+	cur, err := SnapshotDir(s.Dir, WithSnapshotFilters(s.filters...))
+	if err != nil {
+		return nil, err
+	}
+	return DiffSnapshots(s, cur), nil
+}
+
+// ChangedSince get the files and symlinks in the dir which mod time is after the since time, sorted by path.
+//
+// It's lighter than the snapshot, but cannot detect the deleted paths, and cannot distinguish
+// the created or modified. Use DiffSnapshots() for that.
+//
+// Usage:
+//
+//	ents, err := fsutil.ChangedSince("path/to/src", lastBuildAt, fsutil.ExcludeSuffix(".log"))
+func ChangedSince(dir string, since time.Time, filters ...FilterFunc) ([]*SnapshotEntry, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !IsDir(dir) {
+		return nil, errors.New("fsutil: the path is not a dir: " + dir)
+	}
+
+	snap := &DirSnapshot{Dir: dir, filters: filters}
+	ents := make([]*SnapshotEntry, 0)
+
+	err = snap.walk(func(fPath, relPath string, ent fs.DirEntry) error {
+		if ent.IsDir() {
+			return nil
+		}
+
+		info, err := ent.Info()
+		if err != nil || !info.ModTime().After(since) {
+			return err
+		}
+
+		se, err := snap.newEntry(fPath, relPath, info)
+		if err == nil {
+			ents = append(ents, se)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ents, func(i, j int) bool {
+		return ents[i].Path < ents[j].Path
+	})
+	return ents, nil
+}
+
+func (s *DirSnapshot) newEntry(fPath, relPath string, info fs.FileInfo) (se *SnapshotEntry, err error) {
+	se = &SnapshotEntry{
+		Path:    relPath,
+		Mode:    info.Mode(),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}
+
+	if se.IsSymlink() {
+		se.Link, err = os.Readlink(fPath)
+	} else if info.Mode().IsRegular() {
+		se.Hash, err = s.hashFile(fPath, true)
+	}
+	return se, err
+}
+
+func (s *DirSnapshot) restoreEntry(se *SnapshotEntry) error {
+	fPath := filepath.Join(s.Dir, filepath.FromSlash(se.Path))
+	info, err := os.Lstat(fPath)
+	exists := err == nil
+
+	switch {
+	case se.IsDir():
+		if exists && !info.IsDir() {
+			if err := os.Remove(fPath); err != nil {
+				return err
+			}
+		}
+		// keep the dir writable for restore sub entries, the mode will be restored at last.
+		perm := se.Mode.Perm() | 0700
+		if err := os.MkdirAll(fPath, perm); err != nil {
+			return err
+		}
+		return os.Chmod(fPath, perm)
+	case se.IsSymlink():
+		if exists {
+			if link, err := os.Readlink(fPath); err == nil && link == se.Link {
+				return nil
+			}
+			if err := os.RemoveAll(fPath); err != nil {
+				return err
+			}
+		}
+		return os.Symlink(se.Link, fPath)
+	}
+
+	// regular file
+	if exists {
+		if info.Mode().IsRegular() {
+			if hash, err := s.hashFile(fPath, false); err == nil && hash == se.Hash {
+				return s.restoreMeta(fPath, se, info)
+			}
+		}
+
+		if err := os.RemoveAll(fPath); err != nil {
+			return err
+		}
+	}
+
+	if err := s.copyFromStore(se, fPath); err != nil {
+		return err
+	}
+	return s.restoreMeta(fPath, se, nil)
+}
+
+func (s *DirSnapshot) restoreMeta(fPath string, se *SnapshotEntry, info fs.FileInfo) error {
+	if info == nil || info.Mode().Perm() != se.Mode.Perm() {
+		if err := os.Chmod(fPath, se.Mode.Perm()); err != nil {
+			return err
+		}
+	}
+
+	if info == nil || !info.ModTime().Equal(se.ModTime) {
+		return os.Chtimes(fPath, se.ModTime, se.ModTime)
+	}
+	return nil
+}
+
+func (s *DirSnapshot) copyFromStore(se *SnapshotEntry, dstPath string) error {
+	srcFile, err := os.Open(filepath.Join(s.StoreDir, se.Hash))
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.OpenFile(dstPath, FsCWTFlags, se.Mode.Perm())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dstFile, srcFile)
+	if err1 := dstFile.Close(); err1 != nil && err == nil {
+		err = err1
+	}
+	return err
+}
+
+// hashFile calc the file sha256 hash, and save contents to store dir on store=true
+func (s *DirSnapshot) hashFile(fPath string, store bool) (string, error) {
+	file, err := os.Open(fPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err = io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(hasher.Sum(nil))
+
+	if !store || s.StoreDir == "" {
+		return hash, nil
+	}
+
+	// save contents to store dir. same contents only save once.
+	storePath := filepath.Join(s.StoreDir, hash)
+	if FileExists(storePath) {
+		return hash, nil
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	dstFile, err := os.OpenFile(storePath, FsCWTFlags, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = io.Copy(dstFile, file)
+	if err1 := dstFile.Close(); err1 != nil && err == nil {
+		err = err1
+	}
+	return hash, err
+}
+
+// walk all entries in the snapshot dir, will skip the root dir and filtered entries.
+func (s *DirSnapshot) walk(fn func(fPath, relPath string, ent fs.DirEntry) error) error {
+	return filepath.WalkDir(s.Dir, func(fPath string, ent fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if fPath == s.Dir {
+			return nil
+		}
+
+		if len(s.filters) > 0 && ApplyFilters(fPath, ent, s.filters) {
+			if ent.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		relPath, err := filepath.Rel(s.Dir, fPath)
+		if err != nil {
+			return err
+		}
+		return fn(fPath, filepath.ToSlash(relPath), ent)
+	})
+}
@@ -0,0 +1,180 @@
+package fsutil_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestSnapshotDir_restore(t *testing.T) {
+	dir, err := fsutil.OSTempDir("test-snapshot-*")
+	assert.NoErr(t, err)
+	defer fsutil.SafeRemoveAll(dir)
+
+	fsutil.MustSave(dir+"/a.txt", "a contents")
+	fsutil.MustSave(dir+"/sub/b.txt", "b contents")
+	fsutil.MustSave(dir+"/sub/c.txt", "c contents")
+	fsutil.MustSave(dir+"/skip.log", "skip contents")
+
+	// no contents
+	snap, err := fsutil.SnapshotDir(dir)
+	assert.NoErr(t, err)
+	assert.False(t, snap.HasContents())
+	assert.Len(t, snap.Entries, 5)
+	assert.Err(t, fsutil.RestoreSnapshot(snap))
+
+	snap, err = fsutil.SnapshotDir(dir, fsutil.WithSnapshotContents(), fsutil.WithSnapshotFilters(
+		fsutil.ExcludeSuffix(".log"),
+	))
+	assert.NoErr(t, err)
+	assert.True(t, snap.HasContents())
+	assert.Eq(t, []string{"a.txt", "sub", "sub/b.txt", "sub/c.txt"}, snap.Paths())
+	assert.True(t, snap.Entries["sub"].IsDir())
+	assert.NotEmpty(t, snap.Entries["a.txt"].Hash)
+	defer snap.Cleanup()
+
+	// do some changes
+	fsutil.MustSave(dir+"/a.txt", "modified contents")
+	assert.NoErr(t, os.Remove(dir+"/sub/b.txt"))
+	assert.NoErr(t, os.RemoveAll(dir+"/sub/c.txt"))
+	fsutil.MustSave(dir+"/sub/c.txt/d.txt", "d contents")
+	fsutil.MustSave(dir+"/new/e.txt", "e contents")
+	fsutil.MustSave(dir+"/skip.log", "new skip contents")
+
+	assert.NoErr(t, snap.Restore())
+	assert.Eq(t, "a contents", fsutil.ReadString(dir+"/a.txt"))
+	assert.Eq(t, "b contents", fsutil.ReadString(dir+"/sub/b.txt"))
+	assert.Eq(t, "c contents", fsutil.ReadString(dir+"/sub/c.txt"))
+	assert.False(t, fsutil.PathExists(dir+"/new"))
+	// filtered file is not changed
+	assert.Eq(t, "new skip contents", fsutil.ReadString(dir+"/skip.log"))
+
+	info, err := os.Stat(dir + "/a.txt")
+	assert.NoErr(t, err)
+	assert.True(t, info.ModTime().Equal(snap.Entries["a.txt"].ModTime))
+
+	// cleanup
+	storeDir := snap.StoreDir
+	assert.NoErr(t, snap.Cleanup())
+	assert.False(t, fsutil.PathExists(storeDir))
+
+	_, err = fsutil.SnapshotDir(dir + "/a.txt")
+	assert.Err(t, err)
+}
+
+func TestSnapshotDir_restoreReadonlyDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skip on windows, the dir mode is not supported")
+	}
+
+	dir, err := fsutil.OSTempDir("test-snapshot-*")
+	assert.NoErr(t, err)
+	defer fsutil.SafeRemoveAll(dir)
+
+	fsutil.MustSave(dir+"/ro/sub/a.txt", "a contents")
+	assert.NoErr(t, os.Chmod(dir+"/ro/sub", 0555))
+	assert.NoErr(t, os.Chmod(dir+"/ro", 0555))
+
+	snap, err := fsutil.SnapshotDir(dir, fsutil.WithSnapshotContents())
+	assert.NoErr(t, err)
+	defer snap.Cleanup()
+
+	// remove all, then restore from the snapshot
+	assert.NoErr(t, os.Chmod(dir+"/ro", 0755))
+	assert.NoErr(t, os.Chmod(dir+"/ro/sub", 0755))
+	assert.NoErr(t, os.RemoveAll(dir+"/ro"))
+
+	assert.NoErr(t, snap.Restore())
+	assert.Eq(t, "a contents", fsutil.ReadString(dir+"/ro/sub/a.txt"))
+	for _, sub := range []string{"/ro", "/ro/sub"} {
+		info, err := os.Stat(dir + sub)
+		assert.NoErr(t, err)
+		assert.Eq(t, os.FileMode(0555), info.Mode().Perm(), sub)
+	}
+
+	// make writable for cleanup
+	assert.NoErr(t, os.Chmod(dir+"/ro", 0755))
+	assert.NoErr(t, os.Chmod(dir+"/ro/sub", 0755))
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	fsutil.MustSave(dir+"/a.txt", "a contents")
+	fsutil.MustSave(dir+"/sub/b.txt", "b contents")
+	fsutil.MustSave(dir+"/sub/c.txt", "c contents")
+	fsutil.MustSave(dir+"/skip.log", "skip contents")
+
+	snap, err := fsutil.SnapshotDir(dir, fsutil.WithSnapshotFilters(fsutil.ExcludeSuffix(".log")))
+	assert.NoErr(t, err)
+
+	diff, err := snap.Changes()
+	assert.NoErr(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	// touch is not a change
+	future := time.Now().Add(time.Hour)
+	assert.NoErr(t, os.Chtimes(dir+"/a.txt", future, future))
+	// do some changes
+	fsutil.MustSave(dir+"/sub/b.txt", "modified contents")
+	assert.NoErr(t, os.Remove(dir+"/sub/c.txt"))
+	fsutil.MustSave(dir+"/new/d.txt", "d contents")
+	fsutil.MustSave(dir+"/skip.log", "new skip contents")
+
+	diff, err = snap.Changes()
+	assert.NoErr(t, err)
+	assert.Eq(t, 4, diff.Len())
+	assert.Eq(t, []fsutil.SnapshotChange{{Path: "new"}, {Path: "new/d.txt", NewHash: hashOf("d contents")}}, diff.Created)
+	assert.Eq(t, []fsutil.SnapshotChange{{Path: "sub/b.txt", OldHash: hashOf("b contents"), NewHash: hashOf("modified contents")}}, diff.Modified)
+	assert.Eq(t, []fsutil.SnapshotChange{{Path: "sub/c.txt", OldHash: hashOf("c contents")}}, diff.Deleted)
+
+	// type changed
+	cur, err := fsutil.SnapshotDir(dir)
+	assert.NoErr(t, err)
+	assert.NoErr(t, os.Remove(dir+"/a.txt"))
+	assert.NoErr(t, os.Mkdir(dir+"/a.txt", 0755))
+
+	diff, err = cur.Changes()
+	assert.NoErr(t, err)
+	assert.Eq(t, []fsutil.SnapshotChange{{Path: "a.txt", OldHash: hashOf("a contents")}}, diff.Modified)
+	assert.Empty(t, diff.Created)
+	assert.Empty(t, diff.Deleted)
+}
+
+func TestChangedSince(t *testing.T) {
+	dir := t.TempDir()
+	past := time.Now().Add(-time.Hour)
+	for _, name := range []string{"a.txt", "sub/b.txt", "sub/c.log"} {
+		fsutil.MustSave(dir+"/"+name, name+" contents")
+		assert.NoErr(t, os.Chtimes(dir+"/"+name, past, past))
+	}
+
+	since := past.Add(time.Minute)
+	ents, err := fsutil.ChangedSince(dir, since)
+	assert.NoErr(t, err)
+	assert.Empty(t, ents)
+
+	fsutil.MustSave(dir+"/sub/b.txt", "modified contents")
+	fsutil.MustSave(dir+"/sub/c.log", "modified contents")
+	fsutil.MustSave(dir+"/new.txt", "new contents")
+
+	ents, err = fsutil.ChangedSince(dir, since, fsutil.ExcludeSuffix(".log"))
+	assert.NoErr(t, err)
+	assert.Len(t, ents, 2)
+	assert.Eq(t, "new.txt", ents[0].Path)
+	assert.Eq(t, "sub/b.txt", ents[1].Path)
+	assert.Eq(t, hashOf("modified contents"), ents[1].Hash)
+
+	_, err = fsutil.ChangedSince(dir+"/a.txt", since)
+	assert.Err(t, err)
+}
+
+func hashOf(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
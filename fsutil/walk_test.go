@@ -0,0 +1,126 @@
+package fsutil_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func makeWalkTree(tb testing.TB, dirs, files int) string {
+	dir := tb.TempDir()
+	for i := 0; i < dirs; i++ {
+		sub := filepath.Join(dir, "d"+strconv.Itoa(i), "sub")
+		for j := 0; j < files; j++ {
+			fsutil.MustSave(filepath.Join(sub, "f"+strconv.Itoa(j)+".txt"), "hello")
+		}
+	}
+	return dir
+}
+
+func TestWalkParallel(t *testing.T) {
+	dir := makeWalkTree(t, 10, 3)
+
+	var want []string
+	err := fsutil.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		want = append(want, path)
+		return err
+	})
+	assert.NoErr(t, err)
+
+	var mu sync.Mutex
+	var got []string
+	err = fsutil.WalkParallel(dir, 4, func(path string, d fs.DirEntry, err error) error {
+		mu.Lock()
+		got = append(got, path)
+		mu.Unlock()
+		return err
+	})
+	assert.NoErr(t, err)
+
+	sort.Strings(want)
+	sort.Strings(got)
+	assert.Eq(t, want, got)
+
+	size, err := fsutil.DirSize(dir)
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(10*3*5), size)
+}
+
+func TestWalkParallel_skip(t *testing.T) {
+	dir := makeWalkTree(t, 3, 2)
+
+	var mu sync.Mutex
+	var got []string
+	err := fsutil.WalkParallel(dir, 0, func(path string, d fs.DirEntry, err error) error {
+		if d.IsDir() && d.Name() == "d1" {
+			return fs.SkipDir
+		}
+		if d.Name() == "f0.txt" && filepath.Base(filepath.Dir(filepath.Dir(path))) == "d2" {
+			return fs.SkipDir // skip the remaining files in d2/sub
+		}
+
+		mu.Lock()
+		got = append(got, path)
+		mu.Unlock()
+		return err
+	})
+	assert.NoErr(t, err)
+	assert.Len(t, got, 7) // root, d0, d0/sub, d0/sub/f0,f1, d2, d2/sub
+
+	// skip root
+	assert.NoErr(t, fsutil.WalkParallel(dir, 2, func(path string, d fs.DirEntry, err error) error {
+		return fs.SkipDir
+	}))
+}
+
+func TestWalkParallel_error(t *testing.T) {
+	dir := makeWalkTree(t, 5, 2)
+
+	errStop := errors.New("stop walk")
+	err := fsutil.WalkParallel(dir, 2, func(path string, d fs.DirEntry, err error) error {
+		if d.Name() == "f1.txt" {
+			return errStop
+		}
+		return nil
+	})
+	assert.Eq(t, errStop, err)
+
+	// not exists
+	err = fsutil.WalkParallel(filepath.Join(dir, "not-exist"), 2, func(path string, d fs.DirEntry, err error) error {
+		assert.Nil(t, d)
+		return err
+	})
+	assert.Err(t, err)
+
+	// canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	err = fsutil.WalkParallelCtx(ctx, dir, 2, func(path string, d fs.DirEntry, err error) error {
+		cancel()
+		return nil
+	})
+	assert.Eq(t, context.Canceled, err)
+}
+
+func BenchmarkWalkDir(b *testing.B) {
+	dir := makeWalkTree(b, 200, 20)
+	fn := func(path string, d fs.DirEntry, err error) error { return err }
+
+	b.Run("filepath.WalkDir", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = filepath.WalkDir(dir, fn)
+		}
+	})
+	b.Run("WalkParallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = fsutil.WalkParallel(dir, 0, fn)
+		}
+	})
+}
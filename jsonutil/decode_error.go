@@ -0,0 +1,190 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// DecodeError the JSON decode error with location info and fix hint.
+type DecodeError struct {
+	// Line and Column of the error position, 1-based. Column is count by runes.
+	Line, Column int
+	// Offset the byte offset of the error position
+	Offset int64
+	// Snippet the caret-annotated source lines of the error position
+	Snippet string
+	// Hint common fix hint, maybe empty.
+	Hint string
+	// Err the raw error. eg: *json.SyntaxError, *json.UnmarshalTypeError
+	Err error
+}
+
+// Error string. eg:
+//
+//	invalid character '}' looking for beginning of object key string (at line 3, column 1)
+//	   2 |   "age": 23,
+//	   3 | }
+//	     | ^
+//	hint: remove the trailing comma before '}'
+func (e *DecodeError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Err.Error())
+	sb.WriteString(fmt.Sprintf(" (at line %d, column %d)", e.Line, e.Column))
+	if e.Snippet != "" {
+		sb.WriteByte('\n')
+		sb.WriteString(e.Snippet)
+	}
+	if e.Hint != "" {
+		sb.WriteString("\nhint: ")
+		sb.WriteString(e.Hint)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the raw error
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// DecodeWithHint decode JSON bytes to ptr, like the Decode().
+// But on syntax or type error, will return *DecodeError with the line, column,
+// snippet of the error position and common fix hints(eg: trailing comma, single quotes).
+//
+// Useful for decode the user edited config files.
+func DecodeWithHint(bts []byte, ptr any) error {
+	return WrapDecodeError(bts, json.Unmarshal(bts, ptr))
+}
+
+// ReadFileWithHint read JSON file and decode to v, will return *DecodeError on decode fail.
+//
+// see DecodeWithHint()
+func ReadFileWithHint(filePath string, v any) error {
+	bts, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err = DecodeWithHint(bts, v); err != nil {
+		var de *DecodeError
+		if errors.As(err, &de) {
+			return fmt.Errorf("%s:%d:%d: %w", filePath, de.Line, de.Column, err)
+		}
+	}
+	return err
+}
+
+// WrapDecodeError wrap the *json.SyntaxError or *json.UnmarshalTypeError to *DecodeError.
+// src is the decoded JSON source, other errors will be returned as is.
+func WrapDecodeError(src []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var offset int64
+	var synErr *json.SyntaxError
+	var typErr *json.UnmarshalTypeError
+	if errors.As(err, &synErr) {
+		offset = synErr.Offset
+	} else if errors.As(err, &typErr) {
+		offset = typErr.Offset
+	} else {
+		return err
+	}
+
+	// the offset is after the bad char
+	pos := int(offset) - 1
+	if pos >= len(src) {
+		pos = len(src) - 1
+	}
+	if pos < 0 {
+		pos = 0
+	}
+
+	de := &DecodeError{Offset: int64(pos), Err: err}
+	if len(src) > 0 {
+		de.Line, de.Column, de.Snippet = locateSnippet(src, pos)
+	} else {
+		de.Line, de.Column = 1, 1
+	}
+	if synErr != nil {
+		de.Hint = decodeFixHint(src, pos, synErr.Error())
+	}
+	return de
+}
+
+// find the line, column of the pos, and build the snippet with the previous line.
+func locateSnippet(src []byte, pos int) (line, col int, snippet string) {
+	lineStart := bytes.LastIndexByte(src[:pos], '\n') + 1
+	lineEnd := bytes.IndexByte(src[pos:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(src)
+	} else {
+		lineEnd += pos
+	}
+
+	line = bytes.Count(src[:lineStart], []byte{'\n'}) + 1
+	prefix := src[lineStart:pos]
+	col = utf8.RuneCount(prefix) + 1
+
+	var sb strings.Builder
+	if lineStart > 0 {
+		prevStart := bytes.LastIndexByte(src[:lineStart-1], '\n') + 1
+		writeSnippetLine(&sb, line-1, src[prevStart:lineStart-1])
+	}
+	writeSnippetLine(&sb, line, src[lineStart:lineEnd])
+
+	// caret line, keep the tabs for align
+	sb.WriteString("     | ")
+	for _, r := range string(prefix) {
+		if r == '\t' {
+			sb.WriteByte('\t')
+		} else {
+			sb.WriteByte(' ')
+		}
+	}
+	sb.WriteByte('^')
+	return line, col, sb.String()
+}
+
+func writeSnippetLine(sb *strings.Builder, num int, text []byte) {
+	sb.WriteString(fmt.Sprintf("%4d | ", num))
+	sb.Write(bytes.TrimRight(text, "\r"))
+	sb.WriteByte('\n')
+}
+
+// returns the common fix hint by the bad char and error message.
+func decodeFixHint(src []byte, pos int, msg string) string {
+	if strings.Contains(msg, "unexpected end of JSON input") {
+		return "check for missing closing brackets '}', ']' or quotes"
+	}
+
+	c := src[pos]
+	switch {
+	case c == '\'':
+		return "JSON strings and keys must use double quotes, replace the single quotes"
+	case c == '/' || c == '#':
+		return "comments are not allowed in JSON, can strip them by jsonutil.StripComments()"
+	case (c == '}' || c == ']') && prevNonSpace(src, pos) == ',':
+		return fmt.Sprintf("remove the trailing comma before '%c'", c)
+	case strings.Contains(msg, "looking for beginning of object key string"):
+		return "object keys must be double quoted strings"
+	case strings.Contains(msg, "after object key:value pair"), strings.Contains(msg, "after array element"):
+		return "missing comma between elements"
+	}
+	return ""
+}
+
+func prevNonSpace(src []byte, pos int) byte {
+	for i := pos - 1; i >= 0; i-- {
+		switch src[i] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		default:
+			return src[i]
+		}
+	}
+	return 0
+}
@@ -0,0 +1,92 @@
+package jsonutil_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/jsonutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestDecodeWithHint(t *testing.T) {
+	u := &user{}
+	assert.NoErr(t, jsonutil.DecodeWithHint([]byte(`{"name": "inhere", "age": 23}`), u))
+	assert.Eq(t, 23, u.Age)
+
+	tests := []struct {
+		src  string
+		line int
+		col  int
+		hint string
+	}{
+		{"{\n  \"name\": \"inhere\",\n  \"age\": 23,\n}", 4, 1, "remove the trailing comma before '}'"},
+		{"[1, 2, ]", 1, 8, "remove the trailing comma before ']'"},
+		{"{\n  'name': 'inhere'\n}", 2, 3, "double quotes"},
+		{"{\n  // comment\n  \"age\": 1}", 2, 3, "comments are not allowed"},
+		{"{name: 1}", 1, 2, "object keys must be double quoted"},
+		{"{\"name\": \"a\" \"age\": 1}", 1, 14, "missing comma"},
+		{"{\"name\": \"a\"", 1, 12, "missing closing brackets"},
+	}
+
+	for _, tt := range tests {
+		err := jsonutil.DecodeWithHint([]byte(tt.src), &user{})
+		var de *jsonutil.DecodeError
+		assert.True(t, errors.As(err, &de), tt.src)
+		assert.Eq(t, tt.line, de.Line, tt.src)
+		assert.Eq(t, tt.col, de.Column, tt.src)
+		assert.StrContains(t, de.Hint, tt.hint, tt.src)
+
+		var synErr *json.SyntaxError
+		assert.True(t, errors.As(err, &synErr))
+	}
+
+	// snippet
+	err := jsonutil.DecodeWithHint([]byte("{\n  \"age\": 23,\n}"), &user{})
+	assert.Err(t, err)
+	assert.Eq(t, `invalid character '}' looking for beginning of object key string (at line 3, column 1)
+   2 |   "age": 23,
+   3 | }
+     | ^
+hint: remove the trailing comma before '}'`, err.Error())
+
+	// type error
+	err = jsonutil.DecodeWithHint([]byte("{\n\t\"age\": \"23\"}"), &user{})
+	var de *jsonutil.DecodeError
+	assert.True(t, errors.As(err, &de))
+	assert.Eq(t, 2, de.Line)
+	assert.Empty(t, de.Hint)
+	assert.StrContains(t, de.Snippet, "     | \t          ^")
+	var typErr *json.UnmarshalTypeError
+	assert.True(t, errors.As(err, &typErr))
+
+	// empty input
+	err = jsonutil.DecodeWithHint(nil, &user{})
+	assert.True(t, errors.As(err, &de))
+	assert.Eq(t, 1, de.Line)
+	assert.Eq(t, "", de.Snippet)
+
+	// other error
+	err = jsonutil.DecodeWithHint([]byte(`{}`), nil)
+	assert.Err(t, err)
+	assert.False(t, errors.As(err, &de))
+	assert.NoErr(t, jsonutil.WrapDecodeError(nil, nil))
+}
+
+func TestReadFileWithHint(t *testing.T) {
+	u := &user{}
+	assert.NoErr(t, jsonutil.ReadFileWithHint("testdata/test.json", u))
+	assert.Eq(t, "inhere", u.Name)
+
+	fpath := filepath.Join(t.TempDir(), "bad.json")
+	assert.NoErr(t, os.WriteFile(fpath, []byte("{\n  \"name\": 'inhere'\n}"), 0644))
+
+	err := jsonutil.ReadFileWithHint(fpath, u)
+	assert.ErrSubMsg(t, err, fpath+":2:11: invalid character")
+	var de *jsonutil.DecodeError
+	assert.True(t, errors.As(err, &de))
+
+	assert.Err(t, jsonutil.ReadFileWithHint("testdata/not-exists.json", u))
+}
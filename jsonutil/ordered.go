@@ -0,0 +1,92 @@
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"github.com/gookit/goutil/maputil"
+)
+
+// DecodeOrdered decode the JSON object to maputil.OrderedMap, will keep the key order.
+// The nested objects are decoded as *maputil.OrderedMap, numbers are decoded as json.Number.
+func DecodeOrdered(bts []byte) (*maputil.OrderedMap, error) {
+	om := maputil.NewOrderedMap()
+	if err := json.Unmarshal(bts, om); err != nil {
+		return nil, err
+	}
+	return om, nil
+}
+
+// ReadOrderedFile read JSON object file to maputil.OrderedMap. see DecodeOrdered()
+func ReadOrderedFile(filePath string) (*maputil.OrderedMap, error) {
+	bts, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeOrdered(bts)
+}
+
+// EditFile read the JSON object file, edit it by fn, then write back.
+//
+// The key order, number format, indent and the ending newline of the file will be kept.
+// NOTE: the comments and blank lines are not kept.
+//
+// Usage:
+//
+//	err := jsonutil.EditFile("config.json", func(om *maputil.OrderedMap) error {
+//		om.Set("version", "1.2.0")
+//		return nil
+//	})
+func EditFile(filePath string, fn func(om *maputil.OrderedMap) error) error {
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+
+	bts, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	om, err := DecodeOrdered(bts)
+	if err != nil {
+		return err
+	}
+	if err = fn(om); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", DetectIndent(bts))
+	if err = enc.Encode(om); err != nil {
+		return err
+	}
+
+	out := buf.Bytes()
+	if !bytes.HasSuffix(bts, []byte("\n")) {
+		out = bytes.TrimSuffix(out, []byte("\n"))
+	}
+	return os.WriteFile(filePath, out, fi.Mode().Perm())
+}
+
+// DetectIndent detect the indent string of the JSON data, return empty string if it is compact.
+func DetectIndent(bts []byte) string {
+	for {
+		idx := bytes.IndexByte(bts, '\n')
+		if idx < 0 {
+			return ""
+		}
+
+		bts = bts[idx+1:]
+		n := 0
+		for n < len(bts) && (bts[n] == ' ' || bts[n] == '\t') {
+			n++
+		}
+		if n > 0 && n < len(bts) && bts[n] != '\n' && bts[n] != '\r' {
+			return string(bts[:n])
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package jsonutil_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/jsonutil"
+	"github.com/gookit/goutil/maputil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestDecodeOrdered(t *testing.T) {
+	om, err := jsonutil.DecodeOrdered([]byte(`{"z": 1, "a": {"y": true, "b": null}}`))
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"z", "a"}, om.Keys())
+
+	_, err = jsonutil.DecodeOrdered([]byte(`"abc"`))
+	assert.Err(t, err)
+
+	_, err = jsonutil.ReadOrderedFile("testdata/not-exist.json")
+	assert.Err(t, err)
+}
+
+func TestEditFile(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "config.json")
+	src := "{\n\t\"name\": \"app\",\n\t\"port\": 8080,\n\t\"ratio\": 1.50,\n\t\"db\": {\n\t\t\"host\": \"<local>\"\n\t}\n}\n"
+	assert.NoErr(t, os.WriteFile(fpath, []byte(src), 0600))
+
+	err := jsonutil.EditFile(fpath, func(om *maputil.OrderedMap) error {
+		om.Set("port", 9090)
+		om.Set("debug", true)
+		return nil
+	})
+	assert.NoErr(t, err)
+
+	bs, err := os.ReadFile(fpath)
+	assert.NoErr(t, err)
+	assert.Eq(t, "{\n\t\"name\": \"app\",\n\t\"port\": 9090,\n\t\"ratio\": 1.50,\n\t\"db\": {\n\t\t\"host\": \"<local>\"\n\t},\n\t\"debug\": true\n}\n", string(bs))
+
+	om, err := jsonutil.ReadOrderedFile(fpath)
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"name", "port", "ratio", "db", "debug"}, om.Keys())
+
+	// compact, without ending newline
+	assert.NoErr(t, os.WriteFile(fpath, []byte(`{"b":1,"a":2}`), 0600))
+	assert.NoErr(t, jsonutil.EditFile(fpath, func(om *maputil.OrderedMap) error {
+		om.Delete("b")
+		return nil
+	}))
+	bs, err = os.ReadFile(fpath)
+	assert.NoErr(t, err)
+	assert.Eq(t, `{"a":2}`, string(bs))
+
+	// fn error
+	assert.ErrMsg(t, jsonutil.EditFile(fpath, func(om *maputil.OrderedMap) error {
+		return errors.New("edit error")
+	}), "edit error")
+	assert.Err(t, jsonutil.EditFile("testdata/not-exist.json", nil))
+}
+
+func TestDetectIndent(t *testing.T) {
+	assert.Eq(t, "", jsonutil.DetectIndent([]byte(`{"a":1}`)))
+	assert.Eq(t, "  ", jsonutil.DetectIndent([]byte("{\n  \"a\": 1\n}")))
+	assert.Eq(t, "\t", jsonutil.DetectIndent([]byte("{\n\n\t\"a\": 1\n}")))
+	assert.Eq(t, "", jsonutil.DetectIndent([]byte("{\n}")))
+}
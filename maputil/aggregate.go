@@ -0,0 +1,72 @@
+package maputil
+
+import "github.com/gookit/goutil/comdef"
+
+// SumValues sum all values of the map
+//
+// Usage:
+//
+//	total := maputil.SumValues(map[string]int{"a": 1, "b": 2}) // 3
+func SumValues[K comparable, V comdef.XintOrFloat](mp map[K]V) V {
+	var sum V
+	for _, v := range mp {
+		sum += v
+	}
+	return sum
+}
+
+// MaxValueKey find the key of the max value. ok is false on the map is empty.
+//
+// If multiple keys have the max value, the smallest key is returned.
+//
+// Usage:
+//
+//	key, val, ok := maputil.MaxValueKey(map[string]int{"a": 1, "b": 3}) // "b", 3, true
+func MaxValueKey[K comdef.SortedType, V comdef.XintOrFloat](mp map[K]V) (key K, val V, ok bool) {
+	for k, v := range mp {
+		if !ok || v > val || (v == val && k < key) {
+			key, val, ok = k, v, true
+		}
+	}
+	return
+}
+
+// MinValueKey find the key of the min value. ok is false on the map is empty.
+//
+// If multiple keys have the min value, the smallest key is returned.
+func MinValueKey[K comdef.SortedType, V comdef.XintOrFloat](mp map[K]V) (key K, val V, ok bool) {
+	for k, v := range mp {
+		if !ok || v < val || (v == val && k < key) {
+			key, val, ok = k, v, true
+		}
+	}
+	return
+}
+
+// GroupSum group the map keys by the keyFn, and sum the values of each group.
+//
+// Usage:
+//
+//	// sum by the day: map[string]int{"2024-03-05": 3, "2024-03-06": 4}
+//	daily := maputil.GroupSum(hourlyCounts, func(hour string) string { return hour[:10] })
+func GroupSum[K comparable, G comparable, V comdef.XintOrFloat](mp map[K]V, keyFn func(k K) G) map[G]V {
+	groups := make(map[G]V)
+	for k, v := range mp {
+		groups[keyFn(k)] += v
+	}
+	return groups
+}
+
+// InvertCount invert the map to count the number of keys for each value.
+//
+// Usage:
+//
+//	// map[string]int{"done": 2, "failed": 1}
+//	counts := maputil.InvertCount(map[string]string{"t1": "done", "t2": "failed", "t3": "done"})
+func InvertCount[K comparable, V comparable](mp map[K]V) map[V]int {
+	counts := make(map[V]int)
+	for _, v := range mp {
+		counts[v]++
+	}
+	return counts
+}
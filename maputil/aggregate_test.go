@@ -0,0 +1,55 @@
+package maputil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/maputil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestSumValues(t *testing.T) {
+	assert.Eq(t, 6, maputil.SumValues(map[string]int{"a": 1, "b": 2, "c": 3}))
+	assert.Eq(t, 3.5, maputil.SumValues(map[int]float64{1: 1.5, 2: 2}))
+	assert.Eq(t, uint(0), maputil.SumValues(map[string]uint{}))
+}
+
+func TestMaxValueKey(t *testing.T) {
+	mp := map[string]int{"a": 1, "b": 3, "c": 3, "d": -1}
+
+	key, val, ok := maputil.MaxValueKey(mp)
+	assert.True(t, ok)
+	assert.Eq(t, "b", key)
+	assert.Eq(t, 3, val)
+
+	key, val, ok = maputil.MinValueKey(mp)
+	assert.True(t, ok)
+	assert.Eq(t, "d", key)
+	assert.Eq(t, -1, val)
+
+	ik, fv, ok := maputil.MinValueKey(map[int]float64{3: 0.5, 1: 0.5, 2: 1})
+	assert.True(t, ok)
+	assert.Eq(t, 1, ik)
+	assert.Eq(t, 0.5, fv)
+
+	_, _, ok = maputil.MaxValueKey(map[string]int{})
+	assert.False(t, ok)
+	_, _, ok = maputil.MinValueKey(map[string]int(nil))
+	assert.False(t, ok)
+}
+
+func TestGroupSum(t *testing.T) {
+	hourly := map[string]int{
+		"2024-03-05 10": 1,
+		"2024-03-05 11": 2,
+		"2024-03-06 09": 4,
+	}
+
+	daily := maputil.GroupSum(hourly, func(hour string) string { return hour[:10] })
+	assert.Eq(t, map[string]int{"2024-03-05": 3, "2024-03-06": 4}, daily)
+	assert.Empty(t, maputil.GroupSum(map[int]int{}, func(k int) int { return k }))
+}
+
+func TestInvertCount(t *testing.T) {
+	counts := maputil.InvertCount(map[string]string{"t1": "done", "t2": "failed", "t3": "done"})
+	assert.Eq(t, map[string]int{"done": 2, "failed": 1}, counts)
+}
@@ -0,0 +1,196 @@
+package maputil
+
+import (
+	"strings"
+)
+
+// FoldData a Data wrapper with case-insensitive key resolution.
+// The key and each path segment will match ignore case. eg: "HOST", "Server.Port"
+//
+// Usage:
+//
+//	d := maputil.NewFoldData(map[string]any{"host": "localhost"})
+//	d.Str("HOST") // "localhost"
+type FoldData struct {
+	Data
+}
+
+// NewFoldData create a case-insensitive Data from map
+func NewFoldData(mp map[string]any) FoldData {
+	if mp == nil {
+		mp = make(map[string]any)
+	}
+	return FoldData{Data: mp}
+}
+
+// IgnoreCase get a case-insensitive wrapper of the data map
+func (d Data) IgnoreCase() FoldData {
+	return FoldData{Data: d}
+}
+
+// ResolvePath resolve the path to the actual path in data map, keys match ignore case.
+//
+// Example:
+//
+//	d := maputil.NewFoldData(map[string]any{"Server": map[string]any{"host": "x"}})
+//	d.ResolvePath("SERVER.HOST") // "Server.host"
+func (d FoldData) ResolvePath(path string) string {
+	if _, ok := d.Data[path]; ok || path == "" {
+		return path
+	}
+
+	if strings.IndexByte(path, KeySepChar) < 1 {
+		if key, ok := foldKey(d.Data, path); ok {
+			return key
+		}
+		return path
+	}
+	return strings.Join(d.resolveKeys(strings.Split(path, KeySepStr)), KeySepStr)
+}
+
+// resolve each key by matched ignore case. the unmatched keys will be kept.
+func (d FoldData) resolveKeys(keys []string) []string {
+	var item any = map[string]any(d.Data)
+	for i, key := range keys {
+		switch tv := item.(type) {
+		case Data:
+			item = map[string]any(tv)
+		}
+
+		switch tv := item.(type) {
+		case map[string]any:
+			if rk, ok := foldKey(tv, key); ok {
+				keys[i] = rk
+				item = tv[rk]
+				continue
+			}
+		case map[string]string:
+			if rk, ok := foldKey(tv, key); ok {
+				keys[i] = rk
+				item = tv[rk]
+				continue
+			}
+		}
+
+		// not a map or not found. eg: slice index
+		item, _ = getByPathKeys(item, []string{key})
+	}
+	return keys
+}
+
+// find the key in map by ignore case. exact match is preferred,
+// and returns the smallest key when multi keys are matched.
+func foldKey[V any](mp map[string]V, key string) (string, bool) {
+	if _, ok := mp[key]; ok {
+		return key, true
+	}
+
+	var found string
+	var ok bool
+	for k := range mp {
+		if strings.EqualFold(k, key) && (!ok || k < found) {
+			found, ok = k, true
+		}
+	}
+	return found, ok
+}
+
+// Has value on the data map
+func (d FoldData) Has(key string) bool {
+	return d.Data.Has(d.ResolvePath(key))
+}
+
+// Value get from the data map
+func (d FoldData) Value(key string) (any, bool) {
+	return d.Data.Value(d.ResolvePath(key))
+}
+
+// Get value from the data map. Supports dot syntax to get deep values.
+func (d FoldData) Get(key string) any {
+	return d.Data.Get(d.ResolvePath(key))
+}
+
+// GetByPath get value from the data map by path. eg: top.sub
+func (d FoldData) GetByPath(path string) (any, bool) {
+	return d.Data.GetByPath(d.ResolvePath(path))
+}
+
+// Set value to the data map. will overwrite the exists key ignore case.
+func (d FoldData) Set(key string, val any) {
+	d.Data.Set(d.ResolvePath(key), val)
+}
+
+// SetByPath sets a value in the map. the exists path keys are matched ignore case.
+func (d FoldData) SetByPath(path string, value any) error {
+	return d.Data.SetByPath(d.ResolvePath(path), value)
+}
+
+// Default get value from the data map with default value
+func (d FoldData) Default(key string, def any) any {
+	return d.Data.Default(d.ResolvePath(key), def)
+}
+
+// Int value get
+func (d FoldData) Int(key string) int {
+	return d.Data.Int(d.ResolvePath(key))
+}
+
+// Int64 value get
+func (d FoldData) Int64(key string) int64 {
+	return d.Data.Int64(d.ResolvePath(key))
+}
+
+// Uint value get
+func (d FoldData) Uint(key string) uint {
+	return d.Data.Uint(d.ResolvePath(key))
+}
+
+// Uint64 value get
+func (d FoldData) Uint64(key string) uint64 {
+	return d.Data.Uint64(d.ResolvePath(key))
+}
+
+// Str value get by key
+func (d FoldData) Str(key string) string {
+	return d.Data.Str(d.ResolvePath(key))
+}
+
+// Bool value get
+func (d FoldData) Bool(key string) bool {
+	return d.Data.Bool(d.ResolvePath(key))
+}
+
+// Strings get []string value
+func (d FoldData) Strings(key string) []string {
+	return d.Data.Strings(d.ResolvePath(key))
+}
+
+// StrSplit get strings by split key value
+func (d FoldData) StrSplit(key, sep string) []string {
+	return d.Data.StrSplit(d.ResolvePath(key), sep)
+}
+
+// StringsByStr value get by key
+func (d FoldData) StringsByStr(key string) []string {
+	return d.Data.StringsByStr(d.ResolvePath(key))
+}
+
+// StrMap get map[string]string value
+func (d FoldData) StrMap(key string) map[string]string {
+	return d.Data.StrMap(d.ResolvePath(key))
+}
+
+// StringMap get map[string]string value
+func (d FoldData) StringMap(key string) map[string]string {
+	return d.Data.StringMap(d.ResolvePath(key))
+}
+
+// Sub get sub value as new FoldData
+func (d FoldData) Sub(key string) FoldData {
+	return FoldData{Data: d.Data.Sub(d.ResolvePath(key))}
+}
+
+// Slice get []any value from data map
+func (d FoldData) Slice(key string) ([]any, error) {
+	return d.Data.Slice(d.ResolvePath(key))
+}
@@ -0,0 +1,77 @@
+package maputil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/maputil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestFoldData(t *testing.T) {
+	d := maputil.NewFoldData(map[string]any{
+		"HOST":  "localhost",
+		"port":  8080,
+		"Debug": "true",
+		"Server": map[string]any{
+			"Name": "app",
+			"tags": []any{"a", "b"},
+			"env":  map[string]string{"APP_ENV": "dev"},
+		},
+		"list": []map[string]any{
+			{"Key": "v0"},
+		},
+	})
+
+	assert.True(t, d.Has("host"))
+	assert.True(t, d.Has("Host"))
+	assert.False(t, d.Has("not-exist"))
+	assert.Eq(t, "localhost", d.Str("host"))
+	assert.Eq(t, "localhost", d.Get("HOST"))
+	assert.Eq(t, 8080, d.Int("PORT"))
+	assert.Eq(t, int64(8080), d.Int64("Port"))
+	assert.Eq(t, uint(8080), d.Uint("PORT"))
+	assert.Eq(t, uint64(8080), d.Uint64("PORT"))
+	assert.True(t, d.Bool("debug"))
+	assert.Eq(t, "def", d.Default("no-key", "def"))
+
+	// path segments
+	assert.Eq(t, "Server.Name", d.ResolvePath("server.name"))
+	assert.Eq(t, "app", d.Str("SERVER.NAME"))
+	assert.Eq(t, []string{"a", "b"}, d.Strings("server.TAGS"))
+	assert.Eq(t, "b", d.Get("server.tags.1"))
+	assert.Eq(t, "dev", d.Str("server.env.app_env"))
+	assert.Eq(t, map[string]string{"APP_ENV": "dev"}, d.StringMap("SERVER.ENV"))
+	assert.Eq(t, "v0", d.Get("LIST.0.key"))
+	assert.Eq(t, "no.such.key", d.ResolvePath("no.such.key"))
+
+	val, ok := d.Value("server.name")
+	assert.True(t, ok)
+	assert.Eq(t, "app", val)
+
+	sub := d.Sub("server")
+	assert.Eq(t, "app", sub.Str("name"))
+	sl, err := d.Slice("SERVER.Tags")
+	assert.NoErr(t, err)
+	assert.Len(t, sl, 2)
+
+	// set will overwrite the exists key
+	d.Set("host", "127.0.0.1")
+	assert.Eq(t, "127.0.0.1", d.Data["HOST"])
+	assert.NoErr(t, d.SetByPath("server.NAME", "new-app"))
+	assert.Eq(t, "new-app", d.Data.Str("Server.Name"))
+	assert.NoErr(t, d.SetByPath("server.newKey", "val"))
+	assert.Eq(t, "val", d.Data.Str("Server.newKey"))
+}
+
+func TestData_IgnoreCase(t *testing.T) {
+	d := maputil.Data{"host": "a", "Host": "b"}
+	// prefer exact match
+	assert.Eq(t, "b", d.IgnoreCase().Str("Host"))
+	assert.Eq(t, "a", d.IgnoreCase().Str("host"))
+	// use the smallest key on multi matched
+	assert.Eq(t, "b", d.IgnoreCase().Str("HOST"))
+
+	fd := maputil.NewFoldData(nil)
+	fd.Set("Name", "inhere")
+	assert.Eq(t, "inhere", fd.Str("NAME"))
+}
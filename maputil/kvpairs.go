@@ -0,0 +1,64 @@
+package maputil
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gookit/goutil/strutil"
+)
+
+// ParseKVData parse the key=value pair list string to Data. the seps are pair separator chars, default is ",;"
+//
+// The unquoted values will be converted to typed value: bool("true", "false"), int, float64.
+// The quoted values and the numbers cannot round-trip(eg: "023", "1.10") always keep as string.
+//
+// Usage:
+//
+//	data, err := maputil.ParseKVData(`name=inhere,age=23,debug=true,id="023"`)
+//	// data: Data{"name": "inhere", "age": 23, "debug": true, "id": "023"}
+func ParseKVData(s string, seps ...string) (Data, error) {
+	return ParseKVDataWith(s, &strutil.KVPairsOpt{Seps: strings.Join(seps, "")})
+}
+
+// ParseKVDataWith parse the key=value pair list string to Data with options
+func ParseKVDataWith(s string, opt *strutil.KVPairsOpt) (Data, error) {
+	pairs, err := strutil.SplitKVPairs(s, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(Data, len(pairs))
+	for _, pair := range pairs {
+		if pair.Quoted {
+			data[pair.Key] = pair.Value
+		} else {
+			data[pair.Key] = kvTypedValue(pair.Value)
+		}
+	}
+	return data, nil
+}
+
+// convert the string value to bool, int or float64.
+// the number will keep as string if it cannot round-trip. eg: "023", "1.10", "1e3"
+func kvTypedValue(s string) any {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if iv, err := strconv.Atoi(s); err == nil {
+		if strconv.Itoa(iv) == s {
+			return iv
+		}
+		return s
+	}
+
+	if strings.Contains(s, ".") {
+		if fv, err := strconv.ParseFloat(s, 64); err == nil && strconv.FormatFloat(fv, 'f', -1, 64) == s {
+			return fv
+		}
+	}
+	return s
+}
@@ -0,0 +1,50 @@
+package maputil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/maputil"
+	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestParseKVData(t *testing.T) {
+	data, err := maputil.ParseKVData(`name=inhere,age=23;debug=true,rate=1.5,id="023",empty=`)
+	assert.NoErr(t, err)
+	assert.Eq(t, maputil.Data{
+		"name":  "inhere",
+		"age":   23,
+		"debug": true,
+		"rate":  1.5,
+		"id":    "023",
+		"empty": "",
+	}, data)
+	assert.Eq(t, 23, data.Int("age"))
+	assert.True(t, data.Bool("debug"))
+
+	// custom seps
+	data, err = maputil.ParseKVData("a=1\nb=Inf", "\n")
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, data.Get("a"))
+	assert.Eq(t, "Inf", data.Get("b"))
+
+	// keep the numbers cannot round-trip as string
+	data, err = maputil.ParseKVData("a=023,b=1.10,c=+1,d=1e3,e=-2.5,f=-7,g=NaN")
+	assert.NoErr(t, err)
+	assert.Eq(t, maputil.Data{
+		"a": "023",
+		"b": "1.10",
+		"c": "+1",
+		"d": "1e3",
+		"e": -2.5,
+		"f": -7,
+		"g": "NaN",
+	}, data)
+
+	_, err = maputil.ParseKVData("a=1,b")
+	assert.ErrIs(t, err, strutil.ErrKVPairSyntax)
+
+	data, err = maputil.ParseKVDataWith("a=1,a=2", &strutil.KVPairsOpt{DupKey: strutil.DupKeyKeepFirst})
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, data.Get("a"))
+}
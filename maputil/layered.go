@@ -0,0 +1,124 @@
+package maputil
+
+import (
+	"sort"
+	"strings"
+)
+
+// LayeredData a Data wrapper for merge multi layer data, eg: file, env, flag.
+// It records which source layer supplied the value for every final key path.
+//
+// Usage:
+//
+//	d := maputil.NewLayeredData()
+//	d.Merge("file", fileData).Merge("env", envData).Merge("flag", flagData)
+//
+//	d.Int("server.port")      // 8080
+//	d.SourceOf("server.port") // "flag"
+type LayeredData struct {
+	Data
+	layers []string
+	// sources final key path -> source layer name
+	sources map[string]string
+}
+
+// NewLayeredData create an empty LayeredData
+func NewLayeredData() *LayeredData {
+	return &LayeredData{
+		Data:    make(Data),
+		sources: make(map[string]string),
+	}
+}
+
+// Merge deep merge the map data of the source layer, the later merged layer has higher priority.
+//
+// The nested maps(map[string]any, Data) are merged recursively, other values are replaced.
+// The input map will not be modified.
+func (d *LayeredData) Merge(source string, mp map[string]any) *LayeredData {
+	d.layers = append(d.layers, source)
+	d.mergeMap(source, "", mp, d.Data)
+	return d
+}
+
+func (d *LayeredData) mergeMap(source, prefix string, src, dst map[string]any) {
+	for key, val := range src {
+		path := key
+		if prefix != "" {
+			path = prefix + KeySepStr + key
+		}
+
+		sub, isMap := toAnyMap(val)
+		if !isMap {
+			if _, ok := toAnyMap(dst[key]); ok {
+				d.removeSources(path + KeySepStr)
+			}
+
+			dst[key] = val
+			d.sources[path] = source
+			continue
+		}
+
+		dsub, ok := toAnyMap(dst[key])
+		if !ok {
+			delete(d.sources, path)
+			dsub = make(map[string]any, len(sub))
+			dst[key] = dsub
+		}
+		d.mergeMap(source, path, sub, dsub)
+	}
+}
+
+// remove the sources of the path prefix
+func (d *LayeredData) removeSources(prefix string) {
+	for path := range d.sources {
+		if strings.HasPrefix(path, prefix) {
+			delete(d.sources, path)
+		}
+	}
+}
+
+// SourceOf get the source layer name of the final key path. eg: "server.port"
+//
+// Return empty string if the path not exists or is a nested map.
+func (d *LayeredData) SourceOf(path string) string {
+	return d.sources[path]
+}
+
+// Sources get all final key paths and its source layer name
+func (d *LayeredData) Sources() map[string]string {
+	mp := make(map[string]string, len(d.sources))
+	for path, source := range d.sources {
+		mp[path] = source
+	}
+	return mp
+}
+
+// SourcePaths get the final key paths sorted, and the source layer of each path. useful for explain the data.
+func (d *LayeredData) SourcePaths() (paths []string, sources []string) {
+	paths = make([]string, 0, len(d.sources))
+	for path := range d.sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	sources = make([]string, len(paths))
+	for i, path := range paths {
+		sources[i] = d.sources[path]
+	}
+	return
+}
+
+// Layers get the merged source layer names, by merge order.
+func (d *LayeredData) Layers() []string {
+	return d.layers
+}
+
+func toAnyMap(val any) (map[string]any, bool) {
+	switch tv := val.(type) {
+	case map[string]any:
+		return tv, true
+	case Data:
+		return tv, true
+	}
+	return nil, false
+}
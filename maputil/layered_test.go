@@ -0,0 +1,66 @@
+package maputil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/maputil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestLayeredData_Merge(t *testing.T) {
+	fileData := map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"host": "localhost",
+			"port": 8080,
+		},
+		"log":  map[string]any{"level": "info", "file": "app.log"},
+		"tags": []string{"a"},
+	}
+	envData := map[string]any{
+		"server": maputil.Data{"port": 9090},
+		"log":    "stdout", // replace the nested map
+	}
+	flagData := map[string]any{
+		"debug": true,
+		"tags":  []string{"b", "c"},
+		"server": map[string]any{
+			"port": 80,
+			"tls":  map[string]any{"enable": true},
+		},
+	}
+
+	d := maputil.NewLayeredData()
+	d.Merge("file", fileData).Merge("env", envData).Merge("flag", flagData)
+
+	assert.Eq(t, []string{"file", "env", "flag"}, d.Layers())
+	assert.Eq(t, 80, d.Int("server.port"))
+	assert.Eq(t, "localhost", d.Str("server.host"))
+	assert.Eq(t, "stdout", d.Str("log"))
+	assert.Eq(t, []string{"b", "c"}, d.Strings("tags"))
+	assert.True(t, d.Bool("server.tls.enable"))
+
+	assert.Eq(t, "file", d.SourceOf("name"))
+	assert.Eq(t, "file", d.SourceOf("server.host"))
+	assert.Eq(t, "flag", d.SourceOf("server.port"))
+	assert.Eq(t, "flag", d.SourceOf("server.tls.enable"))
+	assert.Eq(t, "env", d.SourceOf("log"))
+	assert.Eq(t, "", d.SourceOf("log.level"))
+	assert.Eq(t, "", d.SourceOf("server"))
+	assert.Eq(t, "", d.SourceOf("not-exist"))
+
+	paths, sources := d.SourcePaths()
+	assert.Eq(t, []string{"debug", "log", "name", "server.host", "server.port", "server.tls.enable", "tags"}, paths)
+	assert.Eq(t, []string{"flag", "env", "file", "file", "flag", "flag", "flag"}, sources)
+	assert.Len(t, d.Sources(), 7)
+
+	// the input maps are not modified
+	assert.Eq(t, 8080, fileData["server"].(map[string]any)["port"])
+	assert.Eq(t, maputil.Data{"port": 9090}, envData["server"])
+
+	// replace the scalar by map
+	d.Merge("override", map[string]any{"log": map[string]any{"level": "debug"}})
+	assert.Eq(t, "", d.SourceOf("log"))
+	assert.Eq(t, "override", d.SourceOf("log.level"))
+	assert.Eq(t, "debug", d.Str("log.level"))
+}
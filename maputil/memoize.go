@@ -0,0 +1,266 @@
+package maputil
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoizeOption the options for Memoize()
+type MemoizeOption struct {
+	// MaxEntries max number of the cached entries, the oldest updated entry will be evicted on exceeded.
+	// default is 0, no limit.
+	MaxEntries int
+	// StaleWhileRevalidate return the expired value immediately and refresh it in background.
+	StaleWhileRevalidate bool
+}
+
+// MemoizeOptFn func for set MemoizeOption
+type MemoizeOptFn func(opt *MemoizeOption)
+
+// WithMaxEntries set the max number of the cached entries
+func WithMaxEntries(n int) MemoizeOptFn {
+	return func(opt *MemoizeOption) {
+		opt.MaxEntries = n
+	}
+}
+
+// WithStaleWhileRevalidate return the expired value and refresh it in background
+func WithStaleWhileRevalidate() MemoizeOptFn {
+	return func(opt *MemoizeOption) {
+		opt.StaleWhileRevalidate = true
+	}
+}
+
+// MemoStats the metrics of the Memoized
+type MemoStats struct {
+	// Hits number of the calls returned the cached value
+	Hits int64
+	// Misses number of the calls which call the fn
+	Misses int64
+	// Stale number of the calls returned the expired value, on StaleWhileRevalidate is enabled.
+	Stale int64
+	// Evictions number of the evicted entries by MaxEntries
+	Evictions int64
+	// Size current number of the cached entries
+	Size int
+}
+
+// HitRate get the cache hit rate, the stale hits are counted.
+func (s MemoStats) HitRate() float64 {
+	total := s.Hits + s.Stale + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits+s.Stale) / float64(total)
+}
+
+// memoCall an in-flight call of the fn, the result is readonly after the wait closed.
+type memoCall[V any] struct {
+	wait chan struct{}
+	val  V
+	err  error
+}
+
+type memoEntry[K comparable, V any] struct {
+	val      V
+	expireAt time.Time
+	// in-flight call on loading
+	call *memoCall[V]
+	// refreshing in background
+	refreshing bool
+	elem       *list.Element
+}
+
+// Memoized the memoized single-argument function, created by Memoize()
+type Memoized[K comparable, V any] struct {
+	mu  sync.Mutex
+	fn  func(key K) (V, error)
+	ttl time.Duration
+	opt MemoizeOption
+
+	entries map[K]*memoEntry[K, V]
+	// keys ordered by the update time, the front is the oldest
+	order *list.List
+	stats MemoStats
+}
+
+// Memoize wrap the single-argument pure function, cache the result by the argument within the ttl.
+// The errors are not cached, and the concurrent calls with same key will share one fn call.
+// The panic in fn will be recovered and returned as error.
+// ttl <= 0 means never expire.
+//
+// Usage:
+//
+//	lookup := maputil.Memoize(func(host string) ([]string, error) {
+//		return net.LookupHost(host)
+//	}, time.Minute, maputil.WithMaxEntries(1000), maputil.WithStaleWhileRevalidate())
+//
+//	addrs, err := lookup.Get("example.com")
+//	fmt.Println(lookup.Stats())
+func Memoize[K comparable, V any](fn func(key K) (V, error), ttl time.Duration, optFns ...MemoizeOptFn) *Memoized[K, V] {
+	m := &Memoized[K, V]{
+		fn:      fn,
+		ttl:     ttl,
+		entries: make(map[K]*memoEntry[K, V]),
+		order:   list.New(),
+	}
+
+	for _, optFn := range optFns {
+		optFn(&m.opt)
+	}
+	return m
+}
+
+// Func get the memoized func, it's same as the Get method.
+func (m *Memoized[K, V]) Func() func(key K) (V, error) { return m.Get }
+
+// Get the value by key, will call the fn on not cached or expired.
+func (m *Memoized[K, V]) Get(key K) (V, error) {
+	m.mu.Lock()
+	e, ok := m.entries[key]
+	if ok {
+		// loading by other caller, wait and share the result
+		if c := e.call; c != nil {
+			m.mu.Unlock()
+			<-c.wait
+			return c.val, c.err
+		}
+
+		if m.ttl <= 0 || time.Now().Before(e.expireAt) {
+			m.stats.Hits++
+			val := e.val
+			m.mu.Unlock()
+			return val, nil
+		}
+
+		if m.opt.StaleWhileRevalidate {
+			m.stats.Stale++
+			if !e.refreshing {
+				e.refreshing = true
+				go m.refresh(key, e)
+			}
+
+			val := e.val
+			m.mu.Unlock()
+			return val, nil
+		}
+	} else {
+		e = &memoEntry[K, V]{}
+		e.elem = m.order.PushBack(key)
+		m.entries[key] = e
+	}
+
+	m.stats.Misses++
+	c := &memoCall[V]{wait: make(chan struct{})}
+	e.call = c
+	m.evict()
+	m.mu.Unlock()
+
+	// cleanup on defer, the waiting callers will not be blocked on fn panic
+	defer func() {
+		m.mu.Lock()
+		e.call = nil
+		// the entry may be removed by Forget() or Clear()
+		if m.entries[key] == e {
+			if c.err != nil {
+				m.remove(key, e)
+			} else {
+				m.store(e, c.val)
+			}
+		}
+		m.mu.Unlock()
+		close(c.wait)
+	}()
+
+	c.val, c.err = m.callFn(key)
+	return c.val, c.err
+}
+
+// callFn call the fn, convert the panic to error.
+func (m *Memoized[K, V]) callFn(key K) (val V, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("maputil: memoized func panic: %v", r)
+		}
+	}()
+	return m.fn(key)
+}
+
+func (m *Memoized[K, V]) refresh(key K, e *memoEntry[K, V]) {
+	val, err := m.callFn(key)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e.refreshing = false
+	// keep the stale value on error, will retry on next call
+	if err == nil && m.entries[key] == e {
+		m.store(e, val)
+	}
+}
+
+func (m *Memoized[K, V]) store(e *memoEntry[K, V], val V) {
+	e.val = val
+	e.expireAt = time.Now().Add(m.ttl)
+	m.order.MoveToBack(e.elem)
+}
+
+func (m *Memoized[K, V]) remove(key K, e *memoEntry[K, V]) {
+	delete(m.entries, key)
+	m.order.Remove(e.elem)
+}
+
+// evict the oldest entries on exceeded the MaxEntries, the loading entries are skipped.
+func (m *Memoized[K, V]) evict() {
+	if m.opt.MaxEntries <= 0 {
+		return
+	}
+
+	for elem := m.order.Front(); elem != nil && m.order.Len() > m.opt.MaxEntries; {
+		next := elem.Next()
+		key := elem.Value.(K)
+		if e := m.entries[key]; e.call == nil {
+			m.remove(key, e)
+			m.stats.Evictions++
+		}
+		elem = next
+	}
+}
+
+// Forget remove the cached value of the key
+func (m *Memoized[K, V]) Forget(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		m.remove(key, e)
+	}
+}
+
+// Clear all cached values
+func (m *Memoized[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[K]*memoEntry[K, V])
+	m.order.Init()
+}
+
+// Len get the number of cached entries
+func (m *Memoized[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Stats get the metrics
+func (m *Memoized[K, V]) Stats() MemoStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	st := m.stats
+	st.Size = len(m.entries)
+	return st
+}
@@ -0,0 +1,152 @@
+package maputil_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/maputil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestMemoize(t *testing.T) {
+	var calls int32
+	m := maputil.Memoize(func(n int) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		if n < 0 {
+			return "", errors.New("negative number")
+		}
+		return strconv.Itoa(n), nil
+	}, 50*time.Millisecond)
+
+	fn := m.Func()
+	for i := 0; i < 3; i++ {
+		s, err := fn(1)
+		assert.NoErr(t, err)
+		assert.Eq(t, "1", s)
+	}
+	assert.Eq(t, int32(1), atomic.LoadInt32(&calls))
+
+	// errors are not cached
+	_, err := m.Get(-1)
+	assert.Err(t, err)
+	_, err = m.Get(-1)
+	assert.Err(t, err)
+	assert.Eq(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Eq(t, 1, m.Len())
+
+	// expired
+	time.Sleep(60 * time.Millisecond)
+	_, err = m.Get(1)
+	assert.NoErr(t, err)
+	assert.Eq(t, int32(4), atomic.LoadInt32(&calls))
+
+	st := m.Stats()
+	assert.Eq(t, int64(2), st.Hits)
+	assert.Eq(t, int64(4), st.Misses)
+	assert.Eq(t, 1, st.Size)
+	assert.Eq(t, 1.0/3, st.HitRate())
+
+	m.Forget(1)
+	assert.Eq(t, 0, m.Len())
+	_, _ = m.Get(2)
+	m.Clear()
+	assert.Eq(t, 0, m.Len())
+}
+
+func TestMemoize_maxEntries(t *testing.T) {
+	m := maputil.Memoize(func(n int) (int, error) {
+		return n * 2, nil
+	}, 0, maputil.WithMaxEntries(2))
+
+	for _, n := range []int{1, 2, 3} {
+		v, err := m.Get(n)
+		assert.NoErr(t, err)
+		assert.Eq(t, n*2, v)
+	}
+
+	st := m.Stats()
+	assert.Eq(t, 2, st.Size)
+	assert.Eq(t, int64(1), st.Evictions)
+
+	// 1 is evicted
+	_, _ = m.Get(1)
+	assert.Eq(t, int64(4), m.Stats().Misses)
+	_, _ = m.Get(3)
+	assert.Eq(t, int64(1), m.Stats().Hits)
+}
+
+func TestMemoize_staleWhileRevalidate(t *testing.T) {
+	var calls int32
+	m := maputil.Memoize(func(key string) (int32, error) {
+		return atomic.AddInt32(&calls, 1), nil
+	}, 20*time.Millisecond, maputil.WithStaleWhileRevalidate())
+
+	v, _ := m.Get("a")
+	assert.Eq(t, int32(1), v)
+
+	time.Sleep(30 * time.Millisecond)
+	// returns the stale value, and refresh in background
+	v, _ = m.Get("a")
+	assert.Eq(t, int32(1), v)
+	assert.Eq(t, int64(1), m.Stats().Stale)
+
+	assert.Eventually(t, func() bool {
+		v, _ = m.Get("a")
+		return v == 2
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestMemoize_concurrent(t *testing.T) {
+	var calls int32
+	m := maputil.Memoize(func(key string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return key + "!", nil
+	}, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := m.Get("k")
+			assert.NoErr(t, err)
+			assert.Eq(t, "k!", v)
+		}()
+	}
+	wg.Wait()
+	assert.Eq(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestMemoize_panic(t *testing.T) {
+	var calls int32
+	m := maputil.Memoize(func(key string) (int32, error) {
+		if n := atomic.AddInt32(&calls, 1); n == 1 || n == 3 {
+			panic("fn panic")
+		}
+		return calls, nil
+	}, 20*time.Millisecond, maputil.WithStaleWhileRevalidate())
+
+	_, err := m.Get("a")
+	assert.ErrSubMsg(t, err, "fn panic")
+	assert.Eq(t, 0, m.Len())
+
+	// not blocked after panic
+	v, err := m.Get("a")
+	assert.NoErr(t, err)
+	assert.Eq(t, int32(2), v)
+
+	// panic on refresh, keep the stale value and refresh again on next call
+	time.Sleep(30 * time.Millisecond)
+	v, _ = m.Get("a")
+	assert.Eq(t, int32(2), v)
+
+	assert.Eventually(t, func() bool {
+		v, _ = m.Get("a")
+		return v == 4
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,204 @@
+package maputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// OrderedMap is a string key map that keeps the insertion order of keys.
+//
+// It implements the json.Marshaler and json.Unmarshaler, the key order of the JSON object will be kept.
+// On decode JSON, the nested objects are decoded as *OrderedMap, numbers are decoded as json.Number.
+type OrderedMap struct {
+	keys []string
+	vals map[string]any
+}
+
+// NewOrderedMap create a new OrderedMap
+func NewOrderedMap(capacity ...int) *OrderedMap {
+	var size int
+	if len(capacity) > 0 {
+		size = capacity[0]
+	}
+
+	return &OrderedMap{
+		keys: make([]string, 0, size),
+		vals: make(map[string]any, size),
+	}
+}
+
+// Len get the number of keys
+func (om *OrderedMap) Len() int { return len(om.keys) }
+
+// Keys get all keys by insertion order
+func (om *OrderedMap) Keys() []string {
+	return append([]string(nil), om.keys...)
+}
+
+// Has check the key exists
+func (om *OrderedMap) Has(key string) bool {
+	_, ok := om.vals[key]
+	return ok
+}
+
+// Get value by key
+func (om *OrderedMap) Get(key string) (any, bool) {
+	val, ok := om.vals[key]
+	return val, ok
+}
+
+// Value get value by key, return nil if not exists
+func (om *OrderedMap) Value(key string) any { return om.vals[key] }
+
+// Set value by key. new key will be appended to the end, existing key will keep the position.
+func (om *OrderedMap) Set(key string, val any) {
+	if om.vals == nil {
+		om.vals = make(map[string]any)
+	}
+
+	if _, ok := om.vals[key]; !ok {
+		om.keys = append(om.keys, key)
+	}
+	om.vals[key] = val
+}
+
+// Delete value by key
+func (om *OrderedMap) Delete(key string) {
+	if _, ok := om.vals[key]; !ok {
+		return
+	}
+
+	delete(om.vals, key)
+	for i, k := range om.keys {
+		if k == key {
+			om.keys = append(om.keys[:i], om.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Each iterate all key and value by insertion order. stop on fn returns false.
+func (om *OrderedMap) Each(fn func(key string, val any) bool) {
+	for _, key := range om.keys {
+		if !fn(key, om.vals[key]) {
+			break
+		}
+	}
+}
+
+// ToMap convert to map[string]any, the nested *OrderedMap will be converted too.
+func (om *OrderedMap) ToMap() map[string]any {
+	mp := make(map[string]any, len(om.keys))
+	for key, val := range om.vals {
+		mp[key] = orderedToPlain(val)
+	}
+	return mp
+}
+
+func orderedToPlain(val any) any {
+	switch typVal := val.(type) {
+	case *OrderedMap:
+		return typVal.ToMap()
+	case []any:
+		ls := make([]any, len(typVal))
+		for i, v := range typVal {
+			ls[i] = orderedToPlain(v)
+		}
+		return ls
+	}
+	return val
+}
+
+// MarshalJSON implements the json.Marshaler, keep the key order. HTML chars will not be escaped.
+func (om *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, key := range om.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(key); err != nil {
+			return nil, err
+		}
+
+		buf.Truncate(buf.Len() - 1) // remove the newline added by Encode
+		buf.WriteByte(':')
+		if err := enc.Encode(om.vals[key]); err != nil {
+			return nil, err
+		}
+		buf.Truncate(buf.Len() - 1)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// ErrNotJSONObject the JSON data is not an object
+var ErrNotJSONObject = errors.New("maputil: JSON data is not an object")
+
+// UnmarshalJSON implements the json.Unmarshaler, keep the key order. will reset the existing data.
+func (om *OrderedMap) UnmarshalJSON(bs []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return ErrNotJSONObject
+	}
+
+	om.keys, om.vals = om.keys[:0], make(map[string]any)
+	return om.decodeObject(dec)
+}
+
+// decode object fields after the '{' token
+func (om *OrderedMap) decodeObject(dec *json.Decoder) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		val, err := decodeJSONValue(dec)
+		if err != nil {
+			return err
+		}
+		om.Set(tok.(string), val)
+	}
+
+	// consume the '}'
+	_, err := dec.Token()
+	return err
+}
+
+func decodeJSONValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		sub := NewOrderedMap()
+		return sub, sub.decodeObject(dec)
+	case json.Delim('['):
+		ls := make([]any, 0)
+		for dec.More() {
+			val, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			ls = append(ls, val)
+		}
+
+		// consume the ']'
+		_, err = dec.Token()
+		return ls, err
+	}
+	return tok, nil
+}
@@ -0,0 +1,75 @@
+package maputil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gookit/goutil/maputil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestOrderedMap_basic(t *testing.T) {
+	om := maputil.NewOrderedMap()
+	om.Set("b", 1)
+	om.Set("a", 2)
+	om.Set("c", 3)
+	om.Set("b", 4) // keep position
+
+	assert.Eq(t, 3, om.Len())
+	assert.Eq(t, []string{"b", "a", "c"}, om.Keys())
+	assert.True(t, om.Has("a"))
+	assert.Eq(t, 4, om.Value("b"))
+
+	val, ok := om.Get("c")
+	assert.True(t, ok)
+	assert.Eq(t, 3, val)
+	_, ok = om.Get("not-exist")
+	assert.False(t, ok)
+
+	om.Delete("a")
+	om.Delete("not-exist")
+	assert.Eq(t, []string{"b", "c"}, om.Keys())
+
+	var keys []string
+	om.Each(func(key string, _ any) bool {
+		keys = append(keys, key)
+		return false
+	})
+	assert.Eq(t, []string{"b"}, keys)
+
+	// zero value
+	var om2 maputil.OrderedMap
+	om2.Set("k", "v")
+	assert.Eq(t, []string{"k"}, om2.Keys())
+}
+
+func TestOrderedMap_JSON(t *testing.T) {
+	src := `{"name":"app","version":1.10,"deps":{"z":"<1.0","a":"^2.0"},"tags":[{"y":1,"x":2},"t"],"none":null}`
+
+	om := maputil.NewOrderedMap()
+	assert.NoErr(t, json.Unmarshal([]byte(src), om))
+	assert.Eq(t, []string{"name", "version", "deps", "tags", "none"}, om.Keys())
+	assert.Eq(t, json.Number("1.10"), om.Value("version"))
+
+	deps, ok := om.Value("deps").(*maputil.OrderedMap)
+	assert.True(t, ok)
+	assert.Eq(t, []string{"z", "a"}, deps.Keys())
+
+	bs, err := json.Marshal(om)
+	assert.NoErr(t, err)
+	// json.Marshal will escape HTML chars
+	assert.Eq(t, `{"name":"app","version":1.10,"deps":{"z":"\u003c1.0","a":"^2.0"},"tags":[{"y":1,"x":2},"t"],"none":null}`, string(bs))
+
+	// MarshalJSON not escape HTML
+	bs, err = om.MarshalJSON()
+	assert.NoErr(t, err)
+	assert.Eq(t, src, string(bs))
+
+	mp := om.ToMap()
+	assert.Eq(t, map[string]any{"z": "<1.0", "a": "^2.0"}, mp["deps"])
+	assert.Eq(t, []any{map[string]any{"y": json.Number("1"), "x": json.Number("2")}, "t"}, mp["tags"])
+
+	// error
+	assert.ErrIs(t, json.Unmarshal([]byte(`[1, 2]`), om), maputil.ErrNotJSONObject)
+	assert.Err(t, json.Unmarshal([]byte(`{"a":}`), om))
+}
@@ -2,9 +2,61 @@ package mathutil
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 )
 
+var (
+	randMu  sync.Mutex
+	randSrc = newTimeRand()
+)
+
+func newTimeRand() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// SetRandSource set the random source for the random helpers of goutil,
+// eg: RandomInt(), strutil.RandomChars(). useful for reproducible the random behavior in tests.
+//
+// Set nil for reset to the default source(seeded by time). Returns the previous source.
+//
+// Usage:
+//
+//	old := mathutil.SetRandSource(rand.New(rand.NewSource(42)))
+//	defer mathutil.SetRandSource(old)
+func SetRandSource(r *rand.Rand) *rand.Rand {
+	if r == nil {
+		r = newTimeRand()
+	}
+
+	randMu.Lock()
+	old := randSrc
+	randSrc = r
+	randMu.Unlock()
+	return old
+}
+
+// RandIntn returns a random int at the [0, n) from the current random source. It is safe for concurrent use.
+func RandIntn(n int) int {
+	randMu.Lock()
+	defer randMu.Unlock()
+	return randSrc.Intn(n)
+}
+
+// RandInt63 returns a non-negative random int64 from the current random source.
+func RandInt63() int64 {
+	randMu.Lock()
+	defer randMu.Unlock()
+	return randSrc.Int63()
+}
+
+// RandFloat64 returns a random float64 at the [0.0, 1.0) from the current random source.
+func RandFloat64() float64 {
+	randMu.Lock()
+	defer randMu.Unlock()
+	return randSrc.Float64()
+}
+
 // RandomInt return a random int at the [min, max)
 //
 // Usage:
@@ -13,8 +65,7 @@ import (
 //	RandomInt(100, 999)
 //	RandomInt(1000, 9999)
 func RandomInt(min, max int) int {
-	rr := rand.New(rand.NewSource(time.Now().UnixNano()))
-	return min + rr.Intn(max-min)
+	return min + RandIntn(max-min)
 }
 
 // RandInt alias of RandomInt()
@@ -2,6 +2,7 @@ package mathutil_test
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -26,3 +27,18 @@ func TestRandomInt(t *testing.T) {
 	assert.True(t, mathutil.RandInt(min, max) > 999)
 	assert.True(t, mathutil.RandIntWithSeed(min, max, 23) > 999)
 }
+
+func TestSetRandSource(t *testing.T) {
+	old := mathutil.SetRandSource(rand.New(rand.NewSource(42)))
+	a := []int{mathutil.RandomInt(0, 1000), mathutil.RandIntn(1000), int(mathutil.RandInt63() % 1000)}
+	f := mathutil.RandFloat64()
+
+	mathutil.SetRandSource(rand.New(rand.NewSource(42)))
+	b := []int{mathutil.RandomInt(0, 1000), mathutil.RandIntn(1000), int(mathutil.RandInt63() % 1000)}
+	assert.Eq(t, a, b)
+	assert.Eq(t, f, mathutil.RandFloat64())
+
+	// reset to default
+	mathutil.SetRandSource(nil)
+	assert.NotEq(t, old, mathutil.SetRandSource(old))
+}
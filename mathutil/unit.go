@@ -0,0 +1,219 @@
+package mathutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// built-in unit families
+const (
+	UnitDataSize    = "data_size"
+	UnitDuration    = "duration"
+	UnitTemperature = "temperature"
+)
+
+var (
+	// ErrUnknownUnit the unit is not registered
+	ErrUnknownUnit = errors.New("mathutil: unknown unit")
+	// ErrUnitMismatch the units are not in the same family
+	ErrUnitMismatch = errors.New("mathutil: cannot convert between different unit families")
+	// ErrInvalidUnitValue the value is NaN, Inf or invalid format
+	ErrInvalidUnitValue = errors.New("mathutil: invalid unit value")
+)
+
+// Unit of a family, the value convert to the family base unit by: base = val*Factor + Offset
+type Unit struct {
+	// Name of the unit. eg: "MB"
+	Name string
+	// Family name of the unit. eg: UnitDataSize
+	Family string
+	// Factor the ratio to the family base unit. must be > 0
+	Factor float64
+	// Offset to the family base unit, use for temperature.
+	Offset float64
+	// Aliases of the unit name. eg: "megabyte"
+	Aliases []string
+}
+
+// ToBase convert the value to the family base unit
+func (u *Unit) ToBase(val float64) float64 { return val*u.Factor + u.Offset }
+
+// FromBase convert the value from the family base unit
+func (u *Unit) FromBase(val float64) float64 { return (val - u.Offset) / u.Factor }
+
+var (
+	unitMu sync.RWMutex
+	// unit name and aliases to unit
+	units = map[string]*Unit{}
+	// lower name to unit, for case-insensitive lookup
+	lowerUnits = map[string]*Unit{}
+)
+
+// RegisterUnit register a unit, will overwrite the exists unit with same name.
+//
+// Usage:
+//
+//	mathutil.RegisterUnit(mathutil.Unit{Name: "km", Family: "length", Factor: 1000})
+func RegisterUnit(u Unit) error {
+	if u.Name == "" || u.Family == "" {
+		return errors.New("mathutil: unit name and family is required")
+	}
+	if u.Factor <= 0 || math.IsInf(u.Factor, 0) || math.IsNaN(u.Factor) {
+		return errors.New("mathutil: unit factor must be a positive number")
+	}
+
+	unitMu.Lock()
+	defer unitMu.Unlock()
+
+	up := &u
+	for _, name := range append([]string{u.Name}, u.Aliases...) {
+		units[name] = up
+		lowerUnits[strings.ToLower(name)] = up
+	}
+	return nil
+}
+
+// LookupUnit find the unit by name or alias. will fallback to case-insensitive match.
+func LookupUnit(name string) (*Unit, bool) {
+	unitMu.RLock()
+	defer unitMu.RUnlock()
+
+	if u, ok := units[name]; ok {
+		return u, true
+	}
+	u, ok := lowerUnits[strings.ToLower(name)]
+	return u, ok
+}
+
+// Convert the value from unit to another unit of the same family.
+//
+// Usage:
+//
+//	mb, err := mathutil.Convert(1.5, "GiB", "MB") // 1610.612736
+//	f, err := mathutil.Convert(100, "C", "F") // 212
+func Convert(val float64, from, to string) (float64, error) {
+	if math.IsNaN(val) || math.IsInf(val, 0) {
+		return 0, ErrInvalidUnitValue
+	}
+
+	fu, ok := LookupUnit(from)
+	if !ok {
+		return 0, unitError(from)
+	}
+	tu, ok := LookupUnit(to)
+	if !ok {
+		return 0, unitError(to)
+	}
+
+	if fu.Family != tu.Family {
+		return 0, ErrUnitMismatch
+	}
+	if fu == tu {
+		return val, nil
+	}
+	return tu.FromBase(fu.ToBase(val)), nil
+}
+
+// ConvertStr parse the value with unit string, then convert to the unit.
+//
+// Usage:
+//
+//	secs, err := mathutil.ConvertStr("1.5h", "s") // 5400
+func ConvertStr(s, to string) (float64, error) {
+	val, unit, err := ParseUnitValue(s)
+	if err != nil {
+		return 0, err
+	}
+	return Convert(val, unit, to)
+}
+
+// ParseUnitValue parse the value and unit name from string. eg: "1.5GiB", "-10 °C"
+//
+// Returns ErrUnknownUnit if the unit is not registered.
+func ParseUnitValue(s string) (val float64, unit string, err error) {
+	s = strings.TrimSpace(s)
+
+	// find the end of number part
+	end := 0
+	for end < len(s) && strings.IndexByte("+-.0123456789eE", s[end]) >= 0 {
+		// 'e' may be the start of unit name
+		if (s[end] == 'e' || s[end] == 'E') && (end == 0 || !IsNumeric(s[end-1])) {
+			break
+		}
+		end++
+	}
+
+	val, err = strconv.ParseFloat(s[:end], 64)
+	if err != nil || math.IsInf(val, 0) {
+		return 0, "", ErrInvalidUnitValue
+	}
+
+	unit = strings.TrimSpace(s[end:])
+	if _, ok := LookupUnit(unit); !ok {
+		return 0, "", unitError(unit)
+	}
+	return val, unit, nil
+}
+
+// FormatUnit format the value with unit name. prec < 0 use the minimum digits.
+//
+// Usage:
+//
+//	mathutil.FormatUnit(1.5, "GiB", -1) // "1.5GiB"
+//	mathutil.FormatUnit(1.5, "GiB", 2) // "1.50GiB"
+func FormatUnit(val float64, unit string, prec int) string {
+	return strconv.FormatFloat(val, 'f', prec, 64) + unit
+}
+
+func unitError(name string) error {
+	return fmt.Errorf("%w: %q", ErrUnknownUnit, name)
+}
+
+func init() {
+	// data size, base unit is byte
+	mustRegisterUnits(UnitDataSize, []Unit{
+		{Name: "B", Factor: 1, Aliases: []string{"byte", "bytes"}},
+		{Name: "KB", Factor: 1e3, Aliases: []string{"kB"}},
+		{Name: "MB", Factor: 1e6},
+		{Name: "GB", Factor: 1e9},
+		{Name: "TB", Factor: 1e12},
+		{Name: "PB", Factor: 1e15},
+		{Name: "KiB", Factor: 1 << 10},
+		{Name: "MiB", Factor: 1 << 20},
+		{Name: "GiB", Factor: 1 << 30},
+		{Name: "TiB", Factor: 1 << 40},
+		{Name: "PiB", Factor: 1 << 50},
+	})
+
+	// duration, base unit is second
+	mustRegisterUnits(UnitDuration, []Unit{
+		{Name: "ns", Factor: 1e-9},
+		{Name: "us", Factor: 1e-6, Aliases: []string{"µs"}},
+		{Name: "ms", Factor: 1e-3},
+		{Name: "s", Factor: 1, Aliases: []string{"sec", "second", "seconds"}},
+		{Name: "m", Factor: 60, Aliases: []string{"min", "minute", "minutes"}},
+		{Name: "h", Factor: 3600, Aliases: []string{"hr", "hour", "hours"}},
+		{Name: "d", Factor: 86400, Aliases: []string{"day", "days"}},
+		{Name: "w", Factor: 7 * 86400, Aliases: []string{"week", "weeks"}},
+	})
+
+	// temperature, base unit is kelvin
+	mustRegisterUnits(UnitTemperature, []Unit{
+		{Name: "K", Factor: 1, Aliases: []string{"kelvin"}},
+		{Name: "C", Factor: 1, Offset: 273.15, Aliases: []string{"°C", "celsius"}},
+		{Name: "F", Factor: 5.0 / 9, Offset: 273.15 - 32*5.0/9, Aliases: []string{"°F", "fahrenheit"}},
+	})
+}
+
+func mustRegisterUnits(family string, us []Unit) {
+	for _, u := range us {
+		u.Family = family
+		if err := RegisterUnit(u); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package mathutil_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gookit/goutil/mathutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		val      float64
+		from, to string
+		want     float64
+	}{
+		{1.5, "GiB", "MB", 1610.612736},
+		{2048, "KiB", "MiB", 2},
+		{1, "GB", "bytes", 1e9},
+		{1, "mb", "kB", 1000},
+		{1.5, "h", "m", 90},
+		{1, "day", "s", 86400},
+		{500, "ms", "s", 0.5},
+		{100, "C", "F", 212},
+		{-40, "°F", "°C", -40},
+		{0, "K", "celsius", -273.15},
+		{3, "s", "s", 3},
+	}
+
+	for _, tt := range tests {
+		got, err := mathutil.Convert(tt.val, tt.from, tt.to)
+		assert.NoErr(t, err)
+		assert.True(t, math.Abs(tt.want-got) < 1e-9, tt.from+" to "+tt.to)
+	}
+
+	_, err := mathutil.Convert(1, "GiB", "s")
+	assert.ErrIs(t, err, mathutil.ErrUnitMismatch)
+	_, err = mathutil.Convert(1, "XB", "MB")
+	assert.ErrIs(t, err, mathutil.ErrUnknownUnit)
+	assert.ErrMsg(t, err, `mathutil: unknown unit: "XB"`)
+	_, err = mathutil.Convert(1, "MB", "XB")
+	assert.ErrIs(t, err, mathutil.ErrUnknownUnit)
+	_, err = mathutil.Convert(math.NaN(), "MB", "B")
+	assert.ErrIs(t, err, mathutil.ErrInvalidUnitValue)
+}
+
+func TestConvertStr(t *testing.T) {
+	val, err := mathutil.ConvertStr("1.5h", "s")
+	assert.NoErr(t, err)
+	assert.Eq(t, float64(5400), val)
+
+	val, err = mathutil.ConvertStr(" 1e3 B ", "KB")
+	assert.NoErr(t, err)
+	assert.Eq(t, float64(1), val)
+
+	val, unit, err := mathutil.ParseUnitValue("-10 °C")
+	assert.NoErr(t, err)
+	assert.Eq(t, float64(-10), val)
+	assert.Eq(t, "°C", unit)
+
+	_, _, err = mathutil.ParseUnitValue("abc")
+	assert.ErrIs(t, err, mathutil.ErrInvalidUnitValue)
+	_, _, err = mathutil.ParseUnitValue("12")
+	assert.ErrIs(t, err, mathutil.ErrUnknownUnit)
+	_, err = mathutil.ConvertStr("12XB", "MB")
+	assert.ErrIs(t, err, mathutil.ErrUnknownUnit)
+}
+
+func TestRegisterUnit(t *testing.T) {
+	assert.NoErr(t, mathutil.RegisterUnit(mathutil.Unit{Name: "km", Family: "length", Factor: 1000}))
+	assert.NoErr(t, mathutil.RegisterUnit(mathutil.Unit{Name: "meter", Family: "length", Factor: 1}))
+
+	val, err := mathutil.Convert(1.2, "km", "meter")
+	assert.NoErr(t, err)
+	assert.Eq(t, float64(1200), val)
+
+	u, ok := mathutil.LookupUnit("KM")
+	assert.True(t, ok)
+	assert.Eq(t, "length", u.Family)
+
+	assert.Err(t, mathutil.RegisterUnit(mathutil.Unit{Name: "km"}))
+	assert.Err(t, mathutil.RegisterUnit(mathutil.Unit{Name: "km", Family: "length"}))
+}
+
+func TestFormatUnit(t *testing.T) {
+	assert.Eq(t, "1.5GiB", mathutil.FormatUnit(1.5, "GiB", -1))
+	assert.Eq(t, "1.50GiB", mathutil.FormatUnit(1.5, "GiB", 2))
+}
@@ -24,6 +24,8 @@ type Client struct {
 	// beforeSend callback
 	beforeSend func(req *http.Request)
 	afterSend  AfterSendFn
+	// debug dump writer. see WithDebug()
+	debugW io.Writer
 }
 
 // New instance with base URL and use http.Client as default http client
@@ -290,7 +292,17 @@ func (h *Client) sendRequest(req *http.Request, opt *Option) (*http.Response, er
 		h.beforeSend(req)
 	}
 
+	var dumper *debugDumper
+	if w := h.debugWriter(); w != nil {
+		dumper = newDebugDumper(w)
+		req = dumper.dumpRequest(req)
+	}
+
 	resp, err := cli.client.Do(req)
+	if dumper != nil {
+		dumper.dumpResponse(resp, err)
+	}
+
 	if h.afterSend != nil {
 		h.afterSend(resp, err)
 	}
@@ -0,0 +1,352 @@
+package httpreq
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/gookit/goutil/netutil/httpctype"
+	"github.com/gookit/goutil/strutil"
+)
+
+// DebugEnvKey the env name for enable the debug dump on runtime. eg: HTTPREQ_DEBUG=true
+const DebugEnvKey = "HTTPREQ_DEBUG"
+
+// DebugFlagName the flag name for enable the debug dump. see BindDebugFlag()
+const DebugFlagName = "http-debug"
+
+// DebugBodyLimit max body length for dump, the body will be truncated on exceeded.
+var DebugBodyLimit = 2048
+
+var debugOn atomic.Bool
+
+// SetDebug enable or disable the debug dump for all clients, the dump will be written to os.Stderr.
+func SetDebug(on bool) { debugOn.Store(on) }
+
+// IsDebug check the debug dump is enabled by SetDebug() or the env DebugEnvKey
+func IsDebug() bool {
+	return debugOn.Load() || strutil.QuietBool(os.Getenv(DebugEnvKey))
+}
+
+type debugFlag struct{}
+
+func (debugFlag) IsBoolFlag() bool { return true }
+
+func (debugFlag) String() string { return strconv.FormatBool(debugOn.Load()) }
+
+func (debugFlag) Set(s string) error {
+	on, err := strconv.ParseBool(s)
+	if err == nil {
+		SetDebug(on)
+	}
+	return err
+}
+
+// BindDebugFlag add the bool flag "--http-debug" to the flag set, for enable the debug dump.
+//
+// Usage:
+//
+//	httpreq.BindDebugFlag(flag.CommandLine)
+//	// with cflag
+//	httpreq.BindDebugFlag(cmd.FlagSet)
+func BindDebugFlag(fs *flag.FlagSet) {
+	fs.Var(debugFlag{}, DebugFlagName, "enable the HTTP request and response debug dump")
+}
+
+// WithDebug always dump the request and response to w for the client, default w is os.Stderr.
+//
+// Dump contents: method, URL, headers(the secrets are redacted), body(truncated, JSON indented),
+// the response summary and the timing breakdown.
+//
+// TIP: use SetDebug(), env DebugEnvKey or BindDebugFlag() to enable the dump for all clients on runtime.
+func (h *Client) WithDebug(w io.Writer) *Client {
+	if w == nil {
+		w = os.Stderr
+	}
+	h.debugW = w
+	return h
+}
+
+// get the debug dump writer, returns nil on debug is disabled.
+func (h *Client) debugWriter() io.Writer {
+	if h.debugW != nil {
+		return h.debugW
+	}
+	if IsDebug() {
+		return os.Stderr
+	}
+	return nil
+}
+
+// the header names should be redacted on dump
+var redactHeaders = []string{"authorization", "proxy-authorization", "cookie", "set-cookie"}
+
+// the header name keywords should be redacted on dump. eg: X-Api-Key, X-Auth-Token
+var redactKeywords = []string{"token", "secret", "password", "key", "session"}
+
+func isSecretHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range redactHeaders {
+		if name == s {
+			return true
+		}
+	}
+
+	return hasRedactKeyword(name)
+}
+
+// check the lower name contains any of the redactKeywords
+func hasRedactKeyword(name string) bool {
+	for _, kw := range redactKeywords {
+		if strings.Contains(name, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// get the URL string for dump, the password and the query values with secret name are redacted.
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.Redacted()
+	}
+
+	pairs := strings.Split(u.RawQuery, "&")
+	for i, pair := range pairs {
+		name, _, _ := strings.Cut(pair, "=")
+		if key, err := url.QueryUnescape(name); err == nil && hasRedactKeyword(strings.ToLower(key)) {
+			pairs[i] = name + "=" + strutil.SecretMask
+		}
+	}
+
+	cu := *u
+	cu.RawQuery = strings.Join(pairs, "&")
+	return cu.Redacted()
+}
+
+// the streaming content types, the body should not be peeked on dump
+var streamContentTypes = []string{"event-stream", "application/x-ndjson", "application/stream+json"}
+
+// check the body is streaming by content type, or its length is unknown(-1)
+func isStreamBody(cType string, length int64) bool {
+	if length < 0 {
+		return true
+	}
+
+	cType = strings.ToLower(cType)
+	for _, typ := range streamContentTypes {
+		if strings.Contains(cType, typ) {
+			return true
+		}
+	}
+	return false
+}
+
+// get the request body length, returns -1 on the length is unknown.
+func reqBodyLength(req *http.Request) int64 {
+	// on client request, 0 with non-nil Body means unknown
+	if req.ContentLength == 0 && req.Body != nil && req.Body != http.NoBody {
+		return -1
+	}
+	return req.ContentLength
+}
+
+// debugDumper dump one request and its response
+type debugDumper struct {
+	w     io.Writer
+	start time.Time
+
+	mu sync.Mutex
+	// timing points
+	dnsStart, dnsDone, connStart, connDone, tlsStart, tlsDone, firstByte time.Time
+	reused                                                               bool
+}
+
+func newDebugDumper(w io.Writer) *debugDumper {
+	return &debugDumper{w: w}
+}
+
+func (d *debugDumper) mark(t *time.Time) {
+	d.mu.Lock()
+	*t = time.Now()
+	d.mu.Unlock()
+}
+
+// dump the request, and returns the request with client trace.
+func (d *debugDumper) dumpRequest(req *http.Request) *http.Request {
+	var buf bytes.Buffer
+	buf.WriteString(color.Cyan.Sprintf("> %s %s %s", req.Method, redactURL(req.URL), req.Proto))
+	buf.WriteByte('\n')
+	d.writeHeaders(&buf, "> ", req.Header)
+
+	// can always peek from the body copy by GetBody
+	cType := req.Header.Get(httpctype.Key)
+	if req.GetBody != nil || !isStreamBody(cType, reqBodyLength(req)) {
+		var body []byte
+		body, req.Body = d.peekBody(req.Body, req.GetBody)
+		d.writeBody(&buf, body, cType)
+	} else {
+		d.writeSkipBody(&buf)
+	}
+	_, _ = d.w.Write(buf.Bytes())
+
+	trace := &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { d.mark(&d.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { d.mark(&d.dnsDone) },
+		ConnectStart:      func(_, _ string) { d.mark(&d.connStart) },
+		ConnectDone:       func(_, _ string, _ error) { d.mark(&d.connDone) },
+		TLSHandshakeStart: func() { d.mark(&d.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { d.mark(&d.tlsDone) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			d.mu.Lock()
+			d.reused = info.Reused
+			d.mu.Unlock()
+		},
+		GotFirstResponseByte: func() { d.mark(&d.firstByte) },
+	}
+
+	d.start = time.Now()
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+// dump the response or error
+func (d *debugDumper) dumpResponse(resp *http.Response, err error) {
+	elapsed := time.Since(d.start)
+
+	var buf bytes.Buffer
+	if err != nil {
+		buf.WriteString(color.Red.Sprintf("< ERROR: %s (%s)", err.Error(), elapsed))
+		buf.WriteByte('\n')
+		d.writeTiming(&buf, elapsed)
+		_, _ = d.w.Write(buf.Bytes())
+		return
+	}
+
+	statusColor := color.Green
+	if resp.StatusCode >= http.StatusBadRequest {
+		statusColor = color.Red
+	} else if resp.StatusCode >= http.StatusMultipleChoices {
+		statusColor = color.Yellow
+	}
+
+	buf.WriteString(statusColor.Sprintf("< %s %s (%s)", resp.Proto, resp.Status, elapsed))
+	buf.WriteByte('\n')
+	d.writeHeaders(&buf, "< ", resp.Header)
+
+	// skip the streaming body, peek it will block until the stream sent enough data.
+	cType := resp.Header.Get(httpctype.Key)
+	if !isStreamBody(cType, resp.ContentLength) {
+		var body []byte
+		body, resp.Body = d.peekBody(resp.Body, nil)
+		d.writeBody(&buf, body, cType)
+	} else if resp.Body != nil && resp.Body != http.NoBody {
+		d.writeSkipBody(&buf)
+	}
+
+	d.writeTiming(&buf, elapsed)
+	_, _ = d.w.Write(buf.Bytes())
+}
+
+func (d *debugDumper) writeHeaders(buf *bytes.Buffer, prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		val := strings.Join(header[name], ", ")
+		if isSecretHeader(name) {
+			val = strutil.SecretMask
+		}
+		buf.WriteString(prefix + color.Magenta.Sprint(name) + ": " + val + "\n")
+	}
+}
+
+// read the body head for dump, and returns a new body reader contains full contents.
+func (d *debugDumper) peekBody(body io.ReadCloser, getBody func() (io.ReadCloser, error)) ([]byte, io.ReadCloser) {
+	if body == nil || body == http.NoBody {
+		return nil, body
+	}
+
+	// read from the body copy
+	if getBody != nil {
+		if rc, err := getBody(); err == nil {
+			head, _ := io.ReadAll(io.LimitReader(rc, int64(DebugBodyLimit+1)))
+			_ = rc.Close()
+			return head, body
+		}
+	}
+
+	head, _ := io.ReadAll(io.LimitReader(body, int64(DebugBodyLimit+1)))
+	return head, &struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), body), body}
+}
+
+func (d *debugDumper) writeSkipBody(buf *bytes.Buffer) {
+	buf.WriteString(color.Gray.Sprint("\n(body is not dumped: streaming or unknown length)"))
+	buf.WriteString("\n\n")
+}
+
+func (d *debugDumper) writeBody(buf *bytes.Buffer, body []byte, cType string) {
+	if len(body) == 0 {
+		return
+	}
+
+	truncated := len(body) > DebugBodyLimit
+	if truncated {
+		body = body[:DebugBodyLimit]
+	} else if strings.Contains(cType, "json") {
+		var out bytes.Buffer
+		if json.Indent(&out, body, "", "  ") == nil {
+			body = out.Bytes()
+		}
+	}
+
+	buf.WriteByte('\n')
+	buf.Write(bytes.TrimRight(body, "\r\n"))
+	if truncated {
+		buf.WriteString(color.Gray.Sprintf("... (truncated, max %d bytes)", DebugBodyLimit))
+	}
+	buf.WriteString("\n\n")
+}
+
+func (d *debugDumper) writeTiming(buf *bytes.Buffer, elapsed time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	parts := make([]string, 0, 5)
+	if !d.dnsDone.IsZero() && !d.dnsStart.IsZero() {
+		parts = append(parts, "dns="+d.dnsDone.Sub(d.dnsStart).String())
+	}
+	if !d.connDone.IsZero() && !d.connStart.IsZero() {
+		parts = append(parts, "connect="+d.connDone.Sub(d.connStart).String())
+	}
+	if !d.tlsDone.IsZero() && !d.tlsStart.IsZero() {
+		parts = append(parts, "tls="+d.tlsDone.Sub(d.tlsStart).String())
+	}
+	if !d.firstByte.IsZero() {
+		parts = append(parts, "ttfb="+d.firstByte.Sub(d.start).String())
+	}
+	parts = append(parts, "total="+elapsed.String())
+
+	line := fmt.Sprintf("* timing: %s, conn reused: %v", strings.Join(parts, " "), d.reused)
+	buf.WriteString(color.Gray.Sprint(line))
+	buf.WriteByte('\n')
+}
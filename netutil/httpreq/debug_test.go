@@ -0,0 +1,133 @@
+package httpreq_test
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/gookit/goutil/netutil/httpctype"
+	"github.com/gookit/goutil/netutil/httpreq"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestClient_WithDebug(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cli := httpreq.New(testSrvAddr).WithDebug(buf)
+
+	resp, err := cli.PostJSON("/json", map[string]string{"name": "inhere"}, func(opt *httpreq.Option) {
+		opt.HeaderMap = map[string]string{
+			"Authorization": "Bearer abc123",
+			"X-Api-Key":     "secret-key",
+			"X-Request-Id":  "req-1",
+		}
+	})
+	assert.NoErr(t, err)
+
+	// the response body is still readable
+	rr := testutil.ParseRespToReply(resp)
+	assert.Eq(t, "POST", rr.Method)
+	assert.Eq(t, "{\"name\":\"inhere\"}\n", rr.Body)
+
+	out := color.ClearCode(buf.String())
+	assert.StrContains(t, out, "> POST "+testSrvAddr+"/json HTTP/1.1\n")
+	assert.StrContains(t, out, "> Authorization: ******\n")
+	assert.StrContains(t, out, "> X-Api-Key: ******\n")
+	assert.StrContains(t, out, "> X-Request-Id: req-1\n")
+	assert.StrContains(t, out, "{\n  \"name\": \"inhere\"\n}")
+	assert.StrContains(t, out, "< HTTP/1.1 200 OK (")
+	assert.StrContains(t, out, "< Content-Type: application/json")
+	assert.StrContains(t, out, "* timing: ")
+	assert.StrContains(t, out, "total=")
+}
+
+func TestClient_WithDebug_redactURL(t *testing.T) {
+	buf := new(bytes.Buffer)
+	addr := strings.Replace(testSrvAddr, "http://", "http://user:pwd@", 1)
+	_, err := httpreq.New(addr).WithDebug(buf).Get("/get?name=inhere&access_token=abc123&API_KEY=xyz")
+	assert.NoErr(t, err)
+
+	// the echo response body contains the raw URL, only check the request line
+	line, _, _ := strings.Cut(color.ClearCode(buf.String()), "\n")
+	assert.StrContains(t, line, "> GET http://user:xxxxx@")
+	assert.StrContains(t, line, "/get?name=inhere&access_token=******&API_KEY=****** HTTP/1.1")
+	assert.StrNotContains(t, line, "pwd")
+}
+
+func TestClient_WithDebug_stream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = w.Write([]byte("{\"id\":1}\n"))
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-r.Context().Done():
+		case <-time.After(3 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	buf := new(bytes.Buffer)
+	start := time.Now()
+	resp, err := httpreq.New(srv.URL).WithDebug(buf).Get("/")
+	assert.NoErr(t, err)
+	// should not block by peek the streaming body
+	assert.True(t, time.Since(start) < time.Second)
+	assert.StrContains(t, color.ClearCode(buf.String()), "(body is not dumped: streaming or unknown length)")
+
+	line, err := io.ReadAll(io.LimitReader(resp.Body, 8))
+	assert.NoErr(t, err)
+	assert.Eq(t, "{\"id\":1}", string(line))
+	assert.NoErr(t, resp.Body.Close())
+}
+
+func TestClient_WithDebug_truncate(t *testing.T) {
+	old := httpreq.DebugBodyLimit
+	httpreq.DebugBodyLimit = 10
+	defer func() { httpreq.DebugBodyLimit = old }()
+
+	buf := new(bytes.Buffer)
+	body := strings.Repeat("a", 30)
+	resp, err := httpreq.New(testSrvAddr).WithDebug(buf).Post("/post", body, func(opt *httpreq.Option) {
+		opt.ContentType = httpctype.Text
+		// body without GetBody
+		opt.Body = io.NopCloser(strings.NewReader(body))
+	})
+	assert.NoErr(t, err)
+
+	rr := testutil.ParseRespToReply(resp)
+	assert.Eq(t, body, rr.Body)
+
+	out := color.ClearCode(buf.String())
+	assert.StrContains(t, out, "\naaaaaaaaaa... (truncated, max 10 bytes)\n")
+
+	// error
+	buf.Reset()
+	_, err = httpreq.New().WithDebug(buf).Get("http://127.0.0.1:1/not-exists")
+	assert.Err(t, err)
+	assert.StrContains(t, color.ClearCode(buf.String()), "< ERROR: ")
+}
+
+func TestSetDebug(t *testing.T) {
+	assert.False(t, httpreq.IsDebug())
+
+	t.Setenv(httpreq.DebugEnvKey, "true")
+	assert.True(t, httpreq.IsDebug())
+	t.Setenv(httpreq.DebugEnvKey, "")
+	assert.False(t, httpreq.IsDebug())
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	httpreq.BindDebugFlag(fs)
+	assert.NoErr(t, fs.Parse([]string{"--http-debug"}))
+	assert.True(t, httpreq.IsDebug())
+	assert.Eq(t, "true", fs.Lookup(httpreq.DebugFlagName).Value.String())
+
+	httpreq.SetDebug(false)
+	assert.False(t, httpreq.IsDebug())
+}
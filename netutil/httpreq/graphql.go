@@ -0,0 +1,243 @@
+package httpreq
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gookit/goutil/errorx"
+)
+
+// GraphQLLocation the error location in the GraphQL query
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError an error item in the GraphQL response "errors" array
+type GraphQLError struct {
+	Message    string            `json:"message"`
+	Path       []any             `json:"path,omitempty"`
+	Locations  []GraphQLLocation `json:"locations,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+// Code get the "extensions.code" value. eg: "UNAUTHENTICATED"
+func (e *GraphQLError) Code() string {
+	if code, ok := e.Extensions["code"].(string); ok {
+		return code
+	}
+	return ""
+}
+
+// Error string. format: "path.to.field: message"
+func (e *GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+
+	ps := make([]string, len(e.Path))
+	for i, p := range e.Path {
+		ps[i] = fmt.Sprint(p)
+	}
+	return strings.Join(ps, ".") + ": " + e.Message
+}
+
+// GraphQLResponse the standard GraphQL response envelope
+type GraphQLResponse struct {
+	Data       json.RawMessage `json:"data"`
+	Errors     []*GraphQLError `json:"errors,omitempty"`
+	Extensions map[string]any  `json:"extensions,omitempty"`
+}
+
+// Err convert the errors array to errorx.Errors, return nil on no error.
+func (r *GraphQLResponse) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+
+	es := make(errorx.Errors, len(r.Errors))
+	for i, e := range r.Errors {
+		es[i] = e
+	}
+	return es
+}
+
+// GraphQLRequest builder for send GraphQL request.
+//
+// Usage:
+//
+//	var out struct {
+//		User struct{ Name string } `json:"user"`
+//	}
+//	err := httpreq.GraphQL("https://example.com/graphql").
+//		Query(`query ($id: ID!) { user(id: $id) { name } }`).
+//		Vars(map[string]any{"id": 1}).
+//		Do(ctx, &out)
+type GraphQLRequest struct {
+	cli      *Client
+	endpoint string
+	query    string
+	opName   string
+	vars     map[string]any
+	headers  map[string]string
+	// persisted use the automatic persisted query
+	persisted bool
+}
+
+// GraphQL create a GraphQL request by the std client
+func GraphQL(endpoint string) *GraphQLRequest {
+	return std.GraphQL(endpoint)
+}
+
+// GraphQL create a GraphQL request builder. endpoint can be relative to the baseURL
+func (h *Client) GraphQL(endpoint string) *GraphQLRequest {
+	return &GraphQLRequest{cli: h, endpoint: endpoint}
+}
+
+// Query set the query document
+func (r *GraphQLRequest) Query(query string) *GraphQLRequest {
+	r.query = query
+	return r
+}
+
+// OperationName set the operation name, required if query contains multi operations
+func (r *GraphQLRequest) OperationName(name string) *GraphQLRequest {
+	r.opName = name
+	return r
+}
+
+// Vars set the variables
+func (r *GraphQLRequest) Vars(vars map[string]any) *GraphQLRequest {
+	if r.vars == nil {
+		r.vars = make(map[string]any, len(vars))
+	}
+	for k, v := range vars {
+		r.vars[k] = v
+	}
+	return r
+}
+
+// Var set one variable
+func (r *GraphQLRequest) Var(name string, val any) *GraphQLRequest {
+	return r.Vars(map[string]any{name: val})
+}
+
+// Header set request header
+func (r *GraphQLRequest) Header(key, val string) *GraphQLRequest {
+	if r.headers == nil {
+		r.headers = make(map[string]string)
+	}
+	r.headers[key] = val
+	return r
+}
+
+// Persisted enable the automatic persisted query(APQ).
+//
+// Will send the query sha256 hash only at first, if the server returns
+// "PersistedQueryNotFound", will resend with the full query for register it.
+func (r *GraphQLRequest) Persisted() *GraphQLRequest {
+	r.persisted = true
+	return r
+}
+
+// QueryHash get the sha256 hex hash of the query, used for persisted query.
+func (r *GraphQLRequest) QueryHash() string {
+	sum := sha256.Sum256([]byte(r.query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Do send the request, and decode the "data" to out.
+//
+// If the response contains errors, will return errorx.Errors with *GraphQLError items,
+// and the partial data still decoded to out.
+func (r *GraphQLRequest) Do(ctx context.Context, out any) error {
+	resp, err := r.Send(ctx)
+	if err != nil {
+		return err
+	}
+
+	if out != nil && len(resp.Data) > 0 && string(resp.Data) != "null" {
+		if err = json.Unmarshal(resp.Data, out); err != nil {
+			return err
+		}
+	}
+	return resp.Err()
+}
+
+// Send the request and return the response envelope. will not check the "errors" of response.
+func (r *GraphQLRequest) Send(ctx context.Context) (*GraphQLResponse, error) {
+	if !r.persisted {
+		return r.send(ctx, true)
+	}
+
+	resp, err := r.send(ctx, false)
+	if err != nil || !isPersistedNotFound(resp) {
+		return resp, err
+	}
+	return r.send(ctx, true)
+}
+
+func (r *GraphQLRequest) send(ctx context.Context, withQuery bool) (*GraphQLResponse, error) {
+	body := map[string]any{}
+	if withQuery {
+		body["query"] = r.query
+	}
+	if r.opName != "" {
+		body["operationName"] = r.opName
+	}
+	if len(r.vars) > 0 {
+		body["variables"] = r.vars
+	}
+	if r.persisted {
+		body["extensions"] = map[string]any{
+			"persistedQuery": map[string]any{"version": 1, "sha256Hash": r.QueryHash()},
+		}
+	}
+
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := NewOpt(WithJSONType).WithMethod(http.MethodPost).WithHeaderMap(r.headers)
+	opt.Context = ctx
+	opt.Body = bytes.NewReader(bs)
+
+	hr, err := r.cli.SendWithOpt(r.endpoint, opt)
+	if err != nil {
+		return nil, err
+	}
+	defer hr.Body.Close()
+
+	rbs, err := io.ReadAll(hr.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GraphQLResponse{}
+	if err = json.Unmarshal(rbs, resp); err != nil || (resp.Data == nil && resp.Errors == nil) {
+		if !IsSuccessful(hr.StatusCode) {
+			return nil, fmt.Errorf("graphql: response status %d: %s", hr.StatusCode, bytes.TrimSpace(rbs))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("graphql: invalid response body: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+func isPersistedNotFound(resp *GraphQLResponse) bool {
+	for _, e := range resp.Errors {
+		if e.Message == "PersistedQueryNotFound" || e.Code() == "PERSISTED_QUERY_NOT_FOUND" {
+			return true
+		}
+	}
+	return false
+}
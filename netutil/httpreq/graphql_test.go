@@ -0,0 +1,108 @@
+package httpreq_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gookit/goutil/errorx"
+	"github.com/gookit/goutil/netutil/httpreq"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func newGraphQLServer(t *testing.T, handle func(body map[string]any) (int, string)) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Eq(t, http.MethodPost, r.Method)
+		assert.StrContains(t, r.Header.Get("Content-Type"), "application/json")
+
+		body := make(map[string]any)
+		assert.NoErr(t, json.NewDecoder(r.Body).Decode(&body))
+
+		code, resp := handle(body)
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGraphQL_Do(t *testing.T) {
+	srv := newGraphQLServer(t, func(body map[string]any) (int, string) {
+		assert.Eq(t, "query ($id: ID!) { user(id: $id) { name } }", body["query"])
+		assert.Eq(t, "GetUser", body["operationName"])
+		assert.Eq(t, map[string]any{"id": float64(1)}, body["variables"])
+		return 200, `{"data": {"user": {"name": "inhere"}}}`
+	})
+
+	var out struct {
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}
+
+	err := httpreq.New(srv.URL).GraphQL("/graphql").
+		Query("query ($id: ID!) { user(id: $id) { name } }").
+		OperationName("GetUser").
+		Var("id", 1).
+		Header("X-Token", "abc").
+		Do(context.Background(), &out)
+	assert.NoErr(t, err)
+	assert.Eq(t, "inhere", out.User.Name)
+}
+
+func TestGraphQL_errors(t *testing.T) {
+	srv := newGraphQLServer(t, func(body map[string]any) (int, string) {
+		return 200, `{"data": {"user": null, "posts": [1]}, "errors": [
+	{"message": "not found", "path": ["user", 0], "locations": [{"line": 1, "column": 3}]},
+	{"message": "no auth", "extensions": {"code": "UNAUTHENTICATED"}}
+]}`
+	})
+
+	out := map[string]any{}
+	err := httpreq.GraphQL(srv.URL).Query("{ user { name } posts }").Do(context.Background(), &out)
+	assert.Err(t, err)
+	assert.NotNil(t, out["posts"])
+
+	es, ok := err.(errorx.Errors)
+	assert.True(t, ok)
+	assert.Len(t, es, 2)
+	assert.Eq(t, "user.0: not found", es[0].Error())
+
+	ge := es[1].(*httpreq.GraphQLError)
+	assert.Eq(t, "UNAUTHENTICATED", ge.Code())
+	assert.Eq(t, "no auth", ge.Error())
+
+	// bad status without graphql body
+	srv = newGraphQLServer(t, func(body map[string]any) (int, string) {
+		return 502, "bad gateway"
+	})
+	err = httpreq.GraphQL(srv.URL).Query("{ user }").Do(context.Background(), nil)
+	assert.ErrMsg(t, err, "graphql: response status 502: bad gateway")
+}
+
+func TestGraphQL_Persisted(t *testing.T) {
+	query := "{ user { name } }"
+	req := httpreq.GraphQL("").Query(query).Persisted()
+	hash := req.QueryHash()
+
+	var calls int
+	srv := newGraphQLServer(t, func(body map[string]any) (int, string) {
+		calls++
+		ext := body["extensions"].(map[string]any)["persistedQuery"].(map[string]any)
+		assert.Eq(t, hash, ext["sha256Hash"])
+
+		if _, ok := body["query"]; !ok {
+			return 200, `{"errors": [{"message": "PersistedQueryNotFound"}]}`
+		}
+		assert.Eq(t, query, body["query"])
+		return 200, `{"data": {"user": {"name": "inhere"}}}`
+	})
+
+	resp, err := httpreq.GraphQL(srv.URL).Query(query).Persisted().Send(context.Background())
+	assert.NoErr(t, err)
+	assert.NoErr(t, resp.Err())
+	assert.Eq(t, 2, calls)
+	assert.Eq(t, `{"user": {"name": "inhere"}}`, string(resp.Data))
+}
@@ -0,0 +1,193 @@
+package httpreq
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrStopEach return it on the each handler func for stop the iteration without error.
+var ErrStopEach = errors.New("stop each iteration")
+
+// SSEvent a server-sent event of the text/event-stream response
+type SSEvent struct {
+	// ID of the event, field "id"
+	ID string
+	// Event type, field "event". empty means "message"
+	Event string
+	// Data of the event, multi "data" fields are joined by newline
+	Data string
+	// Retry reconnection time in milliseconds, field "retry"
+	Retry int
+}
+
+// JSON decode the event data to ptr
+func (e *SSEvent) JSON(ptr any) error {
+	return json.Unmarshal([]byte(e.Data), ptr)
+}
+
+// EachSSE parse the text/event-stream body incrementally, and call fn for each event.
+//
+// It stops on the body is read completely, fn return error or the request context is canceled.
+// Return ErrStopEach on fn for stop without error.
+//
+// NOTICE: will close the resp body.
+//
+// Usage:
+//
+//	err := resp.EachSSE(func(evt *httpreq.SSEvent) error {
+//		if evt.Data == "[DONE]" {
+//			return httpreq.ErrStopEach
+//		}
+//		fmt.Print(evt.Data)
+//		return nil
+//	})
+func (r *RespX) EachSSE(fn func(evt *SSEvent) error) error {
+	defer r.SafeCloseBody()
+
+	ctx := r.reqContext()
+	br := bufio.NewReader(r.Body)
+
+	var hasData bool
+	var dataSb strings.Builder
+	evt := &SSEvent{}
+	for {
+		line, err := readLine(br)
+		if err != nil {
+			// the last incomplete event is discarded, see the SSE spec.
+			return checkEachErr(ctx, err)
+		}
+
+		// blank line: dispatch the event
+		if line == "" {
+			if hasData {
+				evt.Data = dataSb.String()
+				if err := callEachFn(ctx, func() error { return fn(evt) }); err != nil {
+					return stopEachErr(err)
+				}
+			}
+
+			// the last event id is kept for next events
+			evt = &SSEvent{ID: evt.ID}
+			hasData = false
+			dataSb.Reset()
+			continue
+		}
+
+		// comment line
+		if line[0] == ':' {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			if hasData {
+				dataSb.WriteByte('\n')
+			}
+			dataSb.WriteString(value)
+			hasData = true
+		case "event":
+			evt.Event = value
+		case "id":
+			evt.ID = value
+		case "retry":
+			if n, err := strconv.Atoi(value); err == nil {
+				evt.Retry = n
+			}
+		}
+	}
+}
+
+// EachJSONLine parse the NDJSON(newline delimited JSON) body incrementally, and call fn for each line.
+// the blank lines are skipped.
+//
+// It stops on the body is read completely, fn return error or the request context is canceled.
+// Return ErrStopEach on fn for stop without error.
+//
+// NOTICE: will close the resp body.
+//
+// Usage:
+//
+//	err := resp.EachJSONLine(func(line json.RawMessage) error {
+//		var item Item
+//		return json.Unmarshal(line, &item)
+//	})
+func (r *RespX) EachJSONLine(fn func(line json.RawMessage) error) error {
+	defer r.SafeCloseBody()
+
+	ctx := r.reqContext()
+	br := bufio.NewReader(r.Body)
+	for num := 1; ; num++ {
+		line, err := readLine(br)
+		if err != nil {
+			return checkEachErr(ctx, err)
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !json.Valid([]byte(line)) {
+			return fmt.Errorf("invalid JSON at line %d: %q", num, line)
+		}
+
+		if err := callEachFn(ctx, func() error { return fn(json.RawMessage(line)) }); err != nil {
+			return stopEachErr(err)
+		}
+	}
+}
+
+func (r *RespX) reqContext() context.Context {
+	if r.Request != nil {
+		return r.Request.Context()
+	}
+	return context.Background()
+}
+
+// read a line without the line ending. support "\n" and "\r\n"
+func readLine(br *bufio.Reader) (string, error) {
+	line, err := br.ReadString('\n')
+	if err != nil {
+		// the last line without line ending
+		if err == io.EOF && line != "" {
+			return strings.TrimSuffix(line, "\r"), nil
+		}
+		return "", err
+	}
+
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), nil
+}
+
+// check the context is canceled before call fn
+func callEachFn(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// the ErrStopEach means stop without error
+func stopEachErr(err error) error {
+	if errors.Is(err, ErrStopEach) {
+		return nil
+	}
+	return err
+}
+
+func checkEachErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+package httpreq_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/netutil/httpreq"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func newStreamServer(body string, blockAfter bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, body)
+		w.(http.Flusher).Flush()
+
+		if blockAfter {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(3 * time.Second):
+			}
+		}
+	}))
+}
+
+func TestRespX_EachSSE(t *testing.T) {
+	body := ": comment line\n" +
+		"retry: 3000\n" +
+		"data: hello\n\n" +
+		"id: 2\r\nevent: update\r\ndata: line1\r\ndata:line2\r\n\r\n" +
+		"event: ping\n\n" + // no data, not dispatch
+		"data: {\"name\": \"inhere\"}\n\n" +
+		"data: incomplete"
+
+	srv := newStreamServer(body, false)
+	defer srv.Close()
+
+	var evts []httpreq.SSEvent
+	rx := httpreq.MustRespX(httpreq.Get(srv.URL))
+	err := rx.EachSSE(func(evt *httpreq.SSEvent) error {
+		evts = append(evts, *evt)
+		return nil
+	})
+	assert.NoErr(t, err)
+	assert.Len(t, evts, 3)
+
+	assert.Eq(t, httpreq.SSEvent{Data: "hello", Retry: 3000}, evts[0])
+	assert.Eq(t, httpreq.SSEvent{ID: "2", Event: "update", Data: "line1\nline2"}, evts[1])
+	// keep the last event id
+	assert.Eq(t, "2", evts[2].ID)
+	assert.Eq(t, "", evts[2].Event)
+
+	var data map[string]string
+	assert.NoErr(t, evts[2].JSON(&data))
+	assert.Eq(t, "inhere", data["name"])
+
+	// stop by ErrStopEach
+	var n int
+	rx = httpreq.MustRespX(httpreq.Get(srv.URL))
+	err = rx.EachSSE(func(evt *httpreq.SSEvent) error {
+		n++
+		return httpreq.ErrStopEach
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, n)
+
+	// return error
+	rx = httpreq.MustRespX(httpreq.Get(srv.URL))
+	err = rx.EachSSE(func(evt *httpreq.SSEvent) error {
+		return errors.New("handle error")
+	})
+	assert.ErrMsg(t, err, "handle error")
+}
+
+func TestRespX_EachSSE_cancel(t *testing.T) {
+	srv := newStreamServer("data: first\n\n", true)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	assert.NoErr(t, err)
+	rx := httpreq.MustRespX(http.DefaultClient.Do(req))
+
+	var got []string
+	start := time.Now()
+	err = rx.EachSSE(func(evt *httpreq.SSEvent) error {
+		got = append(got, evt.Data)
+		cancel()
+		return nil
+	})
+
+	assert.ErrIs(t, err, context.Canceled)
+	assert.Eq(t, []string{"first"}, got)
+	assert.Lt(t, int(time.Since(start)), int(2*time.Second))
+}
+
+func TestRespX_EachJSONLine(t *testing.T) {
+	srv := newStreamServer("{\"id\": 1}\n\n{\"id\": 2}\r\n[3]", false)
+	defer srv.Close()
+
+	var lines []string
+	rx := httpreq.MustRespX(httpreq.Get(srv.URL))
+	err := rx.EachJSONLine(func(line json.RawMessage) error {
+		lines = append(lines, string(line))
+		return nil
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{`{"id": 1}`, `{"id": 2}`, `[3]`}, lines)
+
+	// stop
+	lines = lines[:0]
+	rx = httpreq.MustRespX(httpreq.Get(srv.URL))
+	err = rx.EachJSONLine(func(line json.RawMessage) error {
+		lines = append(lines, string(line))
+		return httpreq.ErrStopEach
+	})
+	assert.NoErr(t, err)
+	assert.Len(t, lines, 1)
+
+	// invalid JSON
+	srv2 := newStreamServer("{\"id\": 1}\n{invalid\n", false)
+	defer srv2.Close()
+
+	rx = httpreq.MustRespX(httpreq.Get(srv2.URL))
+	err = rx.EachJSONLine(func(line json.RawMessage) error { return nil })
+	assert.ErrSubMsg(t, err, "invalid JSON at line 2")
+}
@@ -1,6 +1,333 @@
 package structs
 
-// MapStruct simple copy src struct value to dst struct
-// func MapStruct(srcSt, dstSt any) {
-// 	// TODO
-// }
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/gookit/goutil/reflects"
+)
+
+// CopyOptions for copy fields between structs
+type CopyOptions struct {
+	// TagName match fields by the tag name, fallback to field name if no tag. eg: "json"
+	//
+	// default is empty, match by field name.
+	TagName string
+	// Ignores the field names or tag names of dst to ignore.
+	Ignores []string
+	// IgnoreCase match field names ignore case
+	IgnoreCase bool
+	// SkipZero skip copy the zero value of src field
+	SkipZero bool
+}
+
+// CopyOptFunc define
+type CopyOptFunc func(opt *CopyOptions)
+
+// WithCopyTag set the tag name for match fields
+func WithCopyTag(tagName string) CopyOptFunc {
+	return func(opt *CopyOptions) {
+		opt.TagName = tagName
+	}
+}
+
+// WithCopyIgnores set the field names to ignore
+func WithCopyIgnores(names ...string) CopyOptFunc {
+	return func(opt *CopyOptions) {
+		opt.Ignores = append(opt.Ignores, names...)
+	}
+}
+
+// CopyIgnoreCase match field names ignore case
+func CopyIgnoreCase(opt *CopyOptions) {
+	opt.IgnoreCase = true
+}
+
+// CopySkipZero skip copy the zero value of src field
+func CopySkipZero(opt *CopyOptions) {
+	opt.SkipZero = true
+}
+
+// CopyReport the result report of CopyFields
+type CopyReport struct {
+	// Copied the dst field names that copied
+	Copied []string
+	// SrcUnmatched the src field names not matched in dst
+	SrcUnmatched []string
+	// DstUnmatched the dst field names not matched in src
+	DstUnmatched []string
+	// Failed the dst field names and error of convert value failed
+	Failed map[string]error
+	// keep the order of failed fields
+	failedOrder []string
+}
+
+// Err get the convert failed error, return nil if all succeed.
+func (r *CopyReport) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(r.Failed))
+	for _, name := range r.failedOrder {
+		msgs = append(msgs, name+": "+r.Failed[name].Error())
+	}
+	return errors.New("copy fields failed: " + strings.Join(msgs, "; "))
+}
+
+// CopyFields copy the field values from src struct to dst struct of different types,
+// match fields by name or tag. the embedded struct fields are promoted.
+//
+// Supported value convert:
+//
+//   - assignable and convertible numbers. eg: int <-> int64, float32 <-> float64
+//   - string <-> number, bool
+//   - fmt.Stringer -> string, string -> encoding.TextUnmarshaler
+//   - T <-> *T
+//   - struct -> struct of different type, by copy fields recursively
+//
+// Usage:
+//
+//	report, err := structs.CopyFields(&user, &userDTO, structs.WithCopyTag("json"))
+func CopyFields(src, dst any, optFns ...CopyOptFunc) (*CopyReport, error) {
+	srcRv := reflect.Indirect(reflect.ValueOf(src))
+	if srcRv.Kind() != reflect.Struct {
+		return nil, errors.New("copy fields: src must be a struct or struct pointer")
+	}
+
+	dstRv := reflect.ValueOf(dst)
+	if dstRv.Kind() != reflect.Ptr || dstRv.IsNil() || dstRv.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("copy fields: dst must be a non-nil struct pointer")
+	}
+
+	opt := &CopyOptions{}
+	for _, fn := range optFns {
+		fn(opt)
+	}
+
+	report := &CopyReport{Failed: make(map[string]error)}
+	copyStruct(srcRv, dstRv.Elem(), opt, report)
+	return report, nil
+}
+
+// copyField info for copy
+type copyField struct {
+	key   string
+	field reflect.StructField
+}
+
+// collect the exported fields, the embedded struct fields are promoted.
+func collectCopyFields(rt reflect.Type, opt *CopyOptions) []copyField {
+	fields := make([]copyField, 0, rt.NumField())
+	for _, sf := range reflect.VisibleFields(rt) {
+		if !sf.IsExported() {
+			continue
+		}
+		// the fields of embedded struct or *struct are promoted
+		if sf.Anonymous && isStructOrPtr(sf.Type) {
+			continue
+		}
+
+		key := sf.Name
+		if opt.TagName != "" {
+			if tagVal, ok := sf.Tag.Lookup(opt.TagName); ok {
+				name := strings.TrimSpace(strings.SplitN(tagVal, ",", 2)[0])
+				if name == "-" {
+					continue
+				}
+				if name != "" {
+					key = name
+				}
+			}
+		}
+
+		if opt.IgnoreCase {
+			key = strings.ToLower(key)
+		}
+		fields = append(fields, copyField{key: key, field: sf})
+	}
+	return fields
+}
+
+func copyStruct(srcRv, dstRv reflect.Value, opt *CopyOptions, report *CopyReport) {
+	srcFields := collectCopyFields(srcRv.Type(), opt)
+	srcMap := make(map[string]reflect.StructField, len(srcFields))
+	for _, cf := range srcFields {
+		srcMap[cf.key] = cf.field
+	}
+
+	ignores := make(map[string]bool, len(opt.Ignores))
+	for _, name := range opt.Ignores {
+		if opt.IgnoreCase {
+			name = strings.ToLower(name)
+		}
+		ignores[name] = true
+	}
+
+	matched := make(map[string]bool, len(srcFields))
+	for _, cf := range collectCopyFields(dstRv.Type(), opt) {
+		name := cf.field.Name
+		if ignores[cf.key] || ignores[name] {
+			matched[cf.key] = true
+			continue
+		}
+
+		sf, ok := srcMap[cf.key]
+		if !ok {
+			report.DstUnmatched = append(report.DstUnmatched, name)
+			continue
+		}
+		matched[cf.key] = true
+
+		sv, err := srcRv.FieldByIndexErr(sf.Index)
+		if err != nil { // nil embedded pointer
+			continue
+		}
+		if opt.SkipZero && sv.IsZero() {
+			continue
+		}
+
+		dv, err := fieldByIndexAlloc(dstRv, cf.field.Index)
+		if err == nil {
+			err = setCopyValue(dv, sv, opt)
+		}
+
+		if err != nil {
+			report.Failed[name] = err
+			report.failedOrder = append(report.failedOrder, name)
+		} else {
+			report.Copied = append(report.Copied, name)
+		}
+	}
+
+	for _, cf := range srcFields {
+		if !matched[cf.key] {
+			report.SrcUnmatched = append(report.SrcUnmatched, cf.field.Name)
+		}
+	}
+}
+
+// get the field by index, will alloc the nil embedded pointer.
+func fieldByIndexAlloc(rv reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return rv, errors.New("cannot set embedded pointer of unexported struct")
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, nil
+}
+
+var (
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textUnmarshalType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// set the src value to dst, will convert the type if needed.
+func setCopyValue(dv, sv reflect.Value, opt *CopyOptions) error {
+	st, dt := sv.Type(), dv.Type()
+	if st.AssignableTo(dt) {
+		dv.Set(sv)
+		return nil
+	}
+
+	// *T -> T
+	if st.Kind() == reflect.Ptr {
+		if sv.IsNil() {
+			dv.Set(reflect.Zero(dt))
+			return nil
+		}
+		if dt.Kind() != reflect.Ptr && !st.Implements(stringerType) {
+			return setCopyValue(dv, sv.Elem(), opt)
+		}
+	}
+
+	// T -> *T
+	if dt.Kind() == reflect.Ptr && st.Kind() != reflect.Ptr {
+		nv := reflect.New(dt.Elem())
+		if err := setCopyValue(nv.Elem(), sv, opt); err != nil {
+			return err
+		}
+		dv.Set(nv)
+		return nil
+	}
+
+	switch {
+	case dt.Kind() == reflect.String && st.Implements(stringerType):
+		dv.SetString(sv.Interface().(fmt.Stringer).String())
+		return nil
+	case st.Kind() == reflect.String && reflect.PtrTo(dt).Implements(textUnmarshalType):
+		return dv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(sv.String()))
+	case isNumberKind(st.Kind()) && isNumberKind(dt.Kind()):
+		return setCopyNumber(dv, sv)
+	case st.Kind() == dt.Kind() && st.ConvertibleTo(dt):
+		dv.Set(sv.Convert(dt))
+		return nil
+	case st.Kind() == reflect.Struct && dt.Kind() == reflect.Struct:
+		sub := &CopyReport{Failed: make(map[string]error)}
+		copyStruct(sv, dv, opt, sub)
+		return sub.Err()
+	}
+
+	nv, err := reflects.ValueByType(sv.Interface(), dt)
+	if err != nil {
+		return fmt.Errorf("cannot convert %s to %s", st, dt)
+	}
+
+	if nv.Type() != dt {
+		nv = nv.Convert(dt)
+	}
+	dv.Set(nv)
+	return nil
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+// set the number value, will return error on overflow or lose the sign.
+func setCopyNumber(dv, sv reflect.Value) error {
+	var overflow bool
+	switch {
+	case sv.CanInt():
+		i64 := sv.Int()
+		if dv.CanUint() {
+			overflow = i64 < 0 || dv.OverflowUint(uint64(i64))
+		} else if dv.CanInt() {
+			overflow = dv.OverflowInt(i64)
+		}
+	case sv.CanUint():
+		u64 := sv.Uint()
+		if dv.CanInt() {
+			overflow = u64 > math.MaxInt64 || dv.OverflowInt(int64(u64))
+		} else if dv.CanUint() {
+			overflow = dv.OverflowUint(u64)
+		}
+	default: // float
+		f64 := sv.Float()
+		switch {
+		case dv.CanInt():
+			overflow = f64 < math.MinInt64 || f64 >= math.MaxInt64 || dv.OverflowInt(int64(f64))
+		case dv.CanUint():
+			overflow = f64 < 0 || f64 >= math.MaxUint64 || dv.OverflowUint(uint64(f64))
+		default:
+			overflow = dv.OverflowFloat(f64)
+		}
+	}
+
+	if overflow {
+		return fmt.Errorf("value %v overflows %s", sv.Interface(), dv.Type())
+	}
+	dv.Set(sv.Convert(dv.Type()))
+	return nil
+}
@@ -0,0 +1,201 @@
+package structs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/structs"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+type copyStatus int
+
+func (s copyStatus) String() string {
+	if s == 1 {
+		return "active"
+	}
+	return "inactive"
+}
+
+func TestCopyFields(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+	type User struct {
+		Base
+		Name    string
+		Age     int
+		Score   float32
+		Status  copyStatus
+		Email   *string
+		Created time.Time
+		secret  string
+		Extra   string
+	}
+	type UserDTO struct {
+		ID      int64
+		Name    *string
+		Age     string
+		Score   float64
+		Status  string
+		Email   string
+		Created string
+		Other   bool
+	}
+
+	email := "inhere@example.com"
+	u := User{
+		Base:    Base{ID: 23},
+		Name:    "inhere",
+		Age:     30,
+		Score:   4.5,
+		Status:  1,
+		Email:   &email,
+		Created: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		secret:  "abc",
+	}
+
+	dto := &UserDTO{}
+	rp, err := structs.CopyFields(&u, dto)
+	assert.NoErr(t, err)
+	assert.NoErr(t, rp.Err())
+	assert.Eq(t, int64(23), dto.ID)
+	assert.Eq(t, "inhere", *dto.Name)
+	assert.Eq(t, "30", dto.Age)
+	assert.Eq(t, float64(4.5), dto.Score)
+	assert.Eq(t, "active", dto.Status)
+	assert.Eq(t, email, dto.Email)
+	assert.StrContains(t, dto.Created, "2023-01-02 03:04:05")
+	assert.Eq(t, []string{"Extra"}, rp.SrcUnmatched)
+	assert.Eq(t, []string{"Other"}, rp.DstUnmatched)
+	assert.Len(t, rp.Copied, 7)
+
+	// copy back
+	u2 := &User{}
+	rp, err = structs.CopyFields(dto, u2, structs.WithCopyIgnores("Status", "Created"))
+	assert.NoErr(t, err)
+	assert.NoErr(t, rp.Err())
+	assert.Eq(t, 23, u2.ID)
+	assert.Eq(t, "inhere", u2.Name)
+	assert.Eq(t, 30, u2.Age)
+	assert.Eq(t, email, *u2.Email)
+	assert.Eq(t, copyStatus(0), u2.Status)
+
+	// invalid args
+	_, err = structs.CopyFields("abc", dto)
+	assert.ErrMsg(t, err, "copy fields: src must be a struct or struct pointer")
+	_, err = structs.CopyFields(u, UserDTO{})
+	assert.ErrMsg(t, err, "copy fields: dst must be a non-nil struct pointer")
+}
+
+func TestCopyFields_tagAndOptions(t *testing.T) {
+	type Addr struct {
+		City string `json:"city"`
+	}
+	type Src struct {
+		UserName string `json:"name"`
+		Age      int    `json:"age"`
+		Nick     string
+		Addr     Addr   `json:"addr"`
+		Token    string `json:"-"`
+	}
+	type AddrDTO struct {
+		City string
+	}
+	type Dst struct {
+		Name  string  `json:"name"`
+		Years int     `json:"age"`
+		NICK  string  `json:"nick"`
+		Addr  AddrDTO `json:"addr"`
+		Token string
+	}
+
+	src := Src{UserName: "inhere", Nick: "in", Addr: Addr{City: "chengdu"}, Token: "xyz"}
+	dst := &Dst{Years: 20}
+	rp, err := structs.CopyFields(src, dst, structs.WithCopyTag("json"), structs.CopySkipZero)
+	assert.NoErr(t, err)
+	assert.NoErr(t, rp.Err())
+	assert.Eq(t, "inhere", dst.Name)
+	assert.Eq(t, 20, dst.Years)
+	assert.Eq(t, "", dst.NICK)
+	assert.Eq(t, "", dst.Token)
+	assert.Eq(t, "chengdu", dst.Addr.City)
+	assert.Eq(t, []string{"Nick"}, rp.SrcUnmatched)
+	assert.Eq(t, []string{"NICK", "Token"}, rp.DstUnmatched)
+
+	// ignore case
+	dst = &Dst{}
+	rp, err = structs.CopyFields(src, dst, structs.WithCopyTag("json"), structs.CopyIgnoreCase)
+	assert.NoErr(t, err)
+	assert.Eq(t, "in", dst.NICK)
+	assert.Eq(t, []string{"Token"}, rp.DstUnmatched)
+}
+
+func TestCopyFields_failed(t *testing.T) {
+	type Src struct {
+		Age  string
+		Tags []string
+	}
+	type Dst struct {
+		Age  int
+		Tags map[string]int
+	}
+
+	dst := &Dst{}
+	rp, err := structs.CopyFields(&Src{Age: "abc", Tags: []string{"a"}}, dst)
+	assert.NoErr(t, err)
+	assert.Len(t, rp.Failed, 2)
+	assert.Empty(t, rp.Copied)
+	assert.ErrMsg(t, rp.Err(), "copy fields failed: Age: cannot convert string to int; Tags: cannot convert []string to map[string]int")
+}
+
+func TestCopyFields_overflow(t *testing.T) {
+	type Src struct {
+		Small int64
+		Neg   int
+		Big   uint64
+		Float float64
+		Ok    int64
+	}
+	type Dst struct {
+		Small int8
+		Neg   uint
+		Big   int64
+		Float int32
+		Ok    uint8
+	}
+
+	dst := &Dst{}
+	rp, err := structs.CopyFields(&Src{Small: 300, Neg: -1, Big: 1 << 63, Float: 1e10, Ok: 255}, dst)
+	assert.NoErr(t, err)
+	assert.Len(t, rp.Failed, 4)
+	assert.ErrMsg(t, rp.Failed["Small"], "value 300 overflows int8")
+	assert.Eq(t, []string{"Ok"}, rp.Copied)
+	assert.Eq(t, Dst{Ok: 255}, *dst)
+}
+
+func TestCopyFields_embedPtr(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+	type User struct {
+		*Base
+		Name string
+	}
+
+	src := &User{Base: &Base{ID: 23}, Name: "inhere"}
+	dst := &User{}
+	rp, err := structs.CopyFields(src, dst)
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"ID", "Name"}, rp.Copied)
+	assert.Eq(t, 23, dst.ID)
+	// not alias the src pointer
+	assert.True(t, dst.Base != src.Base)
+
+	// nil embedded pointer
+	dst = &User{}
+	rp, err = structs.CopyFields(&User{Name: "inhere"}, dst)
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"Name"}, rp.Copied)
+	assert.Nil(t, dst.Base)
+}
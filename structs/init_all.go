@@ -0,0 +1,187 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gookit/goutil/comdef"
+	"github.com/gookit/goutil/reflects"
+)
+
+// Initializer interface. InitAll() will call the Init() method after the default values applied.
+type Initializer interface {
+	Init() error
+}
+
+var initializerType = reflect.TypeOf((*Initializer)(nil)).Elem()
+
+// InitAll init the struct and all nested structs in one pass, useful for materialize the config objects.
+//
+//   - apply the default values by the field "default" tag, same as InitDefaults().
+//   - call the Init() method if the struct implements the Initializer, the nested structs are called first.
+//   - walk the nested struct, pointer to struct, slice, array and map of structs.
+//
+// It does not stop on error, all errors are collected with the field path and returned as comdef.Errors.
+//
+// Example:
+//
+//	type DB struct {
+//		Host string `default:"localhost"`
+//		Port int    `default:"3306"`
+//	}
+//
+//	func (d *DB) Init() error {
+//		if d.Port <= 0 {
+//			return errors.New("invalid port")
+//		}
+//		return nil
+//	}
+//
+//	type Config struct {
+//		Name string `default:"app"`
+//		DB   *DB    `default:""`
+//	}
+//
+//	cfg := &Config{}
+//	err := structs.InitAll(cfg)
+func InitAll(ptr any, optFns ...InitOptFunc) error {
+	rv := reflect.ValueOf(ptr)
+	if !reflects.IsValidPtr(rv) {
+		return errors.New("must be provider an pointer value")
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return errors.New("must be provider an struct value")
+	}
+
+	opt := &InitOptions{TagName: defaultInitTag, EnvPrefixTagName: defaultEnvPrefixTag}
+	for _, fn := range optFns {
+		fn(opt)
+	}
+
+	w := &initWalker{opt: opt, visited: make(map[uintptr]bool)}
+	w.walkStruct(rv, rv.Type().Name(), "", "")
+	return w.errs.ErrOrNil()
+}
+
+type initWalker struct {
+	opt  *InitOptions
+	errs comdef.Errors
+	// visited struct pointers, avoid the circular reference
+	visited map[uintptr]bool
+}
+
+func (w *initWalker) addErr(path string, err error) {
+	w.errs = append(w.errs, fmt.Errorf("%s: %w", path, err))
+}
+
+// walk the fields of the addressable struct value, then call Init()
+func (w *initWalker) walkStruct(rv reflect.Value, name, parent, envPrefix string) {
+	if rv.CanAddr() {
+		addr := rv.Addr().Pointer()
+		if w.visited[addr] {
+			return
+		}
+		w.visited[addr] = true
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if IsUnexported(sf.Name) {
+			continue
+		}
+
+		val, hasTag := sf.Tag.Lookup(w.opt.TagName)
+		if val == "-" {
+			continue
+		}
+
+		prefixVar, _ := sf.Tag.Lookup(w.opt.EnvPrefixTagName)
+		path := joinFieldPath(parent, sf.Name)
+		w.walkField(rv.Field(i), val, hasTag, path, envPrefix+prefixVar, envPrefix)
+	}
+
+	if rv.CanAddr() && rv.Addr().Type().Implements(initializerType) {
+		if err := rv.Addr().Interface().(Initializer).Init(); err != nil {
+			if parent == "" {
+				parent = name
+			}
+			w.addErr(parent, err)
+		}
+	}
+}
+
+func (w *initWalker) walkField(fv reflect.Value, val string, hasTag bool, path, childPrefix, envPrefix string) {
+	switch fv.Kind() {
+	case reflect.Struct:
+		w.walkStruct(fv, "", path, childPrefix)
+		return
+	case reflect.Pointer:
+		if fv.IsNil() {
+			// only create the nil pointer on has default tag
+			if !hasTag || !fv.CanSet() {
+				return
+			}
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+
+		if ev := fv.Elem(); ev.Kind() == reflect.Struct {
+			w.walkStruct(ev, "", path, childPrefix)
+		} else if hasTag && ev.IsZero() {
+			w.setDefault(ev, val, path, envPrefix)
+		}
+		return
+	case reflect.Slice, reflect.Array:
+		if isStructOrPtr(fv.Type().Elem()) {
+			for i := 0; i < fv.Len(); i++ {
+				w.walkElem(fv.Index(i), fmt.Sprintf("%s[%d]", path, i), childPrefix)
+			}
+			return
+		}
+	case reflect.Map:
+		if fv.Type().Elem().Kind() == reflect.Pointer && isStructOrPtr(fv.Type().Elem()) {
+			iter := fv.MapRange()
+			for iter.Next() {
+				w.walkElem(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), childPrefix)
+			}
+			return
+		}
+	}
+
+	if hasTag && fv.IsZero() {
+		w.setDefault(fv, val, path, envPrefix)
+	}
+}
+
+func (w *initWalker) walkElem(ev reflect.Value, path, envPrefix string) {
+	if ev.Kind() == reflect.Pointer {
+		if ev.IsNil() {
+			return
+		}
+		ev = ev.Elem()
+	}
+	w.walkStruct(ev, "", path, envPrefix)
+}
+
+func (w *initWalker) setDefault(fv reflect.Value, val, path, envPrefix string) {
+	if err := initDefaultValue(fv, val, w.opt.ParseEnv, envPrefix); err != nil {
+		w.addErr(path, err)
+	}
+}
+
+func isStructOrPtr(rt reflect.Type) bool {
+	if rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	return rt.Kind() == reflect.Struct
+}
+
+func joinFieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
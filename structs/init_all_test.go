@@ -0,0 +1,119 @@
+package structs_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/gookit/goutil/comdef"
+	"github.com/gookit/goutil/structs"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+type initDB struct {
+	Host  string `default:"localhost"`
+	Port  int    `default:"3306"`
+	DSN   string
+	calls int
+}
+
+func (d *initDB) Init() error {
+	d.calls++
+	if d.Port <= 0 {
+		return errors.New("invalid port")
+	}
+	d.DSN = d.Host + ":" + strconv.Itoa(d.Port)
+	return nil
+}
+
+type initPlugin struct {
+	Name    string `default:"plugin"`
+	Enabled bool   `default:"true"`
+}
+
+type initConfig struct {
+	Name    string   `default:"app"`
+	Debug   *bool    `default:"true"`
+	Tags    []string `default:"a,b"`
+	DB      *initDB  `default:""`
+	Cache   initDB
+	Backup  *initDB
+	Plugins []*initPlugin
+	Named   map[string]*initPlugin
+	Skip    initDB `default:"-"`
+	ready   bool
+}
+
+func (c *initConfig) Init() error {
+	// nested structs are initialized first
+	c.ready = c.DB != nil && c.DB.DSN != ""
+	return nil
+}
+
+func TestInitAll(t *testing.T) {
+	cfg := &initConfig{
+		Cache:   initDB{Port: 6379},
+		Plugins: []*initPlugin{{Name: "p1"}, nil},
+		Named:   map[string]*initPlugin{"p2": {}},
+	}
+
+	err := structs.InitAll(cfg)
+	assert.NoErr(t, err)
+	assert.Eq(t, "app", cfg.Name)
+	assert.True(t, *cfg.Debug)
+	assert.Eq(t, []string{"a", "b"}, cfg.Tags)
+	assert.True(t, cfg.ready)
+
+	assert.Eq(t, "localhost:3306", cfg.DB.DSN)
+	assert.Eq(t, 1, cfg.DB.calls)
+	assert.Eq(t, "localhost:6379", cfg.Cache.DSN)
+	assert.Nil(t, cfg.Backup)
+	assert.Eq(t, "", cfg.Skip.Host)
+	assert.Eq(t, 0, cfg.Skip.calls)
+
+	assert.Eq(t, "p1", cfg.Plugins[0].Name)
+	assert.True(t, cfg.Plugins[0].Enabled)
+	assert.Eq(t, "plugin", cfg.Named["p2"].Name)
+}
+
+func TestInitAll_errors(t *testing.T) {
+	type badConf struct {
+		Age  int `default:"abc"`
+		DB   initDB
+		List []initDB
+	}
+
+	c := &badConf{
+		DB:   initDB{Port: -1},
+		List: []initDB{{Port: -2}},
+	}
+	err := structs.InitAll(c)
+	assert.Err(t, err)
+
+	var es comdef.Errors
+	assert.True(t, errors.As(err, &es))
+	assert.Len(t, es, 3)
+	assert.StrContains(t, es[0].Error(), "Age: ")
+	assert.Eq(t, "DB: invalid port", es[1].Error())
+	assert.Eq(t, "List[0]: invalid port", es[2].Error())
+
+	// root Init error
+	db := &initDB{Port: -1}
+	assert.ErrMsg(t, structs.InitAll(db), "initDB: invalid port\n")
+
+	assert.ErrMsg(t, structs.InitAll(nil), "must be provider an pointer value")
+	assert.ErrMsg(t, structs.InitAll(&[]int{1}), "must be provider an struct value")
+}
+
+type initNode struct {
+	Name string `default:"node"`
+	Next *initNode
+}
+
+func TestInitAll_circular(t *testing.T) {
+	n := &initNode{}
+	n.Next = n
+
+	assert.NoErr(t, structs.InitAll(n))
+	assert.Eq(t, "node", n.Name)
+}
@@ -126,7 +126,7 @@ func (v *Value) SplitToInts(sep ...string) (ss []int) {
 	}
 
 	if str, ok := v.V.(string); ok {
-		ints, err := arrutil.StringsToInts(strutil.Split(str, sepStr(sep)))
+		ints, err := arrutil.ToInts(strutil.Split(str, sepStr(sep)))
 		if err == nil {
 			return ints
 		}
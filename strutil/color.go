@@ -0,0 +1,46 @@
+package strutil
+
+import "hash/fnv"
+
+// the RGB levels of the 6x6x6 color cube in 256-color table
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// colorPalette the 256-color indexes with good contrast on both dark and light background.
+var colorPalette = buildColorPalette()
+
+// build from the 6x6x6 color cube(16-231), exclude the gray, too dark and too bright colors.
+func buildColorPalette() []uint8 {
+	ls := make([]uint8, 0, 160)
+	for i := 16; i < 232; i++ {
+		n := i - 16
+		r, g, b := cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+		if r == g && g == b {
+			continue
+		}
+
+		// perceived brightness, range: 0-255
+		lum := (299*r + 587*g + 114*b) / 1000
+		if lum >= 70 && lum <= 190 {
+			ls = append(ls, uint8(i))
+		}
+	}
+	return ls
+}
+
+// ColorPalette get the 256-color indexes used by ColorForString.
+func ColorPalette() []uint8 {
+	return append([]uint8(nil), colorPalette...)
+}
+
+// ColorForString get a stable 256-color index for the string by hash.
+// The same string always get the same color across runs, the low-contrast colors are avoided.
+//
+// Useful for coloring the log prefixes, table categories. eg:
+//
+//	idx := strutil.ColorForString("worker-1")
+//	fmt.Printf("\x1b[38;5;%dm%s\x1b[0m\n", idx, "worker-1")
+func ColorForString(s string) uint8 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return colorPalette[h.Sum32()%uint32(len(colorPalette))]
+}
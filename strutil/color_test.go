@@ -0,0 +1,34 @@
+package strutil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestColorForString(t *testing.T) {
+	palette := strutil.ColorPalette()
+	assert.NotEmpty(t, palette)
+
+	for _, idx := range palette {
+		assert.True(t, idx >= 16 && idx < 232)
+	}
+	// exclude black, white and grays
+	assert.False(t, arrutil.Contains(palette, uint8(16)))
+	assert.False(t, arrutil.Contains(palette, uint8(231)))
+	assert.False(t, arrutil.Contains(palette, uint8(59)))
+
+	c1 := strutil.ColorForString("worker-1")
+	assert.Eq(t, c1, strutil.ColorForString("worker-1"))
+	assert.True(t, arrutil.Contains(palette, c1))
+
+	colors := make(map[uint8]bool)
+	for _, s := range []string{"", "a", "b", "api", "db", "cache", "worker-1", "worker-2"} {
+		c := strutil.ColorForString(s)
+		assert.True(t, arrutil.Contains(palette, c))
+		colors[c] = true
+	}
+	assert.Gt(t, len(colors), 1)
+}
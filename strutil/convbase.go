@@ -1,6 +1,8 @@
 package strutil
 
 import (
+	"errors"
+	"math"
 	"strconv"
 	"strings"
 
@@ -78,3 +80,115 @@ func BaseConvByTpl(src string, fromBase, toBase string) string {
 	}
 	return res
 }
+
+//
+// -------------------- integer codec --------------------
+//
+
+var (
+	// ErrInvalidAlphabet alphabet for IntCodec is invalid
+	ErrInvalidAlphabet = errors.New("alphabet must be at least 2 unique ASCII chars")
+	// ErrInvalidEncoded the encoded string is invalid for decode
+	ErrInvalidEncoded = errors.New("invalid encoded string")
+)
+
+// base62 codec instance
+var base62Codec = MustIntCodec(Base62Chars)
+
+// EncodeBase62 encode uint64 to base62 string. eg: 123 -> "1Z"
+func EncodeBase62(n uint64) string {
+	return base62Codec.Encode(n)
+}
+
+// DecodeBase62 decode the base62 string to uint64. eg: "1Z" -> 123
+func DecodeBase62(s string) (uint64, error) {
+	return base62Codec.Decode(s)
+}
+
+// IntCodec encode and decode uint64 by custom alphabet.
+//
+// The encoding is reversible without collision: each number has only one encoded string,
+// the non-canonical strings(eg: with leading zero char) will be rejected on decode.
+type IntCodec struct {
+	alphabet string
+	// index of the char in alphabet, -1 is not exists
+	index [256]int16
+}
+
+// NewIntCodec create a new IntCodec with alphabet.
+//
+// Usage:
+//
+//	codec, err := NewIntCodec("23456789abcdefghjkmnpqrstuvwxyz") // no confused chars
+//	id := codec.Encode(123456)
+func NewIntCodec(alphabet string) (*IntCodec, error) {
+	if len(alphabet) < 2 {
+		return nil, ErrInvalidAlphabet
+	}
+
+	c := &IntCodec{alphabet: alphabet}
+	for i := range c.index {
+		c.index[i] = -1
+	}
+
+	for i := 0; i < len(alphabet); i++ {
+		ch := alphabet[i]
+		if ch >= 0x80 || c.index[ch] >= 0 {
+			return nil, ErrInvalidAlphabet
+		}
+		c.index[ch] = int16(i)
+	}
+	return c, nil
+}
+
+// MustIntCodec create a new IntCodec, will panic on error
+func MustIntCodec(alphabet string) *IntCodec {
+	c, err := NewIntCodec(alphabet)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Alphabet get the alphabet of the codec
+func (c *IntCodec) Alphabet() string { return c.alphabet }
+
+// Encode the uint64 number to string
+func (c *IntCodec) Encode(n uint64) string {
+	if n == 0 {
+		return c.alphabet[:1]
+	}
+
+	var buf [64]byte
+	i := len(buf)
+	base := uint64(len(c.alphabet))
+	for n > 0 {
+		i--
+		buf[i] = c.alphabet[n%base]
+		n /= base
+	}
+	return string(buf[i:])
+}
+
+// Decode the encoded string to uint64 number
+func (c *IntCodec) Decode(s string) (uint64, error) {
+	if s == "" || (len(s) > 1 && s[0] == c.alphabet[0]) {
+		return 0, ErrInvalidEncoded
+	}
+
+	var n uint64
+	base := uint64(len(c.alphabet))
+	for i := 0; i < len(s); i++ {
+		idx := c.index[s[i]]
+		if idx < 0 {
+			return 0, ErrInvalidEncoded
+		}
+
+		// check overflow
+		if n > (math.MaxUint64-uint64(idx))/base {
+			return 0, ErrInvalidEncoded
+		}
+		n = n*base + uint64(idx)
+	}
+	return n, nil
+}
@@ -1,6 +1,7 @@
 package strutil_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/gookit/goutil/strutil"
@@ -77,3 +78,50 @@ func TestBase10Conv(t *testing.T) {
 		})
 	})
 }
+
+func TestEncodeBase62(t *testing.T) {
+	assert.Eq(t, "0", strutil.EncodeBase62(0))
+	assert.Eq(t, "1Z", strutil.EncodeBase62(123))
+	assert.Eq(t, "lYGhA16ahyf", strutil.EncodeBase62(math.MaxUint64))
+
+	for _, n := range []uint64{0, 1, 61, 62, 123, 1<<32 + 7, math.MaxUint64} {
+		n2, err := strutil.DecodeBase62(strutil.EncodeBase62(n))
+		assert.NoErr(t, err)
+		assert.Eq(t, n, n2)
+	}
+
+	n, err := strutil.DecodeBase62("1Z")
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(123), n)
+
+	// invalid
+	for _, s := range []string{"", "01Z", "1-Z", "lYGhA16ahyg", "zzzzzzzzzzzz"} {
+		_, err = strutil.DecodeBase62(s)
+		assert.ErrIs(t, err, strutil.ErrInvalidEncoded)
+	}
+}
+
+func TestNewIntCodec(t *testing.T) {
+	c, err := strutil.NewIntCodec("ab")
+	assert.NoErr(t, err)
+	assert.Eq(t, "ab", c.Alphabet())
+	assert.Eq(t, "baa", c.Encode(4))
+	n, err := c.Decode("baa")
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(4), n)
+
+	c = strutil.MustIntCodec("23456789abcdefghjkmnpqrstuvwxyz")
+	for _, n := range []uint64{0, 30, 31, 99999, math.MaxUint64} {
+		n2, err := c.Decode(c.Encode(n))
+		assert.NoErr(t, err)
+		assert.Eq(t, n, n2)
+	}
+
+	for _, s := range []string{"", "a", "aa", "中文"} {
+		_, err = strutil.NewIntCodec(s)
+		assert.ErrIs(t, err, strutil.ErrInvalidAlphabet)
+	}
+	assert.Panics(t, func() {
+		strutil.MustIntCodec("x")
+	})
+}
@@ -0,0 +1,106 @@
+package strutil
+
+import "strings"
+
+// homoglyphs the common confusable chars which look like the ASCII letters.
+var homoglyphs = map[rune]rune{
+	// Cyrillic lower
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c',
+	'у': 'y', 'х': 'x', 'ѕ': 's', 'і': 'i', 'ј': 'j',
+	'һ': 'h', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	// Cyrillic upper
+	'Ѕ': 'S', 'І': 'I', 'Ј': 'J', 'А': 'A', 'В': 'B',
+	'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O',
+	'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'Ү': 'Y',
+	// Greek
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H',
+	'Ι': 'I', 'Κ': 'K', 'Μ': 'M', 'Ν': 'N', 'Ο': 'O',
+	'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	'ο': 'o', 'ν': 'v',
+	// Latin
+	'ı': 'i', 'ɡ': 'g',
+	// space
+	'\u00a0': ' ', '\u2007': ' ', '\u202f': ' ', '\u3000': ' ',
+}
+
+// IsHomoglyph check the rune is a confusable char which looks like the ASCII char.
+// eg: Cyrillic 'а'(U+0430), fullwidth 'Ａ'(U+FF21)
+func IsHomoglyph(r rune) bool {
+	_, ok := homoglyphRune(r)
+	return ok
+}
+
+func homoglyphRune(r rune) (rune, bool) {
+	// fullwidth ASCII variants: U+FF01 - U+FF5E
+	if r >= '！' && r <= '～' {
+		return r - 0xFEE0, true
+	}
+
+	to, ok := homoglyphs[r]
+	return to, ok
+}
+
+// NormalizeHomoglyphs replace the confusable chars to the ASCII chars they look like.
+// useful for compare or validate the user-supplied identifiers.
+//
+// TIP: it does not remove the invisible chars, can use RemoveInvisibleChars() before it.
+//
+// Usage:
+//
+//	// "pаypаl" with Cyrillic 'а'
+//	strutil.NormalizeHomoglyphs("pаypаl") // Output: "paypal"
+//	strutil.NormalizeHomoglyphs("ａｄｍｉｎ") // Output: "admin"
+func NormalizeHomoglyphs(s string) string {
+	return strings.Map(func(r rune) rune {
+		if to, ok := homoglyphRune(r); ok {
+			return to
+		}
+		return r
+	}, s)
+}
+
+// HasHomoglyphs check the string contains confusable chars. see IsHomoglyph()
+func HasHomoglyphs(s string) bool {
+	return strings.IndexFunc(s, IsHomoglyph) >= 0
+}
+
+// IsInvisibleRune check the rune is an invisible format char,
+// eg: zero-width chars, bidi control chars, BOM.
+func IsInvisibleRune(r rune) bool {
+	switch {
+	case r == '\u00ad', // soft hyphen
+		r == '\u061c',                  // arabic letter mark
+		r == '\u180e',                  // mongolian vowel separator
+		r >= '\u200b' && r <= '\u200f', // zero-width space, ZWNJ, ZWJ, LRM, RLM
+		r >= '\u202a' && r <= '\u202e', // bidi embedding and override
+		r >= '\u2060' && r <= '\u2064', // word joiner and invisible operators
+		r >= '\u2066' && r <= '\u2069', // bidi isolate
+		r == '\ufeff':                  // zero-width no-break space, BOM
+		return true
+	}
+	return false
+}
+
+// HasInvisibleChars check the string contains invisible chars. see IsInvisibleRune()
+//
+// Usage:
+//
+//	strutil.HasInvisibleChars("admin\u200b") // true
+//	strutil.HasInvisibleChars("file\u202etxt.exe") // true, bidi override
+func HasInvisibleChars(s string) bool {
+	return strings.IndexFunc(s, IsInvisibleRune) >= 0
+}
+
+// RemoveInvisibleChars remove all invisible chars from the string. see IsInvisibleRune()
+func RemoveInvisibleChars(s string) string {
+	if !HasInvisibleChars(s) {
+		return s
+	}
+
+	return strings.Map(func(r rune) rune {
+		if IsInvisibleRune(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,57 @@
+package strutil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestNormalizeHomoglyphs(t *testing.T) {
+	// Cyrillic 'а', 'е', 'о'
+	assert.Eq(t, "paypal", strutil.NormalizeHomoglyphs("pаypаl"))
+	assert.Eq(t, "google", strutil.NormalizeHomoglyphs("gооglе"))
+	// Greek upper
+	assert.Eq(t, "ABC", strutil.NormalizeHomoglyphs("ΑΒC"))
+	// fullwidth
+	assert.Eq(t, "admin!", strutil.NormalizeHomoglyphs("ａｄｍｉｎ！"))
+	assert.Eq(t, "a b", strutil.NormalizeHomoglyphs("a\u3000b"))
+	// not changed
+	assert.Eq(t, "hello, 世界", strutil.NormalizeHomoglyphs("hello, 世界"))
+	assert.Eq(t, "", strutil.NormalizeHomoglyphs(""))
+
+	assert.True(t, strutil.IsHomoglyph('а'))
+	assert.True(t, strutil.IsHomoglyph('Ｚ'))
+	assert.False(t, strutil.IsHomoglyph('a'))
+	assert.False(t, strutil.IsHomoglyph('世'))
+
+	assert.True(t, strutil.HasHomoglyphs("pаypal"))
+	assert.False(t, strutil.HasHomoglyphs("paypal"))
+}
+
+func TestHasInvisibleChars(t *testing.T) {
+	tests := []string{
+		"admin\u200b",
+		"ad\u200dmin",
+		"\ufeffadmin",
+		"file\u202etxt.exe",
+		"a\u2066b\u2069",
+		"soft\u00adhyphen",
+	}
+	for _, s := range tests {
+		assert.True(t, strutil.HasInvisibleChars(s), s)
+		assert.False(t, strutil.HasInvisibleChars(strutil.RemoveInvisibleChars(s)))
+	}
+
+	assert.False(t, strutil.HasInvisibleChars("admin"))
+	assert.False(t, strutil.HasInvisibleChars("hello 世界\t\n"))
+	assert.False(t, strutil.HasInvisibleChars(""))
+
+	assert.Eq(t, "filetxt.exe", strutil.RemoveInvisibleChars("file\u202etxt.exe"))
+	assert.Eq(t, "admin", strutil.RemoveInvisibleChars("ad\u200bmi\u200cn"))
+	assert.Eq(t, "admin", strutil.RemoveInvisibleChars("admin"))
+
+	// combine for validate the identifiers
+	s := strutil.NormalizeHomoglyphs(strutil.RemoveInvisibleChars("аd\u200bmin"))
+	assert.Eq(t, "admin", s)
+}
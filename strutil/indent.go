@@ -0,0 +1,175 @@
+package strutil
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IndentStyle the indentation style of text
+type IndentStyle struct {
+	// Tab use tab for indent
+	Tab bool
+	// Size number of spaces for one indent level. it's 1 on Tab is true.
+	Size int
+}
+
+// TabIndent the tab indentation style
+var TabIndent = IndentStyle{Tab: true, Size: 1}
+
+// SpaceIndent create a spaces indentation style
+func SpaceIndent(size int) IndentStyle {
+	return IndentStyle{Size: size}
+}
+
+// IsZero check the style is not detected
+func (is IndentStyle) IsZero() bool { return !is.Tab && is.Size == 0 }
+
+// Unit get the indent string of one level. eg: "\t", "    "
+func (is IndentStyle) Unit() string {
+	if is.Tab {
+		return "\t"
+	}
+	return strings.Repeat(" ", is.Size)
+}
+
+// String get the style description. eg: "tab", "4 spaces"
+func (is IndentStyle) String() string {
+	if is.Tab {
+		return "tab"
+	}
+	if is.Size == 0 {
+		return "none"
+	}
+	return strconv.Itoa(is.Size) + " spaces"
+}
+
+// DetectIndent detect the indentation style of the multi line text.
+// will return zero IndentStyle if no indented lines.
+//
+// Usage:
+//
+//	is := strutil.DetectIndent("a:\n  b: 1\n  c:\n    d: 2")
+//	fmt.Println(is) // Output: 2 spaces
+func DetectIndent(s string) IndentStyle {
+	var tabs, spaces, prev int
+	// counts of the spaces indent change between lines
+	deltas := make(map[int]int)
+
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		switch line[0] {
+		case '\t':
+			tabs++
+			continue
+		case ' ':
+			spaces++
+		}
+
+		n := len(line) - len(strings.TrimLeft(line, " "))
+		if delta := n - prev; delta > 0 {
+			deltas[delta]++
+		} else if delta < 0 {
+			deltas[-delta]++
+		}
+		prev = n
+	}
+
+	if tabs == 0 && spaces == 0 {
+		return IndentStyle{}
+	}
+	if tabs > spaces {
+		return TabIndent
+	}
+
+	// use the most used indent change, prefer smaller on same count
+	var size, max int
+	for delta, cnt := range deltas {
+		if cnt > max || (cnt == max && delta < size) {
+			size, max = delta, cnt
+		}
+	}
+	return IndentStyle{Size: size}
+}
+
+// Reindent convert the leading indentation of each line from the style to another style.
+// The remaining spaces less than one level are kept.
+//
+// Usage:
+//
+//	// convert 4 spaces to tab
+//	s = strutil.Reindent(s, strutil.SpaceIndent(4), strutil.TabIndent)
+//	// detect and convert to 2 spaces
+//	s = strutil.Reindent(s, strutil.DetectIndent(s), strutil.SpaceIndent(2))
+func Reindent(s string, from, to IndentStyle) string {
+	if from.IsZero() || from == to {
+		return s
+	}
+
+	unit := to.Unit()
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		var level, cut int
+		if from.Tab {
+			level = len(line) - len(strings.TrimLeft(line, "\t"))
+			cut = level
+		} else {
+			level = (len(line) - len(strings.TrimLeft(line, " "))) / from.Size
+			cut = level * from.Size
+		}
+
+		if level > 0 {
+			lines[i] = strings.Repeat(unit, level) + line[cut:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Dedent remove the common leading whitespace from each line of the text,
+// the whitespace only lines are ignored on calc and be normalized to empty line.
+// useful for the heredoc-style literals in code.
+//
+// Usage:
+//
+//	s := strutil.Dedent(`
+//		name: inhere
+//		info:
+//		  age: 23
+//	`)
+//	// Output: "\nname: inhere\ninfo:\n  age: 23\n"
+func Dedent(s string) string {
+	lines := strings.Split(s, "\n")
+
+	var margin string
+	var found bool
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if !found {
+			margin, found = indent, true
+			continue
+		}
+
+		// get the common prefix
+		j := 0
+		for j < len(margin) && j < len(indent) && margin[j] == indent[j] {
+			j++
+		}
+		margin = margin[:j]
+	}
+
+	if margin == "" {
+		return strings.Join(lines, "\n")
+	}
+
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, margin)
+	}
+	return strings.Join(lines, "\n")
+}
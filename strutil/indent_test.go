@@ -0,0 +1,53 @@
+package strutil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestDetectIndent(t *testing.T) {
+	is := strutil.DetectIndent("a:\n  b: 1\n  c:\n    d: 2\n\n  e: 3")
+	assert.Eq(t, strutil.SpaceIndent(2), is)
+	assert.Eq(t, "2 spaces", is.String())
+	assert.Eq(t, "  ", is.Unit())
+
+	is = strutil.DetectIndent("func() {\n    if ok {\n        return\n    }\n}")
+	assert.Eq(t, 4, is.Size)
+
+	is = strutil.DetectIndent("func() {\n\tif ok {\n\t\treturn\n\t}\n}")
+	assert.Eq(t, strutil.TabIndent, is)
+	assert.Eq(t, "tab", is.String())
+	assert.Eq(t, "\t", is.Unit())
+
+	is = strutil.DetectIndent("a\nb\n")
+	assert.True(t, is.IsZero())
+	assert.Eq(t, "none", is.String())
+}
+
+func TestReindent(t *testing.T) {
+	src := "a:\n    b: 1\n    c:\n        d: 2\n      e: 3"
+	assert.Eq(t, "a:\n  b: 1\n  c:\n    d: 2\n    e: 3", strutil.Reindent(src, strutil.SpaceIndent(4), strutil.SpaceIndent(2)))
+	assert.Eq(t, "a:\n\tb: 1\n\tc:\n\t\td: 2\n\t  e: 3", strutil.Reindent(src, strutil.DetectIndent(src), strutil.TabIndent))
+
+	src = "if ok {\n\treturn\n}"
+	assert.Eq(t, "if ok {\n    return\n}", strutil.Reindent(src, strutil.TabIndent, strutil.SpaceIndent(4)))
+	assert.Eq(t, src, strutil.Reindent(src, strutil.IndentStyle{}, strutil.TabIndent))
+	assert.Eq(t, src, strutil.Reindent(src, strutil.TabIndent, strutil.TabIndent))
+}
+
+func TestDedent(t *testing.T) {
+	s := strutil.Dedent(`
+		name: inhere
+		info:
+		  age: 23
+	`)
+	assert.Eq(t, "\nname: inhere\ninfo:\n  age: 23\n", s)
+
+	assert.Eq(t, "a\n  b\n\nc", strutil.Dedent("  a\n    b\n \n  c"))
+	assert.Eq(t, "a\n b", strutil.Dedent("a\n b"))
+	// mixed tab and spaces, no common prefix
+	assert.Eq(t, "\ta\n  b", strutil.Dedent("\ta\n  b"))
+	assert.Eq(t, "", strutil.Dedent(""))
+}
@@ -0,0 +1,192 @@
+package strutil
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/goutil/comdef"
+)
+
+/*************************************************************
+ * numeric string format
+ *************************************************************/
+
+// PadNumber pad the integer with leading zeros to the width.
+//
+// Usage:
+//
+//	PadNumber(7, 3)  // "007"
+//	PadNumber(-7, 3) // "-07"
+func PadNumber[T comdef.Integer](n T, width int) string {
+	s := strconv.FormatInt(int64(n), 10)
+	if n > 0 && uint64(n) > 1<<63-1 {
+		s = strconv.FormatUint(uint64(n), 10)
+	}
+
+	if len(s) >= width {
+		return s
+	}
+
+	if s[0] == '-' {
+		return "-" + strings.Repeat("0", width-len(s)) + s[1:]
+	}
+	return strings.Repeat("0", width-len(s)) + s
+}
+
+// Thousands format the integer with thousands separator. default sep is ","
+//
+// Usage:
+//
+//	Thousands(1234567, ",") // "1,234,567"
+//	Thousands(-1234, "_")   // "-1_234"
+func Thousands[T comdef.Integer](n T, sep string) string {
+	s := strconv.FormatInt(int64(n), 10)
+	if n > 0 && uint64(n) > 1<<63-1 {
+		s = strconv.FormatUint(uint64(n), 10)
+	}
+	return ThousandsString(s, sep)
+}
+
+// ThousandsString format the numeric string with thousands separator. default sep is ","
+//
+// Usage:
+//
+//	ThousandsString("1234567.891", ",") // "1,234,567.891"
+func ThousandsString(numStr, sep string) string {
+	if sep == "" {
+		sep = ","
+	}
+
+	var sign string
+	if len(numStr) > 0 && (numStr[0] == '-' || numStr[0] == '+') {
+		sign, numStr = numStr[:1], numStr[1:]
+	}
+
+	intPart, decPart := numStr, ""
+	if idx := strings.IndexByte(numStr, '.'); idx >= 0 {
+		intPart, decPart = numStr[:idx], numStr[idx:]
+	}
+
+	ln := len(intPart)
+	if ln <= 3 {
+		return sign + numStr
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(numStr) + len(sign) + ln/3*len(sep))
+	sb.WriteString(sign)
+
+	first := ln % 3
+	if first == 0 {
+		first = 3
+	}
+	sb.WriteString(intPart[:first])
+
+	for i := first; i < ln; i += 3 {
+		sb.WriteString(sep)
+		sb.WriteString(intPart[i : i+3])
+	}
+
+	sb.WriteString(decPart)
+	return sb.String()
+}
+
+// OrdinalSuffix get the english ordinal suffix of the number.
+//
+// Usage:
+//
+//	OrdinalSuffix(1)  // "st"
+//	OrdinalSuffix(12) // "th"
+//	OrdinalSuffix(23) // "rd"
+func OrdinalSuffix(n int) string {
+	if n < 0 {
+		n = -n
+	}
+
+	switch n % 100 {
+	case 11, 12, 13:
+		return "th"
+	}
+
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// Ordinal get the number with english ordinal suffix. eg: 1 -> "1st", 22 -> "22nd"
+func Ordinal(n int) string {
+	return strconv.Itoa(n) + OrdinalSuffix(n)
+}
+
+/*************************************************************
+ * roman numerals
+ *************************************************************/
+
+var romanNumerals = []struct {
+	val int
+	sym string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// ErrInvalidRoman invalid roman numeral string or number is out of range
+var ErrInvalidRoman = errors.New("invalid roman numeral, allowed range is 1-3999")
+
+// ToRoman convert integer to roman numeral string. allowed range is 1-3999
+//
+// Usage:
+//
+//	ToRoman(1994) // "MCMXCIV", nil
+func ToRoman(n int) (string, error) {
+	if n < 1 || n > 3999 {
+		return "", ErrInvalidRoman
+	}
+
+	var sb strings.Builder
+	for _, rn := range romanNumerals {
+		for n >= rn.val {
+			sb.WriteString(rn.sym)
+			n -= rn.val
+		}
+	}
+	return sb.String(), nil
+}
+
+// FromRoman parse the roman numeral string to integer. input is case-insensitive.
+//
+// Usage:
+//
+//	FromRoman("MCMXCIV") // 1994, nil
+func FromRoman(s string) (int, error) {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	if upper == "" {
+		return 0, ErrInvalidRoman
+	}
+
+	var n int
+	rest := upper
+	for _, rn := range romanNumerals {
+		for strings.HasPrefix(rest, rn.sym) {
+			n += rn.val
+			rest = rest[len(rn.sym):]
+		}
+	}
+
+	// check is canonical form. eg: "IIII", "VX" is invalid
+	if rest != "" || n > 3999 {
+		return 0, ErrInvalidRoman
+	}
+	if canonical, _ := ToRoman(n); canonical != upper {
+		return 0, ErrInvalidRoman
+	}
+	return n, nil
+}
@@ -0,0 +1,69 @@
+package strutil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestPadNumber(t *testing.T) {
+	assert.Eq(t, "007", strutil.PadNumber(7, 3))
+	assert.Eq(t, "-07", strutil.PadNumber(-7, 3))
+	assert.Eq(t, "1234", strutil.PadNumber(1234, 3))
+	assert.Eq(t, "00042", strutil.PadNumber(uint8(42), 5))
+	assert.Eq(t, "18446744073709551615", strutil.PadNumber(uint64(18446744073709551615), 5))
+}
+
+func TestThousands(t *testing.T) {
+	assert.Eq(t, "1,234,567", strutil.Thousands(1234567, ","))
+	assert.Eq(t, "-1_234", strutil.Thousands(-1234, "_"))
+	assert.Eq(t, "123", strutil.Thousands(123, ","))
+	assert.Eq(t, "123,456", strutil.Thousands(uint32(123456), ""))
+
+	assert.Eq(t, "1,234,567.891", strutil.ThousandsString("1234567.891", ","))
+	assert.Eq(t, "+12,345", strutil.ThousandsString("+12345", ","))
+	assert.Eq(t, "-123.45", strutil.ThousandsString("-123.45", ","))
+}
+
+func TestOrdinalSuffix(t *testing.T) {
+	tests := map[int]string{
+		1: "st", 2: "nd", 3: "rd", 4: "th", 11: "th", 12: "th", 13: "th",
+		21: "st", 22: "nd", 23: "rd", 101: "st", 111: "th", 0: "th", -2: "nd",
+	}
+	for n, want := range tests {
+		assert.Eq(t, want, strutil.OrdinalSuffix(n))
+	}
+
+	assert.Eq(t, "22nd", strutil.Ordinal(22))
+}
+
+func TestToRoman(t *testing.T) {
+	tests := map[int]string{
+		1: "I", 4: "IV", 9: "IX", 14: "XIV", 40: "XL", 90: "XC",
+		400: "CD", 1994: "MCMXCIV", 2024: "MMXXIV", 3999: "MMMCMXCIX",
+	}
+	for n, want := range tests {
+		s, err := strutil.ToRoman(n)
+		assert.NoErr(t, err)
+		assert.Eq(t, want, s)
+
+		v, err := strutil.FromRoman(want)
+		assert.NoErr(t, err)
+		assert.Eq(t, n, v)
+	}
+
+	_, err := strutil.ToRoman(0)
+	assert.ErrIs(t, err, strutil.ErrInvalidRoman)
+	_, err = strutil.ToRoman(4000)
+	assert.Err(t, err)
+
+	v, err := strutil.FromRoman(" mcmxciv ")
+	assert.NoErr(t, err)
+	assert.Eq(t, 1994, v)
+
+	for _, s := range []string{"", "IIII", "VX", "IC", "ABC", "MMMM"} {
+		_, err = strutil.FromRoman(s)
+		assert.Err(t, err, "input: %s", s)
+	}
+}
@@ -229,3 +229,186 @@ func ToByteSize(sizeStr string) (uint64, error) {
 	}
 	return uint64(size * multiplier), nil
 }
+
+// DupKeyPolicy the policy for duplicate keys on parse KV pairs
+type DupKeyPolicy uint8
+
+const (
+	// DupKeyOverwrite the later value overwrite the earlier one
+	DupKeyOverwrite DupKeyPolicy = iota
+	// DupKeyKeepFirst keep the first value, ignore the later ones
+	DupKeyKeepFirst
+	// DupKeyError return ErrDupKVKey on duplicate key
+	DupKeyError
+)
+
+var (
+	// ErrKVPairSyntax invalid KV pair syntax. eg: missing "=", empty key, unterminated quote
+	ErrKVPairSyntax = errors.New("invalid key=value pair syntax")
+	// ErrDupKVKey duplicate key in the KV pairs
+	ErrDupKVKey = errors.New("duplicate key in key=value pairs")
+)
+
+// KVPairsOpt parse KV pairs options
+type KVPairsOpt struct {
+	// Seps the pair separator chars. default is ",;"
+	Seps string
+	// KVSep the key and value separator. default is '='
+	KVSep rune
+	// DupKey the policy for duplicate keys. default is DupKeyOverwrite
+	DupKey DupKeyPolicy
+}
+
+// copy the opt and set the default values, the caller's opt is not changed.
+func ensureKVOpt(opt *KVPairsOpt) *KVPairsOpt {
+	if opt == nil {
+		opt = &KVPairsOpt{}
+	} else {
+		cp := *opt
+		opt = &cp
+	}
+
+	if opt.Seps == "" {
+		opt.Seps = ",;"
+	}
+	if opt.KVSep == 0 {
+		opt.KVSep = '='
+	}
+	return opt
+}
+
+// KVPair a parsed key=value pair
+type KVPair struct {
+	Key   string
+	Value string
+	// Quoted mark the value is quoted
+	Quoted bool
+}
+
+// ParseKVPairs parse the key=value pair list string to map. the seps are pair separator chars, default is ",;"
+//
+// The key or value can be quoted by double or single quote, the separators in quoted string will be kept.
+// In double quoted string, can use '\' to escape char.
+//
+// Usage:
+//
+//	mp, err := strutil.ParseKVPairs(`a=1,b=2;c="x,y"`)
+//	// mp: map[string]string{"a": "1", "b": "2", "c": "x,y"}
+func ParseKVPairs(s string, seps ...string) (map[string]string, error) {
+	return ParseKVPairsWith(s, &KVPairsOpt{Seps: strings.Join(seps, "")})
+}
+
+// ParseKVPairsWith parse the key=value pair list string to map with options
+func ParseKVPairsWith(s string, opt *KVPairsOpt) (map[string]string, error) {
+	pairs, err := SplitKVPairs(s, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	mp := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		mp[pair.Key] = pair.Value
+	}
+	return mp, nil
+}
+
+// SplitKVPairs split the key=value pair list string to pairs, will keep the order of keys.
+// the duplicate keys are handled by the opt.DupKey policy.
+func SplitKVPairs(s string, opt *KVPairsOpt) ([]KVPair, error) {
+	opt = ensureKVOpt(opt)
+
+	var pairs []KVPair
+	index := make(map[string]int)
+
+	var key, val strings.Builder
+	cur := &key
+	var inVal, keyQuoted, valQuoted, closed, escaped bool
+	var quote rune
+
+	addPair := func() error {
+		k, v := key.String(), val.String()
+		if !keyQuoted {
+			k = strings.TrimSpace(k)
+		}
+		if !valQuoted {
+			v = strings.TrimSpace(v)
+		}
+
+		// skip empty item. eg: "a=1,,b=2", "a=1,"
+		if !inVal && k == "" && !keyQuoted {
+			return nil
+		}
+		if !inVal || k == "" {
+			return ErrKVPairSyntax
+		}
+
+		if i, ok := index[k]; ok {
+			switch opt.DupKey {
+			case DupKeyError:
+				return ErrDupKVKey
+			case DupKeyOverwrite:
+				pairs[i].Value, pairs[i].Quoted = v, valQuoted
+			}
+			return nil
+		}
+
+		index[k] = len(pairs)
+		pairs = append(pairs, KVPair{Key: k, Value: v, Quoted: valQuoted})
+		return nil
+	}
+
+	for _, r := range s {
+		if quote != 0 {
+			switch {
+			case escaped:
+				cur.WriteRune(r)
+				escaped = false
+			case r == '\\' && quote == '"':
+				escaped = true
+			case r == quote:
+				quote, closed = 0, true
+			default:
+				cur.WriteRune(r)
+			}
+			continue
+		}
+
+		switch {
+		case strings.ContainsRune(opt.Seps, r):
+			if err := addPair(); err != nil {
+				return nil, err
+			}
+
+			key.Reset()
+			val.Reset()
+			cur = &key
+			inVal, keyQuoted, valQuoted, closed = false, false, false, false
+		case !inVal && r == opt.KVSep:
+			inVal, closed = true, false
+			cur = &val
+		case closed:
+			// only allow spaces after the quoted string
+			if !unicode.IsSpace(r) {
+				return nil, ErrKVPairSyntax
+			}
+		case (r == '"' || r == '\'') && strings.TrimSpace(cur.String()) == "":
+			cur.Reset()
+			quote = r
+			if inVal {
+				valQuoted = true
+			} else {
+				keyQuoted = true
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	if quote != 0 {
+		return nil, ErrKVPairSyntax
+	}
+	if err := addPair(); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
@@ -123,3 +123,58 @@ func TestParseSizeRange(t *testing.T) {
 	is.Equal(uint64(1024), min)
 	is.Equal(uint64(1024*1024), max)
 }
+
+func TestParseKVPairs(t *testing.T) {
+	mp, err := strutil.ParseKVPairs(`a=1,b = 2 ;c="x,y", d='x;y',e="say \"hi\"",f=,`)
+	assert.NoErr(t, err)
+	assert.Eq(t, map[string]string{
+		"a": "1",
+		"b": "2",
+		"c": "x,y",
+		"d": "x;y",
+		"e": `say "hi"`,
+		"f": "",
+	}, mp)
+
+	// custom seps
+	mp, err = strutil.ParseKVPairs("a=1 b=2\tc=3", " ", "\t")
+	assert.NoErr(t, err)
+	assert.Eq(t, map[string]string{"a": "1", "b": "2", "c": "3"}, mp)
+
+	// quoted key, value contains "="
+	mp, err = strutil.ParseKVPairs(`"a b"=x=y`)
+	assert.NoErr(t, err)
+	assert.Eq(t, map[string]string{"a b": "x=y"}, mp)
+
+	mp, err = strutil.ParseKVPairs("")
+	assert.NoErr(t, err)
+	assert.Empty(t, mp)
+
+	// invalid
+	for _, s := range []string{"a", "a=1,b", "=1", `a="1`, `a="1"x`} {
+		_, err = strutil.ParseKVPairs(s)
+		assert.ErrIs(t, err, strutil.ErrKVPairSyntax, s)
+	}
+}
+
+func TestSplitKVPairs_dupKey(t *testing.T) {
+	s := "a=1,b=2,a=3"
+	pairs, err := strutil.SplitKVPairs(s, nil)
+	assert.NoErr(t, err)
+	assert.Eq(t, []strutil.KVPair{{Key: "a", Value: "3"}, {Key: "b", Value: "2"}}, pairs)
+
+	opt := &strutil.KVPairsOpt{DupKey: strutil.DupKeyKeepFirst}
+	mp, err := strutil.ParseKVPairsWith(s, opt)
+	assert.NoErr(t, err)
+	assert.Eq(t, "1", mp["a"])
+	// the opt is not changed
+	assert.Eq(t, strutil.KVPairsOpt{DupKey: strutil.DupKeyKeepFirst}, *opt)
+
+	_, err = strutil.ParseKVPairsWith(s, &strutil.KVPairsOpt{DupKey: strutil.DupKeyError})
+	assert.ErrIs(t, err, strutil.ErrDupKVKey)
+
+	// custom KV sep
+	mp, err = strutil.ParseKVPairsWith("a:1|b:'x|y'", &strutil.KVPairsOpt{Seps: "|", KVSep: ':'})
+	assert.NoErr(t, err)
+	assert.Eq(t, map[string]string{"a": "1", "b": "x|y"}, mp)
+}
@@ -4,15 +4,13 @@ package strutil
 
 import (
 	"math"
-	"math/rand"
-	"time"
 	"unsafe"
+
+	"github.com/gookit/goutil/mathutil"
 )
 
 const MaximumCapacity = math.MaxInt>>1 + 1
 
-var rn = rand.NewSource(time.Now().UnixNano())
-
 // nearestPowerOfTwo 返回一个大于等于cap的最近的2的整数次幂，参考java8的hashmap的tableSizeFor函数
 //   - cap 输入参数
 //
@@ -64,10 +62,10 @@ func buildRandomString(letters string, length int) string {
 
 	// UnixNano: 1607400451937462000
 	// 循环生成随机字符串
-	for i, cache, remain := length-1, rn.Int63(), letterIdMax; i >= 0; {
+	for i, cache, remain := length-1, mathutil.RandInt63(), letterIdMax; i >= 0; {
 		// 检查随机数生成器是否用尽所有随机数
 		if remain == 0 {
-			cache, remain = rn.Int63(), letterIdMax
+			cache, remain = mathutil.RandInt63(), letterIdMax
 		}
 		// 从可用字符的字符串中随机选择一个字符
 		if idx := int(cache & letterIdMask); idx < strLength {
@@ -1,6 +1,10 @@
 package strutil
 
-import "strings"
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
 
 // BeforeFirst get substring before first sep.
 func BeforeFirst(s, sep string) string {
@@ -189,3 +193,118 @@ func FirstLine(output string) string {
 	}
 	return output
 }
+
+// ErrCSVQuote the quoted field of CSV line is invalid
+var ErrCSVQuote = errors.New("invalid quoted field in CSV line")
+
+// SplitCSVLine split a CSV line to fields, handle the quoted fields per RFC 4180.
+// The quoted field can contain the separator, newline and escaped quote(""). default sep is ','
+//
+// Usage:
+//
+//	fields, err := strutil.SplitCSVLine(`a,"b,c","say ""hi"""`)
+//	// fields: []string{"a", "b,c", `say "hi"`}
+func SplitCSVLine(s string, sep ...rune) ([]string, error) {
+	comma := ','
+	if len(sep) > 0 {
+		comma = sep[0]
+	}
+
+	// remove the line ending
+	s = strings.TrimSuffix(s, "\n")
+	s = strings.TrimSuffix(s, "\r")
+
+	var fields []string
+	var sb strings.Builder
+	for {
+		if s == "" || s[0] != '"' {
+			// unquoted field
+			i := strings.IndexRune(s, comma)
+			field := s
+			if i >= 0 {
+				field = s[:i]
+			}
+			if strings.IndexByte(field, '"') >= 0 {
+				return nil, ErrCSVQuote
+			}
+
+			fields = append(fields, field)
+			if i < 0 {
+				return fields, nil
+			}
+			s = s[i+utf8.RuneLen(comma):]
+			continue
+		}
+
+		// quoted field
+		sb.Reset()
+		s = s[1:]
+		for {
+			i := strings.IndexByte(s, '"')
+			if i < 0 {
+				return nil, ErrCSVQuote // unterminated
+			}
+
+			sb.WriteString(s[:i])
+			s = s[i+1:]
+			if strings.HasPrefix(s, `"`) { // escaped quote
+				sb.WriteByte('"')
+				s = s[1:]
+				continue
+			}
+			break
+		}
+
+		fields = append(fields, sb.String())
+		if s == "" {
+			return fields, nil
+		}
+
+		r, size := utf8.DecodeRuneInString(s)
+		if r != comma {
+			return nil, ErrCSVQuote
+		}
+		s = s[size:]
+	}
+}
+
+// JoinCSVLine join fields to a CSV line, the field will be quoted if it contains
+// the separator, quote, newline or leading space. default sep is ','
+//
+// Usage:
+//
+//	line := strutil.JoinCSVLine([]string{"a", "b,c", `say "hi"`})
+//	// line: a,"b,c","say ""hi"""
+func JoinCSVLine(fields []string, sep ...rune) string {
+	comma := ','
+	if len(sep) > 0 {
+		comma = sep[0]
+	}
+
+	var sb strings.Builder
+	for i, field := range fields {
+		if i > 0 {
+			sb.WriteRune(comma)
+		}
+
+		if !csvNeedQuote(field, comma) {
+			sb.WriteString(field)
+			continue
+		}
+
+		sb.WriteByte('"')
+		sb.WriteString(strings.ReplaceAll(field, `"`, `""`))
+		sb.WriteByte('"')
+	}
+	return sb.String()
+}
+
+func csvNeedQuote(field string, comma rune) bool {
+	if field == "" {
+		return false
+	}
+	if field[0] == ' ' || field[0] == '\t' {
+		return true
+	}
+	return strings.ContainsRune(field, comma) || strings.ContainsAny(field, "\"\r\n")
+}
@@ -181,3 +181,52 @@ func TestSplitInlineComment(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitCSVLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"", []string{""}},
+		{"a,b,c", []string{"a", "b", "c"}},
+		{"a,,c,", []string{"a", "", "c", ""}},
+		{`a,"b,c","say ""hi"""`, []string{"a", "b,c", `say "hi"`}},
+		{"\"multi\nline\",x\r\n", []string{"multi\nline", "x"}},
+		{`"",""`, []string{"", ""}},
+		{" a , b ", []string{" a ", " b "}},
+	}
+
+	for _, tt := range tests {
+		ss, err := strutil.SplitCSVLine(tt.line)
+		assert.NoErr(t, err)
+		assert.Eq(t, tt.want, ss)
+	}
+
+	// custom separator
+	ss, err := strutil.SplitCSVLine(`a;"b;c";中`, ';')
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"a", "b;c", "中"}, ss)
+	ss, err = strutil.SplitCSVLine("a｜b", '｜')
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"a", "b"}, ss)
+
+	// invalid
+	for _, line := range []string{`"abc`, `"ab"c,d`, `ab"c`, `a,"b`} {
+		_, err = strutil.SplitCSVLine(line)
+		assert.ErrIs(t, err, strutil.ErrCSVQuote)
+	}
+}
+
+func TestJoinCSVLine(t *testing.T) {
+	assert.Eq(t, "", strutil.JoinCSVLine(nil))
+	assert.Eq(t, "a,b", strutil.JoinCSVLine([]string{"a", "b"}))
+	assert.Eq(t, `a,"b,c","say ""hi"""," x","l1`+"\n"+`l2"`,
+		strutil.JoinCSVLine([]string{"a", "b,c", `say "hi"`, " x", "l1\nl2"}))
+	assert.Eq(t, `a;"b;c";d,e`, strutil.JoinCSVLine([]string{"a", "b;c", "d,e"}, ';'))
+
+	// round trip
+	fields := []string{"", "a,b", `"q"`, "line\r\nend", " sp", "中文"}
+	ss, err := strutil.SplitCSVLine(strutil.JoinCSVLine(fields))
+	assert.NoErr(t, err)
+	assert.Eq(t, fields, ss)
+}
@@ -0,0 +1,157 @@
+package strutil
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// TemplateFuncs returns a new common function map for use with text/template. eg: upper, snake, default, join, indent
+//
+// The argument order is same as the sprig, the piped value is the last argument.
+//
+// Usage:
+//
+//	tpl := template.New("gen").Funcs(strutil.TemplateFuncs())
+//	tpl = template.Must(tpl.Parse(`type {{ .Name | camel | upperFirst }} struct{}`))
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// case convert
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"title":      UpperWord,
+		"upperFirst": UpperFirst,
+		"lowerFirst": LowerFirst,
+		"snake":      func(s string) string { return SnakeCase(s) },
+		"kebab":      func(s string) string { return SnakeCase(s, "-") },
+		"camel":      tplCamel,
+		// trim and replace
+		"trim":       strings.TrimSpace,
+		"trimAll":    func(cutset, s string) string { return strings.Trim(s, cutset) },
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"repeat":     func(n int, s string) string { return Repeat(s, n) },
+		"trunc":      tplTrunc,
+		// check
+		"contains":  func(sub, s string) bool { return strings.Contains(s, sub) },
+		"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"empty":     tplEmpty,
+		// default value
+		"default":  tplDefault,
+		"coalesce": tplCoalesce,
+		// join and split
+		"join":  tplJoin,
+		"split": func(sep, s string) []string { return strings.Split(s, sep) },
+		// format
+		"indent":   func(n int, s string) string { return Indent(s, Repeat(" ", n)) },
+		"nindent":  func(n int, s string) string { return "\n" + Indent(s, Repeat(" ", n)) },
+		"quote":    tplQuote,
+		"squote":   tplSQuote,
+		"toString": SafeString,
+		// encode
+		"b64enc": B64Encode,
+		"b64dec": B64Decode,
+	}
+}
+
+// convert "snake_case", "kebab-case" or "space words" to camel case
+func tplCamel(s string) string {
+	return CamelCase(strings.NewReplacer("-", "_", " ", "_").Replace(s))
+}
+
+// truncate the string to n chars, n < 0 to keep the last n chars.
+func tplTrunc(n int, s string) string {
+	rs := []rune(s)
+	if n < 0 {
+		if -n < len(rs) {
+			return string(rs[len(rs)+n:])
+		}
+		return s
+	}
+
+	if n < len(rs) {
+		return string(rs[:n])
+	}
+	return s
+}
+
+// check the value is empty: nil, zero value, empty string/slice/map
+func tplEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Array, reflect.Slice, reflect.Map, reflect.Chan:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+	return rv.IsZero()
+}
+
+// returns the given value, or the default value if it is empty.
+// eg: {{ .Port | default 8080 }}
+func tplDefault(def any, given ...any) any {
+	if len(given) == 0 || tplEmpty(given[0]) {
+		return def
+	}
+	return given[0]
+}
+
+// returns the first non-empty value
+func tplCoalesce(vs ...any) any {
+	for _, v := range vs {
+		if !tplEmpty(v) {
+			return v
+		}
+	}
+	return nil
+}
+
+// join the slice elements to string. eg: {{ .Tags | join ", " }}
+func tplJoin(sep string, v any) string {
+	switch typVal := v.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(typVal, sep)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return SafeString(v)
+	}
+
+	ss := make([]string, rv.Len())
+	for i := range ss {
+		ss[i] = SafeString(rv.Index(i).Interface())
+	}
+	return strings.Join(ss, sep)
+}
+
+// wrap each value with double quotes, join with space.
+func tplQuote(vs ...any) string {
+	ss := make([]string, 0, len(vs))
+	for _, v := range vs {
+		if v != nil {
+			ss = append(ss, strconv.Quote(SafeString(v)))
+		}
+	}
+	return strings.Join(ss, " ")
+}
+
+// wrap each value with single quotes, join with space.
+func tplSQuote(vs ...any) string {
+	ss := make([]string, 0, len(vs))
+	for _, v := range vs {
+		if v != nil {
+			ss = append(ss, "'"+SafeString(v)+"'")
+		}
+	}
+	return strings.Join(ss, " ")
+}
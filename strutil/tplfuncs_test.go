@@ -0,0 +1,72 @@
+package strutil_test
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	render := func(tplText string, data any) string {
+		tpl, err := template.New("test").Funcs(strutil.TemplateFuncs()).Parse(tplText)
+		assert.NoErr(t, err)
+
+		buf := new(bytes.Buffer)
+		assert.NoErr(t, tpl.Execute(buf, data))
+		return buf.String()
+	}
+
+	data := map[string]any{
+		"name":  "user_profile",
+		"title": "hello world",
+		"tags":  []string{"a", "b"},
+		"ids":   []int{1, 2},
+		"empty": "",
+		"port":  0,
+		"text":  "line1\nline2",
+	}
+
+	tests := []struct {
+		tpl  string
+		want string
+	}{
+		{`{{ .title | upper }}`, "HELLO WORLD"},
+		{`{{ "ABC" | lower }}`, "abc"},
+		{`{{ .title | title }}`, "Hello World"},
+		{`{{ "UserProfile" | snake }}`, "user_profile"},
+		{`{{ "UserProfile" | kebab }}`, "user-profile"},
+		{`{{ .name | camel }}`, "userProfile"},
+		{`{{ "user-profile name" | camel | upperFirst }}`, "UserProfileName"},
+		{`{{ "  abc " | trim }}`, "abc"},
+		{`{{ "--abc--" | trimAll "-" }}`, "abc"},
+		{`{{ .name | trimPrefix "user_" }}`, "profile"},
+		{`{{ .name | trimSuffix "_profile" }}`, "user"},
+		{`{{ .name | replace "_" "." }}`, "user.profile"},
+		{`{{ "ab" | repeat 3 }}`, "ababab"},
+		{`{{ "hello" | trunc 3 }}|{{ "hello" | trunc -2 }}|{{ "hi" | trunc 5 }}`, "hel|lo|hi"},
+		{`{{ if .name | contains "prof" }}yes{{ end }}`, "yes"},
+		{`{{ if .name | hasPrefix "user" }}yes{{ end }}{{ if .name | hasSuffix "user" }}no{{ end }}`, "yes"},
+		{`{{ empty .empty }} {{ empty .port }} {{ empty .tags }} {{ empty .notExist }}`, "true true false true"},
+		{`{{ .empty | default "def" }}|{{ .port | default 8080 }}|{{ .name | default "def" }}`, "def|8080|user_profile"},
+		{`{{ coalesce .empty .port .name }}`, "user_profile"},
+		{`{{ .tags | join ", " }}|{{ .ids | join "-" }}|{{ .notExist | join "," }}`, "a, b|1-2|"},
+		{`{{ "a,b" | split "," }}`, "[a b]"},
+		{`{{ .text | indent 2 }}`, "  line1\n  line2"},
+		{`key:{{ .text | nindent 2 }}`, "key:\n  line1\n  line2"},
+		{`{{ .name | quote }}|{{ quote "a" 1 }}|{{ .name | squote }}`, `"user_profile"|"a" "1"|'user_profile'`},
+		{`{{ 23 | toString }}`, "23"},
+		{`{{ "abc" | b64enc }}|{{ "YWJj" | b64dec }}`, "YWJj|abc"},
+	}
+
+	for _, tt := range tests {
+		assert.Eq(t, tt.want, render(tt.tpl, data), tt.tpl)
+	}
+
+	// new map on each call
+	fm := strutil.TemplateFuncs()
+	delete(fm, "upper")
+	assert.NotEmpty(t, strutil.TemplateFuncs()["upper"])
+}
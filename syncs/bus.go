@@ -0,0 +1,248 @@
+package syncs
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// SlowPolicy the policy for the slow subscriber, on its channel is full.
+type SlowPolicy uint8
+
+const (
+	// SlowBlock block the publisher until the subscriber received, or its context done.
+	SlowBlock SlowPolicy = iota
+	// SlowDropNewest drop the new event for the slow subscriber
+	SlowDropNewest
+	// SlowDropOldest drop the oldest buffered event, then add the new event.
+	//
+	// NOTE: on the unbuffered mode, it is same as SlowDropNewest.
+	SlowDropOldest
+)
+
+// BusOption struct
+type BusOption struct {
+	// BufSize the channel buffer size of each subscriber. 0 is unbuffered mode.
+	BufSize int
+	// Policy for the slow subscriber. default is SlowBlock
+	Policy SlowPolicy
+}
+
+// BusOptFn type
+type BusOptFn func(opt *BusOption)
+
+// WithBufSize set the channel buffer size of each subscriber
+func WithBufSize(size int) BusOptFn {
+	return func(opt *BusOption) {
+		opt.BufSize = size
+	}
+}
+
+// WithSlowPolicy set the policy for the slow subscriber
+func WithSlowPolicy(policy SlowPolicy) BusOptFn {
+	return func(opt *BusOption) {
+		opt.Policy = policy
+	}
+}
+
+type subscriber[T any] struct {
+	ch   chan T
+	done <-chan struct{}
+	// mu guard the ch closing. the senders hold the read lock.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// close the subscriber channel, will wait the sending finished.
+func (s *subscriber[T]) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+// Bus is a simple in-process pub/sub for broadcast typed events to all subscribers.
+// it is safe for concurrent use.
+//
+// Usage:
+//
+//	bus := syncs.NewBus[string](syncs.WithBufSize(10), syncs.WithSlowPolicy(syncs.SlowDropOldest))
+//	defer bus.Close()
+//
+//	ch := bus.Subscribe(ctx)
+//	go func() {
+//		for evt := range ch {
+//			fmt.Println(evt)
+//		}
+//	}()
+//
+//	bus.Publish("file changed")
+type Bus[T any] struct {
+	opt BusOption
+	mu  sync.RWMutex
+	// all subscribers
+	subs map[*subscriber[T]]struct{}
+	// closed by Close()
+	done    chan struct{}
+	once    sync.Once
+	closed  bool
+	dropped uint64
+}
+
+// NewBus create a new event bus
+func NewBus[T any](fns ...BusOptFn) *Bus[T] {
+	b := &Bus[T]{
+		subs: make(map[*subscriber[T]]struct{}),
+		done: make(chan struct{}),
+	}
+
+	for _, fn := range fns {
+		fn(&b.opt)
+	}
+	if b.opt.BufSize < 0 {
+		b.opt.BufSize = 0
+	}
+	return b
+}
+
+// Subscribe the events. the returned channel will be closed on ctx done or the bus closed.
+func (b *Bus[T]) Subscribe(ctx context.Context) <-chan T {
+	s := &subscriber[T]{
+		ch:   make(chan T, b.opt.BufSize),
+		done: ctx.Done(),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(s.ch)
+		return s.ch
+	}
+
+	b.subs[s] = struct{}{}
+	go func() {
+		select {
+		case <-s.done:
+			b.unsubscribe(s)
+		case <-b.done:
+		}
+	}()
+	return s.ch
+}
+
+func (b *Bus[T]) unsubscribe(s *subscriber[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		s.close()
+	}
+}
+
+// Publish an event to all subscribers, return the number of subscribers received it.
+//
+// On SlowBlock policy, it will block until all subscribers received, or their context done.
+// The slow subscriber does not block the Subscribe(), unsubscribe and other publishers.
+func (b *Bus[T]) Publish(evt T) (n int) {
+	// snapshot the subscribers, deliver the event after unlock.
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return 0
+	}
+
+	subs := make([]*subscriber[T], 0, len(b.subs))
+	for s := range b.subs {
+		subs = append(subs, s)
+	}
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		if b.send(s, evt) {
+			n++
+		} else {
+			atomic.AddUint64(&b.dropped, 1)
+		}
+	}
+	return n
+}
+
+func (b *Bus[T]) send(s *subscriber[T], evt T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// unsubscribed after the snapshot
+	if s.closed {
+		return false
+	}
+
+	switch b.opt.Policy {
+	case SlowDropNewest:
+		select {
+		case s.ch <- evt:
+			return true
+		default:
+			return false
+		}
+	case SlowDropOldest:
+		for {
+			select {
+			case s.ch <- evt:
+				return true
+			default:
+			}
+
+			if b.opt.BufSize == 0 {
+				return false
+			}
+
+			// drop the oldest one and retry
+			select {
+			case <-s.ch:
+				atomic.AddUint64(&b.dropped, 1)
+			default:
+			}
+		}
+	default: // SlowBlock
+		select {
+		case s.ch <- evt:
+			return true
+		case <-s.done:
+		case <-b.done:
+		}
+		return false
+	}
+}
+
+// Subscribers get the number of subscribers
+func (b *Bus[T]) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
+
+// Dropped get the number of dropped events for slow subscribers
+func (b *Bus[T]) Dropped() uint64 {
+	return atomic.LoadUint64(&b.dropped)
+}
+
+// Close the bus, all subscriber channels will be closed.
+func (b *Bus[T]) Close() {
+	// notify the blocked publishers first, then wait them release the subscribers.
+	b.once.Do(func() { close(b.done) })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	b.closed = true
+	for s := range b.subs {
+		s.close()
+	}
+	b.subs = make(map[*subscriber[T]]struct{})
+}
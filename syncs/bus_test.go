@@ -0,0 +1,151 @@
+package syncs_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/syncs"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestBus_Publish(t *testing.T) {
+	bus := syncs.NewBus[string](syncs.WithBufSize(2))
+	defer bus.Close()
+
+	ch1 := bus.Subscribe(context.Background())
+	ch2 := bus.Subscribe(context.Background())
+	assert.Eq(t, 2, bus.Subscribers())
+
+	assert.Eq(t, 2, bus.Publish("a"))
+	assert.Eq(t, 2, bus.Publish("b"))
+	assert.Eq(t, "a", <-ch1)
+	assert.Eq(t, "b", <-ch1)
+	assert.Eq(t, "a", <-ch2)
+	assert.Eq(t, "b", <-ch2)
+	assert.Eq(t, uint64(0), bus.Dropped())
+}
+
+func TestBus_unbuffered(t *testing.T) {
+	bus := syncs.NewBus[int]()
+	ch := bus.Subscribe(context.Background())
+
+	var got []int
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for v := range ch {
+			got = append(got, v)
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		assert.Eq(t, 1, bus.Publish(i))
+	}
+
+	bus.Close()
+	wg.Wait()
+	assert.Eq(t, []int{0, 1, 2}, got)
+	assert.Eq(t, 0, bus.Subscribers())
+	assert.Eq(t, 0, bus.Publish(3))
+
+	// subscribe after closed
+	_, ok := <-bus.Subscribe(context.Background())
+	assert.False(t, ok)
+	bus.Close() // close again
+}
+
+func TestBus_Subscribe_ctxDone(t *testing.T) {
+	bus := syncs.NewBus[int]()
+	defer bus.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := bus.Subscribe(ctx)
+
+	// block publish will be released on ctx done
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	assert.Eq(t, 0, bus.Publish(1))
+	assert.Eq(t, uint64(1), bus.Dropped())
+
+	_, ok := <-ch
+	assert.False(t, ok)
+	assert.Eq(t, 0, bus.Subscribers())
+}
+
+func TestBus_slowPolicy(t *testing.T) {
+	t.Run("drop newest", func(t *testing.T) {
+		bus := syncs.NewBus[int](syncs.WithBufSize(2), syncs.WithSlowPolicy(syncs.SlowDropNewest))
+		ch := bus.Subscribe(context.Background())
+		for i := 1; i <= 4; i++ {
+			bus.Publish(i)
+		}
+		bus.Close()
+
+		var got []int
+		for v := range ch {
+			got = append(got, v)
+		}
+		assert.Eq(t, []int{1, 2}, got)
+		assert.Eq(t, uint64(2), bus.Dropped())
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		bus := syncs.NewBus[int](syncs.WithBufSize(2), syncs.WithSlowPolicy(syncs.SlowDropOldest))
+		ch := bus.Subscribe(context.Background())
+		for i := 1; i <= 4; i++ {
+			assert.Eq(t, 1, bus.Publish(i))
+		}
+		bus.Close()
+
+		var got []int
+		for v := range ch {
+			got = append(got, v)
+		}
+		assert.Eq(t, []int{3, 4}, got)
+		assert.Eq(t, uint64(2), bus.Dropped())
+	})
+
+	t.Run("drop oldest unbuffered", func(t *testing.T) {
+		bus := syncs.NewBus[int](syncs.WithSlowPolicy(syncs.SlowDropOldest))
+		defer bus.Close()
+
+		bus.Subscribe(context.Background())
+		assert.Eq(t, 0, bus.Publish(1))
+		assert.Eq(t, uint64(1), bus.Dropped())
+	})
+}
+
+func TestBus_slowBlock_notLockBus(t *testing.T) {
+	bus := syncs.NewBus[int]()
+	_ = bus.Subscribe(context.Background()) // slow subscriber, never read
+
+	published := make(chan int)
+	go func() {
+		published <- bus.Publish(1)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// the blocked publisher should not block the subscribe
+	subscribed := make(chan struct{})
+	go func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		bus.Subscribe(ctx)
+		cancel()
+		close(subscribed)
+	}()
+
+	select {
+	case <-subscribed:
+	case <-time.After(time.Second):
+		t.Fatal("subscribe is blocked by the slow subscriber")
+	}
+
+	bus.Close()
+	assert.Eq(t, 0, <-published)
+	assert.Eq(t, uint64(1), bus.Dropped())
+}
@@ -0,0 +1,200 @@
+package sysutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// AppDirOption the options for get app dirs
+type AppDirOption struct {
+	// EnsureExists create the dir if not exists
+	EnsureExists bool
+	// Perm the dir perm on create. default is 0700
+	Perm os.FileMode
+}
+
+// AppDirOptFn func for set AppDirOption
+type AppDirOptFn func(opt *AppDirOption)
+
+// WithEnsureExists create the dir if not exists. perm default is 0700
+func WithEnsureExists(perm ...os.FileMode) AppDirOptFn {
+	return func(opt *AppDirOption) {
+		opt.EnsureExists = true
+		if len(perm) > 0 {
+			opt.Perm = perm[0]
+		}
+	}
+}
+
+// kinds of the app dir
+const (
+	appDirConfig = iota
+	appDirData
+	appDirState
+	appDirRuntime
+)
+
+// ConfigDir get the config dir for the app.
+//
+//   - Linux: $XDG_CONFIG_HOME/app or $HOME/.config/app
+//   - macOS: $HOME/Library/Application Support/app
+//   - Windows: %APPDATA%\app
+//
+// NOTE: on unix-like system, will use the $XDG_* env if it is set with an absolute path.
+func ConfigDir(app string, fns ...AppDirOptFn) (string, error) {
+	return appDir(appDirConfig, app, fns)
+}
+
+// DataDir get the data dir for the app.
+//
+//   - Linux: $XDG_DATA_HOME/app or $HOME/.local/share/app
+//   - macOS: $HOME/Library/Application Support/app
+//   - Windows: %LOCALAPPDATA%\app
+func DataDir(app string, fns ...AppDirOptFn) (string, error) {
+	return appDir(appDirData, app, fns)
+}
+
+// StateDir get the state dir for the app. eg: logs, history
+//
+//   - Linux: $XDG_STATE_HOME/app or $HOME/.local/state/app
+//   - macOS: $HOME/Library/Application Support/app/state
+//   - Windows: %LOCALAPPDATA%\app\state
+func StateDir(app string, fns ...AppDirOptFn) (string, error) {
+	return appDir(appDirState, app, fns)
+}
+
+// RuntimeDir get the runtime dir for the app. eg: sockets, pid files
+//
+//   - Linux: $XDG_RUNTIME_DIR/app or $TMPDIR/app-{uid}
+//   - macOS: $TMPDIR/app
+//   - Windows: %TEMP%\app
+func RuntimeDir(app string, fns ...AppDirOptFn) (string, error) {
+	return appDir(appDirRuntime, app, fns)
+}
+
+func appDir(kind int, app string, fns []AppDirOptFn) (string, error) {
+	if app == "" {
+		return "", errors.New("sysutil: app name is required")
+	}
+
+	opt := &AppDirOption{Perm: 0700}
+	for _, fn := range fns {
+		fn(opt)
+	}
+
+	dir, err := appDirPath(kind, app)
+	if err != nil {
+		return "", err
+	}
+
+	if opt.EnsureExists {
+		if err = os.MkdirAll(dir, opt.Perm); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+func appDirPath(kind int, app string) (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return winAppDir(kind, app)
+	case "darwin", "ios":
+		if dir := xdgEnvDir(kind); dir != "" {
+			return filepath.Join(dir, app), nil
+		}
+		return macAppDir(kind, app)
+	}
+
+	if dir := xdgEnvDir(kind); dir != "" {
+		return filepath.Join(dir, app), nil
+	}
+
+	if kind == appDirRuntime {
+		return filepath.Join(os.TempDir(), app+"-"+strconv.Itoa(os.Getuid())), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch kind {
+	case appDirConfig:
+		return filepath.Join(home, ".config", app), nil
+	case appDirData:
+		return filepath.Join(home, ".local", "share", app), nil
+	default:
+		return filepath.Join(home, ".local", "state", app), nil
+	}
+}
+
+// get dir from the XDG env, relative path is invalid by the spec.
+func xdgEnvDir(kind int) string {
+	var dir string
+	switch kind {
+	case appDirConfig:
+		dir = os.Getenv("XDG_CONFIG_HOME")
+	case appDirData:
+		dir = os.Getenv("XDG_DATA_HOME")
+	case appDirState:
+		dir = os.Getenv("XDG_STATE_HOME")
+	default:
+		dir = os.Getenv("XDG_RUNTIME_DIR")
+	}
+
+	if dir != "" && filepath.IsAbs(dir) {
+		return dir
+	}
+	return ""
+}
+
+func macAppDir(kind int, app string) (string, error) {
+	if kind == appDirRuntime {
+		return filepath.Join(os.TempDir(), app), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(home, "Library", "Application Support", app)
+	if kind == appDirState {
+		return filepath.Join(dir, "state"), nil
+	}
+	return dir, nil
+}
+
+func winAppDir(kind int, app string) (string, error) {
+	var base string
+	switch kind {
+	case appDirConfig:
+		base = os.Getenv("APPDATA")
+	case appDirRuntime:
+		return filepath.Join(os.TempDir(), app), nil
+	default:
+		base = os.Getenv("LOCALAPPDATA")
+	}
+
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+
+		if kind == appDirConfig {
+			base = filepath.Join(home, "AppData", "Roaming")
+		} else {
+			base = filepath.Join(home, "AppData", "Local")
+		}
+	}
+
+	if kind == appDirState {
+		return filepath.Join(base, app, "state"), nil
+	}
+	return filepath.Join(base, app), nil
+}
@@ -0,0 +1,80 @@
+package sysutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gookit/goutil/sysutil"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestAppDirs_xdg(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skip on windows")
+	}
+
+	base := t.TempDir()
+	testutil.MockEnvValues(map[string]string{
+		"XDG_CONFIG_HOME": filepath.Join(base, "config"),
+		"XDG_DATA_HOME":   filepath.Join(base, "data"),
+		"XDG_STATE_HOME":  filepath.Join(base, "state"),
+		"XDG_RUNTIME_DIR": filepath.Join(base, "run"),
+	}, func() {
+		dir, err := sysutil.ConfigDir("myapp")
+		assert.NoErr(t, err)
+		assert.Eq(t, filepath.Join(base, "config", "myapp"), dir)
+
+		dir, err = sysutil.DataDir("myapp")
+		assert.NoErr(t, err)
+		assert.Eq(t, filepath.Join(base, "data", "myapp"), dir)
+
+		dir, err = sysutil.RuntimeDir("myapp")
+		assert.NoErr(t, err)
+		assert.Eq(t, filepath.Join(base, "run", "myapp"), dir)
+
+		// ensure exists
+		dir, err = sysutil.StateDir("myapp", sysutil.WithEnsureExists())
+		assert.NoErr(t, err)
+		assert.Eq(t, filepath.Join(base, "state", "myapp"), dir)
+		fi, err := os.Stat(dir)
+		assert.NoErr(t, err)
+		assert.True(t, fi.IsDir())
+	})
+}
+
+func TestAppDirs_fallback(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("only test on linux")
+	}
+
+	home := t.TempDir()
+	testutil.MockEnvValues(map[string]string{
+		"HOME":            home,
+		"XDG_CONFIG_HOME": "relative/path", // invalid, will be ignored
+		"XDG_DATA_HOME":   "",
+		"XDG_STATE_HOME":  "",
+		"XDG_RUNTIME_DIR": "",
+	}, func() {
+		dir, err := sysutil.ConfigDir("myapp")
+		assert.NoErr(t, err)
+		assert.Eq(t, filepath.Join(home, ".config", "myapp"), dir)
+
+		dir, err = sysutil.DataDir("myapp")
+		assert.NoErr(t, err)
+		assert.Eq(t, filepath.Join(home, ".local/share", "myapp"), dir)
+
+		dir, err = sysutil.StateDir("myapp")
+		assert.NoErr(t, err)
+		assert.Eq(t, filepath.Join(home, ".local/state", "myapp"), dir)
+
+		dir, err = sysutil.RuntimeDir("myapp")
+		assert.NoErr(t, err)
+		assert.StrContains(t, dir, "myapp-")
+	})
+
+	_, err := sysutil.ConfigDir("")
+	assert.Err(t, err)
+}
@@ -28,6 +28,9 @@ type Cmd struct {
 	BeforeRun func(c *Cmd)
 	// AfterRun hook
 	AfterRun func(c *Cmd, err error)
+
+	// resource limits for run the command. see WithLimits
+	limits *Limits
 }
 
 // NewGitCmd instance
@@ -394,7 +397,13 @@ func (c *Cmd) Output() (string, error) {
 		return "DRY-RUN: ok", nil
 	}
 
-	output, err := c.Cmd.Output()
+	var output []byte
+	var err error
+	if c.limits != nil {
+		output, err = c.limitedOutput(false)
+	} else {
+		output, err = c.Cmd.Output()
+	}
 
 	if c.AfterRun != nil {
 		c.AfterRun(c, err)
@@ -412,7 +421,13 @@ func (c *Cmd) CombinedOutput() (string, error) {
 		return "DRY-RUN: ok", nil
 	}
 
-	output, err := c.Cmd.CombinedOutput()
+	var output []byte
+	var err error
+	if c.limits != nil {
+		output, err = c.limitedOutput(true)
+	} else {
+		output, err = c.Cmd.CombinedOutput()
+	}
 
 	if c.AfterRun != nil {
 		c.AfterRun(c, err)
@@ -443,7 +458,12 @@ func (c *Cmd) Run() error {
 	}
 
 	// do running
-	err := c.Cmd.Run()
+	var err error
+	if c.limits != nil {
+		err = c.runLimited()
+	} else {
+		err = c.Cmd.Run()
+	}
 
 	if c.AfterRun != nil {
 		c.AfterRun(c, err)
@@ -0,0 +1,179 @@
+package cmdr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// LimitKind the kind of resource limit
+type LimitKind string
+
+// built in limit kinds
+const (
+	LimitCPU      LimitKind = "cpu"
+	LimitMemory   LimitKind = "memory"
+	LimitWallTime LimitKind = "walltime"
+)
+
+// ErrLimitExceeded the command process is terminated by hit the resource limit.
+// can use errors.Is(err, ErrLimitExceeded) to check it.
+var ErrLimitExceeded = errors.New("resource limit exceeded")
+
+// Limits resource limits for run the command process. zero value field means no limit.
+//
+// How the limits are applied:
+//
+//   - linux: CPU and Memory by setrlimit(RLIMIT_CPU, RLIMIT_AS), or cgroup v2 memory.max if CgroupDir is set.
+//     the command is started by a "/bin/sh" wrapper, it applies the limits before exec the command.
+//   - windows: CPU and Memory by the job object, it is assigned right after the process started.
+//   - others: only support the WallTime limit
+type Limits struct {
+	// CPUTime max CPU time(user+system) of the process. on linux, it is rounded up to seconds.
+	CPUTime time.Duration
+	// Memory max memory bytes of the process.
+	//
+	// NOTE: on linux without CgroupDir, it limits the virtual memory. the process may exit
+	// with its own error on the allocation failed, so cannot be classified as LimitMemory.
+	Memory uint64
+	// WallTime max running time, the process will be killed on timeout.
+	WallTime time.Duration
+	// CgroupDir the parent cgroup v2 dir for create the sub cgroup of process, only for linux.
+	// the "memory" controller must be enabled in its cgroup.subtree_control.
+	//
+	// eg: "/sys/fs/cgroup/user.slice/user-1000.slice/user@1000.service/app.slice"
+	CgroupDir string
+}
+
+// IsEmpty check there is no limit set
+func (l *Limits) IsEmpty() bool {
+	return l.CPUTime <= 0 && l.Memory == 0 && l.WallTime <= 0
+}
+
+// LimitError the error of the command process is terminated by hit the resource limit.
+type LimitError struct {
+	// Kind of the hit limit
+	Kind LimitKind
+	// Limits of the command
+	Limits Limits
+	// Err the raw run error. eg: *exec.ExitError
+	Err error
+}
+
+// Error string
+func (e *LimitError) Error() string {
+	var limit string
+	switch e.Kind {
+	case LimitCPU:
+		limit = e.Limits.CPUTime.String()
+	case LimitMemory:
+		limit = fmt.Sprintf("%d bytes", e.Limits.Memory)
+	case LimitWallTime:
+		limit = e.Limits.WallTime.String()
+	}
+	return fmt.Sprintf("cmdr: %s limit %s exceeded: %v", e.Kind, limit, e.Err)
+}
+
+// Unwrap the raw error
+func (e *LimitError) Unwrap() error { return e.Err }
+
+// Is check target is ErrLimitExceeded
+func (e *LimitError) Is(target error) bool { return target == ErrLimitExceeded }
+
+// IsLimitError check the error is LimitError and return it.
+func IsLimitError(err error) (*LimitError, bool) {
+	var le *LimitError
+	ok := errors.As(err, &le)
+	return le, ok
+}
+
+// WithLimits set the resource limits for run the command.
+//
+// Usage:
+//
+//	err := cmdr.NewCmd("some-tool").WithLimits(cmdr.Limits{
+//		CPUTime: 2 * time.Second,
+//		Memory:  256 << 20,
+//		WallTime: 10 * time.Second,
+//	}).Run()
+//
+//	if le, ok := cmdr.IsLimitError(err); ok {
+//		fmt.Println("hit the limit:", le.Kind)
+//	}
+func (c *Cmd) WithLimits(l Limits) *Cmd {
+	if l.IsEmpty() {
+		c.limits = nil
+	} else {
+		c.limits = &l
+	}
+	return c
+}
+
+// run the command with limits and collect the output
+func (c *Cmd) limitedOutput(combined bool) ([]byte, error) {
+	if c.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+
+	var buf bytes.Buffer
+	c.Stdout = &buf
+	if combined {
+		if c.Stderr != nil {
+			return nil, errors.New("exec: Stderr already set")
+		}
+		c.Stderr = &buf
+	}
+
+	err := c.runLimited()
+	return buf.Bytes(), err
+}
+
+// run the command with limits
+func (c *Cmd) runLimited() error {
+	l := c.limits
+	ol, err := newOSLimiter(l)
+	if err != nil {
+		return err
+	}
+	defer ol.close()
+
+	if err = ol.prepare(c.Cmd); err != nil {
+		return err
+	}
+
+	err = c.Cmd.Start()
+	ol.restore(c.Cmd)
+	if err != nil {
+		return err
+	}
+	if err = ol.apply(c.Process.Pid); err != nil {
+		_ = c.Process.Kill()
+		_ = c.Cmd.Wait()
+		return err
+	}
+
+	var timedOut atomic.Bool
+	if l.WallTime > 0 {
+		timer := time.AfterFunc(l.WallTime, func() {
+			timedOut.Store(true)
+			_ = c.Process.Kill()
+		})
+		defer timer.Stop()
+	}
+
+	if err = c.Cmd.Wait(); err == nil {
+		return nil
+	}
+
+	kind := ol.exceeded(c.ProcessState)
+	if timedOut.Load() {
+		kind = LimitWallTime
+	}
+
+	if kind != "" {
+		return &LimitError{Kind: kind, Limits: *l, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,211 @@
+package cmdr
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+type osLimiter struct {
+	l *Limits
+	// the sub cgroup dir of the process
+	cgDir string
+	// the pipe for receive the error of the wrapper script
+	errR, errW *os.File
+	// the raw command path, args and extra files before wrapped
+	path  string
+	args  []string
+	files []*os.File
+}
+
+func newOSLimiter(l *Limits) (*osLimiter, error) {
+	return &osLimiter{l: l}, nil
+}
+
+// the sequence for make the cgroup name unique
+var cgroupSeq uint64
+
+// prepare wrap the command by a sh script, the script applies the limits then exec the command.
+// so the limits are effective before the command starts, and the failure will not run the command.
+func (ol *osLimiter) prepare(cmd *exec.Cmd) error {
+	l := ol.l
+	if l.CPUTime <= 0 && l.Memory == 0 {
+		return nil
+	}
+
+	path := cmd.Path
+	if !strings.Contains(path, "/") {
+		// exec.Command() not found the command
+		lp, err := exec.LookPath(path)
+		if err != nil {
+			return err
+		}
+		path = lp
+	}
+
+	var sb strings.Builder
+	// the wrapper writes the error message to fd and exit on apply failed.
+	fd := 3 + len(cmd.ExtraFiles)
+	fail := func(msg string) string {
+		return fmt.Sprintf(" || { echo '%s' >&%d; exit 125; }\n", msg, fd)
+	}
+
+	if l.CPUTime > 0 {
+		// the process will receive SIGXCPU on hit the soft limit, SIGKILL on the hard limit
+		sec := uint64((l.CPUTime + time.Second - 1) / time.Second)
+		sb.WriteString(fmt.Sprintf("{ ulimit -S -t %d && ulimit -H -t %d; } 2>/dev/null", sec, sec+1))
+		sb.WriteString(fail("cmdr: set cpu limit error"))
+	}
+
+	if l.Memory > 0 {
+		if l.CgroupDir != "" {
+			if err := ol.createCgroup(l); err != nil {
+				return err
+			}
+			procsFile := filepath.Join(ol.cgDir, "cgroup.procs")
+			sb.WriteString(fmt.Sprintf("{ echo $$ > '%s'; } 2>/dev/null", strings.ReplaceAll(procsFile, "'", `'\''`)))
+			sb.WriteString(fail("cmdr: join cgroup error"))
+		} else {
+			kb := (l.Memory + 1023) / 1024
+			sb.WriteString(fmt.Sprintf("{ ulimit -v %d; } 2>/dev/null", kb))
+			sb.WriteString(fail("cmdr: set memory limit error"))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("exec %d>&-\n", fd))
+
+	// the $0 is the original argv[0], $1 is the command path.
+	// use bash for keep the argv[0] by "exec -a", the dash(/bin/sh) does not support it.
+	shell, execLine := "/bin/sh", `p=$1; shift; exec "$p" "$@"`
+	if bashPath, err := exec.LookPath("bash"); err == nil {
+		shell, execLine = bashPath, `p=$1; shift; exec -a "$0" "$p" "$@"`
+	}
+	sb.WriteString(execLine)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	ol.errR, ol.errW = r, w
+
+	ol.path, ol.args, ol.files = cmd.Path, cmd.Args, cmd.ExtraFiles
+	argv0, args := path, cmd.Args
+	if len(args) > 0 {
+		argv0, args = args[0], args[1:]
+	}
+
+	cmd.Path = shell
+	cmd.Args = append([]string{filepath.Base(shell), "-c", sb.String(), argv0, path}, args...)
+	cmd.ExtraFiles = append(append([]*os.File(nil), cmd.ExtraFiles...), w)
+	return nil
+}
+
+// restore the command path and args after started
+func (ol *osLimiter) restore(cmd *exec.Cmd) {
+	if ol.errW != nil {
+		cmd.Path, cmd.Args, cmd.ExtraFiles = ol.path, ol.args, ol.files
+	}
+}
+
+// apply wait the wrapper script applied the limits, returns the error reported by the script.
+func (ol *osLimiter) apply(_ int) error {
+	if ol.errW == nil {
+		return nil
+	}
+
+	// close the write end in parent, then got EOF on the wrapper closed it or exited.
+	_ = ol.errW.Close()
+	ol.errW = nil
+
+	msg, err := io.ReadAll(ol.errR)
+	if err != nil {
+		return fmt.Errorf("cmdr: read the limits apply result error: %w", err)
+	}
+	if len(msg) > 0 {
+		return errors.New(strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// create a sub cgroup for the process and set the memory limit.
+func (ol *osLimiter) createCgroup(l *Limits) error {
+	if _, err := os.Stat(filepath.Join(l.CgroupDir, "cgroup.controllers")); err != nil {
+		return fmt.Errorf("cmdr: create cgroup error: %s is not a cgroup v2 dir", l.CgroupDir)
+	}
+
+	name := fmt.Sprintf("cmdr-%d-%d", os.Getpid(), atomic.AddUint64(&cgroupSeq, 1))
+	dir := filepath.Join(l.CgroupDir, name)
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return fmt.Errorf("cmdr: create cgroup error: %w", err)
+	}
+
+	ol.cgDir = dir
+	if err := writeCgroupFile(dir, "memory.max", strconv.FormatUint(l.Memory, 10)); err != nil {
+		return err
+	}
+	// disable swap for the memory limit is effective. ignore error on swap is not enabled
+	_ = writeCgroupFile(dir, "memory.swap.max", "0")
+	return nil
+}
+
+func writeCgroupFile(dir, name, val string) error {
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(val), 0644); err != nil {
+		return fmt.Errorf("cmdr: write cgroup file %s error: %w", name, err)
+	}
+	return nil
+}
+
+func (ol *osLimiter) exceeded(ps *os.ProcessState) LimitKind {
+	if ol.cgDir != "" && ol.oomKilled() {
+		return LimitMemory
+	}
+
+	// killed by the soft(SIGXCPU) or hard(SIGKILL) cpu time limit
+	if ps != nil && ol.l.CPUTime > 0 {
+		ws, ok := ps.Sys().(syscall.WaitStatus)
+		if ok && ws.Signaled() && (ws.Signal() == syscall.SIGXCPU || ws.Signal() == syscall.SIGKILL) {
+			return LimitCPU
+		}
+	}
+	return ""
+}
+
+// check the oom_kill count in memory.events
+func (ol *osLimiter) oomKilled() bool {
+	f, err := os.Open(filepath.Join(ol.cgDir, "memory.events"))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := s.Text(); strings.HasPrefix(line, "oom_kill ") {
+			return strings.TrimPrefix(line, "oom_kill ") != "0"
+		}
+	}
+	return false
+}
+
+func (ol *osLimiter) close() {
+	for _, f := range []*os.File{ol.errR, ol.errW} {
+		if f != nil {
+			_ = f.Close()
+		}
+	}
+	ol.errR, ol.errW = nil, nil
+
+	if ol.cgDir != "" {
+		// the cgroup can be removed after all processes exited
+		_ = os.Remove(ol.cgDir)
+		ol.cgDir = ""
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !linux && !windows
+
+package cmdr
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+type osLimiter struct{}
+
+func newOSLimiter(l *Limits) (*osLimiter, error) {
+	if l.CPUTime > 0 || l.Memory > 0 {
+		return nil, fmt.Errorf("cmdr: the cpu and memory limits are not supported on %s", runtime.GOOS)
+	}
+	return &osLimiter{}, nil
+}
+
+func (ol *osLimiter) prepare(_ *exec.Cmd) error { return nil }
+
+func (ol *osLimiter) restore(_ *exec.Cmd) {}
+
+func (ol *osLimiter) apply(_ int) error { return nil }
+
+func (ol *osLimiter) exceeded(_ *os.ProcessState) LimitKind { return "" }
+
+func (ol *osLimiter) close() {}
@@ -0,0 +1,109 @@
+package cmdr_test
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/sysutil/cmdr"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestCmd_WithLimits_wallTime(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skip on windows")
+	}
+
+	st := time.Now()
+	err := cmdr.NewCmd("sleep", "3").WithLimits(cmdr.Limits{WallTime: 100 * time.Millisecond}).Run()
+	assert.Err(t, err)
+	assert.True(t, time.Since(st) < 2*time.Second)
+	assert.True(t, errors.Is(err, cmdr.ErrLimitExceeded))
+
+	le, ok := cmdr.IsLimitError(err)
+	assert.True(t, ok)
+	assert.Eq(t, cmdr.LimitWallTime, le.Kind)
+	assert.StrContains(t, err.Error(), "cmdr: walltime limit 100ms exceeded")
+
+	// not exceeded
+	out, err := cmdr.NewCmd("echo", "hi").WithLimits(cmdr.Limits{WallTime: 3 * time.Second}).Output()
+	assert.NoErr(t, err)
+	assert.Eq(t, "hi\n", out)
+
+	// normal error
+	err = cmdr.NewCmd("sh", "-c", "exit 3").WithLimits(cmdr.Limits{WallTime: 3 * time.Second}).Run()
+	assert.Err(t, err)
+	_, ok = cmdr.IsLimitError(err)
+	assert.False(t, ok)
+}
+
+func TestCmd_WithLimits_linux(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("only for linux")
+	}
+
+	t.Run("applied", func(t *testing.T) {
+		out, err := cmdr.NewCmd("sh", "-c", "sleep 0.1; ulimit -t; ulimit -v").
+			WithLimits(cmdr.Limits{CPUTime: 1500 * time.Millisecond, Memory: 512 << 20}).
+			CombinedOutput()
+		assert.NoErr(t, err)
+		assert.Eq(t, []string{"2", "524288"}, strings.Fields(out))
+	})
+
+	t.Run("cpu", func(t *testing.T) {
+		err := cmdr.NewCmd("sh", "-c", "while :; do :; done").
+			WithLimits(cmdr.Limits{CPUTime: time.Second, WallTime: 10 * time.Second}).
+			Run()
+
+		le, ok := cmdr.IsLimitError(err)
+		assert.True(t, ok)
+		assert.Eq(t, cmdr.LimitCPU, le.Kind)
+	})
+
+	t.Run("args restored", func(t *testing.T) {
+		c := cmdr.NewCmd("echo", "hi", "there").WithLimits(cmdr.Limits{CPUTime: time.Second})
+		out, err := c.Output()
+		assert.NoErr(t, err)
+		assert.Eq(t, "hi there\n", out)
+		assert.Eq(t, []string{"echo", "hi", "there"}, c.Args)
+	})
+
+	t.Run("keep argv0", func(t *testing.T) {
+		if _, err := exec.LookPath("bash"); err != nil {
+			t.Skip("the argv[0] is kept by bash")
+		}
+
+		c := cmdr.NewCmd("sh", "-c", "echo $0").WithLimits(cmdr.Limits{CPUTime: time.Second})
+		c.Args[0] = "my-sh"
+		out, err := c.Output()
+		assert.NoErr(t, err)
+		assert.Eq(t, "my-sh\n", out)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := cmdr.NewCmd("not-exists-cmd").WithLimits(cmdr.Limits{CPUTime: time.Second}).Run()
+		assert.ErrSubMsg(t, err, "executable file not found")
+	})
+
+	t.Run("cgroup error", func(t *testing.T) {
+		err := cmdr.NewCmd("echo", "hi").
+			WithLimits(cmdr.Limits{Memory: 64 << 20, CgroupDir: "/path/not-exists"}).
+			Run()
+		assert.ErrSubMsg(t, err, "cmdr: create cgroup error")
+
+		// not a cgroup v2 dir
+		err = cmdr.NewCmd("echo", "hi").
+			WithLimits(cmdr.Limits{Memory: 64 << 20, CgroupDir: t.TempDir()}).
+			Run()
+		assert.ErrSubMsg(t, err, "is not a cgroup v2 dir")
+	})
+}
+
+func TestCmd_WithLimits_empty(t *testing.T) {
+	c := cmdr.NewCmd("echo", "hi").WithLimits(cmdr.Limits{})
+	_, err := c.Output()
+	assert.NoErr(t, err)
+}
@@ -0,0 +1,109 @@
+//go:build windows
+
+package cmdr
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+type osLimiter struct {
+	job   windows.Handle
+	limit uint64
+}
+
+func newOSLimiter(l *Limits) (*osLimiter, error) {
+	ol := &osLimiter{limit: l.Memory}
+	if l.CPUTime <= 0 && l.Memory == 0 {
+		return ol, nil
+	}
+
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cmdr: create job object error: %w", err)
+	}
+	ol.job = job
+
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+	info.BasicLimitInformation.LimitFlags = windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+	if l.CPUTime > 0 {
+		info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_PROCESS_TIME
+		// in 100-nanosecond ticks
+		info.BasicLimitInformation.PerProcessUserTimeLimit = int64(l.CPUTime / 100)
+	}
+	if l.Memory > 0 {
+		info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_PROCESS_MEMORY
+		info.ProcessMemoryLimit = uintptr(l.Memory)
+	}
+
+	_, err = windows.SetInformationJobObject(
+		job,
+		windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)),
+		uint32(unsafe.Sizeof(info)),
+	)
+	if err != nil {
+		ol.close()
+		return nil, fmt.Errorf("cmdr: set job object limits error: %w", err)
+	}
+	return ol, nil
+}
+
+func (ol *osLimiter) prepare(_ *exec.Cmd) error { return nil }
+
+func (ol *osLimiter) restore(_ *exec.Cmd) {}
+
+// apply assign the process to the job object.
+//
+// NOTE: the process maybe run a short time without limits before assigned.
+func (ol *osLimiter) apply(pid int) error {
+	if ol.job == 0 {
+		return nil
+	}
+
+	ph, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		return fmt.Errorf("cmdr: open process error: %w", err)
+	}
+	defer windows.CloseHandle(ph)
+
+	if err = windows.AssignProcessToJobObject(ol.job, ph); err != nil {
+		return fmt.Errorf("cmdr: assign process to job object error: %w", err)
+	}
+	return nil
+}
+
+func (ol *osLimiter) exceeded(ps *os.ProcessState) LimitKind {
+	// the process is terminated with ERROR_NOT_ENOUGH_QUOTA on exceed the CPU time limit
+	if ps != nil && ps.ExitCode() == int(windows.ERROR_NOT_ENOUGH_QUOTA) {
+		return LimitCPU
+	}
+
+	if ol.job != 0 && ol.limit > 0 {
+		info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+		err := windows.QueryInformationJobObject(
+			ol.job,
+			windows.JobObjectExtendedLimitInformation,
+			uintptr(unsafe.Pointer(&info)),
+			uint32(unsafe.Sizeof(info)),
+			nil,
+		)
+
+		// the allocation over the limit will fail, so the peak is close to the limit
+		if err == nil && uint64(info.PeakProcessMemoryUsed)+ol.limit/20 >= ol.limit {
+			return LimitMemory
+		}
+	}
+	return ""
+}
+
+func (ol *osLimiter) close() {
+	if ol.job != 0 {
+		_ = windows.CloseHandle(ol.job)
+		ol.job = 0
+	}
+}
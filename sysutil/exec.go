@@ -1,7 +1,6 @@
 package sysutil
 
 import (
-	"bytes"
 	"os/exec"
 
 	"github.com/gookit/goutil/cliutil/cmdline"
@@ -18,17 +17,21 @@ func FlushExec(bin string, args ...string) error {
 	return cmdr.NewCmd(bin, args...).FlushRun()
 }
 
-// QuickExec quick exec an simple command line
-func QuickExec(cmdLine string, workDir ...string) (string, error) {
-	return ExecLine(cmdLine, workDir...)
+// CommandRunner interface for exec a command and return output.
+//
+// The exec helpers ExecCmd, ExecLine and ShellExec run commands by it,
+// so can replace it with a fake runner on testing. see testutil.FakeExecutor
+type CommandRunner interface {
+	ExecCmd(binName string, args []string, workDir ...string) (string, error)
 }
 
-// ExecLine quick exec an command line string
-func ExecLine(cmdLine string, workDir ...string) (string, error) {
-	p := cmdline.NewParser(cmdLine)
+// OSRunner the default CommandRunner, exec the command by os/exec
+type OSRunner struct{}
 
+// ExecCmd a command and return output.
+func (OSRunner) ExecCmd(binName string, args []string, workDir ...string) (string, error) {
 	// create a new Cmd instance
-	cmd := p.NewExecCmd()
+	cmd := exec.Command(binName, args...)
 	if len(workDir) > 0 {
 		cmd.Dir = workDir[0]
 	}
@@ -37,20 +40,44 @@ func ExecLine(cmdLine string, workDir ...string) (string, error) {
 	return string(bs), err
 }
 
+var cmdRunner CommandRunner = OSRunner{}
+
+// CmdRunner get the current command runner
+func CmdRunner() CommandRunner {
+	return cmdRunner
+}
+
+// SetCmdRunner set the command runner for exec helpers, return the old runner.
+//
+// NOTE: it is not concurrent safe, should only use on init or testing.
+func SetCmdRunner(r CommandRunner) CommandRunner {
+	old := cmdRunner
+	if r == nil {
+		r = OSRunner{}
+	}
+
+	cmdRunner = r
+	return old
+}
+
+// QuickExec quick exec an simple command line
+func QuickExec(cmdLine string, workDir ...string) (string, error) {
+	return ExecLine(cmdLine, workDir...)
+}
+
+// ExecLine quick exec an command line string
+func ExecLine(cmdLine string, workDir ...string) (string, error) {
+	binName, args := cmdline.NewParser(cmdLine).BinAndArgs()
+	return cmdRunner.ExecCmd(binName, args, workDir...)
+}
+
 // ExecCmd a command and return output.
 //
 // Usage:
 //
 //	ExecCmd("ls", []string{"-al"})
 func ExecCmd(binName string, args []string, workDir ...string) (string, error) {
-	// create a new Cmd instance
-	cmd := exec.Command(binName, args...)
-	if len(workDir) > 0 {
-		cmd.Dir = workDir[0]
-	}
-
-	bs, err := cmd.Output()
-	return string(bs), err
+	return cmdRunner.ExecCmd(binName, args, workDir...)
 }
 
 // ShellExec exec command by shell cmdLine. eg: "ls -al"
@@ -61,12 +88,9 @@ func ShellExec(cmdLine string, shells ...string) (string, error) {
 		shell = shells[0]
 	}
 
-	var out bytes.Buffer
-	cmd := exec.Command(shell, "-c", cmdLine)
-	cmd.Stdout = &out
-
-	if err := cmd.Run(); err != nil {
+	out, err := cmdRunner.ExecCmd(shell, []string{"-c", cmdLine})
+	if err != nil {
 		return "", err
 	}
-	return out.String(), nil
+	return out, nil
 }
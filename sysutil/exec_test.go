@@ -33,3 +33,15 @@ func TestShellExec(t *testing.T) {
 	assert.NoErr(t, err)
 	assert.Eq(t, "OK", strings.TrimSpace(ret))
 }
+
+func TestSetCmdRunner(t *testing.T) {
+	old := sysutil.SetCmdRunner(nil)
+	defer sysutil.SetCmdRunner(old)
+
+	_, ok := sysutil.CmdRunner().(sysutil.OSRunner)
+	assert.True(t, ok)
+
+	ret, err := sysutil.OSRunner{}.ExecCmd("echo", []string{"OK"})
+	assert.NoErr(t, err)
+	assert.Eq(t, "OK", strings.TrimSpace(ret))
+}
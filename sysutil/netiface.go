@@ -0,0 +1,136 @@
+package sysutil
+
+import (
+	"net"
+	"strings"
+)
+
+// VirtualIfacePrefixes name prefixes for check virtual network interface.
+//
+// eg: docker bridge, veth pair, vm host-only network, vpn tunnel
+var VirtualIfacePrefixes = []string{
+	"docker", "veth", "br-", "virbr", "vmnet", "vboxnet", "cni", "flannel",
+	"cali", "tun", "tap", "utun", "zt", "wg", "tailscale", "vEthernet",
+}
+
+// NetInterface info
+type NetInterface struct {
+	Index int
+	Name  string
+	// MAC hardware address. eg: "00:1a:2b:3c:4d:5e", empty for loopback
+	MAC string
+	MTU int
+	// Up the interface is up state
+	Up       bool
+	Loopback bool
+	// Virtual check by VirtualIfacePrefixes
+	Virtual bool
+	Flags   net.Flags
+	// IPv4 address list. eg: ["192.168.1.2"]
+	IPv4 []string
+	// IPv6 address list. eg: ["fe80::1"]
+	IPv6 []string
+}
+
+// HasIP check has any ip address
+func (ni *NetInterface) HasIP() bool {
+	return len(ni.IPv4) > 0 || len(ni.IPv6) > 0
+}
+
+// NetIfaceOption for filter network interfaces
+type NetIfaceOption struct {
+	// SkipLoopback skip loopback interfaces
+	SkipLoopback bool
+	// SkipVirtual skip virtual interfaces. see VirtualIfacePrefixes
+	SkipVirtual bool
+	// SkipDown skip interfaces not in up state
+	SkipDown bool
+	// OnlyHasIP only return interfaces has ip address
+	OnlyHasIP bool
+	// Filter custom filter func. return false will skip the interface.
+	Filter func(ni *NetInterface) bool
+}
+
+// IsVirtualIface check the network interface name is virtual interface
+func IsVirtualIface(name string) bool {
+	for _, prefix := range VirtualIfacePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// NetInterfaces get network interfaces info, and can filter by option.
+// if opt is nil, will return all interfaces.
+//
+// Usage:
+//
+//	// get physical, up and has ip address interfaces
+//	ifs, err := sysutil.NetInterfaces(&sysutil.NetIfaceOption{
+//		SkipLoopback: true,
+//		SkipVirtual:  true,
+//		SkipDown:     true,
+//		OnlyHasIP:    true,
+//	})
+func NetInterfaces(opt *NetIfaceOption) ([]*NetInterface, error) {
+	if opt == nil {
+		opt = &NetIfaceOption{}
+	}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*NetInterface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		ni := &NetInterface{
+			Index:    iface.Index,
+			Name:     iface.Name,
+			MAC:      iface.HardwareAddr.String(),
+			MTU:      iface.MTU,
+			Flags:    iface.Flags,
+			Up:       iface.Flags&net.FlagUp != 0,
+			Loopback: iface.Flags&net.FlagLoopback != 0,
+			Virtual:  IsVirtualIface(iface.Name),
+		}
+
+		if (opt.SkipLoopback && ni.Loopback) || (opt.SkipVirtual && ni.Virtual) || (opt.SkipDown && !ni.Up) {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, addr := range addrs {
+			var ip net.IP
+			switch v := addr.(type) {
+			case *net.IPNet:
+				ip = v.IP
+			case *net.IPAddr:
+				ip = v.IP
+			default:
+				continue
+			}
+
+			if ip4 := ip.To4(); ip4 != nil {
+				ni.IPv4 = append(ni.IPv4, ip4.String())
+			} else {
+				ni.IPv6 = append(ni.IPv6, ip.String())
+			}
+		}
+
+		if opt.OnlyHasIP && !ni.HasIP() {
+			continue
+		}
+		if opt.Filter != nil && !opt.Filter(ni) {
+			continue
+		}
+		list = append(list, ni)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,41 @@
+package sysutil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/sysutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestNetInterfaces(t *testing.T) {
+	all, err := sysutil.NetInterfaces(nil)
+	assert.NoErr(t, err)
+
+	ifs, err := sysutil.NetInterfaces(&sysutil.NetIfaceOption{
+		SkipLoopback: true,
+		SkipVirtual:  true,
+	})
+	assert.NoErr(t, err)
+	assert.True(t, len(ifs) <= len(all))
+	for _, ni := range ifs {
+		assert.False(t, ni.Loopback)
+		assert.False(t, ni.Virtual)
+	}
+
+	ifs, err = sysutil.NetInterfaces(&sysutil.NetIfaceOption{
+		Filter: func(ni *sysutil.NetInterface) bool {
+			return ni.Loopback
+		},
+	})
+	assert.NoErr(t, err)
+	for _, ni := range ifs {
+		assert.True(t, ni.Loopback)
+	}
+}
+
+func TestIsVirtualIface(t *testing.T) {
+	assert.True(t, sysutil.IsVirtualIface("docker0"))
+	assert.True(t, sysutil.IsVirtualIface("veth1a2b3c"))
+	assert.False(t, sysutil.IsVirtualIface("eth0"))
+	assert.False(t, sysutil.IsVirtualIface("en0"))
+}
@@ -0,0 +1,326 @@
+package sysutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/netutil/httpreq"
+)
+
+// SelfUpdateOption the options for SelfUpdate()
+type SelfUpdateOption struct {
+	// URL the download URL of the new binary. required
+	URL string
+	// Checksum the expected SHA256 hex string of the new binary. optional
+	Checksum string
+	// ChecksumURL the URL of the checksum file, the content format: "<sha256 hex>  <file name>". optional
+	//
+	// It can be a multi-entry file like SHA256SUMS, will find the line by ChecksumName.
+	ChecksumURL string
+	// ChecksumName the file name for find the checksum in the checksum file. default is the file name of URL.
+	ChecksumName string
+	// Verify custom verify the downloaded binary, eg: check the signature. optional
+	Verify func(binPath string) error
+	// Insecure allow to update without any verification, ie: Checksum, ChecksumURL and Verify are all empty.
+	//
+	// NOT recommended, the binary may be tampered.
+	Insecure bool
+	// Check the new binary after replaced, eg: run "app --version". will roll back on error. optional
+	Check func(exePath string) error
+	// TargetPath the executable path to replace. default is current executable.
+	TargetPath string
+	// KeepBackup keep the old binary as "{TargetPath}.old" after updated, for call SelfRollback() later.
+	//
+	// NOTE: on Windows, the running executable cannot be removed, so the backup is always kept.
+	KeepBackup bool
+	// Client custom http client for download. default use http.Client with Timeout
+	Client httpreq.Doer
+	// Timeout for download. default is 5 minutes
+	Timeout time.Duration
+}
+
+// SelfUpdate download the new binary and replace the current executable.
+// It's the backbone of an "upgrade" command for the cli apps.
+//
+// Steps:
+//   - download the new binary to "{TargetPath}.new", in the same dir for make the rename atomic.
+//   - verify the SHA256 checksum and call opt.Verify, at least one of them is required unless opt.Insecure is set.
+//   - backup the current executable to "{TargetPath}.old" by hard link or copy, then replace it by
+//     rename the new binary to TargetPath atomically.
+//     on Windows, the running executable cannot be overwritten, so it is renamed to the backup path first.
+//   - call opt.Check if set, will roll back to the old binary on check failed.
+//
+// Usage:
+//
+//	err := sysutil.SelfUpdate(&sysutil.SelfUpdateOption{
+//		URL:         "https://example.com/releases/v1.2.0/app-linux-amd64",
+//		ChecksumURL: "https://example.com/releases/v1.2.0/app-linux-amd64.sha256",
+//	})
+func SelfUpdate(opt *SelfUpdateOption) error {
+	if opt == nil || opt.URL == "" {
+		return errors.New("sysutil: the download URL is required for self update")
+	}
+	if opt.Checksum == "" && opt.ChecksumURL == "" && opt.Verify == nil && !opt.Insecure {
+		return errors.New("sysutil: the checksum or verify func is required for self update, or set Insecure=true")
+	}
+
+	target, err := selfUpdateTarget(opt.TargetPath)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return err
+	}
+
+	cli := opt.Client
+	if cli == nil {
+		timeout := opt.Timeout
+		if timeout <= 0 {
+			timeout = 5 * time.Minute
+		}
+		cli = &http.Client{Timeout: timeout}
+	}
+
+	checksum := opt.Checksum
+	if checksum == "" && opt.ChecksumURL != "" {
+		name := opt.ChecksumName
+		if name == "" {
+			name = urlFileName(opt.URL)
+		}
+
+		if checksum, err = fetchChecksum(cli, opt.ChecksumURL, name); err != nil {
+			return err
+		}
+	}
+
+	newPath := target + ".new"
+	if err = downloadBinary(cli, opt.URL, newPath, checksum, info.Mode()); err != nil {
+		_ = os.Remove(newPath)
+		return err
+	}
+
+	if opt.Verify != nil {
+		if err = opt.Verify(newPath); err != nil {
+			_ = os.Remove(newPath)
+			return fmt.Errorf("sysutil: verify the new binary failed: %w", err)
+		}
+	}
+
+	oldPath := target + ".old"
+	if IsWindows() {
+		err = replaceByRenameAway(target, newPath, oldPath)
+	} else {
+		err = replaceAtomic(target, newPath, oldPath, info.Mode())
+	}
+	if err != nil {
+		_ = os.Remove(newPath)
+		return err
+	}
+
+	if opt.Check != nil {
+		if err = opt.Check(target); err != nil {
+			if rbErr := SelfRollback(target); rbErr != nil {
+				return fmt.Errorf("sysutil: check the new binary failed: %w, and rollback failed: %s", err, rbErr.Error())
+			}
+			return fmt.Errorf("sysutil: check the new binary failed, rolled back: %w", err)
+		}
+	}
+
+	if !opt.KeepBackup && !IsWindows() {
+		_ = os.Remove(oldPath)
+	}
+	return nil
+}
+
+// SelfRollback restore the executable from the backup "{TargetPath}.old", which is created by SelfUpdate().
+// targetPath default is current executable.
+func SelfRollback(targetPath string) error {
+	target, err := selfUpdateTarget(targetPath)
+	if err != nil {
+		return err
+	}
+
+	oldPath := target + ".old"
+	if _, err = os.Stat(oldPath); err != nil {
+		return fmt.Errorf("sysutil: the backup binary not found: %w", err)
+	}
+
+	if !IsWindows() {
+		return os.Rename(oldPath, target)
+	}
+
+	// rename the bad one first, it may be running on Windows.
+	badPath := target + ".bad"
+	_ = os.Remove(badPath)
+	if err = os.Rename(target, badPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err = os.Rename(oldPath, target); err != nil {
+		_ = os.Rename(badPath, target)
+		return err
+	}
+
+	_ = os.Remove(badPath)
+	return nil
+}
+
+// replace the target by single rename, the target path always has an executable.
+func replaceAtomic(target, newPath, oldPath string, mode os.FileMode) error {
+	_ = os.Remove(oldPath)
+	if err := os.Link(target, oldPath); err != nil {
+		// eg: the file system not support hard link
+		if err = fsutil.CopyFile(target, oldPath); err != nil {
+			return err
+		}
+		if err = os.Chmod(oldPath, mode.Perm()); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Rename(newPath, target); err != nil {
+		return err
+	}
+
+	// sync the dir for persist the rename
+	if d, err := os.Open(filepath.Dir(target)); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+	return nil
+}
+
+// the running executable cannot be overwritten or removed on Windows, but can be renamed.
+func replaceByRenameAway(target, newPath, oldPath string) error {
+	_ = os.Remove(oldPath)
+	if err := os.Rename(target, oldPath); err != nil {
+		return err
+	}
+
+	if err := os.Rename(newPath, target); err != nil {
+		// restore the old binary
+		_ = os.Rename(oldPath, target)
+		return err
+	}
+	return nil
+}
+
+// get the file name of the URL path
+func urlFileName(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		return path.Base(u.Path)
+	}
+	return path.Base(rawURL)
+}
+
+func selfUpdateTarget(target string) (string, error) {
+	if target == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return "", err
+		}
+		target = exe
+	}
+
+	// resolve the symlink, replace the real file
+	if real, err := filepath.EvalSymlinks(target); err == nil {
+		target = real
+	}
+	return target, nil
+}
+
+func httpGet(cli httpreq.Doer, url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !httpreq.IsSuccessful(resp.StatusCode) {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("sysutil: request %s failed, status: %s", url, resp.Status)
+	}
+	return resp, nil
+}
+
+// fetch the checksum file and find the checksum of the file name.
+//
+// content format: "<sha256 hex>  <file name>" per line, the name may have "*" prefix on binary mode.
+// a single hash without name is also allowed.
+func fetchChecksum(cli httpreq.Doer, sumURL, name string) (string, error) {
+	resp, err := httpGet(cli, sumURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(bs)), "\n")
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case len(fields) == 1 && len(lines) == 1:
+			return fields[0], nil
+		case len(fields) >= 2 && path.Base(strings.TrimPrefix(fields[len(fields)-1], "*")) == name:
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("sysutil: the checksum of %q not found in %s", name, sumURL)
+}
+
+func downloadBinary(cli httpreq.Doer, url, dstPath, checksum string, mode os.FileMode) error {
+	resp, err := httpGet(cli, url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	f, err := os.OpenFile(dstPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), resp.Body)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if checksum != "" {
+		if sum := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(sum, checksum) {
+			return fmt.Errorf("sysutil: checksum mismatch, want %s, got %s", checksum, sum)
+		}
+	}
+
+	// ensure the mode, the umask may be applied on create
+	return os.Chmod(dstPath, mode.Perm())
+}
@@ -0,0 +1,136 @@
+package sysutil_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/sysutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestSelfUpdate(t *testing.T) {
+	newBin := []byte("new binary contents")
+	sum := sha256.Sum256(newBin)
+	sumHex := hex.EncodeToString(sum[:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(newBin)
+	})
+	mux.HandleFunc("/app.sha256", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(sumHex + "  app\n"))
+	})
+	mux.HandleFunc("/SHA256SUMS", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("0123abcd  app.tar.gz\n" + sumHex + " *app\n"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	target := filepath.Join(t.TempDir(), "app")
+	assert.NoErr(t, os.WriteFile(target, []byte("old binary"), 0755))
+
+	err := sysutil.SelfUpdate(&sysutil.SelfUpdateOption{
+		URL:         srv.URL + "/app",
+		ChecksumURL: srv.URL + "/app.sha256",
+		TargetPath:  target,
+		KeepBackup:  true,
+		Verify: func(binPath string) error {
+			assert.Eq(t, target+".new", binPath)
+			return nil
+		},
+	})
+	assert.NoErr(t, err)
+	assertFileContents(t, target, "new binary contents")
+	assertFileContents(t, target+".old", "old binary")
+	assert.False(t, fsutil.PathExists(target+".new"))
+
+	info, err := os.Stat(target)
+	assert.NoErr(t, err)
+	assert.Eq(t, os.FileMode(0755), info.Mode().Perm())
+
+	// rollback
+	assert.NoErr(t, sysutil.SelfRollback(target))
+	assertFileContents(t, target, "old binary")
+	assert.Err(t, sysutil.SelfRollback(target))
+
+	// checksum mismatch
+	err = sysutil.SelfUpdate(&sysutil.SelfUpdateOption{
+		URL:        srv.URL + "/app",
+		Checksum:   "abc",
+		TargetPath: target,
+	})
+	assert.ErrSubMsg(t, err, "checksum mismatch")
+	assertFileContents(t, target, "old binary")
+	assert.False(t, fsutil.PathExists(target+".new"))
+
+	// multi-entry checksum file
+	err = sysutil.SelfUpdate(&sysutil.SelfUpdateOption{
+		URL:         srv.URL + "/app",
+		ChecksumURL: srv.URL + "/SHA256SUMS",
+		TargetPath:  target,
+	})
+	assert.NoErr(t, err)
+	assertFileContents(t, target, "new binary contents")
+	assert.False(t, fsutil.PathExists(target+".old"))
+
+	err = sysutil.SelfUpdate(&sysutil.SelfUpdateOption{
+		URL:          srv.URL + "/app",
+		ChecksumURL:  srv.URL + "/SHA256SUMS",
+		ChecksumName: "app.zip",
+		TargetPath:   target,
+	})
+	assert.ErrSubMsg(t, err, `the checksum of "app.zip" not found`)
+
+	// no verification
+	err = sysutil.SelfUpdate(&sysutil.SelfUpdateOption{URL: srv.URL + "/app", TargetPath: target})
+	assert.ErrSubMsg(t, err, "checksum or verify func is required")
+
+	// not found
+	err = sysutil.SelfUpdate(&sysutil.SelfUpdateOption{URL: srv.URL + "/not-exists", TargetPath: target, Insecure: true})
+	assert.ErrSubMsg(t, err, "404 Not Found")
+	assert.Err(t, sysutil.SelfUpdate(nil))
+}
+
+func TestSelfUpdate_checkFail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("bad binary"))
+	}))
+	defer srv.Close()
+
+	target := filepath.Join(t.TempDir(), "app")
+	assert.NoErr(t, os.WriteFile(target, []byte("old binary"), 0755))
+
+	err := sysutil.SelfUpdate(&sysutil.SelfUpdateOption{
+		URL:        srv.URL,
+		TargetPath: target,
+		Insecure:   true,
+		Check: func(exePath string) error {
+			assertFileContents(t, exePath, "bad binary")
+			return errors.New("run failed")
+		},
+	})
+	assert.ErrSubMsg(t, err, "rolled back: run failed")
+	assertFileContents(t, target, "old binary")
+
+	err = sysutil.SelfUpdate(&sysutil.SelfUpdateOption{
+		URL:        srv.URL,
+		TargetPath: target,
+		Verify:     func(string) error { return errors.New("invalid signature") },
+	})
+	assert.ErrSubMsg(t, err, "invalid signature")
+	assertFileContents(t, target, "old binary")
+}
+
+func assertFileContents(t *testing.T, fPath, want string) {
+	t.Helper()
+	bs, err := os.ReadFile(fPath)
+	assert.NoErr(t, err)
+	assert.Eq(t, want, string(bs))
+}
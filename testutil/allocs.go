@@ -0,0 +1,108 @@
+package testutil
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// DefaultAllocRuns default run times for measure the allocations
+var DefaultAllocRuns = 100
+
+// AssertMaxAllocs check the average allocations per run of fn is not greater than max.
+// It use testing.AllocsPerRun, so fn will be run DefaultAllocRuns+1 times.
+//
+// NOTE: the allocations will be increased on enable the race detector or coverage.
+//
+// Usage:
+//
+//	testutil.AssertMaxAllocs(t, 0, func() {
+//		_ = strutil.QuoteStr("abc")
+//	})
+func AssertMaxAllocs(t testing.TB, max int, fn func()) bool {
+	t.Helper()
+	avg := testing.AllocsPerRun(DefaultAllocRuns, fn)
+	if avg > float64(max) {
+		t.Errorf("expected at most %d allocs per run, but got %v", max, avg)
+		return false
+	}
+	return true
+}
+
+// AllocStats the measured result of MeasureAllocs, like the testing.BenchmarkResult
+type AllocStats struct {
+	// Runs the run times of fn
+	Runs int
+	// Allocs total memory allocations
+	Allocs uint64
+	// Bytes total allocated bytes
+	Bytes uint64
+	// Elapsed total elapsed time
+	Elapsed time.Duration
+}
+
+// AllocsPerOp returns the allocations per run
+func (s AllocStats) AllocsPerOp() int64 {
+	if s.Runs <= 0 {
+		return 0
+	}
+	return int64(s.Allocs / uint64(s.Runs))
+}
+
+// BytesPerOp returns the allocated bytes per run
+func (s AllocStats) BytesPerOp() int64 {
+	if s.Runs <= 0 {
+		return 0
+	}
+	return int64(s.Bytes / uint64(s.Runs))
+}
+
+// NsPerOp returns the elapsed nanoseconds per run
+func (s AllocStats) NsPerOp() int64 {
+	if s.Runs <= 0 {
+		return 0
+	}
+	return s.Elapsed.Nanoseconds() / int64(s.Runs)
+}
+
+// String format like the benchmark output. eg: "100  25 ns/op  16 B/op  1 allocs/op"
+func (s AllocStats) String() string {
+	return fmt.Sprintf("%d\t%d ns/op\t%d B/op\t%d allocs/op", s.Runs, s.NsPerOp(), s.BytesPerOp(), s.AllocsPerOp())
+}
+
+// MeasureAllocs run fn multi times and measure the allocations, bytes and time like b.ReportAllocs(),
+// but can be used in normal tests. runs default is DefaultAllocRuns.
+//
+// Usage:
+//
+//	st := testutil.MeasureAllocs(func() {
+//		_ = strutil.Join(",", "a", "b")
+//	})
+//	assert.Lt(t, int(st.BytesPerOp()), 64)
+func MeasureAllocs(fn func(), runs ...int) AllocStats {
+	n := DefaultAllocRuns
+	if len(runs) > 0 && runs[0] > 0 {
+		n = runs[0]
+	}
+
+	// same as testing.AllocsPerRun: measure on single P and warm up once
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+	fn()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		fn()
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return AllocStats{
+		Runs:    n,
+		Allocs:  after.Mallocs - before.Mallocs,
+		Bytes:   after.TotalAlloc - before.TotalAlloc,
+		Elapsed: elapsed,
+	}
+}
@@ -0,0 +1,44 @@
+package testutil_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+var allocSink []byte
+
+func TestAssertMaxAllocs(t *testing.T) {
+	assert.True(t, testutil.AssertMaxAllocs(t, 0, func() {
+		_ = strings.HasPrefix("abc", "a")
+	}))
+
+	tb := &snapTB{TB: t}
+	ok := testutil.AssertMaxAllocs(tb, 0, func() {
+		allocSink = make([]byte, 64)
+	})
+	assert.False(t, ok)
+	assert.Len(t, tb.errs, 1)
+	assert.StrContains(t, tb.errs[0], "expected at most 0 allocs per run, but got 1")
+}
+
+func TestMeasureAllocs(t *testing.T) {
+	st := testutil.MeasureAllocs(func() {
+		allocSink = make([]byte, 64)
+	}, 50)
+	assert.Eq(t, 50, st.Runs)
+	assert.Eq(t, int64(1), st.AllocsPerOp())
+	assert.Eq(t, int64(64), st.BytesPerOp())
+	assert.StrContains(t, st.String(), "64 B/op\t1 allocs/op")
+
+	st = testutil.MeasureAllocs(func() {})
+	assert.Eq(t, testutil.DefaultAllocRuns, st.Runs)
+	assert.Eq(t, int64(0), st.AllocsPerOp())
+
+	st = testutil.AllocStats{}
+	assert.Eq(t, int64(0), st.AllocsPerOp())
+	assert.Eq(t, int64(0), st.BytesPerOp())
+	assert.Eq(t, int64(0), st.NsPerOp())
+}
@@ -1,5 +1,7 @@
 package assert
 
+import "time"
+
 // Nil asserts that the given is a nil value
 func (as *Assertions) Nil(give any, fmtAndArgs ...any) *Assertions {
 	as.t.Helper()
@@ -91,6 +93,13 @@ func (as *Assertions) ContainsKey(mp, key any, fmtAndArgs ...any) *Assertions {
 	return as
 }
 
+// ContainsMap asserts that the superset map contains all key and values of the subset map.
+func (as *Assertions) ContainsMap(superset, subset any, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = ContainsMap(as.t, superset, subset, fmtAndArgs...)
+	return as
+}
+
 // StrContains asserts that the given strings is contains sub-string
 func (as *Assertions) StrContains(s, sub string, fmtAndArgs ...any) *Assertions {
 	as.t.Helper()
@@ -133,6 +142,27 @@ func (as *Assertions) ErrIs(err, wantErr error, fmtAndArgs ...any) *Assertions {
 	return as
 }
 
+// ErrorIs asserts that the given error is equals wantErr
+func (as *Assertions) ErrorIs(err, wantErr error, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = ErrIs(as.t, err, wantErr, fmtAndArgs...)
+	return as
+}
+
+// ErrAs asserts that the given error can be as the target by errors.As()
+func (as *Assertions) ErrAs(err error, target any, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = ErrAs(as.t, err, target, fmtAndArgs...)
+	return as
+}
+
+// ErrorAs asserts that the given error can be as the target by errors.As()
+func (as *Assertions) ErrorAs(err error, target any, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = ErrAs(as.t, err, target, fmtAndArgs...)
+	return as
+}
+
 // ErrMsg asserts that the given is a not nil error and error message equals wantMsg
 func (as *Assertions) ErrMsg(err error, errMsg string, fmtAndArgs ...any) *Assertions {
 	as.t.Helper()
@@ -168,6 +198,13 @@ func (as *Assertions) Eq(want, give any, fmtAndArgs ...any) *Assertions {
 	return as
 }
 
+// JSONEq asserts that the two JSON strings are equivalent
+func (as *Assertions) JSONEq(want, give string, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = JSONEq(as.t, want, give, fmtAndArgs...)
+	return as
+}
+
 // Equal asserts that the want should equal to the given
 //
 // Alias of Eq()
@@ -236,6 +273,20 @@ func (as *Assertions) IsType(wantType, give any, fmtAndArgs ...any) *Assertions
 	return as
 }
 
+// Eventually asserts that the cond func will return true within the timeout
+func (as *Assertions) Eventually(cond func() bool, timeout, interval time.Duration, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = Eventually(as.t, cond, timeout, interval, fmtAndArgs...)
+	return as
+}
+
+// Never asserts that the cond func always return false in the duration
+func (as *Assertions) Never(cond func() bool, duration, interval time.Duration, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = Never(as.t, cond, duration, interval, fmtAndArgs...)
+	return as
+}
+
 // Fail reports a failure through
 func (as *Assertions) Fail(failMsg string, fmtAndArgs ...any) *Assertions {
 	as.t.Helper()
@@ -249,3 +300,31 @@ func (as *Assertions) FailNow(failMsg string, fmtAndArgs ...any) *Assertions {
 	as.ok = FailNow(as.t, failMsg, fmtAndArgs...)
 	return as
 }
+
+// StatusCode asserts that the HTTP response status code is equal to the want.
+func (as *Assertions) StatusCode(resp any, want int, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = StatusCode(as.t, resp, want, fmtAndArgs...)
+	return as
+}
+
+// HeaderEq asserts that the HTTP response header value is equal to the want.
+func (as *Assertions) HeaderEq(resp any, key, want string, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = HeaderEq(as.t, resp, key, want, fmtAndArgs...)
+	return as
+}
+
+// BodyContains asserts that the HTTP response body contains the sub string.
+func (as *Assertions) BodyContains(resp any, sub string, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = BodyContains(as.t, resp, sub, fmtAndArgs...)
+	return as
+}
+
+// BodyJSONPathEq asserts that the value at the path of the JSON response body is equal to the want.
+func (as *Assertions) BodyJSONPathEq(resp any, path string, want any, fmtAndArgs ...any) *Assertions {
+	as.t.Helper()
+	as.ok = BodyJSONPathEq(as.t, resp, path, want, fmtAndArgs...)
+	return as
+}
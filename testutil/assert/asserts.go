@@ -1,11 +1,13 @@
 package assert
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/gookit/goutil/arrutil"
 	"github.com/gookit/goutil/comdef"
@@ -288,6 +290,51 @@ func NotContainsKeys(t TestingT, mp any, keys any, fmtAndArgs ...any) bool {
 	return true
 }
 
+// ContainsMap asserts that the superset map contains all key and values of the subset map.
+// the values are compared by reflects.IsEqual(), nested maps should be equal.
+//
+// Usage:
+//
+//	ContainsMap(t, map[string]any{"a": 1, "b": 2}, map[string]any{"a": 1})
+func ContainsMap(t TestingT, superset, subset any, fmtAndArgs ...any) bool {
+	supRv, subRv := reflect.Indirect(reflect.ValueOf(superset)), reflect.Indirect(reflect.ValueOf(subset))
+	if supRv.Kind() != reflect.Map || subRv.Kind() != reflect.Map {
+		t.Helper()
+		return fail(t, fmt.Sprintf("Both should be map, but got: %T and %T", superset, subset), fmtAndArgs)
+	}
+
+	for _, key := range subRv.MapKeys() {
+		// convert key type. eg: subset is map[string]int, superset is map[string]any
+		supKey := key
+		if kt := supRv.Type().Key(); key.Type() != kt {
+			if kt.Kind() != reflect.Interface && kt.Kind() != key.Kind() {
+				t.Helper()
+				return fail(t, fmt.Sprintf("Map key type mismatch: %s and %s", kt, key.Type()), fmtAndArgs)
+			}
+			supKey = key.Convert(kt)
+		}
+
+		supVal := supRv.MapIndex(supKey)
+		if !supVal.IsValid() {
+			t.Helper()
+			return fail(t, fmt.Sprintf(
+				"Map should contains the key: %#v\nMap data:\n%v",
+				key.Interface(), maputil.FormatIndent(superset, "  "),
+			), fmtAndArgs)
+		}
+
+		want, give := subRv.MapIndex(key).Interface(), supVal.Interface()
+		if !reflects.IsEqual(want, give) {
+			t.Helper()
+			want, give = formatUnequalValues(want, give)
+			return fail(t, fmt.Sprintf("Map value of the key %#v not equal:\n"+
+				"expect: %s\n"+
+				"actual: %s", key.Interface(), want, give), fmtAndArgs)
+		}
+	}
+	return true
+}
+
 // ContainsElems asserts that the given list should contains sub elements.
 func ContainsElems[T comdef.ScalarType](t TestingT, list, sub []T, fmtAndArgs ...any) bool {
 	if arrutil.ContainsAll(list, sub) {
@@ -388,6 +435,37 @@ func ErrIs(t TestingT, err, wantErr error, fmtAndArgs ...any) bool {
 	return true
 }
 
+// ErrorIs asserts that the given error is equals wantErr. alias of ErrIs()
+func ErrorIs(t TestingT, err, wantErr error, fmtAndArgs ...any) bool {
+	t.Helper()
+	return ErrIs(t, err, wantErr, fmtAndArgs...)
+}
+
+// ErrAs asserts that the given error can be as the target by errors.As(), and set target to the error value.
+//
+// Usage:
+//
+//	var pathErr *fs.PathError
+//	assert.ErrAs(t, err, &pathErr)
+func ErrAs(t TestingT, err error, target any, fmtAndArgs ...any) bool {
+	if err == nil {
+		t.Helper()
+		return fail(t, "An error is expected but got nil.", fmtAndArgs)
+	}
+
+	if !errors.As(err, target) {
+		t.Helper()
+		return fail(t, fmt.Sprintf("Expect given err can be as %T, but got: %#v", target, err), fmtAndArgs)
+	}
+	return true
+}
+
+// ErrorAs asserts that the given error can be as the target. alias of ErrAs()
+func ErrorAs(t TestingT, err error, target any, fmtAndArgs ...any) bool {
+	t.Helper()
+	return ErrAs(t, err, target, fmtAndArgs...)
+}
+
 // ErrMsg asserts that the given is a not nil error and error message equals wantMsg
 func ErrMsg(t TestingT, err error, wantMsg string, fmtAndArgs ...any) bool {
 	if err == nil {
@@ -492,6 +570,34 @@ func Eq(t TestingT, want, give any, fmtAndArgs ...any) bool {
 	return true
 }
 
+// JSONEq asserts that the two JSON strings are equivalent, the keys order and whitespace are ignored.
+//
+// Usage:
+//
+//	JSONEq(t, `{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`)
+func JSONEq(t TestingT, want, give string, fmtAndArgs ...any) bool {
+	var wantVal, giveVal any
+	if err := json.Unmarshal([]byte(want), &wantVal); err != nil {
+		t.Helper()
+		return fail(t, fmt.Sprintf("Expected value is not valid JSON: %q\nError: %s", want, err), fmtAndArgs)
+	}
+	if err := json.Unmarshal([]byte(give), &giveVal); err != nil {
+		t.Helper()
+		return fail(t, fmt.Sprintf("Given value is not valid JSON: %q\nError: %s", give, err), fmtAndArgs)
+	}
+
+	if !reflect.DeepEqual(wantVal, giveVal) {
+		t.Helper()
+		// normalize for show the diff
+		wantBs, _ := json.MarshalIndent(wantVal, "", "  ")
+		giveBs, _ := json.MarshalIndent(giveVal, "", "  ")
+		return fail(t, fmt.Sprintf("JSON not equal: \n"+
+			"expect: %s\n"+
+			"actual: %s", wantBs, giveBs), fmtAndArgs)
+	}
+	return true
+}
+
 // Neq asserts that the want should not be equal to the given.
 //
 // alias of NotEq()
@@ -647,6 +753,72 @@ func samePointers(first, second any) bool {
 	return first == second
 }
 
+//
+// -------------------- async --------------------
+//
+
+// Eventually asserts that the cond func will return true within the timeout,
+// the cond will be checked on every interval.
+//
+//	assert.Eventually(t, func() bool { return srv.Ready() }, time.Second, 10*time.Millisecond)
+func Eventually(t TestingT, cond func() bool, timeout, interval time.Duration, fmtAndArgs ...any) bool {
+	start := time.Now()
+	deadline := start.Add(timeout)
+
+	var checks int
+	for {
+		checks++
+		if cond() {
+			return true
+		}
+
+		left := time.Until(deadline)
+		if left <= 0 {
+			break
+		}
+		if interval < left {
+			left = interval
+		}
+		time.Sleep(left)
+	}
+
+	t.Helper()
+	return fail(t, fmt.Sprintf(
+		"Condition never satisfied within %s\n\tWaited: %s, checked %d times every %s",
+		timeout, time.Since(start).Round(time.Millisecond), checks, interval,
+	), fmtAndArgs)
+}
+
+// Never asserts that the cond func always return false in the duration,
+// the cond will be checked on every interval.
+//
+//	assert.Never(t, func() bool { return closed.Load() }, 100*time.Millisecond, 10*time.Millisecond)
+func Never(t TestingT, cond func() bool, duration, interval time.Duration, fmtAndArgs ...any) bool {
+	start := time.Now()
+	deadline := start.Add(duration)
+
+	var checks int
+	for {
+		checks++
+		if cond() {
+			t.Helper()
+			return fail(t, fmt.Sprintf(
+				"Condition should never be satisfied within %s\n\tSatisfied after: %s, on the check %d (every %s)",
+				duration, time.Since(start).Round(time.Millisecond), checks, interval,
+			), fmtAndArgs)
+		}
+
+		left := time.Until(deadline)
+		if left <= 0 {
+			return true
+		}
+		if interval < left {
+			left = interval
+		}
+		time.Sleep(left)
+	}
+}
+
 //
 // -------------------- fail --------------------
 //
@@ -3,8 +3,12 @@ package assert_test
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"reflect"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gookit/goutil/testutil/assert"
 )
@@ -299,6 +303,58 @@ func TestErr(t *testing.T) {
 	assert.Err(t, err, "user custom message")
 	assert.Error(t, err)
 	assert.ErrMsg(t, err, "this is a error")
+
+	// is and as
+	wrapErr := fmt.Errorf("wrap: %w", &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist})
+	assert.ErrorIs(t, wrapErr, fs.ErrNotExist)
+
+	var pathErr *fs.PathError
+	assert.ErrAs(t, wrapErr, &pathErr)
+	assert.Eq(t, "a.txt", pathErr.Path)
+	assert.ErrorAs(t, wrapErr, &pathErr)
+	assert.New(t).ErrorIs(wrapErr, fs.ErrNotExist).ErrorAs(wrapErr, &pathErr)
+
+	tc := &tCustomTesting{T: t}
+	var sErr *strconv.NumError
+	assert.False(t, assert.ErrAs(tc, wrapErr, &sErr))
+	assert.StrContains(t, tc.ResetGet(), "Expect given err can be as **strconv.NumError")
+	assert.False(t, assert.ErrorAs(tc, nil, &sErr))
+	assert.StrContains(t, tc.ResetGet(), "An error is expected but got nil.")
+}
+
+func TestJSONEq(t *testing.T) {
+	assert.JSONEq(t, `{"a": 1, "b": [1, {"c": "d"}]}`, `{"b":[1,{"c":"d"}],"a":1.0}`)
+	assert.New(t).JSONEq(`[1, 2]`, `[1,2]`)
+
+	tc := &tCustomTesting{T: t}
+	assert.False(t, assert.JSONEq(tc, `{"a": 1}`, `{"a": 2}`))
+	assert.StrContains(t, tc.ResetGet(), "JSON not equal")
+	assert.False(t, assert.JSONEq(tc, `{"a": 1`, `{"a": 1}`))
+	assert.StrContains(t, tc.ResetGet(), "Expected value is not valid JSON")
+	assert.False(t, assert.JSONEq(tc, `{"a": 1}`, `invalid`))
+	assert.StrContains(t, tc.ResetGet(), "Given value is not valid JSON")
+}
+
+func TestContainsMap(t *testing.T) {
+	mp := map[string]any{
+		"age":  456,
+		"name": "inhere",
+		"tags": []string{"go"},
+	}
+	assert.ContainsMap(t, mp, map[string]any{"name": "inhere", "tags": []string{"go"}})
+	assert.ContainsMap(t, mp, map[string]string{"name": "inhere"})
+	assert.ContainsMap(t, mp, map[string]any{})
+	assert.New(t).ContainsMap(&mp, map[string]int{"age": 456})
+
+	tc := &tCustomTesting{T: t}
+	assert.False(t, assert.ContainsMap(tc, mp, map[string]any{"addr": "chengdu"}))
+	assert.StrContains(t, tc.ResetGet(), `Map should contains the key: "addr"`)
+	assert.False(t, assert.ContainsMap(tc, mp, map[string]any{"age": 123}))
+	assert.StrContains(t, tc.ResetGet(), `Map value of the key "age" not equal`)
+	assert.False(t, assert.ContainsMap(tc, mp, map[int]any{1: 123}))
+	assert.StrContains(t, tc.ResetGet(), "Map key type mismatch")
+	assert.False(t, assert.ContainsMap(tc, "abc", mp))
+	assert.StrContains(t, tc.ResetGet(), "Both should be map")
 }
 
 func TestContains(t *testing.T) {
@@ -320,3 +376,38 @@ func TestContains(t *testing.T) {
 	assert.ContainsElems(t, []string{"def"}, []string{"def"})
 	assert.ContainsElems(t, []string{"def", "abc"}, []string{"def"})
 }
+
+func TestEventually(t *testing.T) {
+	var n int32
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		atomic.StoreInt32(&n, 1)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&n) == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.New(t).Eventually(func() bool { return true }, time.Millisecond, time.Millisecond)
+
+	tc := &tCustomTesting{T: t}
+	assert.False(t, assert.Eventually(tc, func() bool { return false }, 30*time.Millisecond, 10*time.Millisecond))
+	msg := tc.ResetGet()
+	assert.StrContains(t, msg, "Condition never satisfied within 30ms")
+	assert.StrContains(t, msg, "Waited: 3")
+	assert.StrContains(t, msg, "every 10ms")
+}
+
+func TestNever(t *testing.T) {
+	assert.Never(t, func() bool { return false }, 20*time.Millisecond, 5*time.Millisecond)
+	assert.New(t).Never(func() bool { return false }, time.Millisecond, time.Millisecond)
+
+	var checks int
+	tc := &tCustomTesting{T: t}
+	assert.False(t, assert.Never(tc, func() bool {
+		checks++
+		return checks == 3
+	}, time.Second, time.Millisecond))
+	msg := tc.ResetGet()
+	assert.StrContains(t, msg, "Condition should never be satisfied within 1s")
+	assert.StrContains(t, msg, "on the check 3")
+}
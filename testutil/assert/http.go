@@ -0,0 +1,182 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+
+	"github.com/gookit/goutil/maputil"
+)
+
+//
+// -------------------- HTTP response --------------------
+//
+// The resp can be *http.Response or *httptest.ResponseRecorder.
+// The body of *http.Response will be reset after read, so can be asserted multiple times.
+//
+
+// StatusCode asserts that the HTTP response status code is equal to the want.
+func StatusCode(t TestingT, resp any, want int, fmtAndArgs ...any) bool {
+	res, err := toHTTPResponse(resp)
+	if err != nil {
+		t.Helper()
+		return fail(t, err.Error(), fmtAndArgs)
+	}
+
+	if res.StatusCode != want {
+		t.Helper()
+		body, _ := readRespBody(res)
+		return fail(t, fmt.Sprintf("HTTP status code not equal:\n"+
+			"expect: %d\n"+
+			"actual: %d\n"+
+			"body  : %s", want, res.StatusCode, truncBody(body)), fmtAndArgs)
+	}
+	return true
+}
+
+// HeaderEq asserts that the HTTP response header value is equal to the want.
+func HeaderEq(t TestingT, resp any, key, want string, fmtAndArgs ...any) bool {
+	res, err := toHTTPResponse(resp)
+	if err != nil {
+		t.Helper()
+		return fail(t, err.Error(), fmtAndArgs)
+	}
+
+	if give := res.Header.Get(key); give != want {
+		t.Helper()
+		return fail(t, fmt.Sprintf("HTTP header %q not equal:\n"+
+			"expect: %q\n"+
+			"actual: %q", key, want, give), fmtAndArgs)
+	}
+	return true
+}
+
+// BodyContains asserts that the HTTP response body contains the sub string.
+func BodyContains(t TestingT, resp any, sub string, fmtAndArgs ...any) bool {
+	body, err := respBody(resp)
+	if err != nil {
+		t.Helper()
+		return fail(t, err.Error(), fmtAndArgs)
+	}
+
+	if !strings.Contains(string(body), sub) {
+		t.Helper()
+		return fail(t, fmt.Sprintf("HTTP body should contains: %q\nbody: %s", sub, truncBody(body)), fmtAndArgs)
+	}
+	return true
+}
+
+// BodyJSONPathEq asserts that the value at the path of the JSON response body is equal to the want.
+// the path is separated by ".", use number for index of array. eg: "data.items.0.id"
+//
+// the want value will be compared after converted to JSON type. eg: 5 -> float64(5)
+//
+// Usage:
+//
+//	assert.BodyJSONPathEq(t, resp, "data.items.0.id", 5)
+func BodyJSONPathEq(t TestingT, resp any, path string, want any, fmtAndArgs ...any) bool {
+	body, err := respBody(resp)
+	if err != nil {
+		t.Helper()
+		return fail(t, err.Error(), fmtAndArgs)
+	}
+
+	var data any
+	if err = json.Unmarshal(body, &data); err != nil {
+		t.Helper()
+		return fail(t, fmt.Sprintf("HTTP body is not valid JSON: %s\nbody: %s", err, truncBody(body)), fmtAndArgs)
+	}
+
+	give, ok := jsonPathGet(data, path)
+	if !ok {
+		t.Helper()
+		return fail(t, fmt.Sprintf("JSON path %q not found in body: %s", path, truncBody(body)), fmtAndArgs)
+	}
+
+	// normalize the want value to JSON type
+	wantBs, err := json.Marshal(want)
+	if err != nil {
+		t.Helper()
+		return fail(t, fmt.Sprintf("Expected value cannot convert to JSON: %s", err), fmtAndArgs)
+	}
+
+	var wantVal any
+	_ = json.Unmarshal(wantBs, &wantVal)
+	if !reflect.DeepEqual(wantVal, give) {
+		t.Helper()
+		giveBs, _ := json.Marshal(give)
+		return fail(t, fmt.Sprintf("JSON path %q value not equal:\n"+
+			"expect: %s\n"+
+			"actual: %s", path, wantBs, giveBs), fmtAndArgs)
+	}
+	return true
+}
+
+func jsonPathGet(data any, path string) (any, bool) {
+	if path == "" {
+		return data, true
+	}
+
+	// the root is array or scalar
+	mp, ok := data.(map[string]any)
+	if !ok {
+		mp, path = map[string]any{"@": data}, "@."+path
+	}
+	return maputil.GetByPath(path, mp)
+}
+
+func toHTTPResponse(resp any) (*http.Response, error) {
+	switch typVal := resp.(type) {
+	case *http.Response:
+		if typVal != nil {
+			return typVal, nil
+		}
+	case *httptest.ResponseRecorder:
+		if typVal != nil {
+			return typVal.Result(), nil
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported HTTP response type: %T", resp)
+	}
+	return nil, fmt.Errorf("HTTP response is nil")
+}
+
+func respBody(resp any) ([]byte, error) {
+	if rr, ok := resp.(*httptest.ResponseRecorder); ok && rr != nil {
+		return rr.Body.Bytes(), nil
+	}
+
+	res, err := toHTTPResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	return readRespBody(res)
+}
+
+// read the body and reset it for read again
+func readRespBody(res *http.Response) ([]byte, error) {
+	if res.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("Read HTTP body error: %s", err)
+	}
+	return body, nil
+}
+
+// limit the body length on show
+func truncBody(body []byte) string {
+	if len(body) > 512 {
+		return string(body[:512]) + "...(truncated)"
+	}
+	return string(body)
+}
@@ -0,0 +1,82 @@
+package assert_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func newJSONRecorder(code int, body string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	rr.Header().Set("Content-Type", "application/json")
+	rr.WriteHeader(code)
+	_, _ = rr.WriteString(body)
+	return rr
+}
+
+func TestHTTPResponse_asserts(t *testing.T) {
+	body := `{"code": 0, "data": {"name": "inhere", "items": [{"id": 5, "tags": ["a"]}], "ok": true}}`
+	rr := newJSONRecorder(200, body)
+
+	assert.StatusCode(t, rr, http.StatusOK)
+	assert.HeaderEq(t, rr, "content-type", "application/json")
+	assert.BodyContains(t, rr, `"name": "inhere"`)
+	assert.BodyJSONPathEq(t, rr, "data.items.0.id", 5)
+	assert.BodyJSONPathEq(t, rr, "data.items.0.tags", []string{"a"})
+	assert.BodyJSONPathEq(t, rr, "data.ok", true)
+	assert.New(t).StatusCode(rr, 200).
+		HeaderEq(rr, "Content-Type", "application/json").
+		BodyContains(rr, "inhere").
+		BodyJSONPathEq(rr, "data.name", "inhere")
+
+	// *http.Response, body can be read multi times
+	res := rr.Result()
+	assert.BodyContains(t, res, "inhere")
+	assert.BodyJSONPathEq(t, res, "code", 0)
+	bs, err := io.ReadAll(res.Body)
+	assert.NoErr(t, err)
+	assert.Eq(t, body, string(bs))
+
+	// array root
+	assert.BodyJSONPathEq(t, newJSONRecorder(200, `[{"id": 1}, {"id": 2}]`), "1.id", 2)
+}
+
+func TestHTTPResponse_fail(t *testing.T) {
+	rr := newJSONRecorder(404, `{"error": "not found", "items": [1]}`)
+	tc := &tCustomTesting{T: t}
+
+	assert.False(t, assert.StatusCode(tc, rr, 200))
+	msg := tc.ResetGet()
+	assert.StrContains(t, msg, "HTTP status code not equal")
+	assert.StrContains(t, msg, `"error": "not found"`)
+
+	assert.False(t, assert.HeaderEq(tc, rr, "X-Request-Id", "abc"))
+	assert.StrContains(t, tc.ResetGet(), `HTTP header "X-Request-Id" not equal`)
+
+	assert.False(t, assert.BodyContains(tc, rr, "success"))
+	assert.StrContains(t, tc.ResetGet(), `HTTP body should contains: "success"`)
+
+	assert.False(t, assert.BodyJSONPathEq(tc, rr, "error", "other"))
+	assert.StrContains(t, tc.ResetGet(), `JSON path "error" value not equal`)
+
+	assert.False(t, assert.BodyJSONPathEq(tc, rr, "items.3", 1))
+	assert.StrContains(t, tc.ResetGet(), `JSON path "items.3" not found`)
+
+	assert.False(t, assert.BodyJSONPathEq(tc, newJSONRecorder(200, "not json"), "a", 1))
+	assert.StrContains(t, tc.ResetGet(), "HTTP body is not valid JSON")
+
+	assert.False(t, assert.StatusCode(tc, "invalid", 200))
+	assert.StrContains(t, tc.ResetGet(), "Unsupported HTTP response type: string")
+
+	var res *http.Response
+	assert.False(t, assert.BodyContains(tc, res, "a"))
+	assert.StrContains(t, tc.ResetGet(), "HTTP response is nil")
+
+	res = &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(strings.Repeat("a", 600)))}
+	assert.False(t, assert.BodyContains(tc, res, "b"))
+	assert.StrContains(t, tc.ResetGet(), "...(truncated)")
+}
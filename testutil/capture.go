@@ -0,0 +1,74 @@
+package testutil
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+)
+
+// CaptureOutput run fn and capture the os.Stdout and os.Stderr output.
+//
+// The output is read by background goroutines while fn is running, so large
+// output will not block on the pipe buffer. os.Stdout and os.Stderr will be
+// restored after fn returns, even if fn panics.
+//
+// Usage:
+//
+//	out, errOut := testutil.CaptureOutput(func() {
+//		fmt.Println("hello")
+//		fmt.Fprintln(os.Stderr, "error")
+//	})
+func CaptureOutput(fn func()) (stdout, stderr string) {
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		panic(err)
+	}
+
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		_ = outR.Close()
+		_ = outW.Close()
+		panic(err)
+	}
+
+	var wg sync.WaitGroup
+	var outBuf, errBuf bytes.Buffer
+	readPipe := func(buf *bytes.Buffer, r *os.File) {
+		defer wg.Done()
+		_, _ = io.Copy(buf, r)
+		_ = r.Close()
+	}
+
+	wg.Add(2)
+	go readPipe(&outBuf, outR)
+	go readPipe(&errBuf, errR)
+
+	bakOut, bakErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+
+	defer func() {
+		os.Stdout, os.Stderr = bakOut, bakErr
+		// must close writers before wait the readers
+		_ = outW.Close()
+		_ = errW.Close()
+		wg.Wait()
+
+		stdout, stderr = outBuf.String(), errBuf.String()
+	}()
+
+	fn()
+	return
+}
+
+// CaptureStdout run fn and capture the os.Stdout output.
+func CaptureStdout(fn func()) string {
+	out, _ := CaptureOutput(fn)
+	return out
+}
+
+// CaptureStderr run fn and capture the os.Stderr output.
+func CaptureStderr(fn func()) string {
+	_, errOut := CaptureOutput(fn)
+	return errOut
+}
@@ -0,0 +1,56 @@
+package testutil_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestCaptureOutput(t *testing.T) {
+	bakOut, bakErr := os.Stdout, os.Stderr
+	out, errOut := testutil.CaptureOutput(func() {
+		fmt.Println("hello")
+		_, _ = fmt.Fprint(os.Stderr, "error")
+	})
+
+	assert.Eq(t, "hello\n", out)
+	assert.Eq(t, "error", errOut)
+	assert.True(t, os.Stdout == bakOut)
+	assert.True(t, os.Stderr == bakErr)
+
+	assert.Eq(t, "hi", testutil.CaptureStdout(func() {
+		fmt.Print("hi")
+	}))
+	assert.Eq(t, "oops", testutil.CaptureStderr(func() {
+		_, _ = fmt.Fprint(os.Stderr, "oops")
+	}))
+}
+
+func TestCaptureOutput_large(t *testing.T) {
+	// larger than the pipe buffer size(64K on linux)
+	line := strings.Repeat("a", 1023) + "\n"
+	out, errOut := testutil.CaptureOutput(func() {
+		for i := 0; i < 512; i++ {
+			_, _ = os.Stdout.WriteString(line)
+			_, _ = os.Stderr.WriteString(line)
+		}
+	})
+
+	assert.Len(t, out, 512*1024)
+	assert.Len(t, errOut, 512*1024)
+}
+
+func TestCaptureOutput_panic(t *testing.T) {
+	bakOut := os.Stdout
+	assert.Panics(t, func() {
+		testutil.CaptureOutput(func() {
+			fmt.Println("hello")
+			panic("error")
+		})
+	})
+	assert.True(t, os.Stdout == bakOut)
+}
@@ -0,0 +1,127 @@
+package testutil
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// CaseNameFields the field names for get the subtest name from the case struct. see RunCases
+var CaseNameFields = []string{"Name", "Title", "Desc"}
+
+// CaseOption options for RunCases
+type CaseOption[T any] struct {
+	// Setup func, will call it before each case run.
+	Setup func(t *testing.T, c T)
+	// Teardown func, will call it after each case run, even if the case failed.
+	Teardown func(t *testing.T, c T)
+}
+
+// CaseOptFn option func for RunCases
+type CaseOptFn[T any] func(opt *CaseOption[T])
+
+// WithSetup set the setup func, will call it before each case run.
+func WithSetup[T any](fn func(t *testing.T, c T)) CaseOptFn[T] {
+	return func(opt *CaseOption[T]) {
+		opt.Setup = fn
+	}
+}
+
+// WithTeardown set the teardown func, will call it after each case run, even if the case failed.
+func WithTeardown[T any](fn func(t *testing.T, c T)) CaseOptFn[T] {
+	return func(opt *CaseOption[T]) {
+		opt.Teardown = fn
+	}
+}
+
+// RunCases run the table-driven test cases, each case will run in a subtest.
+//
+//   - the subtest name from the string field in CaseNameFields, or the case index. eg: "#2"
+//   - a case with bool field "Skip" is true, will be skipped
+//   - if any case with bool field "Focus" is true, only run the focused cases
+//
+// Usage:
+//
+//	type tcase struct {
+//		Name  string
+//		In    string
+//		Want  int
+//		Focus bool
+//	}
+//
+//	tests := []tcase{
+//		{Name: "empty", In: "", Want: 0},
+//		{Name: "ascii", In: "abc", Want: 3},
+//	}
+//
+//	testutil.RunCases(t, tests, func(t *testing.T, c tcase) {
+//		assert.Eq(t, c.Want, len(c.In))
+//	})
+func RunCases[T any](t *testing.T, cases []T, fn func(t *testing.T, c T), optFns ...CaseOptFn[T]) {
+	t.Helper()
+	opt := &CaseOption[T]{}
+	for _, optFn := range optFns {
+		optFn(opt)
+	}
+
+	var hasFocus bool
+	for _, c := range cases {
+		if caseBoolField(c, "Focus") {
+			hasFocus = true
+			break
+		}
+	}
+
+	for i, c := range cases {
+		c := c
+		t.Run(caseName(c, i), func(t *testing.T) {
+			if caseBoolField(c, "Skip") {
+				t.Skip("skipped by the Skip marker")
+			}
+			if hasFocus && !caseBoolField(c, "Focus") {
+				t.Skip("skipped since other cases are focused")
+			}
+
+			if opt.Teardown != nil {
+				t.Cleanup(func() { opt.Teardown(t, c) })
+			}
+			if opt.Setup != nil {
+				opt.Setup(t, c)
+			}
+			fn(t, c)
+		})
+	}
+}
+
+// get the case struct value, returns invalid value on it is not struct
+func caseStructValue(c any) reflect.Value {
+	rv := reflect.ValueOf(c)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return rv
+}
+
+func caseName(c any, idx int) string {
+	if rv := caseStructValue(c); rv.IsValid() {
+		for _, name := range CaseNameFields {
+			fv := rv.FieldByName(name)
+			if fv.IsValid() && fv.Kind() == reflect.String && fv.String() != "" {
+				return fv.String()
+			}
+		}
+	}
+	return fmt.Sprintf("#%d", idx)
+}
+
+func caseBoolField(c any, name string) bool {
+	if rv := caseStructValue(c); rv.IsValid() {
+		fv := rv.FieldByName(name)
+		return fv.IsValid() && fv.Kind() == reflect.Bool && fv.Bool()
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package testutil_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestRunCases(t *testing.T) {
+	type tcase struct {
+		Name string
+		In   string
+		Want int
+		Skip bool
+	}
+
+	tests := []tcase{
+		{Name: "empty", In: "", Want: 0},
+		{Name: "ascii", In: "abc", Want: 3},
+		{In: "no name", Want: 7},
+		{Name: "skipped", In: "abc", Want: 100, Skip: true},
+	}
+
+	var names, setups, teardowns []string
+	testutil.RunCases(t, tests, func(t *testing.T, c tcase) {
+		names = append(names, t.Name())
+		assert.Eq(t, c.Want, len(c.In))
+	}, testutil.WithSetup(func(t *testing.T, c tcase) {
+		setups = append(setups, c.In)
+	}), testutil.WithTeardown(func(t *testing.T, c tcase) {
+		teardowns = append(teardowns, c.In)
+	}))
+
+	assert.Eq(t, []string{"TestRunCases/empty", "TestRunCases/ascii", "TestRunCases/#2"}, names)
+	assert.Eq(t, []string{"", "abc", "no name"}, setups)
+	assert.Eq(t, setups, teardowns)
+}
+
+func TestRunCases_focus(t *testing.T) {
+	tests := []*struct {
+		Title string
+		Focus bool
+	}{
+		{Title: "case1"},
+		{Title: "case2", Focus: true},
+		{Title: "case3"},
+	}
+
+	var ran []string
+	testutil.RunCases(t, tests, func(t *testing.T, c *struct {
+		Title string
+		Focus bool
+	}) {
+		ran = append(ran, c.Title)
+	})
+	assert.Eq(t, []string{"case2"}, ran)
+
+	// not struct
+	var sum int
+	testutil.RunCases(t, []int{1, 2, 3}, func(t *testing.T, n int) {
+		sum += n
+	})
+	assert.Eq(t, 6, sum)
+}
@@ -0,0 +1,194 @@
+package testutil
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gookit/goutil/timex"
+)
+
+// Clock a fake clock for testing the time-dependent code. the time only changed by Advance() or Set().
+// It implements the timex.Clock interface.
+//
+// Usage:
+//
+//	clk := testutil.NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	ch := clk.After(time.Minute)
+//
+//	clk.Advance(time.Minute)
+//	<-ch // fired
+type Clock struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	now  time.Time
+	// waiters of After, Sleep and tickers
+	waiters []*clockWaiter
+}
+
+type clockWaiter struct {
+	at time.Time
+	ch chan time.Time
+	// period > 0 for ticker
+	period time.Duration
+}
+
+var _ timex.Clock = (*Clock)(nil)
+
+// NewClock create a fake clock, default start time is time.Now()
+func NewClock(start ...time.Time) *Clock {
+	now := time.Now()
+	if len(start) > 0 {
+		now = start[0]
+	}
+
+	c := &Clock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now get current fake time
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since get the duration since t
+func (c *Clock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+// After returns a channel, will receive the time after advanced d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &clockWaiter{at: c.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.ch <- c.now
+		return w.ch
+	}
+
+	c.addWaiter(w)
+	return w.ch
+}
+
+// Sleep blocks until the clock advanced d.
+func (c *Clock) Sleep(d time.Duration) { <-c.After(d) }
+
+// NewTicker create a fake ticker, will tick on the clock advanced every d.
+func (c *Clock) NewTicker(d time.Duration) timex.Ticker {
+	if d <= 0 {
+		panic("testutil: non-positive interval for Clock.NewTicker")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &clockWaiter{at: c.now.Add(d), ch: make(chan time.Time, 1), period: d}
+	c.addWaiter(w)
+	return &fakeTicker{c: c, w: w}
+}
+
+// Waiters get the number of pending waiters. include After, Sleep and tickers.
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil blocks until there are at least n pending waiters.
+// useful for wait the goroutines call Sleep() or After() before Advance().
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// Advance the clock by d, will fire the due waiters by time order.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+
+	c.Set(target)
+}
+
+// Set the clock to t, will fire the due waiters by time order. t before now will be ignored.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t.Before(c.now) {
+		return
+	}
+
+	for len(c.waiters) > 0 && !c.waiters[0].at.After(t) {
+		w := c.waiters[0]
+		c.waiters = c.waiters[1:]
+		c.now = w.at
+
+		// like time.Ticker, drop the tick if the receiver is slow.
+		select {
+		case w.ch <- w.at:
+		default:
+		}
+
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+			c.addWaiter(w)
+		}
+	}
+	c.now = t
+}
+
+// add waiter and keep the waiters sorted by time. must hold the lock.
+func (c *Clock) addWaiter(w *clockWaiter) {
+	idx := sort.Search(len(c.waiters), func(i int) bool {
+		return c.waiters[i].at.After(w.at)
+	})
+
+	c.waiters = append(c.waiters, nil)
+	copy(c.waiters[idx+1:], c.waiters[idx:])
+	c.waiters[idx] = w
+	c.cond.Broadcast()
+}
+
+// remove waiter. must hold the lock.
+func (c *Clock) removeWaiter(w *clockWaiter) {
+	for i, cw := range c.waiters {
+		if cw == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+type fakeTicker struct {
+	c *Clock
+	w *clockWaiter
+}
+
+func (t *fakeTicker) Chan() <-chan time.Time { return t.w.ch }
+
+func (t *fakeTicker) Stop() {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	t.c.removeWaiter(t.w)
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("testutil: non-positive interval for Ticker.Reset")
+	}
+
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+
+	t.c.removeWaiter(t.w)
+	t.w.period = d
+	t.w.at = t.c.now.Add(d)
+	t.c.addWaiter(t.w)
+}
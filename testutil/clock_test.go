@@ -0,0 +1,94 @@
+package testutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/timex"
+)
+
+var clockStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestClock_After(t *testing.T) {
+	var clk timex.Clock = testutil.NewClock(clockStart)
+	fc := clk.(*testutil.Clock)
+	assert.Eq(t, clockStart, clk.Now())
+
+	ch1 := clk.After(2 * time.Second)
+	ch2 := clk.After(time.Second)
+	assert.Eq(t, 2, fc.Waiters())
+
+	// fire immediately
+	assert.Eq(t, clockStart, <-clk.After(0))
+
+	fc.Advance(500 * time.Millisecond)
+	assert.Eq(t, 500*time.Millisecond, clk.Since(clockStart))
+	assertNotFired(t, ch1)
+	assertNotFired(t, ch2)
+
+	fc.Advance(time.Second)
+	assertNotFired(t, ch1)
+	assert.Eq(t, clockStart.Add(time.Second), <-ch2)
+
+	fc.Set(clockStart.Add(time.Hour))
+	assert.Eq(t, clockStart.Add(2*time.Second), <-ch1)
+	assert.Eq(t, 0, fc.Waiters())
+	assert.Eq(t, clockStart.Add(time.Hour), fc.Now())
+
+	// ignore set to before
+	fc.Set(clockStart)
+	assert.Eq(t, clockStart.Add(time.Hour), fc.Now())
+}
+
+func TestClock_Sleep(t *testing.T) {
+	clk := testutil.NewClock()
+	start := clk.Now()
+
+	done := make(chan time.Time)
+	go func() {
+		clk.Sleep(time.Minute)
+		done <- clk.Now()
+	}()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+	assert.Eq(t, start.Add(time.Minute), <-done)
+}
+
+func TestClock_NewTicker(t *testing.T) {
+	clk := testutil.NewClock(clockStart)
+	tk := clk.NewTicker(time.Second)
+
+	clk.Advance(time.Second)
+	assert.Eq(t, clockStart.Add(time.Second), <-tk.Chan())
+
+	// slow receiver, the ticks are dropped
+	clk.Advance(3 * time.Second)
+	assert.Eq(t, clockStart.Add(2*time.Second), <-tk.Chan())
+	assertNotFired(t, tk.Chan())
+
+	tk.Reset(10 * time.Second)
+	clk.Advance(5 * time.Second)
+	assertNotFired(t, tk.Chan())
+	clk.Advance(5 * time.Second)
+	assert.Eq(t, clockStart.Add(14*time.Second), <-tk.Chan())
+
+	tk.Stop()
+	assert.Eq(t, 0, clk.Waiters())
+	clk.Advance(time.Minute)
+	assertNotFired(t, tk.Chan())
+
+	assert.Panics(t, func() {
+		clk.NewTicker(0)
+	})
+}
+
+func assertNotFired(t *testing.T, ch <-chan time.Time) {
+	select {
+	case v := <-ch:
+		t.Errorf("unexpected fired at %v", v)
+	default:
+	}
+}
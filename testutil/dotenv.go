@@ -0,0 +1,187 @@
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// LoadEnvFile load the dotenv file and set the ENV by t.Setenv, will restore on the test finished.
+// returns the parsed ENV data.
+//
+// Supported syntax see ParseDotenv.
+//
+// Usage:
+//
+//	testutil.LoadEnvFile(t, "testdata/test.env")
+func LoadEnvFile(t testing.TB, fpath string) map[string]string {
+	t.Helper()
+	bs, err := os.ReadFile(fpath)
+	if err != nil {
+		t.Fatalf("testutil: load env file error: %v", err)
+		return nil
+	}
+
+	mp, err := ParseDotenv(string(bs))
+	if err != nil {
+		t.Fatalf("testutil: parse env file %q error: %v", fpath, err)
+		return nil
+	}
+
+	for key, val := range mp {
+		t.Setenv(key, val)
+	}
+	return mp
+}
+
+// ParseDotenv parse the dotenv format text to map.
+//
+// Supported syntax:
+//
+//   - comment line start with "#", inline comment start with " #" on the unquoted value
+//   - optional "export " prefix
+//   - single-quoted value: raw string, no escape and expansion
+//   - double-quoted value: can be multi line, support escape: \n \r \t \" \\ \$
+//   - expand variable in unquoted and double-quoted value: $VAR, ${VAR}, ${VAR:-default}.
+//     will find from the previous defined keys first, then the OS ENV.
+func ParseDotenv(text string) (map[string]string, error) {
+	mp := make(map[string]string)
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		key, val, ok := strings.Cut(line, "=")
+		if key = strings.TrimSpace(key); !ok || !isEnvKey(key) {
+			return nil, fmt.Errorf("invalid env line %d: %q", lineNo, lines[i])
+		}
+
+		val = strings.TrimSpace(val)
+		if val == "" {
+			mp[key] = ""
+			continue
+		}
+
+		switch val[0] {
+		case '\'':
+			end := strings.IndexByte(val[1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed single quote at line %d", lineNo)
+			}
+			mp[key] = val[1 : end+1]
+		case '"':
+			// find the close quote, the value can be multi line
+			raw, closed := val[1:], false
+			for {
+				if end := closeQuoteIndex(raw); end >= 0 {
+					raw, closed = raw[:end], true
+					break
+				}
+				if i+1 >= len(lines) {
+					break
+				}
+				i++
+				raw += "\n" + lines[i]
+			}
+
+			if !closed {
+				return nil, fmt.Errorf("unclosed double quote at line %d", lineNo)
+			}
+			mp[key] = expandEnvVars(unescapeDQ(raw), mp)
+		default:
+			if pos := strings.Index(val, " #"); pos >= 0 {
+				val = strings.TrimSpace(val[:pos])
+			}
+			mp[key] = expandEnvVars(val, mp)
+		}
+	}
+	return mp, nil
+}
+
+func isEnvKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, c := range key {
+		if c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// find the unescaped double quote index
+func closeQuoteIndex(s string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+		} else if s[i] == '"' {
+			return i
+		}
+	}
+	return -1
+}
+
+// escaped "$" is kept as "\x00" for skip expand, will be restored by expandEnvVars
+const escapedDollar = "\x00"
+
+func unescapeDQ(s string) string {
+	if !strings.ContainsRune(s, '\\') {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case '$':
+			sb.WriteString(escapedDollar)
+		case '"', '\\':
+			sb.WriteByte(s[i])
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+// expand the $VAR, ${VAR}, ${VAR:-default} in the value.
+func expandEnvVars(s string, mp map[string]string) string {
+	if strings.ContainsRune(s, '$') {
+		s = os.Expand(s, func(name string) string {
+			var def string
+			if key, val, ok := strings.Cut(name, ":-"); ok {
+				name, def = key, val
+			}
+
+			val, ok := mp[name]
+			if !ok {
+				val = os.Getenv(name)
+			}
+			if val == "" {
+				return def
+			}
+			return val
+		})
+	}
+	return strings.ReplaceAll(s, escapedDollar, "$")
+}
@@ -0,0 +1,68 @@
+package testutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestParseDotenv(t *testing.T) {
+	t.Setenv("DOTENV_OS_VAR", "from-os")
+
+	mp, err := testutil.ParseDotenv(`
+# comment line
+APP_NAME=demo
+export APP_ENV = dev # inline comment
+EMPTY=
+SINGLE='raw $APP_NAME \n # not comment'
+DOUBLE="hello\t\"$APP_NAME\"\n"
+MULTI="line1
+line2"
+HASH=abc#def
+EXPAND=${APP_NAME}-$APP_ENV-${DOTENV_OS_VAR}
+DEFAULT=${NOT_EXIST_VAR:-def-val}
+ESCAPED="cost \$100"
+`)
+	assert.NoErr(t, err)
+	assert.Eq(t, "demo", mp["APP_NAME"])
+	assert.Eq(t, "dev", mp["APP_ENV"])
+	assert.Eq(t, "", mp["EMPTY"])
+	assert.Eq(t, `raw $APP_NAME \n # not comment`, mp["SINGLE"])
+	assert.Eq(t, "hello\t\"demo\"\n", mp["DOUBLE"])
+	assert.Eq(t, "line1\nline2", mp["MULTI"])
+	assert.Eq(t, "abc#def", mp["HASH"])
+	assert.Eq(t, "demo-dev-from-os", mp["EXPAND"])
+	assert.Eq(t, "def-val", mp["DEFAULT"])
+	assert.Eq(t, "cost $100", mp["ESCAPED"])
+
+	// invalid
+	_, err = testutil.ParseDotenv("INVALID LINE")
+	assert.ErrSubMsg(t, err, "invalid env line 1")
+	_, err = testutil.ParseDotenv("1KEY=val")
+	assert.Err(t, err)
+	_, err = testutil.ParseDotenv("KEY='val")
+	assert.ErrSubMsg(t, err, "unclosed single quote")
+	_, err = testutil.ParseDotenv("KEY=\"val\nNEXT=val")
+	assert.ErrSubMsg(t, err, "unclosed double quote at line 1")
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "test.env")
+	err := os.WriteFile(fpath, []byte("DOTENV_KEY1=val1\nexport DOTENV_KEY2=\"${DOTENV_KEY1}-val2\"\n"), 0644)
+	assert.NoErr(t, err)
+
+	t.Run("load", func(t *testing.T) {
+		mp := testutil.LoadEnvFile(t, fpath)
+		assert.Len(t, mp, 2)
+		assert.Eq(t, "val1", os.Getenv("DOTENV_KEY1"))
+		assert.Eq(t, "val1-val2", os.Getenv("DOTENV_KEY2"))
+	})
+
+	// restored
+	assert.Eq(t, "", os.Getenv("DOTENV_KEY1"))
+	_, ok := os.LookupEnv("DOTENV_KEY2")
+	assert.False(t, ok)
+}
@@ -3,6 +3,8 @@ package testutil
 import (
 	"os"
 	"strings"
+	"sync"
+	"testing"
 )
 
 // Env mocking
@@ -91,19 +93,47 @@ func MockOsEnv(mp map[string]string, fn func()) {
 // backup os ENV
 var envBak = os.Environ()
 
-// ClearOSEnv info.
+// guard the ClearOSEnv and RevertOSEnv are called in pairs
+var envClear = struct {
+	sync.Mutex
+	cleared bool
+}{}
+
+// ClearOSEnv info. will backup the current ENV, can be restored by RevertOSEnv.
+//
+// NOTE: it changes the global process ENV, will panic on call it again before RevertOSEnv.
+// eg: used in parallel tests. please use CleanEnv instead.
 //
 // Usage:
 //
 //	testutil.ClearOSEnv()
 //	defer testutil.RevertOSEnv()
 //	// do something ...
-func ClearOSEnv() { os.Clearenv() }
+func ClearOSEnv() {
+	envClear.Lock()
+	defer envClear.Unlock()
+	if envClear.cleared {
+		panic("testutil: ClearOSEnv called again before RevertOSEnv, maybe used in parallel tests")
+	}
+
+	envClear.cleared = true
+	envBak = os.Environ()
+	os.Clearenv()
+}
 
 // RevertOSEnv info
 func RevertOSEnv() {
+	envClear.Lock()
+	defer envClear.Unlock()
+
+	envClear.cleared = false
+	restoreEnv(envBak)
+}
+
+// restore the ENV from os.Environ() format data
+func restoreEnv(environ []string) {
 	os.Clearenv()
-	for _, str := range envBak {
+	for _, str := range environ {
 		nodes := strings.SplitN(str, "=", 2)
 		_ = os.Setenv(nodes[0], nodes[1])
 	}
@@ -114,16 +144,64 @@ func RevertOSEnv() {
 // will clear all old ENV data, use given data map.
 // will recover old ENV after fn run.
 func MockCleanOsEnv(mp map[string]string, fn func()) {
+	backup := os.Environ()
 	os.Clearenv()
 	for key, val := range mp {
 		_ = os.Setenv(key, val)
 	}
 
 	fn()
+	restoreEnv(backup)
+}
+
+//
+// ENV mocking by t.Setenv, will restore on the test finished.
+// They will panic on the test or its ancestors called t.Parallel(),
+// since the process ENV is global.
+//
+
+// SetEnv set the ENV value by t.Setenv, will restore on the test finished.
+func SetEnv(t testing.TB, key, val string) {
+	t.Helper()
+	t.Setenv(key, val)
+}
 
+// SetEnvs set multi ENV values by t.Setenv, will restore on the test finished.
+func SetEnvs(t testing.TB, kvMap map[string]string) {
+	t.Helper()
+	for key, val := range kvMap {
+		t.Setenv(key, val)
+	}
+}
+
+// UnsetEnv unset the ENV key, will restore on the test finished.
+func UnsetEnv(t testing.TB, key string) {
+	t.Helper()
+	t.Setenv(key, "") // register the restore on cleanup
+	_ = os.Unsetenv(key)
+}
+
+// envMockMarker the ENV key for mark the test is changed ENV.
+const envMockMarker = "GOUTIL_TEST_ENV_MOCKED"
+
+// CleanEnv clear all ENV and set the given data map, will restore on the test finished.
+//
+// Usage:
+//
+//	testutil.CleanEnv(t, map[string]string{"APP_ENV": "dev"})
+//	// os.Environ() only contains APP_ENV
+func CleanEnv(t testing.TB, mp map[string]string) {
+	t.Helper()
+	// mark the test is incompatible with parallel
+	t.Setenv(envMockMarker, "1")
+
+	backup := os.Environ()
 	os.Clearenv()
-	for _, str := range envBak {
-		nodes := strings.SplitN(str, "=", 2)
-		_ = os.Setenv(nodes[0], nodes[1])
+	for key, val := range mp {
+		_ = os.Setenv(key, val)
 	}
+
+	t.Cleanup(func() {
+		restoreEnv(backup)
+	})
 }
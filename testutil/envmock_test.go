@@ -77,3 +77,46 @@ APP_PWD=
 		assert.Eq(t, "", os.Getenv("APP_PWD"))
 	})
 }
+
+func TestClearOSEnv_guard(t *testing.T) {
+	testutil.ClearOSEnv()
+	assert.PanicsMsg(t, testutil.ClearOSEnv, "testutil: ClearOSEnv called again before RevertOSEnv, maybe used in parallel tests")
+	testutil.RevertOSEnv()
+	assert.NotEmpty(t, os.Environ())
+}
+
+func TestSetEnv(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		testutil.SetEnv(t, "APP_COMMAND", "login")
+		testutil.SetEnvs(t, map[string]string{"APP_ENV": "dev", "APP_DEBUG": "true"})
+		assert.Eq(t, "login", os.Getenv("APP_COMMAND"))
+		assert.Eq(t, "dev", os.Getenv("APP_ENV"))
+		assert.Eq(t, "true", os.Getenv("APP_DEBUG"))
+	})
+
+	assert.Eq(t, "", os.Getenv("APP_COMMAND"))
+	_, ok := os.LookupEnv("APP_ENV")
+	assert.False(t, ok)
+
+	assert.NoErr(t, os.Setenv("APP_NAME", "goutil"))
+	defer os.Unsetenv("APP_NAME")
+	t.Run("unset", func(t *testing.T) {
+		testutil.UnsetEnv(t, "APP_NAME")
+		_, ok := os.LookupEnv("APP_NAME")
+		assert.False(t, ok)
+	})
+	assert.Eq(t, "goutil", os.Getenv("APP_NAME"))
+}
+
+func TestCleanEnv(t *testing.T) {
+	num := len(os.Environ())
+
+	t.Run("clean", func(t *testing.T) {
+		testutil.CleanEnv(t, map[string]string{"APP_ENV": "dev"})
+		assert.Eq(t, []string{"APP_ENV=dev"}, os.Environ())
+	})
+
+	assert.Len(t, os.Environ(), num)
+	assert.Eq(t, "", os.Getenv("APP_ENV"))
+	assert.Eq(t, "", os.Getenv("GOUTIL_TEST_ENV_MOCKED"))
+}
@@ -0,0 +1,197 @@
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gookit/goutil/sysutil"
+)
+
+// ErrUnexpectedCmd error for exec the command not scripted on FakeExecutor
+var ErrUnexpectedCmd = errors.New("fake exec: unexpected command")
+
+// ExecCall the executed command info on FakeExecutor
+type ExecCall struct {
+	Bin     string
+	Args    []string
+	WorkDir string
+}
+
+// Line get the command line string. eg: "git status -s"
+func (c ExecCall) Line() string {
+	return strings.TrimSpace(c.Bin + " " + strings.Join(c.Args, " "))
+}
+
+// FakeExitError the error for the fake command exit with non-zero code.
+//
+// It has the ExitCode() method like the exec.ExitError
+type FakeExitError struct {
+	Code   int
+	Stderr string
+}
+
+// Error string
+func (e *FakeExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// ExitCode get
+func (e *FakeExitError) ExitCode() int {
+	return e.Code
+}
+
+// FakeCmd the scripted command result on FakeExecutor
+type FakeCmd struct {
+	// pattern for match the command line, end with "*" for match prefix.
+	pattern string
+	output  string
+	err     error
+}
+
+// Return set the output of the command
+func (c *FakeCmd) Return(output string) *FakeCmd {
+	c.output = output
+	return c
+}
+
+// Exit set the command exit with code and stderr. code 0 means success.
+func (c *FakeCmd) Exit(code int, stderr ...string) *FakeCmd {
+	if code == 0 {
+		c.err = nil
+	} else {
+		e := &FakeExitError{Code: code}
+		if len(stderr) > 0 {
+			e.Stderr = stderr[0]
+		}
+		c.err = e
+	}
+	return c
+}
+
+// Fail set the command return error. eg: exec.ErrNotFound
+func (c *FakeCmd) Fail(err error) *FakeCmd {
+	c.err = err
+	return c
+}
+
+func (c *FakeCmd) match(line string) bool {
+	if strings.HasSuffix(c.pattern, "*") {
+		return strings.HasPrefix(line, strings.TrimSuffix(c.pattern, "*"))
+	}
+	return line == c.pattern
+}
+
+// FakeExecutor a fake sysutil.CommandRunner for testing, can script the output and
+// exit code for specific commands, and assert what was executed.
+//
+// The not scripted command will return ErrUnexpectedCmd.
+//
+// Usage:
+//
+//	fe := testutil.NewFakeExecutor()
+//	fe.On("git rev-parse HEAD").Return("abc123\n")
+//	fe.On("git push *").Exit(1, "rejected")
+//	testutil.MockCmdRunner(t, fe)
+//
+//	out, err := sysutil.ExecLine("git rev-parse HEAD")
+//	assert.True(t, fe.Called("git rev-parse HEAD"))
+type FakeExecutor struct {
+	mu    sync.Mutex
+	cmds  []*FakeCmd
+	calls []ExecCall
+}
+
+var _ sysutil.CommandRunner = (*FakeExecutor)(nil)
+
+// NewFakeExecutor create
+func NewFakeExecutor() *FakeExecutor {
+	return &FakeExecutor{}
+}
+
+// On script a command by the command line. end with "*" for match prefix. eg: "git push *"
+//
+// The later scripted command has higher priority.
+func (fe *FakeExecutor) On(cmdLine string) *FakeCmd {
+	fc := &FakeCmd{pattern: strings.TrimSpace(cmdLine)}
+
+	fe.mu.Lock()
+	fe.cmds = append(fe.cmds, fc)
+	fe.mu.Unlock()
+	return fc
+}
+
+// ExecCmd record the call and return the scripted result. implements sysutil.CommandRunner
+func (fe *FakeExecutor) ExecCmd(binName string, args []string, workDir ...string) (string, error) {
+	call := ExecCall{Bin: binName, Args: append([]string(nil), args...)}
+	if len(workDir) > 0 {
+		call.WorkDir = workDir[0]
+	}
+	line := call.Line()
+
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	fe.calls = append(fe.calls, call)
+
+	for i := len(fe.cmds) - 1; i >= 0; i-- {
+		if fc := fe.cmds[i]; fc.match(line) {
+			return fc.output, fc.err
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnexpectedCmd, line)
+}
+
+// Calls get the executed commands
+func (fe *FakeExecutor) Calls() []ExecCall {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	return append([]ExecCall(nil), fe.calls...)
+}
+
+// CallLines get the executed command lines
+func (fe *FakeExecutor) CallLines() []string {
+	calls := fe.Calls()
+	lines := make([]string, len(calls))
+	for i, call := range calls {
+		lines[i] = call.Line()
+	}
+	return lines
+}
+
+// Called check the command line is executed. end with "*" for match prefix.
+func (fe *FakeExecutor) Called(cmdLine string) bool {
+	return fe.CallCount(cmdLine) > 0
+}
+
+// CallCount get the executed times of the command line. end with "*" for match prefix.
+func (fe *FakeExecutor) CallCount(cmdLine string) int {
+	fc := &FakeCmd{pattern: strings.TrimSpace(cmdLine)}
+
+	var n int
+	for _, line := range fe.CallLines() {
+		if fc.match(line) {
+			n++
+		}
+	}
+	return n
+}
+
+// Reset the scripted commands and the call records
+func (fe *FakeExecutor) Reset() {
+	fe.mu.Lock()
+	fe.cmds = nil
+	fe.calls = nil
+	fe.mu.Unlock()
+}
+
+// MockCmdRunner set the runner for sysutil exec helpers, will restore on the test finished.
+//
+// NOTE: it changes the global runner, don't use it with t.Parallel()
+func MockCmdRunner(t testing.TB, r sysutil.CommandRunner) {
+	old := sysutil.SetCmdRunner(r)
+	t.Cleanup(func() {
+		sysutil.SetCmdRunner(old)
+	})
+}
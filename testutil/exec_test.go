@@ -0,0 +1,72 @@
+package testutil_test
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/gookit/goutil/cliutil"
+	"github.com/gookit/goutil/sysutil"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestFakeExecutor(t *testing.T) {
+	fe := testutil.NewFakeExecutor()
+	fe.On("git rev-parse HEAD").Return("abc123\n")
+	fe.On("git push *").Exit(1, "rejected")
+	fe.On("not-exist").Fail(exec.ErrNotFound)
+	testutil.MockCmdRunner(t, fe)
+
+	out, err := sysutil.ExecLine("git rev-parse HEAD", "/tmp")
+	assert.NoErr(t, err)
+	assert.Eq(t, "abc123\n", out)
+
+	out, err = cliutil.ExecCmd("git", []string{"push", "origin", "main"})
+	assert.Err(t, err)
+	assert.Eq(t, "", out)
+	var exitErr *testutil.FakeExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Eq(t, 1, exitErr.ExitCode())
+	assert.Eq(t, "rejected", exitErr.Stderr)
+	assert.Eq(t, "exit status 1", err.Error())
+
+	_, err = sysutil.ExecCmd("not-exist", nil)
+	assert.ErrIs(t, err, exec.ErrNotFound)
+
+	_, err = sysutil.ShellExec("rm -rf /")
+	assert.ErrIs(t, err, testutil.ErrUnexpectedCmd)
+	assert.ErrSubMsg(t, err, `"sh -c rm -rf /"`)
+
+	calls := fe.Calls()
+	assert.Len(t, calls, 4)
+	assert.Eq(t, "/tmp", calls[0].WorkDir)
+	assert.Eq(t, []string{"push", "origin", "main"}, calls[1].Args)
+	assert.Eq(t, "git push origin main", fe.CallLines()[1])
+	assert.True(t, fe.Called("git rev-parse HEAD"))
+	assert.True(t, fe.Called("git *"))
+	assert.Eq(t, 2, fe.CallCount("git *"))
+	assert.False(t, fe.Called("git status"))
+
+	// later scripted has higher priority
+	fe.On("git push origin main").Exit(0).Return("ok")
+	out, err = sysutil.ExecCmd("git", []string{"push", "origin", "main"})
+	assert.NoErr(t, err)
+	assert.Eq(t, "ok", out)
+
+	fe.Reset()
+	assert.Empty(t, fe.Calls())
+	_, err = sysutil.ExecLine("git rev-parse HEAD")
+	assert.ErrIs(t, err, testutil.ErrUnexpectedCmd)
+}
+
+func TestMockCmdRunner_restore(t *testing.T) {
+	t.Run("mock", func(t *testing.T) {
+		testutil.MockCmdRunner(t, testutil.NewFakeExecutor())
+		_, ok := sysutil.CmdRunner().(*testutil.FakeExecutor)
+		assert.True(t, ok)
+	})
+
+	_, ok := sysutil.CmdRunner().(sysutil.OSRunner)
+	assert.True(t, ok)
+}
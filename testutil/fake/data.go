@@ -0,0 +1,26 @@
+package fake
+
+var firstNames = []string{
+	"Alice", "Bob", "Carol", "David", "Emma", "Frank", "Grace", "Henry",
+	"Ivy", "Jack", "Kate", "Leo", "Mia", "Noah", "Olivia", "Paul",
+	"Quinn", "Ruby", "Sam", "Tina", "Uma", "Victor", "Wendy", "Yuki", "Zoe",
+}
+
+var lastNames = []string{
+	"Smith", "Johnson", "Brown", "Taylor", "Miller", "Wilson", "Moore", "Clark",
+	"Lewis", "Walker", "Hall", "Young", "King", "Wright", "Green", "Baker",
+	"Adams", "Nelson", "Carter", "Turner", "Parker", "Evans", "Collins", "Lee",
+}
+
+var emailDomains = []string{
+	"example.com", "example.org", "example.net", "test.com", "mail.test",
+}
+
+var words = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit",
+	"sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et",
+	"dolore", "magna", "aliqua", "enim", "ad", "minim", "veniam", "quis",
+	"nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip", "ex", "ea",
+	"commodo", "consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+	"velit", "esse", "cillum", "fugiat", "nulla", "pariatur", "excepteur", "sint",
+}
@@ -0,0 +1,241 @@
+// Package fake provides fake data generators for testing. such as
+// names, emails, IPs, UUIDs, sentences and fill struct by reflection.
+//
+// All generators are deterministic with the same seed, useful for
+// property-style and fixture-heavy tests.
+package fake
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Faker fake data generator. It is safe for concurrent use.
+type Faker struct {
+	mu   sync.Mutex
+	rd   *rand.Rand
+	seed int64
+}
+
+// New create a Faker with the seed. same seed will generate same data.
+func New(seed int64) *Faker {
+	return &Faker{rd: rand.New(rand.NewSource(seed)), seed: seed}
+}
+
+// Seed get the seed value
+func (f *Faker) Seed() int64 {
+	return f.seed
+}
+
+// Reset reset the generator with new seed
+func (f *Faker) Reset(seed int64) {
+	f.mu.Lock()
+	f.rd = rand.New(rand.NewSource(seed))
+	f.seed = seed
+	f.mu.Unlock()
+}
+
+// Int63 get a random non-negative int64
+func (f *Faker) Int63() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rd.Int63()
+}
+
+// IntN get a random int in [0, n). return 0 if n <= 0
+func (f *Faker) IntN(n int) int {
+	if n <= 0 {
+		return 0
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rd.Intn(n)
+}
+
+// IntBetween get a random int in [min, max]
+func (f *Faker) IntBetween(min, max int) int {
+	if min > max {
+		min, max = max, min
+	}
+	return min + f.IntN(max-min+1)
+}
+
+// Float64 get a random float64 in [0.0, 1.0)
+func (f *Faker) Float64() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rd.Float64()
+}
+
+// Bool get a random bool value
+func (f *Faker) Bool() bool {
+	return f.IntN(2) == 1
+}
+
+// Pick a random element from the list. return empty string if list is empty.
+func (f *Faker) Pick(list []string) string {
+	if len(list) == 0 {
+		return ""
+	}
+	return list[f.IntN(len(list))]
+}
+
+// Chars get a random string with lower letters and digits.
+func (f *Faker) Chars(n int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	bs := make([]byte, n)
+	for i := range bs {
+		bs[i] = letters[f.IntN(len(letters))]
+	}
+	return string(bs)
+}
+
+// FirstName get a random first name
+func (f *Faker) FirstName() string { return f.Pick(firstNames) }
+
+// LastName get a random last name
+func (f *Faker) LastName() string { return f.Pick(lastNames) }
+
+// Name get a random full name. eg: "Alice Smith"
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Username get a random username. eg: "alice.smith42"
+func (f *Faker) Username() string {
+	return strings.ToLower(f.FirstName()+"."+f.LastName()) + fmt.Sprint(f.IntN(100))
+}
+
+// Email get a random email address. eg: "alice.smith42@example.com"
+func (f *Faker) Email() string {
+	return f.Username() + "@" + f.Pick(emailDomains)
+}
+
+// IPv4 get a random IPv4 address. eg: "192.168.3.12"
+func (f *Faker) IPv4() string {
+	return fmt.Sprintf("%d.%d.%d.%d", f.IntBetween(1, 254), f.IntN(256), f.IntN(256), f.IntBetween(1, 254))
+}
+
+// IPv6 get a random IPv6 address. eg: "2001:db8:85a3:8d3:1319:8a2e:370:7348"
+func (f *Faker) IPv6() string {
+	parts := make([]string, 8)
+	for i := range parts {
+		parts[i] = fmt.Sprintf("%x", f.IntN(1<<16))
+	}
+	return strings.Join(parts, ":")
+}
+
+// UUID get a random UUID string of version 4. eg: "9b2c4e4a-5f1d-4c3e-8a7b-1d2e3f4a5b6c"
+func (f *Faker) UUID() string {
+	var b [16]byte
+	f.mu.Lock()
+	_, _ = f.rd.Read(b[:])
+	f.mu.Unlock()
+
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // variant RFC4122
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Word get a random lower word
+func (f *Faker) Word() string { return f.Pick(words) }
+
+// Words get n random words
+func (f *Faker) Words(n int) []string {
+	ss := make([]string, n)
+	for i := range ss {
+		ss[i] = f.Word()
+	}
+	return ss
+}
+
+// Sentence get a random sentence with 4-12 words. eg: "Lorem ipsum dolor sit amet."
+func (f *Faker) Sentence() string {
+	s := strings.Join(f.Words(f.IntBetween(4, 12)), " ")
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+// Paragraph get a random paragraph with n sentences
+func (f *Faker) Paragraph(n int) string {
+	ss := make([]string, n)
+	for i := range ss {
+		ss[i] = f.Sentence()
+	}
+	return strings.Join(ss, " ")
+}
+
+// TimeBetween get a random time in [start, end). will swap them if start after end.
+func (f *Faker) TimeBetween(start, end time.Time) time.Time {
+	if start.After(end) {
+		start, end = end, start
+	}
+
+	span := int64(end.Sub(start))
+	if span <= 0 {
+		return start
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return start.Add(time.Duration(f.rd.Int63n(span)))
+}
+
+// DurationBetween get a random duration in [min, max)
+func (f *Faker) DurationBetween(min, max time.Duration) time.Duration {
+	if min > max {
+		min, max = max, min
+	}
+	if min == max {
+		return min
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return min + time.Duration(f.rd.Int63n(int64(max-min)))
+}
+
+/*************************************************************
+ * quick use the default faker
+ *************************************************************/
+
+var std = New(time.Now().UnixNano())
+
+// Std get the default faker
+func Std() *Faker { return std }
+
+// Seed reset the default faker with the seed. make the package level functions deterministic.
+func Seed(seed int64) { std.Reset(seed) }
+
+// IntBetween get a random int in [min, max] by the default faker
+func IntBetween(min, max int) int { return std.IntBetween(min, max) }
+
+// Name get a random full name by the default faker
+func Name() string { return std.Name() }
+
+// Email get a random email address by the default faker
+func Email() string { return std.Email() }
+
+// IPv4 get a random IPv4 address by the default faker
+func IPv4() string { return std.IPv4() }
+
+// IPv6 get a random IPv6 address by the default faker
+func IPv6() string { return std.IPv6() }
+
+// UUID get a random UUID v4 string by the default faker
+func UUID() string { return std.UUID() }
+
+// Word get a random word by the default faker
+func Word() string { return std.Word() }
+
+// Sentence get a random sentence by the default faker
+func Sentence() string { return std.Sentence() }
+
+// TimeBetween get a random time in [start, end) by the default faker
+func TimeBetween(start, end time.Time) time.Time { return std.TimeBetween(start, end) }
+
+// Fill the struct fields with fake data by the default faker. see Faker.Fill
+func Fill(ptr any) error { return std.Fill(ptr) }
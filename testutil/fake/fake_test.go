@@ -0,0 +1,117 @@
+package fake_test
+
+import (
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/testutil/fake"
+)
+
+func TestFaker_deterministic(t *testing.T) {
+	f1 := fake.New(42)
+	f2 := fake.New(42)
+	assert.Eq(t, int64(42), f1.Seed())
+
+	for i := 0; i < 10; i++ {
+		assert.Eq(t, f1.Name(), f2.Name())
+		assert.Eq(t, f1.Email(), f2.Email())
+		assert.Eq(t, f1.UUID(), f2.UUID())
+		assert.Eq(t, f1.Sentence(), f2.Sentence())
+	}
+
+	f1.Reset(7)
+	f2.Reset(7)
+	assert.Eq(t, f1.IPv4(), f2.IPv4())
+
+	fake.Seed(3)
+	s1 := fake.Sentence()
+	fake.Seed(3)
+	assert.Eq(t, s1, fake.Sentence())
+}
+
+func TestFaker_generators(t *testing.T) {
+	f := fake.New(1)
+
+	assert.Len(t, strings.Fields(f.Name()), 2)
+	assert.StrContains(t, f.Email(), "@")
+	assert.NotNil(t, net.ParseIP(f.IPv4()).To4())
+	assert.NotNil(t, net.ParseIP(f.IPv6()))
+	assert.Len(t, f.Chars(6), 6)
+	assert.Eq(t, "", f.Pick(nil))
+	assert.Eq(t, 0, f.IntN(0))
+
+	uuidRe := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for i := 0; i < 20; i++ {
+		assert.True(t, uuidRe.MatchString(f.UUID()))
+
+		n := f.IntBetween(5, 3)
+		assert.True(t, n >= 3 && n <= 5)
+	}
+
+	s := f.Sentence()
+	assert.True(t, strings.HasSuffix(s, "."))
+	assert.Eq(t, strings.ToUpper(s[:1]), s[:1])
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	for i := 0; i < 20; i++ {
+		tm := f.TimeBetween(end, start)
+		assert.True(t, !tm.Before(start) && tm.Before(end))
+
+		d := f.DurationBetween(time.Second, time.Minute)
+		assert.True(t, d >= time.Second && d < time.Minute)
+	}
+	assert.Eq(t, start, f.TimeBetween(start, start))
+}
+
+type fakeAddr struct {
+	City string
+	IP   string
+}
+
+type fakeUser struct {
+	ID        string `fake:"uuid"`
+	Name      string
+	Email     string
+	Age       int
+	Score     float64
+	Active    bool
+	Tags      []string `fake:"word"`
+	Addr      fakeAddr
+	Parent    *fakeUser
+	CreatedAt time.Time
+	Skip      string `fake:"-"`
+	Meta      map[string]int
+	secret    string
+}
+
+func TestFaker_Fill(t *testing.T) {
+	u1 := &fakeUser{}
+	assert.NoErr(t, fake.New(9).Fill(u1))
+
+	assert.Len(t, u1.ID, 36)
+	assert.Len(t, strings.Fields(u1.Name), 2)
+	assert.StrContains(t, u1.Email, "@")
+	assert.NotEmpty(t, u1.Tags)
+	assert.NotEmpty(t, u1.Addr.City)
+	assert.NotNil(t, net.ParseIP(u1.Addr.IP))
+	assert.NotNil(t, u1.Parent)
+	assert.False(t, u1.CreatedAt.IsZero())
+	assert.Eq(t, "", u1.Skip)
+	assert.NotEmpty(t, u1.Meta)
+	assert.Eq(t, "", u1.secret)
+
+	// same seed, same data
+	u2 := &fakeUser{}
+	assert.NoErr(t, fake.New(9).Fill(u2))
+	assert.Eq(t, u1.ID, u2.ID)
+	assert.Eq(t, u1.Email, u2.Email)
+	assert.Eq(t, u1.CreatedAt, u2.CreatedAt)
+
+	assert.Err(t, fake.Fill(fakeUser{}))
+	assert.Err(t, fake.Fill(nil))
+}
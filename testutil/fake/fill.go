@@ -0,0 +1,183 @@
+package fake
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TagName for custom the fake data kind of struct field.
+//
+// Allowed values:
+//
+//	name, first_name, last_name, username, email, ipv4, ip, ipv6, uuid,
+//	word, sentence, paragraph, "-" to skip the field
+//
+// eg: `fake:"email"`
+const TagName = "fake"
+
+// max depth for fill nested struct, avoid infinite recursion on self-reference types.
+const maxFillDepth = 5
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	// fill time.Time field value in this range, keep it deterministic
+	fillTimeStart = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	fillTimeEnd   = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Fill the exported fields of struct with fake data by reflection.
+//
+// The string field value is generated by the `fake` tag, or guess by field name.
+// eg: field "Email" will fill an email address. Nested struct, pointer,
+// slice and map fields will be filled too.
+//
+// Usage:
+//
+//	type User struct {
+//		ID    string `fake:"uuid"`
+//		Name  string
+//		Email string
+//		Age   int
+//	}
+//
+//	u := &User{}
+//	err := fake.New(1).Fill(u)
+func (f *Faker) Fill(ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("fake: fill target must be a non-nil struct pointer")
+	}
+
+	f.fillStruct(rv.Elem(), 0)
+	return nil
+}
+
+func (f *Faker) fillStruct(rv reflect.Value, depth int) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		kind := sf.Tag.Get(TagName)
+		if kind == "-" {
+			continue
+		}
+		if kind == "" {
+			kind = guessKind(sf.Name)
+		}
+		f.fillValue(rv.Field(i), kind, depth)
+	}
+}
+
+func (f *Faker) fillValue(rv reflect.Value, kind string, depth int) {
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(f.stringByKind(kind))
+	case reflect.Bool:
+		rv.SetBool(f.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		rv.SetInt(int64(f.IntN(100)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		rv.SetUint(uint64(f.IntN(100)))
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(float64(f.IntN(10000)) / 100)
+	case reflect.Ptr:
+		if depth >= maxFillDepth {
+			return
+		}
+		nv := reflect.New(rv.Type().Elem())
+		f.fillValue(nv.Elem(), kind, depth+1)
+		rv.Set(nv)
+	case reflect.Struct:
+		if rv.Type() == timeType {
+			rv.Set(reflect.ValueOf(f.TimeBetween(fillTimeStart, fillTimeEnd)))
+		} else if depth < maxFillDepth {
+			f.fillStruct(rv, depth+1)
+		}
+	case reflect.Slice:
+		if depth >= maxFillDepth {
+			return
+		}
+		n := f.IntBetween(1, 3)
+		sl := reflect.MakeSlice(rv.Type(), n, n)
+		for i := 0; i < n; i++ {
+			f.fillValue(sl.Index(i), kind, depth+1)
+		}
+		rv.Set(sl)
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			f.fillValue(rv.Index(i), kind, depth+1)
+		}
+	case reflect.Map:
+		if depth >= maxFillDepth {
+			return
+		}
+		mt := rv.Type()
+		mp := reflect.MakeMap(mt)
+		for i, n := 0, f.IntBetween(1, 3); i < n; i++ {
+			key := reflect.New(mt.Key()).Elem()
+			f.fillValue(key, "", depth+1)
+			val := reflect.New(mt.Elem()).Elem()
+			f.fillValue(val, kind, depth+1)
+			mp.SetMapIndex(key, val)
+		}
+		rv.Set(mp)
+	}
+}
+
+func (f *Faker) stringByKind(kind string) string {
+	switch kind {
+	case "name":
+		return f.Name()
+	case "first_name":
+		return f.FirstName()
+	case "last_name":
+		return f.LastName()
+	case "username":
+		return f.Username()
+	case "email":
+		return f.Email()
+	case "ip", "ipv4":
+		return f.IPv4()
+	case "ipv6":
+		return f.IPv6()
+	case "uuid":
+		return f.UUID()
+	case "sentence":
+		return f.Sentence()
+	case "paragraph":
+		return f.Paragraph(3)
+	default:
+		return f.Word()
+	}
+}
+
+// guess the fake data kind by field name
+func guessKind(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, "email"):
+		return "email"
+	case strings.Contains(lower, "uuid"):
+		return "uuid"
+	case strings.Contains(lower, "ipv6"):
+		return "ipv6"
+	case lower == "ip" || strings.HasSuffix(lower, "ip") || strings.Contains(lower, "ipv4"):
+		return "ipv4"
+	case lower == "firstname":
+		return "first_name"
+	case lower == "lastname":
+		return "last_name"
+	case lower == "username" || lower == "user":
+		return "username"
+	case strings.HasSuffix(lower, "name"):
+		return "name"
+	case lower == "desc" || strings.Contains(lower, "description") || lower == "title":
+		return "sentence"
+	}
+	return ""
+}
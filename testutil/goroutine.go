@@ -0,0 +1,162 @@
+package testutil
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// LeakOption options for NoGoroutineLeak
+type LeakOption struct {
+	// Grace period for wait the goroutines exit. default is 1s
+	Grace time.Duration
+	// IgnoreStacks ignore the goroutines, if the stack contains any of the sub strings.
+	IgnoreStacks []string
+}
+
+// LeakOptFn option func for NoGoroutineLeak
+type LeakOptFn func(opt *LeakOption)
+
+// WithGrace set the grace period for wait the goroutines exit.
+func WithGrace(d time.Duration) LeakOptFn {
+	return func(opt *LeakOption) {
+		opt.Grace = d
+	}
+}
+
+// WithIgnoreStacks ignore the goroutines, if the stack contains any of the sub strings.
+// eg: function name "net/http.(*persistConn).readLoop"
+func WithIgnoreStacks(subs ...string) LeakOptFn {
+	return func(opt *LeakOption) {
+		opt.IgnoreStacks = append(opt.IgnoreStacks, subs...)
+	}
+}
+
+// the known goroutines of the runtime and testing
+var knownStacks = []string{
+	"testing.(*T).Run(",
+	"testing.(*T).Parallel(",
+	"testing.runTests(",
+	"testing.(*M).",
+	"os/signal.signal_recv(",
+	"os/signal.loop(",
+	"runtime.ensureSigM(",
+	"runtime.ReadTrace(",
+}
+
+// NoGoroutineLeak snapshot the goroutines at start, the returned func will fail the test
+// if new goroutines still remain after the grace period.
+//
+// NOTE: should not be used in parallel tests, the goroutines of other tests will be reported.
+//
+// Usage:
+//
+//	func TestSomething(t *testing.T) {
+//		defer testutil.NoGoroutineLeak(t)()
+//		// ...
+//	}
+func NoGoroutineLeak(t testing.TB, optFns ...LeakOptFn) func() {
+	t.Helper()
+	opt := &LeakOption{Grace: time.Second}
+	for _, fn := range optFns {
+		fn(opt)
+	}
+
+	before := make(map[string]bool)
+	for _, g := range goroutineStacks() {
+		before[g.id] = true
+	}
+
+	return func() {
+		t.Helper()
+		deadline := time.Now().Add(opt.Grace)
+		wait := time.Millisecond
+
+		for {
+			leaks := leakedGoroutines(before, opt.IgnoreStacks)
+			if len(leaks) == 0 {
+				return
+			}
+
+			if time.Now().After(deadline) {
+				ss := make([]string, len(leaks))
+				for i, g := range leaks {
+					ss[i] = g.stack
+				}
+				t.Errorf("found %d leaked goroutine(s) after %s:\n\n%s", len(leaks), opt.Grace, strings.Join(ss, "\n\n"))
+				return
+			}
+
+			time.Sleep(wait)
+			if wait < 100*time.Millisecond {
+				wait *= 2
+			}
+		}
+	}
+}
+
+type goroutineInfo struct {
+	id    string
+	stack string
+}
+
+func leakedGoroutines(before map[string]bool, ignores []string) (leaks []goroutineInfo) {
+	curID := currentGoroutineID()
+	for _, g := range goroutineStacks() {
+		if before[g.id] || g.id == curID || stackContains(g.stack, knownStacks) || stackContains(g.stack, ignores) {
+			continue
+		}
+		leaks = append(leaks, g)
+	}
+	return
+}
+
+func stackContains(stack string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(stack, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// get all goroutine stacks
+func goroutineStacks() []goroutineInfo {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	blocks := strings.Split(strings.TrimSpace(string(buf)), "\n\n")
+	gs := make([]goroutineInfo, 0, len(blocks))
+	for _, block := range blocks {
+		if id := goroutineID(block); id != "" {
+			gs = append(gs, goroutineInfo{id: id, stack: block})
+		}
+	}
+	return gs
+}
+
+func currentGoroutineID() string {
+	buf := make([]byte, 64)
+	n := runtime.Stack(buf, false)
+	return goroutineID(string(buf[:n]))
+}
+
+// parse id from the stack header. eg: "goroutine 12 [chan receive]:"
+func goroutineID(stack string) string {
+	stack = strings.TrimPrefix(stack, "goroutine ")
+	if pos := strings.IndexByte(stack, ' '); pos > 0 {
+		if _, err := strconv.Atoi(stack[:pos]); err == nil {
+			return stack[:pos]
+		}
+	}
+	return ""
+}
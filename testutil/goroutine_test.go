@@ -0,0 +1,59 @@
+package testutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestNoGoroutineLeak(t *testing.T) {
+	t.Run("no leak", func(t *testing.T) {
+		tb := &snapTB{TB: t}
+		check := testutil.NoGoroutineLeak(tb, testutil.WithGrace(time.Second))
+
+		done := make(chan struct{})
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			close(done)
+		}()
+
+		// goroutine exit in the grace period
+		check()
+		assert.Empty(t, tb.errs)
+	})
+
+	t.Run("leaked", func(t *testing.T) {
+		tb := &snapTB{TB: t}
+		check := testutil.NoGoroutineLeak(tb, testutil.WithGrace(50*time.Millisecond))
+
+		stop := make(chan struct{})
+		defer close(stop)
+		go leakedWorker(stop)
+
+		check()
+		assert.Len(t, tb.errs, 1)
+		assert.StrContains(t, tb.errs[0], "found 1 leaked goroutine(s) after 50ms")
+		assert.StrContains(t, tb.errs[0], "testutil_test.leakedWorker")
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		tb := &snapTB{TB: t}
+		check := testutil.NoGoroutineLeak(tb,
+			testutil.WithGrace(20*time.Millisecond),
+			testutil.WithIgnoreStacks("testutil_test.leakedWorker"),
+		)
+
+		stop := make(chan struct{})
+		defer close(stop)
+		go leakedWorker(stop)
+
+		check()
+		assert.Empty(t, tb.errs)
+	})
+}
+
+func leakedWorker(stop chan struct{}) {
+	<-stop
+}
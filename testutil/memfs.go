@@ -0,0 +1,398 @@
+package testutil
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gookit/goutil/fsutil"
+)
+
+// ErrDiskFull the fake disk full error of MemFS
+var ErrDiskFull = errors.New("no space left on device")
+
+// MemFS operation names, use for MemFS.FailOn()
+const (
+	FsOpOpen   = "open"
+	FsOpStat   = "stat"
+	FsOpRead   = "read"
+	FsOpWrite  = "write"
+	FsOpMkdir  = "mkdir"
+	FsOpRemove = "remove"
+)
+
+// check implements
+var _ fsutil.FS = (*MemFS)(nil)
+
+type memNode struct {
+	dir   bool
+	data  []byte
+	mode  fs.FileMode
+	mtime time.Time
+}
+
+// MemFS is an in-memory file system for testing, it implements the fs.FS and fsutil.FS.
+//
+// Support failure injection by FailOn(), and fake disk full by SetDiskLimit().
+// The paths must be valid by fs.ValidPath(). eg: "conf/app.ini", the root dir is "."
+//
+// Usage:
+//
+//	mfs := testutil.NewMemFS(map[string]string{"conf/app.ini": "name=app"})
+//	mfs.FailOn(testutil.FsOpWrite, "conf/app.ini", fs.ErrPermission)
+//
+//	err := fsutil.SaveFileFS(mfs, "conf/app.ini", []byte("name=new"))
+//	// errors.Is(err, fs.ErrPermission) == true
+type MemFS struct {
+	mu    sync.RWMutex
+	nodes map[string]*memNode
+	// op:path => error
+	fails map[string]error
+	// disk size limit, 0 is unlimited
+	limit int64
+	// NowFn for set the modify time. default is time.Now
+	NowFn func() time.Time
+}
+
+// NewMemFS create a MemFS, can with init files. key is file path, value is contents.
+func NewMemFS(files ...map[string]string) *MemFS {
+	m := &MemFS{
+		nodes: map[string]*memNode{".": {dir: true, mode: fs.ModeDir | 0755}},
+		fails: make(map[string]error),
+		NowFn: time.Now,
+	}
+
+	for _, mp := range files {
+		for name, contents := range mp {
+			if err := fsutil.SaveFileFS(m, name, []byte(contents), 0644); err != nil {
+				panic(err)
+			}
+		}
+	}
+	return m
+}
+
+// check the path name is valid
+func checkPath(op, name string) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return nil
+}
+
+// FailOn inject the error on the operation of the path. empty op or path match any.
+//
+// Usage:
+//
+//	mfs.FailOn(testutil.FsOpWrite, "logs/app.log", fs.ErrPermission)
+//	mfs.FailOn("", "data.db", io.ErrUnexpectedEOF) // any op of the path
+func (m *MemFS) FailOn(op, name string, err error) {
+	m.mu.Lock()
+	m.fails[op+":"+name] = err
+	m.mu.Unlock()
+}
+
+// ClearFails clear all injected errors
+func (m *MemFS) ClearFails() {
+	m.mu.Lock()
+	m.fails = make(map[string]error)
+	m.mu.Unlock()
+}
+
+// SetDiskLimit set the total size limit of all files, write exceeded will return ErrDiskFull. 0 is unlimited.
+func (m *MemFS) SetDiskLimit(size int64) {
+	m.mu.Lock()
+	m.limit = size
+	m.mu.Unlock()
+}
+
+// check the injected error. must hold the lock
+func (m *MemFS) failErr(op, name string) error {
+	for _, key := range []string{op + ":" + name, op + ":", ":" + name, ":"} {
+		if err, ok := m.fails[key]; ok {
+			return &fs.PathError{Op: op, Path: name, Err: err}
+		}
+	}
+	return nil
+}
+
+func (m *MemFS) lookup(op, name string) (string, *memNode, error) {
+	if err := checkPath(op, name); err != nil {
+		return name, nil, err
+	}
+	if err := m.failErr(op, name); err != nil {
+		return name, nil, err
+	}
+
+	node, ok := m.nodes[name]
+	if !ok {
+		return name, nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return name, node, nil
+}
+
+// Open the file, implements the fs.FS
+func (m *MemFS) Open(name string) (fs.File, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name, node, err := m.lookup(FsOpOpen, name)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &memFile{info: m.fileInfo(name, node)}
+	if node.dir {
+		f.entries = m.dirEntries(name)
+	} else {
+		f.r = bytes.NewReader(node.data)
+		f.readErr = m.failErr(FsOpRead, name)
+	}
+	return f, nil
+}
+
+// Stat get the file info, implements the fs.StatFS
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name, node, err := m.lookup(FsOpStat, name)
+	if err != nil {
+		return nil, err
+	}
+	return m.fileInfo(name, node), nil
+}
+
+// ReadFile read the file contents, implements the fs.ReadFileFS
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name, node, err := m.lookup(FsOpRead, name)
+	if err != nil {
+		return nil, err
+	}
+	if node.dir {
+		return nil, &fs.PathError{Op: FsOpRead, Path: name, Err: errors.New("is a directory")}
+	}
+	return append([]byte(nil), node.data...), nil
+}
+
+// ReadDir read the dir entries, implements the fs.ReadDirFS
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name, node, err := m.lookup(FsOpRead, name)
+	if err != nil {
+		return nil, err
+	}
+	if !node.dir {
+		return nil, &fs.PathError{Op: FsOpRead, Path: name, Err: errors.New("not a directory")}
+	}
+	return m.dirEntries(name), nil
+}
+
+// WriteFile write data to the file, will create it if not exists. the parent dir must exist.
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := checkPath(FsOpWrite, name); err != nil {
+		return err
+	}
+	if err := m.failErr(FsOpWrite, name); err != nil {
+		return err
+	}
+
+	if parent, ok := m.nodes[path.Dir(name)]; !ok || !parent.dir {
+		return &fs.PathError{Op: FsOpWrite, Path: name, Err: fs.ErrNotExist}
+	}
+
+	var oldSize int64
+	node, ok := m.nodes[name]
+	if ok {
+		if node.dir {
+			return &fs.PathError{Op: FsOpWrite, Path: name, Err: errors.New("is a directory")}
+		}
+		if node.mode&0200 == 0 {
+			return &fs.PathError{Op: FsOpWrite, Path: name, Err: fs.ErrPermission}
+		}
+		oldSize = int64(len(node.data))
+	}
+
+	if m.limit > 0 && m.usedSize()-oldSize+int64(len(data)) > m.limit {
+		return &fs.PathError{Op: FsOpWrite, Path: name, Err: ErrDiskFull}
+	}
+
+	if !ok {
+		node = &memNode{mode: perm.Perm()}
+		m.nodes[name] = node
+	}
+	node.data = append([]byte(nil), data...)
+	node.mtime = m.NowFn()
+	return nil
+}
+
+// MkdirAll create the dir and all parents
+func (m *MemFS) MkdirAll(dirPath string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := checkPath(FsOpMkdir, dirPath); err != nil {
+		return err
+	}
+	if err := m.failErr(FsOpMkdir, dirPath); err != nil {
+		return err
+	}
+
+	// check and collect the not exists dirs
+	var dirs []string
+	for p := dirPath; p != "."; p = path.Dir(p) {
+		if node, ok := m.nodes[p]; ok {
+			if !node.dir {
+				return &fs.PathError{Op: FsOpMkdir, Path: p, Err: errors.New("not a directory")}
+			}
+			break
+		}
+		dirs = append(dirs, p)
+	}
+
+	for _, p := range dirs {
+		m.nodes[p] = &memNode{dir: true, mode: fs.ModeDir | perm.Perm(), mtime: m.NowFn()}
+	}
+	return nil
+}
+
+// Remove the file or empty dir
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name, node, err := m.lookup(FsOpRemove, name)
+	if err != nil {
+		return err
+	}
+
+	if node.dir && (name == "." || len(m.dirEntries(name)) > 0) {
+		return &fs.PathError{Op: FsOpRemove, Path: name, Err: errors.New("directory not empty")}
+	}
+	delete(m.nodes, name)
+	return nil
+}
+
+// Files get all file paths, sorted by path.
+func (m *MemFS) Files() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var files []string
+	for name, node := range m.nodes {
+		if !node.dir {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// must hold the lock
+func (m *MemFS) usedSize() (size int64) {
+	for _, node := range m.nodes {
+		size += int64(len(node.data))
+	}
+	return
+}
+
+// must hold the lock
+func (m *MemFS) dirEntries(dir string) []fs.DirEntry {
+	prefix := dir + "/"
+	if dir == "." {
+		prefix = ""
+	}
+
+	var ents []fs.DirEntry
+	for name, node := range m.nodes {
+		if name == "." || !strings.HasPrefix(name, prefix) || strings.Contains(name[len(prefix):], "/") {
+			continue
+		}
+		ents = append(ents, fs.FileInfoToDirEntry(m.fileInfo(name, node)))
+	}
+
+	sort.Slice(ents, func(i, j int) bool { return ents[i].Name() < ents[j].Name() })
+	return ents
+}
+
+func (m *MemFS) fileInfo(name string, node *memNode) *memFileInfo {
+	return &memFileInfo{
+		name:  path.Base(name),
+		size:  int64(len(node.data)),
+		mode:  node.mode,
+		mtime: node.mtime,
+	}
+}
+
+// memFileInfo implements the fs.FileInfo
+type memFileInfo struct {
+	name  string
+	size  int64
+	mode  fs.FileMode
+	mtime time.Time
+}
+
+func (fi *memFileInfo) Name() string       { return fi.name }
+func (fi *memFileInfo) Size() int64        { return fi.size }
+func (fi *memFileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi *memFileInfo) ModTime() time.Time { return fi.mtime }
+func (fi *memFileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *memFileInfo) Sys() any           { return nil }
+
+// memFile implements the fs.File and fs.ReadDirFile
+type memFile struct {
+	info    *memFileInfo
+	r       *bytes.Reader
+	readErr error
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.info.IsDir() {
+		return 0, &fs.PathError{Op: FsOpRead, Path: f.info.name, Err: errors.New("is a directory")}
+	}
+	if f.readErr != nil {
+		return 0, f.readErr
+	}
+	return f.r.Read(p)
+}
+
+func (f *memFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !f.info.IsDir() {
+		return nil, &fs.PathError{Op: FsOpRead, Path: f.info.name, Err: errors.New("not a directory")}
+	}
+
+	rest := f.entries[f.offset:]
+	if n <= 0 {
+		f.offset = len(f.entries)
+		return rest, nil
+	}
+
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	f.offset += n
+	return rest[:n], nil
+}
+
+func (f *memFile) Close() error { return nil }
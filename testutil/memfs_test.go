@@ -0,0 +1,100 @@
+package testutil_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestMemFS_fstest(t *testing.T) {
+	mfs := testutil.NewMemFS(map[string]string{
+		"a.txt":          "hello",
+		"conf/app.ini":   "name=app",
+		"conf/sub/b.txt": "world",
+	})
+
+	assert.NoErr(t, fstest.TestFS(mfs, "a.txt", "conf/app.ini", "conf/sub/b.txt"))
+	assert.Eq(t, []string{"a.txt", "conf/app.ini", "conf/sub/b.txt"}, mfs.Files())
+}
+
+func TestMemFS_readWrite(t *testing.T) {
+	mfs := testutil.NewMemFS()
+
+	// parent dir not exists
+	err := mfs.WriteFile("logs/app.log", []byte("hi"), 0644)
+	assert.ErrIs(t, err, fs.ErrNotExist)
+
+	assert.ErrIs(t, fsutil.SaveFileFS(mfs, "/logs/app.log", []byte("hi")), fs.ErrInvalid)
+	assert.NoErr(t, fsutil.SaveFileFS(mfs, "logs/app.log", []byte("hi")))
+	assert.True(t, fsutil.IsFileFS(mfs, "logs/app.log"))
+	assert.True(t, fsutil.IsDirFS(mfs, "logs"))
+	assert.True(t, fsutil.PathExistsFS(mfs, "logs"))
+	assert.False(t, fsutil.PathExistsFS(mfs, "not-exists"))
+
+	bs, err := mfs.ReadFile("logs/app.log")
+	assert.NoErr(t, err)
+	assert.Eq(t, "hi", string(bs))
+
+	_, err = mfs.ReadFile("logs")
+	assert.Err(t, err)
+	_, err = mfs.ReadDir("logs/app.log")
+	assert.Err(t, err)
+	assert.Err(t, mfs.WriteFile("logs", nil, 0644))
+	assert.Err(t, mfs.MkdirAll("logs/app.log/sub", 0755))
+
+	// copy and remove
+	assert.NoErr(t, fsutil.CopyFileFS(mfs, "logs/app.log", "bak/app.log"))
+	assert.Eq(t, []string{"bak/app.log", "logs/app.log"}, mfs.Files())
+
+	assert.Err(t, mfs.Remove("logs"))
+	assert.NoErr(t, mfs.Remove("logs/app.log"))
+	assert.NoErr(t, mfs.Remove("logs"))
+	assert.NoErr(t, fsutil.RemoveIfExistFS(mfs, "logs"))
+	assert.ErrIs(t, mfs.Remove("logs"), fs.ErrNotExist)
+
+	// read only file
+	assert.NoErr(t, mfs.WriteFile("ro.txt", []byte("ro"), 0444))
+	assert.ErrIs(t, mfs.WriteFile("ro.txt", []byte("new"), 0644), fs.ErrPermission)
+}
+
+func TestMemFS_failOn(t *testing.T) {
+	mfs := testutil.NewMemFS(map[string]string{"data.txt": "hello"})
+
+	mfs.FailOn(testutil.FsOpWrite, "data.txt", fs.ErrPermission)
+	err := fsutil.SaveFileFS(mfs, "data.txt", []byte("new"))
+	assert.ErrIs(t, err, fs.ErrPermission)
+	var pathErr *fs.PathError
+	assert.ErrAs(t, err, &pathErr)
+	assert.Eq(t, "write", pathErr.Op)
+
+	// other path is ok
+	assert.NoErr(t, fsutil.SaveFileFS(mfs, "other.txt", []byte("new")))
+
+	// read error on the file reading
+	mfs.FailOn(testutil.FsOpRead, "data.txt", io.ErrUnexpectedEOF)
+	f, err := mfs.Open("data.txt")
+	assert.NoErr(t, err)
+	_, err = io.ReadAll(f)
+	assert.ErrIs(t, err, io.ErrUnexpectedEOF)
+
+	// any op
+	mfs.FailOn("", "data.txt", fs.ErrClosed)
+	_, err = mfs.Stat("data.txt")
+	assert.ErrIs(t, err, fs.ErrClosed)
+
+	mfs.ClearFails()
+	_, err = mfs.Stat("data.txt")
+	assert.NoErr(t, err)
+
+	// disk full
+	mfs.SetDiskLimit(10)
+	assert.NoErr(t, mfs.WriteFile("data.txt", []byte("12345"), 0644))
+	err = mfs.WriteFile("more.txt", []byte("1234567"), 0644)
+	assert.True(t, errors.Is(err, testutil.ErrDiskFull))
+}
@@ -0,0 +1,114 @@
+package testutil
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+)
+
+// ListenTCP listen on a free port of 127.0.0.1, will close it on the test finished.
+func ListenTCP(t testing.TB) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("testutil: listen tcp error: %v", err)
+		return nil
+	}
+
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+	return ln
+}
+
+// NewTCPServer start a TCP server on a free port, each connection is handled in new goroutine.
+// returns the server address. eg: "127.0.0.1:45678"
+//
+// On the test finished, will close the listener and all connections, then wait the handlers exit.
+//
+// Usage:
+//
+//	addr := testutil.NewTCPServer(t, func(conn net.Conn) {
+//		_, _ = io.Copy(conn, conn) // echo
+//	})
+func NewTCPServer(t testing.TB, handle func(conn net.Conn)) string {
+	t.Helper()
+	ln := ListenTCP(t)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	conns := make(map[net.Conn]struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			conns[conn] = struct{}{}
+			mu.Unlock()
+
+			wg.Add(1)
+			go func() {
+				defer func() {
+					mu.Lock()
+					delete(conns, conn)
+					mu.Unlock()
+					_ = conn.Close()
+					wg.Done()
+				}()
+				handle(conn)
+			}()
+		}
+	}()
+
+	t.Cleanup(func() {
+		_ = ln.Close()
+		mu.Lock()
+		for conn := range conns {
+			_ = conn.Close()
+		}
+		mu.Unlock()
+		wg.Wait()
+	})
+	return ln.Addr().String()
+}
+
+// ListenServer the server can serve on a net.Listener. eg: *grpc.Server
+type ListenServer interface {
+	Serve(ln net.Listener) error
+	Stop()
+}
+
+// StartServer serve the server on a free port, will stop it on the test finished.
+// returns the server address. eg: "127.0.0.1:45678"
+//
+// Usage with gRPC:
+//
+//	srv := grpc.NewServer()
+//	pb.RegisterGreeterServer(srv, &greeterImpl{})
+//
+//	addr := testutil.StartServer(t, srv)
+//	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func StartServer(t testing.TB, srv ListenServer) string {
+	t.Helper()
+	ln := ListenTCP(t)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	t.Cleanup(func() {
+		srv.Stop()
+		if err := <-errCh; err != nil && !errors.Is(err, net.ErrClosed) {
+			t.Errorf("testutil: serve error: %v", err)
+		}
+	})
+	return ln.Addr().String()
+}
@@ -0,0 +1,59 @@
+package testutil_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestNewTCPServer(t *testing.T) {
+	addr := testutil.NewTCPServer(t, func(conn net.Conn) {
+		_, _ = io.Copy(conn, conn)
+	})
+	assert.StrContains(t, addr, "127.0.0.1:")
+
+	conn, err := net.Dial("tcp", addr)
+	assert.NoErr(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("hello\n"))
+	assert.NoErr(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	assert.NoErr(t, err)
+	assert.Eq(t, "hello\n", line)
+}
+
+// lineServer write a line to each connection, like the *grpc.Server usage.
+type lineServer struct {
+	ln net.Listener
+}
+
+func (s *lineServer) Serve(ln net.Listener) error {
+	s.ln = ln
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		_, _ = conn.Write([]byte("hi\n"))
+		_ = conn.Close()
+	}
+}
+
+func (s *lineServer) Stop() { _ = s.ln.Close() }
+
+func TestStartServer(t *testing.T) {
+	addr := testutil.StartServer(t, &lineServer{})
+
+	conn, err := net.Dial("tcp", addr)
+	assert.NoErr(t, err)
+	defer conn.Close()
+
+	bs, err := io.ReadAll(conn)
+	assert.NoErr(t, err)
+	assert.Eq(t, "hi\n", string(bs))
+}
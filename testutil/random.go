@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gookit/goutil/mathutil"
+)
+
+// SeedRandom set a deterministic random source with the seed for the random helpers of goutil,
+// eg: mathutil.RandomInt(), strutil.RandomChars(), arrutil.Shuffle(). will restore the source on the test finished.
+//
+// Returns the installed *rand.Rand, can be used for generate the test data in the test scope.
+//
+// NOTE: the random source is global, should not be used in parallel tests.
+// And the returned Rand is not safe for concurrent use with the random helpers.
+//
+// Usage:
+//
+//	r := testutil.SeedRandom(t, 42)
+//	s := strutil.RandomChars(6) // always same value on every run
+//	n := r.Intn(100)
+func SeedRandom(t testing.TB, seed int64) *rand.Rand {
+	t.Helper()
+	r := rand.New(rand.NewSource(seed))
+	UseRandSource(t, r)
+	return r
+}
+
+// UseRandSource point the random helpers of goutil to the given random source in the test,
+// will restore the previous source on the test finished. see SeedRandom()
+//
+// Usage:
+//
+//	testutil.UseRandSource(t, rand.New(mySource))
+func UseRandSource(t testing.TB, r *rand.Rand) {
+	t.Helper()
+	old := mathutil.SetRandSource(r)
+	t.Cleanup(func() {
+		mathutil.SetRandSource(old)
+	})
+}
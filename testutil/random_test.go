@@ -0,0 +1,60 @@
+package testutil_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/gookit/goutil/mathutil"
+	"github.com/gookit/goutil/strutil"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestSeedRandom(t *testing.T) {
+	var s1, s2 string
+	var n1, n2 int
+
+	t.Run("first", func(t *testing.T) {
+		testutil.SeedRandom(t, 42)
+		s1 = strutil.RandomChars(8)
+		n1 = mathutil.RandomInt(0, 10000)
+	})
+	t.Run("second", func(t *testing.T) {
+		testutil.SeedRandom(t, 42)
+		s2 = strutil.RandomChars(8)
+		n2 = mathutil.RandomInt(0, 10000)
+	})
+
+	assert.Len(t, s1, 8)
+	assert.Eq(t, s1, s2)
+	assert.Eq(t, n1, n2)
+}
+
+func TestSeedRandom_scoped(t *testing.T) {
+	var first []int
+	var r1, r2 int
+
+	t.Run("first", func(t *testing.T) {
+		r := testutil.SeedRandom(t, 7)
+		first = []int{1, 2, 3, 4, 5, 6}
+		arrutil.Shuffle(first)
+		r1 = r.Intn(1000)
+	})
+	t.Run("second", func(t *testing.T) {
+		r := testutil.SeedRandom(t, 7)
+		ls := []int{1, 2, 3, 4, 5, 6}
+		arrutil.Shuffle(ls)
+		assert.Eq(t, first, ls)
+		r2 = r.Intn(1000)
+	})
+	assert.Eq(t, r1, r2)
+
+	t.Run("use source", func(t *testing.T) {
+		r := rand.New(rand.NewSource(7))
+		testutil.UseRandSource(t, r)
+		ls := []int{1, 2, 3, 4, 5, 6}
+		arrutil.Shuffle(ls)
+		assert.Eq(t, first, ls)
+	})
+}
@@ -0,0 +1,238 @@
+package testutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gookit/goutil/dump"
+	"github.com/gookit/goutil/strutil"
+)
+
+// UpdateSnapshotsEnv the ENV name for enable snapshot update mode. eg: UPDATE_SNAPSHOTS=1 go test ./...
+const UpdateSnapshotsEnv = "UPDATE_SNAPSHOTS"
+
+// DefaultSnapshotDir the default dir for store snapshot files
+var DefaultSnapshotDir = filepath.Join("testdata", "__snapshots__")
+
+// SnapshotOption for MatchSnapshot
+type SnapshotOption struct {
+	// Dir the snapshot files dir. default is DefaultSnapshotDir
+	Dir string
+	// Name the snapshot name. default is the test name, with a index suffix on called multi times.
+	Name string
+	// Update overwrite the snapshot file instead of compare.
+	//
+	// default is true on ENV UPDATE_SNAPSHOTS is true.
+	Update bool
+	// Redacts the field names or map keys to redact, the value will be replaced with "<redacted>".
+	//
+	// useful for the dynamic values. eg: time, id
+	Redacts []string
+}
+
+// SnapshotOptFn func
+type SnapshotOptFn func(opt *SnapshotOption)
+
+// WithSnapshotName set the snapshot name
+func WithSnapshotName(name string) SnapshotOptFn {
+	return func(opt *SnapshotOption) {
+		opt.Name = name
+	}
+}
+
+// WithSnapshotDir set the snapshot files dir
+func WithSnapshotDir(dir string) SnapshotOptFn {
+	return func(opt *SnapshotOption) {
+		opt.Dir = dir
+	}
+}
+
+// WithRedacts set the field names or map keys to redact
+func WithRedacts(names ...string) SnapshotOptFn {
+	return func(opt *SnapshotOption) {
+		opt.Redacts = append(opt.Redacts, names...)
+	}
+}
+
+// UpdateSnapshot overwrite the snapshot file
+func UpdateSnapshot(opt *SnapshotOption) {
+	opt.Update = true
+}
+
+// record the call times of MatchSnapshot in each test
+var snapCalls = struct {
+	sync.Mutex
+	mp map[testing.TB]int
+}{mp: make(map[testing.TB]int)}
+
+// MatchSnapshot serialize the value by dump package, and compare with the stored snapshot file.
+//
+// The snapshot file will be created on not exists, and can be updated by ENV UPDATE_SNAPSHOTS=1.
+// The map keys are sorted, so the output is stable.
+//
+// Usage:
+//
+//	testutil.MatchSnapshot(t, user, testutil.WithRedacts("CreatedAt"))
+//
+// The snapshot file: testdata/__snapshots__/TestName.snap
+func MatchSnapshot(t testing.TB, value any, optFns ...SnapshotOptFn) {
+	t.Helper()
+
+	opt := &SnapshotOption{
+		Dir:    DefaultSnapshotDir,
+		Update: strutil.QuietBool(os.Getenv(UpdateSnapshotsEnv)),
+	}
+	for _, fn := range optFns {
+		fn(opt)
+	}
+
+	if opt.Name == "" {
+		opt.Name = autoSnapshotName(t)
+	}
+
+	got := SnapshotString(value, opt.Redacts...)
+	fpath := filepath.Join(opt.Dir, snapshotFileName(opt.Name))
+
+	want, err := os.ReadFile(fpath)
+	if errors.Is(err, os.ErrNotExist) || opt.Update {
+		if err := os.MkdirAll(opt.Dir, 0755); err != nil {
+			t.Fatalf("create snapshot dir error: %v", err)
+		}
+		if err := os.WriteFile(fpath, []byte(got), 0644); err != nil {
+			t.Fatalf("write snapshot file error: %v", err)
+		}
+
+		t.Logf("snapshot %q is written to %s", opt.Name, fpath)
+		return
+	}
+	if err != nil {
+		t.Fatalf("read snapshot file error: %v", err)
+	}
+
+	if string(want) != got {
+		t.Errorf("snapshot %q not match (file: %s)\n%s\nrun with ENV %s=1 for update the snapshot",
+			opt.Name, fpath, diffLines(string(want), got), UpdateSnapshotsEnv)
+	}
+}
+
+// SnapshotString serialize the value for snapshot. will redact the fields by names.
+func SnapshotString(value any, redacts ...string) string {
+	buf := new(bytes.Buffer)
+	d := dump.NewWithOptions(dump.WithoutColor(), dump.WithoutPosition(), dump.SortMapKeys(), func(opts *dump.Options) {
+		opts.MaxDepth = 10
+	})
+	d.Fprint(buf, value)
+
+	if len(redacts) == 0 {
+		return buf.String()
+	}
+	return redactDumpLines(buf.String(), redacts)
+}
+
+// open a nested value block. eg: "Name: dump.st0 {", "Tags: []string [ #len=2,cap=2"
+var blockOpenRegex = regexp.MustCompile(`(\{|\[)( #len=[\d,=a-z]+)?$`)
+
+// replace the value of the redacted fields with "<redacted>", include the nested block lines.
+func redactDumpLines(s string, names []string) string {
+	prefixes := make([]string, 0, len(names)*2)
+	for _, name := range names {
+		prefixes = append(prefixes, name+": ", fmt.Sprintf("%q: ", name))
+	}
+
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimLeft(line, " ")
+		indent := line[:len(line)-len(trimmed)]
+
+		var prefix string
+		for _, p := range prefixes {
+			if strings.HasPrefix(trimmed, p) {
+				prefix = p
+				break
+			}
+		}
+
+		if prefix == "" {
+			out = append(out, line)
+			continue
+		}
+
+		out = append(out, indent+prefix+"<redacted>,")
+		if !blockOpenRegex.MatchString(trimmed) {
+			continue
+		}
+
+		// skip the nested lines, until the close line with same indent
+		for i++; i < len(lines); i++ {
+			next := lines[i]
+			if strings.HasPrefix(next, indent+"}") || strings.HasPrefix(next, indent+"]") {
+				break
+			}
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+var snapNameRegex = regexp.MustCompile(`[^\w.-]+`)
+
+func snapshotFileName(name string) string {
+	return snapNameRegex.ReplaceAllString(name, "_") + ".snap"
+}
+
+func autoSnapshotName(t testing.TB) string {
+	snapCalls.Lock()
+	n := snapCalls.mp[t]
+	if n == 0 {
+		// clean the record on the test finished
+		t.Cleanup(func() {
+			snapCalls.Lock()
+			delete(snapCalls.mp, t)
+			snapCalls.Unlock()
+		})
+	}
+	snapCalls.mp[t] = n + 1
+	snapCalls.Unlock()
+
+	if n == 0 {
+		return t.Name()
+	}
+	return fmt.Sprintf("%s_%d", t.Name(), n+1)
+}
+
+// simple line based diff for display
+func diffLines(want, got string) string {
+	wls, gls := strings.Split(want, "\n"), strings.Split(got, "\n")
+
+	var sb strings.Builder
+	for i := 0; i < len(wls) || i < len(gls); i++ {
+		var wl, gl string
+		if i < len(wls) {
+			wl = wls[i]
+		}
+		if i < len(gls) {
+			gl = gls[i]
+		}
+
+		if wl == gl {
+			continue
+		}
+		if i < len(wls) {
+			sb.WriteString(fmt.Sprintf("line %d\n  - %s\n", i+1, wl))
+		} else {
+			sb.WriteString(fmt.Sprintf("line %d\n", i+1))
+		}
+		if i < len(gls) {
+			sb.WriteString(fmt.Sprintf("  + %s\n", gl))
+		}
+	}
+	return sb.String()
+}
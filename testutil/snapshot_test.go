@@ -0,0 +1,96 @@
+package testutil_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/fsutil"
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+type snapUser struct {
+	Name      string
+	Tags      []string
+	Extra     map[string]int
+	Profile   *snapProfile
+	CreatedAt time.Time
+}
+
+type snapProfile struct {
+	City string
+	Age  int
+}
+
+func newSnapUser() *snapUser {
+	return &snapUser{
+		Name:      "inhere",
+		Tags:      []string{"go", "php"},
+		Extra:     map[string]int{"c": 3, "a": 1, "b": 2},
+		Profile:   &snapProfile{City: "chengdu", Age: 23},
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestSnapshotString(t *testing.T) {
+	s := testutil.SnapshotString(map[string]int{"b": 2, "a": 1})
+	assert.Eq(t, "map[string]int { #len=2\n  \"a\": int(1),\n  \"b\": int(2),\n},\n", s)
+
+	s = testutil.SnapshotString(newSnapUser(), "CreatedAt", "Profile", "b")
+	assert.StrContains(t, s, "  Profile: <redacted>,\n  CreatedAt: <redacted>,\n},\n")
+	assert.StrContains(t, s, `"b": <redacted>,`)
+	assert.NotContains(t, s, "chengdu")
+	assert.StrContains(t, s, `Name: string("inhere")`)
+}
+
+func TestMatchSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	u := newSnapUser()
+
+	// first call: create the snapshot files
+	testutil.MatchSnapshot(t, u, testutil.WithSnapshotDir(dir), testutil.WithRedacts("CreatedAt"))
+	testutil.MatchSnapshot(t, "second", testutil.WithSnapshotDir(dir))
+	testutil.MatchSnapshot(t, 23, testutil.WithSnapshotDir(dir), testutil.WithSnapshotName("user/age"))
+
+	assert.True(t, fsutil.IsFile(filepath.Join(dir, "TestMatchSnapshot.snap")))
+	assert.True(t, fsutil.IsFile(filepath.Join(dir, "TestMatchSnapshot_2.snap")))
+	assert.True(t, fsutil.IsFile(filepath.Join(dir, "user_age.snap")))
+
+	// compare with the stored snapshot
+	u.CreatedAt = time.Now().Add(time.Hour)
+	testutil.MatchSnapshot(t, u, testutil.WithSnapshotDir(dir), testutil.WithSnapshotName("TestMatchSnapshot"), testutil.WithRedacts("CreatedAt"))
+
+	// update mode
+	testutil.MatchSnapshot(t, 24, testutil.WithSnapshotDir(dir), testutil.WithSnapshotName("user/age"), testutil.UpdateSnapshot)
+	bs, err := os.ReadFile(filepath.Join(dir, "user_age.snap"))
+	assert.NoErr(t, err)
+	assert.Eq(t, "int(24),\n", string(bs))
+}
+
+// record the errors instead of fail the test
+type snapTB struct {
+	testing.TB
+	errs []string
+}
+
+func (t *snapTB) Errorf(format string, args ...any) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func TestMatchSnapshot_mismatch(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoErr(t, os.WriteFile(filepath.Join(dir, "age.snap"), []byte("int(23),\n"), 0644))
+
+	tb := &snapTB{TB: t}
+	testutil.MatchSnapshot(tb, 23, testutil.WithSnapshotDir(dir), testutil.WithSnapshotName("age"))
+	assert.Empty(t, tb.errs)
+
+	testutil.MatchSnapshot(tb, 25, testutil.WithSnapshotDir(dir), testutil.WithSnapshotName("age"))
+	assert.Len(t, tb.errs, 1)
+	assert.StrContains(t, tb.errs[0], `snapshot "age" not match`)
+	assert.StrContains(t, tb.errs[0], "  - int(23),\n  + int(25),")
+	assert.StrContains(t, tb.errs[0], "UPDATE_SNAPSHOTS=1")
+}
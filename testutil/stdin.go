@@ -0,0 +1,40 @@
+package testutil
+
+import (
+	"io"
+	"os"
+)
+
+// MockStdin run fn with os.Stdin replaced by a pipe that contains the input,
+// the reader will get io.EOF after read all input. os.Stdin will be restored after fn returns.
+//
+// NOTE: the readers created from os.Stdin before call it will not be affected.
+//
+// Usage:
+//
+//	testutil.MockStdin("y\ninhere\n", func() {
+//		scanner := bufio.NewScanner(os.Stdin)
+//		scanner.Scan() // "y"
+//		scanner.Scan() // "inhere"
+//	})
+func MockStdin(input string, fn func()) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		panic(err)
+	}
+
+	// write in background, so large input will not block on the pipe buffer.
+	go func() {
+		_, _ = io.WriteString(w, input)
+		_ = w.Close()
+	}()
+
+	bak := os.Stdin
+	os.Stdin = r
+	defer func() {
+		os.Stdin = bak
+		_ = r.Close()
+	}()
+
+	fn()
+}
@@ -0,0 +1,37 @@
+package testutil_test
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestMockStdin(t *testing.T) {
+	bak := os.Stdin
+	testutil.MockStdin("y\ninhere\n", func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		assert.True(t, scanner.Scan())
+		assert.Eq(t, "y", scanner.Text())
+		assert.True(t, scanner.Scan())
+		assert.Eq(t, "inhere", scanner.Text())
+		assert.False(t, scanner.Scan())
+	})
+	assert.True(t, os.Stdin == bak)
+
+	// large input
+	input := strings.Repeat("abc\n", 64*1024)
+	testutil.MockStdin(input, func() {
+		bs, err := io.ReadAll(os.Stdin)
+		assert.NoErr(t, err)
+		assert.Eq(t, len(input), len(bs))
+	})
+
+	// not read all input
+	testutil.MockStdin(input, func() {})
+	assert.True(t, os.Stdin == bak)
+}
@@ -0,0 +1,99 @@
+package testutil
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// SubprocessEnvKey the env marker key for run the subprocess test. value is the subprocess name.
+const SubprocessEnvKey = "GOUTIL_SUBPROCESS_TEST"
+
+// SubprocessResult the result of the subprocess test
+type SubprocessResult struct {
+	// Name of the subprocess
+	Name string
+	// ExitCode of the subprocess. panic will exit with code 2
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	// Err run error, exclude the non-zero exit code error
+	Err error
+}
+
+// Success check the subprocess is run success and exit with code 0
+func (r *SubprocessResult) Success() bool {
+	return r.Err == nil && r.ExitCode == 0
+}
+
+// Panicked check the subprocess is exited by panic
+func (r *SubprocessResult) Panicked() bool {
+	return r.ExitCode == 2 && strings.Contains(r.Stderr, "panic: ")
+}
+
+// InSubprocess check current process is the subprocess for run the test of name
+func InSubprocess(name string) bool {
+	return os.Getenv(SubprocessEnvKey) == name
+}
+
+// RunSubprocessTest re-execute the current test in a subprocess with the env marker, and only
+// run fn in it. the fn can call os.Exit() or panic, then assert the exit code and output.
+//
+// NOTE: the test func will be re-run in the subprocess, so must call it at the beginning of the test.
+// Coverage will be collected when the test run with -test.gocoverdir or the GOCOVERDIR env(go 1.20+).
+//
+// Usage:
+//
+//	func TestApp_Run_exit(t *testing.T) {
+//		res := testutil.RunSubprocessTest(t, "exit", func() {
+//			app.Run() // will call os.Exit()
+//		})
+//
+//		assert.Eq(t, 2, res.ExitCode)
+//		assert.StrContains(t, res.Stderr, "ERROR")
+//	}
+func RunSubprocessTest(t testing.TB, name string, fn func()) *SubprocessResult {
+	if InSubprocess(name) {
+		fn()
+		os.Exit(0)
+	}
+
+	args := []string{"-test.run=" + testRunPattern(t.Name())}
+	if f := flag.Lookup("test.gocoverdir"); f != nil && f.Value.String() != "" {
+		args = append(args, "-test.gocoverdir="+f.Value.String())
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), SubprocessEnvKey+"="+name)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	res := &SubprocessResult{Name: name}
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		res.ExitCode = exitErr.ExitCode()
+	} else if err != nil {
+		res.Err = err
+		res.ExitCode = -1
+	}
+
+	res.Stdout, res.Stderr = stdout.String(), stderr.String()
+	return res
+}
+
+// build the -test.run pattern for exactly match the test name. eg: "^TestA$/^sub$"
+func testRunPattern(testName string) string {
+	nodes := strings.Split(testName, "/")
+	for i, node := range nodes {
+		nodes[i] = "^" + regexp.QuoteMeta(node) + "$"
+	}
+	return strings.Join(nodes, "/")
+}
@@ -0,0 +1,49 @@
+package testutil_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestRunSubprocessTest(t *testing.T) {
+	res := testutil.RunSubprocessTest(t, "exit", func() {
+		fmt.Println("to stdout")
+		fmt.Fprintln(os.Stderr, "to stderr")
+		os.Exit(3)
+	})
+
+	assert.NoErr(t, res.Err)
+	assert.Eq(t, "exit", res.Name)
+	assert.Eq(t, 3, res.ExitCode)
+	assert.False(t, res.Success())
+	assert.False(t, res.Panicked())
+	// NOTE: the TestMain also run in subprocess, stdout may contain other output
+	assert.StrContains(t, res.Stdout, "to stdout\n")
+	assert.Eq(t, "to stderr\n", res.Stderr)
+
+	res = testutil.RunSubprocessTest(t, "panic", func() {
+		panic("hard fail")
+	})
+	assert.True(t, res.Panicked())
+	assert.StrContains(t, res.Stderr, "hard fail")
+
+	res = testutil.RunSubprocessTest(t, "ok", func() {
+		fmt.Print("done")
+	})
+	assert.True(t, res.Success())
+	assert.StrContains(t, res.Stdout, "done")
+}
+
+func TestRunSubprocessTest_subtest(t *testing.T) {
+	t.Run("sub test", func(t *testing.T) {
+		res := testutil.RunSubprocessTest(t, "sub", func() {
+			os.Exit(5)
+		})
+		assert.Eq(t, 5, res.ExitCode)
+		assert.False(t, testutil.InSubprocess("sub"))
+	})
+}
@@ -0,0 +1,91 @@
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TempDirWith create a temp dir by t.TempDir(), and create the files tree from the map.
+// The key is slash separated relative path, the value is file contents.
+// The key ends with "/" will create an empty dir.
+//
+// The temp dir will be removed automatically on the test finished.
+//
+// Usage:
+//
+//	dir := testutil.TempDirWith(t, map[string]string{
+//		"a/b.txt": "content",
+//		"empty/":  "",
+//	})
+func TempDirWith(t testing.TB, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	for name, contents := range files {
+		fpath := filepath.Join(dir, filepath.FromSlash(name))
+		if strings.HasSuffix(name, "/") {
+			if err := os.MkdirAll(fpath, 0755); err != nil {
+				t.Fatalf("create dir %q error: %v", name, err)
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
+			t.Fatalf("create dir for %q error: %v", name, err)
+		}
+		if err := os.WriteFile(fpath, []byte(contents), 0644); err != nil {
+			t.Fatalf("write file %q error: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// Chdir change the workdir to dir, and restore it on the test finished.
+//
+// NOTE: the workdir is process-wide, so cannot use it in parallel tests.
+func Chdir(t testing.TB, dir string) {
+	t.Helper()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("get workdir error: %v", err)
+	}
+
+	if err = os.Chdir(dir); err != nil {
+		t.Fatalf("change workdir to %q error: %v", dir, err)
+	}
+
+	t.Cleanup(func() {
+		if err := os.Chdir(oldWd); err != nil {
+			t.Errorf("restore workdir to %q error: %v", oldWd, err)
+		}
+	})
+}
+
+// InDir run fn in the dir as workdir, will restore the workdir after fn returns, even if fn panics.
+func InDir(dir string, fn func()) error {
+	oldWd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	if err = os.Chdir(dir); err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Chdir(oldWd)
+	}()
+
+	fn()
+	return nil
+}
+
+// ChdirTempWith create a temp dir with files by TempDirWith(), and change workdir into it.
+// the workdir will be restored and the dir removed on the test finished.
+func ChdirTempWith(t testing.TB, files map[string]string) string {
+	t.Helper()
+	dir := TempDirWith(t, files)
+	Chdir(t, dir)
+	return dir
+}
@@ -0,0 +1,82 @@
+package testutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestTempDirWith(t *testing.T) {
+	dir := testutil.TempDirWith(t, map[string]string{
+		"a/b.txt": "content",
+		"c.txt":   "",
+		"empty/":  "",
+	})
+
+	bs, err := os.ReadFile(filepath.Join(dir, "a", "b.txt"))
+	assert.NoErr(t, err)
+	assert.Eq(t, "content", string(bs))
+
+	fi, err := os.Stat(filepath.Join(dir, "c.txt"))
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(0), fi.Size())
+
+	fi, err = os.Stat(filepath.Join(dir, "empty"))
+	assert.NoErr(t, err)
+	assert.True(t, fi.IsDir())
+}
+
+func TestChdir(t *testing.T) {
+	oldWd, err := os.Getwd()
+	assert.NoErr(t, err)
+
+	t.Run("chdir", func(t *testing.T) {
+		dir := testutil.ChdirTempWith(t, map[string]string{"a.txt": "hi"})
+		wd, err := os.Getwd()
+		assert.NoErr(t, err)
+		assert.True(t, sameDir(dir, wd))
+
+		bs, err := os.ReadFile("a.txt")
+		assert.NoErr(t, err)
+		assert.Eq(t, "hi", string(bs))
+	})
+
+	wd, err := os.Getwd()
+	assert.NoErr(t, err)
+	assert.Eq(t, oldWd, wd)
+}
+
+func TestInDir(t *testing.T) {
+	oldWd, err := os.Getwd()
+	assert.NoErr(t, err)
+	dir := testutil.TempDirWith(t, map[string]string{"a.txt": "hi"})
+
+	err = testutil.InDir(dir, func() {
+		_, err := os.Stat("a.txt")
+		assert.NoErr(t, err)
+	})
+	assert.NoErr(t, err)
+
+	// restore on panic
+	assert.Panics(t, func() {
+		_ = testutil.InDir(dir, func() {
+			panic("error")
+		})
+	})
+
+	wd, err := os.Getwd()
+	assert.NoErr(t, err)
+	assert.Eq(t, oldWd, wd)
+
+	assert.Err(t, testutil.InDir(filepath.Join(dir, "not-exists"), func() {}))
+}
+
+// the temp dir may be a symlink. eg: /tmp on macOS
+func sameDir(a, b string) bool {
+	fa, err1 := os.Stat(a)
+	fb, err2 := os.Stat(b)
+	return err1 == nil && err2 == nil && os.SameFile(fa, fb)
+}
@@ -0,0 +1,41 @@
+package timex
+
+import "time"
+
+// Clock interface for get current time and wait. the packages accept it for replace the
+// time functions on testing. eg: use testutil.Clock for control time manually.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker interface, wrap the time.Ticker
+type Ticker interface {
+	// Chan get the channel for receive ticks
+	Chan() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// SystemClock the real clock, use the functions of the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (systemClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return &systemTicker{Ticker: time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t *systemTicker) Chan() <-chan time.Time { return t.C }
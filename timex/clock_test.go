@@ -0,0 +1,22 @@
+package timex_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/timex"
+)
+
+func TestSystemClock(t *testing.T) {
+	clk := timex.SystemClock
+	start := clk.Now()
+	clk.Sleep(time.Millisecond)
+	assert.True(t, clk.Since(start) >= time.Millisecond)
+	<-clk.After(time.Millisecond)
+
+	tk := clk.NewTicker(time.Millisecond)
+	<-tk.Chan()
+	tk.Reset(2 * time.Millisecond)
+	tk.Stop()
+}
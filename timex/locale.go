@@ -0,0 +1,184 @@
+package timex
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gookit/goutil/strutil"
+)
+
+// Locale the localized month, weekday and am/pm names for format date.
+type Locale struct {
+	// Name of the locale. eg: en, zh
+	Name string
+	// Months full month names, January through December
+	Months [12]string
+	// ShortMonths short month names, Jan through Dec
+	ShortMonths [12]string
+	// Weekdays full weekday names, Sunday through Saturday
+	Weekdays [7]string
+	// ShortWeekdays short weekday names, Sun through Sat
+	ShortWeekdays [7]string
+	// AM, PM names
+	AM, PM string
+}
+
+// built-in locales
+var (
+	LocaleEN = &Locale{
+		Name: "en",
+		Months: [12]string{
+			"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December",
+		},
+		ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		AM:            "AM",
+		PM:            "PM",
+	}
+
+	LocaleZH = &Locale{
+		Name:          "zh",
+		Months:        [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		ShortMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		ShortWeekdays: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		AM:            "上午",
+		PM:            "下午",
+	}
+)
+
+var locales = map[string]*Locale{
+	"en": LocaleEN,
+	"zh": LocaleZH,
+}
+
+// RegisterLocale add or replace a locale by the Locale.Name
+func RegisterLocale(l *Locale) {
+	locales[strings.ToLower(l.Name)] = l
+}
+
+// GetLocale get locale by name. the region will be fallback to language. eg: "zh-CN", "zh_CN" -> "zh"
+func GetLocale(name string) (*Locale, bool) {
+	name = strings.ToLower(name)
+	if l, ok := locales[name]; ok {
+		return l, true
+	}
+
+	if pos := strings.IndexAny(name, "-_"); pos > 0 {
+		l, ok := locales[name[:pos]]
+		return l, ok
+	}
+	return nil, false
+}
+
+// FormatLocale format time by date template, and use the localized names of the locale
+// for the month, weekday and am/pm chars: M, F, D, w, W, l, a, A.
+//
+// Unknown locale will use the "en" names. see ToLayout() for the template chars.
+//
+// Usage:
+//
+//	timex.FormatLocale(t, "Y年n月j日 l", "zh") // Output: 2024年3月5日 星期二
+//	timex.FormatLocale(t, "l, F j", "en") // Output: Tuesday, March 5
+func FormatLocale(t time.Time, template, locale string) string {
+	l, ok := GetLocale(locale)
+	if !ok || l == LocaleEN {
+		return FormatByTpl(t, template)
+	}
+
+	var sb strings.Builder
+	start := 0
+	for i, c := range strutil.ToBytes(template) {
+		name, ok := l.nameFor(t, c)
+		if !ok {
+			continue
+		}
+
+		if start < i {
+			sb.WriteString(FormatByTpl(t, template[start:i]))
+		}
+		sb.WriteString(name)
+		start = i + 1
+	}
+
+	if start < len(template) {
+		sb.WriteString(FormatByTpl(t, template[start:]))
+	}
+	return sb.String()
+}
+
+// get the localized name by the template char
+func (l *Locale) nameFor(t time.Time, c byte) (string, bool) {
+	switch c {
+	case 'M':
+		return l.ShortMonths[t.Month()-1], true
+	case 'F':
+		return l.Months[t.Month()-1], true
+	case 'D', 'w':
+		return l.ShortWeekdays[t.Weekday()], true
+	case 'W', 'l':
+		return l.Weekdays[t.Weekday()], true
+	case 'a', 'A':
+		if t.Hour() < 12 {
+			return l.AM, true
+		}
+		return l.PM, true
+	}
+	return "", false
+}
+
+// ParseLocale parse the date string by the date template, the localized names of the locale will be recognized.
+//
+// Usage:
+//
+//	t, err := timex.ParseLocale("2024年3月5日 星期二", "Y年n月j日 l", "zh")
+func ParseLocale(s, template, locale string) (time.Time, error) {
+	if l, ok := GetLocale(locale); ok && l != LocaleEN {
+		s = l.toEnglish(s, template)
+	}
+	return time.Parse(ToLayout(template), s)
+}
+
+// replace the localized names used by the template to english names, the longer name replace first.
+func (l *Locale) toEnglish(s, template string) string {
+	var pairs [][2]string
+	addPairs := func(from, to []string) {
+		for i, name := range from {
+			pairs = append(pairs, [2]string{name, to[i]})
+		}
+	}
+
+	en := LocaleEN
+	if strings.IndexByte(template, 'F') >= 0 {
+		addPairs(l.Months[:], en.Months[:])
+	}
+	if strings.IndexByte(template, 'M') >= 0 {
+		addPairs(l.ShortMonths[:], en.ShortMonths[:])
+	}
+	if strings.ContainsAny(template, "Wl") {
+		addPairs(l.Weekdays[:], en.Weekdays[:])
+	}
+	if strings.ContainsAny(template, "Dw") {
+		addPairs(l.ShortWeekdays[:], en.ShortWeekdays[:])
+	}
+	if strings.IndexByte(template, 'A') >= 0 {
+		addPairs([]string{l.AM, l.PM}, []string{en.AM, en.PM})
+	} else if strings.IndexByte(template, 'a') >= 0 {
+		addPairs([]string{l.AM, l.PM}, []string{strings.ToLower(en.AM), strings.ToLower(en.PM)})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return len(pairs[i][0]) > len(pairs[j][0])
+	})
+
+	args := make([]string, 0, len(pairs)*2)
+	for _, p := range pairs {
+		if p[0] != "" {
+			args = append(args, p[0], p[1])
+		}
+	}
+	return strings.NewReplacer(args...).Replace(s)
+}
@@ -0,0 +1,64 @@
+package timex_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/timex"
+)
+
+func TestFormatLocale(t *testing.T) {
+	tt := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+
+	assert.Eq(t, "Tuesday, March 5", timex.FormatLocale(tt, "l, F j", "en"))
+	assert.Eq(t, "2024年3月5日 星期二", timex.FormatLocale(tt, "Y年n月j日 l", "zh"))
+	assert.Eq(t, "三月 05 周二 下午 02:30", timex.FormatLocale(tt, "F d D A h:I", "zh-CN"))
+	assert.Eq(t, "3月-05", timex.FormatLocale(tt, "M-d", "zh_CN"))
+	assert.Eq(t, "上午", timex.FormatLocale(tt.Add(-10*time.Hour), "a", "zh"))
+	// unknown locale
+	assert.Eq(t, "Mar 05", timex.FormatLocale(tt, "M d", "xx"))
+
+	timex.RegisterLocale(&timex.Locale{
+		Name:          "de",
+		Months:        [12]string{"Januar", "Februar", "März"},
+		ShortMonths:   [12]string{"Jan", "Feb", "Mär"},
+		Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag"},
+		ShortWeekdays: [7]string{"So", "Mo", "Di"},
+	})
+	assert.Eq(t, "Dienstag, 5. März", timex.FormatLocale(tt, "l, j. F", "de"))
+
+	l, ok := timex.GetLocale("ZH")
+	assert.True(t, ok)
+	assert.Eq(t, timex.LocaleZH, l)
+	_, ok = timex.GetLocale("fr-FR")
+	assert.False(t, ok)
+}
+
+func TestParseLocale(t *testing.T) {
+	want := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	tt, err := timex.ParseLocale("2024年3月5日 星期二", "Y年n月j日 l", "zh")
+	assert.NoErr(t, err)
+	assert.Eq(t, want, tt)
+
+	tt, err = timex.ParseLocale("2024 十一月 05", "Y F d", "zh")
+	assert.NoErr(t, err)
+	assert.Eq(t, time.November, tt.Month())
+
+	tt, err = timex.ParseLocale("2024-11月-05 下午 02:30", "Y-M-d A h:I", "zh")
+	assert.NoErr(t, err)
+	assert.Eq(t, time.November, tt.Month())
+	assert.Eq(t, 14, tt.Hour())
+
+	tt, err = timex.ParseLocale("2024-1月-05 下午 02:30", "Y-M-d a h:I", "zh")
+	assert.NoErr(t, err)
+	assert.Eq(t, time.January, tt.Month())
+
+	tt, err = timex.ParseLocale("Tuesday, March 5 2024", "l, F j Y", "en")
+	assert.NoErr(t, err)
+	assert.Eq(t, want, tt)
+
+	_, err = timex.ParseLocale("2024 十三月", "Y F", "zh")
+	assert.Err(t, err)
+}
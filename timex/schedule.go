@@ -0,0 +1,219 @@
+package timex
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ScheduleFunc the scheduled job func
+type ScheduleFunc func(ctx context.Context) error
+
+// ScheduleOpt options for Schedule()
+type ScheduleOpt struct {
+	// SkipIfRunning skip the tick if last run is not finished, and record as missed tick.
+	//
+	// If is false, will run fn on the loop goroutine, ticks during running will be dropped.
+	SkipIfRunning bool
+	// Immediate run fn once immediately on start, not wait first tick.
+	Immediate bool
+	// OnError handler for fn returned error
+	OnError func(err error)
+	// Clock for create ticker and get time. default is SystemClock
+	Clock Clock
+}
+
+// ScheduleOptFn option func for Schedule()
+type ScheduleOptFn func(opt *ScheduleOpt)
+
+// WithSkipIfRunning set skip the tick if last run is not finished
+func WithSkipIfRunning() ScheduleOptFn {
+	return func(opt *ScheduleOpt) {
+		opt.SkipIfRunning = true
+	}
+}
+
+// WithImmediate set run fn once immediately on start
+func WithImmediate() ScheduleOptFn {
+	return func(opt *ScheduleOpt) {
+		opt.Immediate = true
+	}
+}
+
+// WithOnError set handler for fn returned error
+func WithOnError(fn func(err error)) ScheduleOptFn {
+	return func(opt *ScheduleOpt) {
+		opt.OnError = fn
+	}
+}
+
+// WithClock set the clock for the scheduler. eg: use testutil.Clock on testing
+func WithClock(clk Clock) ScheduleOptFn {
+	return func(opt *ScheduleOpt) {
+		opt.Clock = clk
+	}
+}
+
+// ScheduleStats run stats of the Scheduler
+type ScheduleStats struct {
+	// Runs total run times
+	Runs int64
+	// Errors total error times
+	Errors int64
+	// Missed ticks number. skipped or dropped ticks because of last run is not finished
+	Missed int64
+	// LastStart time of last run
+	LastStart time.Time
+	// LastDuration of last run
+	LastDuration time.Duration
+	// LastErr returned by last run
+	LastErr error
+}
+
+// Scheduler a monotonic interval scheduler, fn will never overlap with next tick.
+type Scheduler struct {
+	opt      *ScheduleOpt
+	fn       ScheduleFunc
+	interval time.Duration
+
+	mu      sync.Mutex
+	stats   ScheduleStats
+	running bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Schedule run fn periodically by interval, until the ctx is done or call Stop().
+//
+// Usage:
+//
+//	s := timex.Schedule(ctx, time.Minute, func(ctx context.Context) error {
+//		return doSomething(ctx)
+//	}, timex.WithSkipIfRunning())
+//	defer s.Stop()
+func Schedule(ctx context.Context, interval time.Duration, fn ScheduleFunc, fns ...ScheduleOptFn) *Scheduler {
+	if interval <= 0 {
+		panic("timex: schedule interval must be greater than 0")
+	}
+
+	opt := &ScheduleOpt{}
+	for _, f := range fns {
+		f(opt)
+	}
+	if opt.Clock == nil {
+		opt.Clock = SystemClock
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Scheduler{
+		opt:      opt,
+		fn:       fn,
+		interval: interval,
+		cancel:   cancel,
+	}
+
+	s.wg.Add(1)
+	go s.loop(ctx)
+	return s
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	defer s.wg.Done()
+
+	if s.opt.Immediate {
+		s.tick(ctx)
+	}
+
+	ticker := s.opt.Clock.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	if !s.opt.SkipIfRunning {
+		s.mu.Lock()
+		s.running = true
+		s.mu.Unlock()
+
+		dur := s.run(ctx)
+		// the ticks during running are dropped by ticker
+		if missed := int64(dur / s.interval); missed > 0 {
+			s.mu.Lock()
+			s.stats.Missed += missed
+			s.mu.Unlock()
+		}
+		return
+	}
+
+	s.mu.Lock()
+	if s.running {
+		s.stats.Missed++
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx)
+	}()
+}
+
+func (s *Scheduler) run(ctx context.Context) time.Duration {
+	start := s.opt.Clock.Now()
+	err := s.fn(ctx)
+	dur := s.opt.Clock.Since(start)
+
+	// call before reset running, make sure the OnError also not overlap
+	if err != nil && s.opt.OnError != nil {
+		s.opt.OnError(err)
+	}
+
+	s.mu.Lock()
+	s.running = false
+	s.stats.Runs++
+	s.stats.LastStart = start
+	s.stats.LastDuration = dur
+	s.stats.LastErr = err
+	if err != nil {
+		s.stats.Errors++
+	}
+	s.mu.Unlock()
+	return dur
+}
+
+// Stats get the run stats
+func (s *Scheduler) Stats() ScheduleStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// Running check the fn is running
+func (s *Scheduler) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// Stop the scheduler, and wait the running fn finished.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Wait the scheduler stopped by ctx done or Stop()
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
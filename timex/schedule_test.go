@@ -0,0 +1,127 @@
+package timex_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/timex"
+)
+
+func TestSchedule(t *testing.T) {
+	var n int32
+	s := timex.Schedule(context.Background(), 10*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&n, 1)
+		return nil
+	}, timex.WithImmediate())
+
+	time.Sleep(55 * time.Millisecond)
+	s.Stop()
+
+	st := s.Stats()
+	assert.True(t, st.Runs >= 3)
+	assert.Eq(t, int64(atomic.LoadInt32(&n)), st.Runs)
+	assert.Eq(t, int64(0), st.Errors)
+	assert.False(t, st.LastStart.IsZero())
+	assert.False(t, s.Running())
+}
+
+func TestSchedule_skipIfRunning(t *testing.T) {
+	var active, maxActive int32
+	var gotErr error
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := timex.Schedule(ctx, 5*time.Millisecond, func(ctx context.Context) error {
+		if cur := atomic.AddInt32(&active, 1); cur > atomic.LoadInt32(&maxActive) {
+			atomic.StoreInt32(&maxActive, cur)
+		}
+		defer atomic.AddInt32(&active, -1)
+
+		time.Sleep(22 * time.Millisecond)
+		return errors.New("slow job")
+	}, timex.WithSkipIfRunning(), timex.WithOnError(func(err error) {
+		gotErr = err
+	}))
+
+	time.Sleep(60 * time.Millisecond)
+	cancel()
+	s.Wait()
+
+	st := s.Stats()
+	assert.Eq(t, int32(1), atomic.LoadInt32(&maxActive))
+	assert.True(t, st.Runs >= 1)
+	assert.True(t, st.Missed >= 2)
+	assert.Eq(t, st.Runs, st.Errors)
+	assert.ErrMsg(t, st.LastErr, "slow job")
+	assert.ErrMsg(t, gotErr, "slow job")
+	assert.True(t, st.LastDuration >= 20*time.Millisecond)
+}
+
+func TestSchedule_slowInline(t *testing.T) {
+	s := timex.Schedule(context.Background(), 5*time.Millisecond, func(ctx context.Context) error {
+		time.Sleep(12 * time.Millisecond)
+		return nil
+	})
+
+	time.Sleep(40 * time.Millisecond)
+	s.Stop()
+
+	st := s.Stats()
+	assert.True(t, st.Runs >= 1)
+	assert.True(t, st.Missed >= 2)
+
+	assert.Panics(t, func() {
+		timex.Schedule(context.Background(), 0, nil)
+	})
+}
+
+func TestSchedule_withClock(t *testing.T) {
+	clk := testutil.NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ran := make(chan time.Time)
+
+	s := timex.Schedule(context.Background(), time.Minute, func(ctx context.Context) error {
+		ran <- clk.Now()
+		return nil
+	}, timex.WithClock(clk))
+
+	// wait the ticker created
+	clk.BlockUntil(1)
+	select {
+	case <-ran:
+		t.Fatal("should not run before the clock advanced")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clk.Advance(time.Minute)
+	assert.Eq(t, time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC), <-ran)
+	clk.Advance(time.Minute)
+	assert.Eq(t, time.Date(2024, 1, 1, 0, 2, 0, 0, time.UTC), <-ran)
+
+	s.Stop()
+	st := s.Stats()
+	assert.Eq(t, int64(2), st.Runs)
+	assert.Eq(t, time.Date(2024, 1, 1, 0, 2, 0, 0, time.UTC), st.LastStart)
+	assert.Eq(t, 0, clk.Waiters())
+}
+
+func TestScheduler_Running(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	s := timex.Schedule(context.Background(), time.Minute, func(ctx context.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}, timex.WithImmediate(), timex.WithClock(testutil.NewClock()))
+
+	// the inline mode also mark running
+	<-started
+	assert.True(t, s.Running())
+
+	close(release)
+	s.Stop()
+	assert.False(t, s.Running())
+	assert.Eq(t, int64(1), s.Stats().Runs)
+}
@@ -0,0 +1,120 @@
+package termenv
+
+import (
+	"strings"
+
+	"github.com/gookit/goutil/strutil"
+)
+
+// TokenKind the kind of ANSI token
+type TokenKind uint8
+
+// ANSI token kinds
+const (
+	// TokenText plain text
+	TokenText TokenKind = iota
+	// TokenSGR the SGR style sequence. eg: "\x1b[1;32m"
+	TokenSGR
+	// TokenCSI the other CSI sequence. eg: cursor move "\x1b[2A"
+	TokenCSI
+	// TokenOSC the OSC sequence. eg: set title "\x1b]2;title\a"
+	TokenOSC
+	// TokenEsc the other escape sequence. eg: DCS, APC, "\x1b7"
+	TokenEsc
+)
+
+// String get kind name
+func (k TokenKind) String() string {
+	switch k {
+	case TokenSGR:
+		return "sgr"
+	case TokenCSI:
+		return "csi"
+	case TokenOSC:
+		return "osc"
+	case TokenEsc:
+		return "esc"
+	default:
+		return "text"
+	}
+}
+
+// Token a text or escape sequence token of the ANSI string
+type Token struct {
+	Kind  TokenKind
+	Value string
+}
+
+// IsText check is plain text token
+func (t Token) IsText() bool { return t.Kind == TokenText }
+
+// TokenizeANSI split the string to text and escape sequence tokens.
+// An incomplete sequence at the end will be as an escape token.
+//
+// Usage:
+//
+//	tokens := termenv.TokenizeANSI("\x1b[32mOK\x1b[0m")
+//	// [{sgr "\x1b[32m"} {text "OK"} {sgr "\x1b[0m"}]
+func TokenizeANSI(s string) []Token {
+	var tokens []Token
+	for s != "" {
+		i := strings.IndexByte(s, escChar)
+		if i < 0 {
+			tokens = append(tokens, Token{Kind: TokenText, Value: s})
+			break
+		}
+		if i > 0 {
+			tokens = append(tokens, Token{Kind: TokenText, Value: s[:i]})
+			s = s[i:]
+		}
+
+		n, complete := escapeSeqLen([]byte(s))
+		if !complete {
+			n = len(s)
+		}
+
+		tokens = append(tokens, Token{Kind: escTokenKind(s[:n]), Value: s[:n]})
+		s = s[n:]
+	}
+	return tokens
+}
+
+func escTokenKind(seq string) TokenKind {
+	if len(seq) < 2 {
+		return TokenEsc
+	}
+
+	switch seq[1] {
+	case '[':
+		if seq[len(seq)-1] == 'm' {
+			return TokenSGR
+		}
+		return TokenCSI
+	case ']':
+		return TokenOSC
+	}
+	return TokenEsc
+}
+
+// HasANSI check the string contains escape sequence
+func HasANSI(s string) bool { return strings.IndexByte(s, escChar) >= 0 }
+
+// StripANSI remove all escape sequences from the string.
+// useful for write the colored output to log files.
+func StripANSI(s string) string {
+	if !HasANSI(s) {
+		return s
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for _, tk := range TokenizeANSI(s) {
+		if tk.Kind == TokenText {
+			sb.WriteString(tk.Value)
+		}
+	}
+	return sb.String()
+}
+
+// VisibleWidth get the display width of the string, escape sequences are not counted.
+func VisibleWidth(s string) int { return strutil.Utf8Width(StripANSI(s)) }
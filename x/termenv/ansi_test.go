@@ -0,0 +1,52 @@
+package termenv_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestTokenizeANSI(t *testing.T) {
+	tokens := termenv.TokenizeANSI("\x1b[1;32mOK\x1b[0m done\x1b[2A\x1b]2;title\a\x1b7end")
+	assert.Eq(t, []termenv.Token{
+		{Kind: termenv.TokenSGR, Value: "\x1b[1;32m"},
+		{Kind: termenv.TokenText, Value: "OK"},
+		{Kind: termenv.TokenSGR, Value: "\x1b[0m"},
+		{Kind: termenv.TokenText, Value: " done"},
+		{Kind: termenv.TokenCSI, Value: "\x1b[2A"},
+		{Kind: termenv.TokenOSC, Value: "\x1b]2;title\a"},
+		{Kind: termenv.TokenEsc, Value: "\x1b7"},
+		{Kind: termenv.TokenText, Value: "end"},
+	}, tokens)
+	assert.True(t, tokens[1].IsText())
+	assert.Eq(t, "sgr", tokens[0].Kind.String())
+
+	// incomplete at end
+	tokens = termenv.TokenizeANSI("abc\x1b[3")
+	assert.Len(t, tokens, 2)
+	assert.Eq(t, termenv.TokenCSI, tokens[1].Kind)
+	assert.Eq(t, "\x1b[3", tokens[1].Value)
+
+	assert.Empty(t, termenv.TokenizeANSI(""))
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"plain text", "plain text"},
+		{"\x1b[1;32mOK\x1b[0m", "OK"},
+		{"\x1b[38;2;255;0;0mred\x1b[39m and \x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "red and link"},
+		{"tail\x1b", "tail"},
+	}
+
+	for _, tt := range tests {
+		assert.Eq(t, tt.want, termenv.StripANSI(tt.in))
+	}
+
+	assert.False(t, termenv.HasANSI("abc"))
+	assert.True(t, termenv.HasANSI("\x1b[0m"))
+	assert.Eq(t, 6, termenv.VisibleWidth("\x1b[32m中文ab\x1b[0m"))
+}
@@ -0,0 +1,125 @@
+package termenv
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RGB color value
+type RGB struct {
+	R, G, B uint8
+}
+
+// Luminance get the relative luminance of the color, range: 0-1
+func (c RGB) Luminance() float64 {
+	return (0.2126*float64(c.R) + 0.7152*float64(c.G) + 0.0722*float64(c.B)) / 255
+}
+
+// IsDark check the color is dark
+func (c RGB) IsDark() bool { return c.Luminance() < 0.5 }
+
+// String get hex string. eg: "#1e1e1e"
+func (c RGB) String() string {
+	const hexChars = "0123456789abcdef"
+	return string([]byte{
+		'#',
+		hexChars[c.R>>4], hexChars[c.R&0x0f],
+		hexChars[c.G>>4], hexChars[c.G&0x0f],
+		hexChars[c.B>>4], hexChars[c.B&0x0f],
+	})
+}
+
+var (
+	bgOnce  sync.Once
+	bgColor RGB
+	bgErr   error
+)
+
+// BackgroundColor get the terminal background color. will query on first call and cache the result.
+//
+// see QueryBackground()
+func BackgroundColor() (RGB, error) {
+	bgOnce.Do(func() {
+		bgColor, bgErr = QueryBackground(DefaultQueryTimeout)
+	})
+	return bgColor, bgErr
+}
+
+// QueryBackground query the terminal background color by OSC 11 sequence.
+//
+// If query failed, will fallback parse the COLORFGBG env. eg: "15;0"
+func QueryBackground(timeout time.Duration) (RGB, error) {
+	resp, err := QueryTerminal("\x1b]11;?\x1b\\", timeout)
+	if err == nil {
+		return ParseOSCColor(resp)
+	}
+
+	if c, ok := colorFromFgBgEnv(); ok {
+		return c, nil
+	}
+	return RGB{}, err
+}
+
+// HasDarkBackground check the terminal has dark background.
+// If cannot detect background color, will return true.
+func HasDarkBackground() bool {
+	c, err := BackgroundColor()
+	if err != nil {
+		return true
+	}
+	return c.IsDark()
+}
+
+// ParseOSCColor parse the OSC color query response.
+//
+// eg: "\x1b]11;rgb:1e1e/1e1e/1e1e\x1b\\" or "\x1b]11;rgb:ff/ff/ff\a"
+func ParseOSCColor(resp string) (RGB, error) {
+	idx := strings.Index(resp, "rgb:")
+	if idx < 0 {
+		return RGB{}, errors.New("termenv: invalid OSC color response")
+	}
+
+	s := strings.TrimRight(resp[idx+4:], "\a\x1b\\")
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 {
+		return RGB{}, errors.New("termenv: invalid OSC color response")
+	}
+
+	var vs [3]uint8
+	for i, part := range parts {
+		if len(part) == 0 || len(part) > 4 {
+			return RGB{}, errors.New("termenv: invalid OSC color value: " + part)
+		}
+
+		v, err := strconv.ParseUint(part, 16, 16)
+		if err != nil {
+			return RGB{}, err
+		}
+
+		// scale to 8-bit value. eg: "ffff" -> 255
+		maxVal := uint64(1)<<(4*len(part)) - 1
+		vs[i] = uint8(v * 255 / maxVal)
+	}
+	return RGB{vs[0], vs[1], vs[2]}, nil
+}
+
+// parse the COLORFGBG env, format: "fg;bg" or "fg;default;bg"
+func colorFromFgBgEnv() (RGB, bool) {
+	val := os.Getenv("COLORFGBG")
+	if val == "" {
+		return RGB{}, false
+	}
+
+	parts := strings.Split(val, ";")
+	bg, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return RGB{}, false
+	}
+
+	c := basic16Colors[bg]
+	return RGB{c[0], c[1], c[2]}, true
+}
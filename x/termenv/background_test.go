@@ -0,0 +1,34 @@
+package termenv_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestParseOSCColor(t *testing.T) {
+	c, err := termenv.ParseOSCColor("\x1b]11;rgb:1e1e/1e1e/1e1e\x1b\\")
+	assert.NoErr(t, err)
+	assert.Eq(t, termenv.RGB{R: 30, G: 30, B: 30}, c)
+	assert.Eq(t, "#1e1e1e", c.String())
+	assert.True(t, c.IsDark())
+
+	c, err = termenv.ParseOSCColor("\x1b]11;rgb:ff/ff/ff\a")
+	assert.NoErr(t, err)
+	assert.Eq(t, "#ffffff", c.String())
+	assert.False(t, c.IsDark())
+
+	_, err = termenv.ParseOSCColor("invalid")
+	assert.Err(t, err)
+	_, err = termenv.ParseOSCColor("\x1b]11;rgb:ff/ff\a")
+	assert.Err(t, err)
+	_, err = termenv.ParseOSCColor("\x1b]11;rgb:ff/ff/zz\a")
+	assert.Err(t, err)
+}
+
+func TestRGB_Luminance(t *testing.T) {
+	assert.Eq(t, float64(0), termenv.RGB{}.Luminance())
+	assert.True(t, termenv.RGB{R: 255, G: 255, B: 255}.Luminance() > 0.99)
+	assert.False(t, termenv.RGB{R: 200, G: 200, B: 200}.IsDark())
+}
@@ -0,0 +1,148 @@
+package termenv
+
+import "strconv"
+
+// basic 16 colors RGB values. refer the xterm default palette
+var basic16Colors = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// the 6x6x6 color cube levels in 256 colors
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// ConvertRGBTo256 convert RGB color to the nearest 256 color index(16-255)
+//
+// Usage:
+//
+//	termenv.ConvertRGBTo256(255, 0, 0) // 196
+func ConvertRGBTo256(r, g, b uint8) uint8 {
+	// nearest color in the 6x6x6 cube
+	ri, gi, bi := cubeIndex(r), cubeIndex(g), cubeIndex(b)
+	cubeIdx := 16 + 36*ri + 6*gi + bi
+	cubeDist := colorDistance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	// nearest color in the 24 grayscale: 8, 18, ..., 238
+	avg := (int(r) + int(g) + int(b)) / 3
+	grayIdx := 23
+	if avg < 238 {
+		grayIdx = (avg - 3) / 10
+		if grayIdx < 0 {
+			grayIdx = 0
+		}
+	}
+	gv := uint8(8 + 10*grayIdx)
+	grayDist := colorDistance(r, g, b, gv, gv, gv)
+
+	if grayDist < cubeDist {
+		return uint8(232 + grayIdx)
+	}
+	return cubeIdx
+}
+
+func cubeIndex(v uint8) uint8 {
+	if v < 48 {
+		return 0
+	}
+	if v < 115 {
+		return 1
+	}
+	return (v - 35) / 40
+}
+
+// Convert256ToRGB convert 256 color index to RGB color
+func Convert256ToRGB(idx uint8) RGB {
+	switch {
+	case idx < 16:
+		c := basic16Colors[idx]
+		return RGB{c[0], c[1], c[2]}
+	case idx < 232:
+		idx -= 16
+		return RGB{cubeLevels[idx/36], cubeLevels[(idx/6)%6], cubeLevels[idx%6]}
+	default:
+		v := 8 + 10*(idx-232)
+		return RGB{v, v, v}
+	}
+}
+
+// ConvertTo16 convert RGB color to the nearest basic 16 color index(0-15).
+//
+// Usage:
+//
+//	idx := termenv.ConvertTo16(255, 0, 0) // 9
+//	code := termenv.ANSICode16(idx, false) // 91
+func ConvertTo16(r, g, b uint8) uint8 {
+	var idx uint8
+	minDist := -1
+	for i, c := range basic16Colors {
+		dist := colorDistance(r, g, b, c[0], c[1], c[2])
+		if minDist < 0 || dist < minDist {
+			minDist, idx = dist, uint8(i)
+		}
+	}
+	return idx
+}
+
+// Convert256To16 convert 256 color index to the nearest basic 16 color index(0-15)
+func Convert256To16(idx uint8) uint8 {
+	if idx < 16 {
+		return idx
+	}
+
+	c := Convert256ToRGB(idx)
+	return ConvertTo16(c.R, c.G, c.B)
+}
+
+// colorDistance squared distance of two RGB colors
+func colorDistance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr, dg, db := int(r1)-int(r2), int(g1)-int(g2), int(b1)-int(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+// ANSICode16 get the ANSI SGR code for basic 16 color index(0-15). isBg: background color
+//
+// eg: 1 -> 31, 9 -> 91, 1(bg) -> 41
+func ANSICode16(idx uint8, isBg bool) int {
+	code := 30 + int(idx)
+	if idx >= 8 {
+		code = 90 + int(idx) - 8
+	}
+
+	if isBg {
+		code += 10
+	}
+	return code
+}
+
+// To256 convert to the nearest 256 color index
+func (c RGB) To256() uint8 { return ConvertRGBTo256(c.R, c.G, c.B) }
+
+// To16 convert to the nearest basic 16 color index
+func (c RGB) To16() uint8 { return ConvertTo16(c.R, c.G, c.B) }
+
+// ColorCode get the best SGR color params of RGB color for the level. isBg: background color
+//
+// Usage:
+//
+//	termenv.ColorCode(c, termenv.TermColorTrue, false) // "38;2;255;0;0"
+//	termenv.ColorCode(c, termenv.TermColor256, false)  // "38;5;196"
+//	termenv.ColorCode(c, termenv.TermColor16, false)   // "91"
+//	termenv.ColorCode(c, termenv.TermColorNone, false) // ""
+func ColorCode(c RGB, level ColorLevel, isBg bool) string {
+	prefix := "38;"
+	if isBg {
+		prefix = "48;"
+	}
+
+	switch level {
+	case TermColorTrue:
+		return prefix + "2;" + strconv.Itoa(int(c.R)) + ";" + strconv.Itoa(int(c.G)) + ";" + strconv.Itoa(int(c.B))
+	case TermColor256:
+		return prefix + "5;" + strconv.Itoa(int(c.To256()))
+	case TermColor16:
+		return strconv.Itoa(ANSICode16(c.To16(), isBg))
+	}
+	return ""
+}
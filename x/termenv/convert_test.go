@@ -0,0 +1,47 @@
+package termenv_test
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestConvertRGBTo256(t *testing.T) {
+	assert.Eq(t, uint8(196), termenv.ConvertRGBTo256(255, 0, 0))
+	assert.Eq(t, uint8(21), termenv.ConvertRGBTo256(0, 0, 255))
+	assert.Eq(t, uint8(16), termenv.ConvertRGBTo256(0, 0, 0))
+	assert.Eq(t, uint8(231), termenv.ConvertRGBTo256(255, 255, 255))
+	// grayscale
+	assert.Eq(t, uint8(235), termenv.ConvertRGBTo256(38, 38, 38))
+	assert.Eq(t, uint8(244), termenv.ConvertRGBTo256(128, 128, 128))
+
+	assert.Eq(t, termenv.RGB{R: 255}, termenv.Convert256ToRGB(196))
+	assert.Eq(t, termenv.RGB{R: 205}, termenv.Convert256ToRGB(1))
+	assert.Eq(t, termenv.RGB{R: 238, G: 238, B: 238}, termenv.Convert256ToRGB(255))
+}
+
+func TestConvertTo16(t *testing.T) {
+	assert.Eq(t, uint8(9), termenv.ConvertTo16(255, 0, 0))
+	assert.Eq(t, uint8(0), termenv.ConvertTo16(10, 10, 10))
+	assert.Eq(t, uint8(15), termenv.ConvertTo16(250, 250, 250))
+
+	assert.Eq(t, uint8(9), termenv.Convert256To16(196))
+	assert.Eq(t, uint8(3), termenv.Convert256To16(3))
+
+	assert.Eq(t, 31, termenv.ANSICode16(1, false))
+	assert.Eq(t, 91, termenv.ANSICode16(9, false))
+	assert.Eq(t, 41, termenv.ANSICode16(1, true))
+	assert.Eq(t, 101, termenv.ANSICode16(9, true))
+}
+
+func TestColorCode(t *testing.T) {
+	c := termenv.RGB{R: 255}
+	assert.Eq(t, uint8(196), c.To256())
+	assert.Eq(t, uint8(9), c.To16())
+
+	assert.Eq(t, "38;2;255;0;0", termenv.ColorCode(c, termenv.TermColorTrue, false))
+	assert.Eq(t, "48;5;196", termenv.ColorCode(c, termenv.TermColor256, true))
+	assert.Eq(t, "91", termenv.ColorCode(c, termenv.TermColor16, false))
+	assert.Eq(t, "", termenv.ColorCode(c, termenv.TermColorNone, false))
+}
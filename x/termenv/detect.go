@@ -0,0 +1,168 @@
+package termenv
+
+import (
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/gookit/goutil/envutil"
+)
+
+// DetectColorLevel detect the color level for current env.
+//
+// NOTE: will re-detect on each call. if only want to get current level, please use TermColorLevel().
+// If the level is pinned by SetColorLevel(), will return it directly.
+func DetectColorLevel() ColorLevel {
+	level, _ := detectColorLevel()
+	return level
+}
+
+// env keys for override the detected color level. allow: none|16|256|true
+const (
+	ColorEnvKey = "GOUTIL_COLOR"
+	// ForceLevelEnvKey alias of ColorEnvKey, GOUTIL_COLOR has higher priority.
+	ForceLevelEnvKey = "FORCE_COLOR_LEVEL"
+)
+
+// WriterColorLevel detect the color level for the writer. eg: os.Stdout, os.Stderr, file.
+//
+// The ENV(NO_COLOR, FORCE_COLOR, TERM ...) detection is same as DetectColorLevel(),
+// but check the w is terminal instead of os.Stdout. pipe, file and buffer will be TermColorNone.
+func WriterColorLevel(w io.Writer) ColorLevel {
+	level, _ := detectLevelWith(IsTerminalWriter(w))
+	return level
+}
+
+// detect terminal color level by ENV and stdout is terminal
+func detectColorLevel() (level ColorLevel, needVTP bool) {
+	return detectLevelWith(IsTerminal())
+}
+
+// detect color level by ENV and the output is terminal
+func detectLevelWith(isTerm bool) (level ColorLevel, needVTP bool) {
+	mu.RLock()
+	if pinned {
+		level = colorLevel
+		mu.RUnlock()
+		return level, false
+	}
+	mu.RUnlock()
+
+	return detectEnvLevel(isTerm)
+}
+
+// detect color level by ENV and the output is terminal, ignore the pinned level.
+func detectEnvLevel(isTerm bool) (level ColorLevel, needVTP bool) {
+
+	// GOUTIL_COLOR, FORCE_COLOR_LEVEL=none|16|256|true
+	for _, key := range []string{ColorEnvKey, ForceLevelEnvKey} {
+		if lv, ok := ParseColorLevel(os.Getenv(key)); ok {
+			return lv, false
+		}
+	}
+
+	// https://no-color.org/
+	if os.Getenv("NO_COLOR") != "" {
+		return TermColorNone, false
+	}
+
+	// FORCE_COLOR=0|1|2|3
+	if val, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		return parseForceColor(val), false
+	}
+
+	if !isTerm {
+		return TermColorNone, false
+	}
+
+	isWin := runtime.GOOS == "windows"
+	termVal := os.Getenv("TERM")
+	if termVal == "dumb" {
+		return TermColorNone, false
+	}
+
+	// on WSL, Windows Terminal: support true-color
+	if os.Getenv("WT_SESSION") != "" || (os.Getenv("WSL_DISTRO_NAME") != "" && envutil.IsWSL()) {
+		return TermColorTrue, isWin
+	}
+
+	level = detectLevelFromEnv(termVal)
+	if level == TermColorNone && isWin && termVal == "" {
+		// Windows 10+ console support true-color after enable VTP
+		return TermColorTrue, true
+	}
+	return level, false
+}
+
+// detect color level by COLORTERM, TERM_PROGRAM and TERM env
+func detectLevelFromEnv(termVal string) ColorLevel {
+	// on TERM=screen: not support true-color
+	maxLevel := TermColorTrue
+	if termVal == "screen" {
+		maxLevel = TermColor256
+	}
+
+	colorTerm := os.Getenv("COLORTERM")
+	if strings.Contains(colorTerm, "truecolor") || strings.Contains(colorTerm, "24bit") {
+		return maxLevel
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "Hyper", "Terminus", "vscode", "WezTerm":
+		return maxLevel
+	case "Apple_Terminal":
+		return TermColor256
+	}
+
+	if os.Getenv("TERMINAL_EMULATOR") == "JetBrains-JediTerm" {
+		return maxLevel
+	}
+
+	switch {
+	case strings.Contains(termVal, "truecolor"), strings.Contains(termVal, "direct"):
+		return maxLevel
+	case strings.Contains(termVal, "256color"):
+		return TermColor256
+	case colorTerm != "", strings.Contains(termVal, "color"), strings.Contains(termVal, "xterm"),
+		strings.HasPrefix(termVal, "screen"), strings.HasPrefix(termVal, "tmux"),
+		strings.HasPrefix(termVal, "vt100"), termVal == "linux", termVal == "ansi", termVal == "alacritty":
+		return TermColor16
+	}
+
+	// like on ConEmu software, e.g "ConEmuANSI=ON", "ANSICON=189x2000 (189x43)"
+	if os.Getenv("ConEmuANSI") == "ON" || os.Getenv("ANSICON") != "" {
+		return TermColor16
+	}
+	return TermColorNone
+}
+
+// parse FORCE_COLOR value to color level
+func parseForceColor(val string) ColorLevel {
+	switch strings.ToLower(strings.TrimSpace(val)) {
+	case "0", "false", "off", "no":
+		return TermColorNone
+	case "2", "256":
+		return TermColor256
+	case "3", "truecolor", "24bit":
+		return TermColorTrue
+	default: // "", "1", "true" ...
+		return TermColor16
+	}
+}
+
+// ParseColorLevel parse the level name to ColorLevel. allow: none|16|256|true,
+// and the aliases: off|0, basic, truecolor|24bit. returns false on invalid name.
+func ParseColorLevel(val string) (ColorLevel, bool) {
+	switch strings.ToLower(strings.TrimSpace(val)) {
+	case "none", "off", "0":
+		return TermColorNone, true
+	case "16", "basic":
+		return TermColor16, true
+	case "256":
+		return TermColor256, true
+	case "true", "truecolor", "24bit":
+		return TermColorTrue, true
+	}
+	return TermColorNone, false
+}
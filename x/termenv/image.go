@@ -0,0 +1,338 @@
+package termenv
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ImageProtocol the inline image protocol of terminal
+type ImageProtocol uint8
+
+// inline image protocols
+const (
+	ImageNone ImageProtocol = iota
+	// ImageKitty the kitty graphics protocol. kitty, ghostty
+	ImageKitty
+	// ImageITerm2 the iTerm2 inline images protocol(OSC 1337). iTerm2, WezTerm, VSCode
+	ImageITerm2
+	// ImageSixel the DEC sixel graphics. foot, Windows Terminal, xterm(-ti vt340) ...
+	ImageSixel
+)
+
+// String get protocol name
+func (p ImageProtocol) String() string {
+	switch p {
+	case ImageKitty:
+		return "kitty"
+	case ImageITerm2:
+		return "iterm2"
+	case ImageSixel:
+		return "sixel"
+	default:
+		return "none"
+	}
+}
+
+// DetectImageProtocol detect the supported inline image protocol of current terminal.
+//
+// It is detected by ENV first, fallback to query the terminal attributes(DA1) for sixel
+// when stdout is a terminal.
+func DetectImageProtocol() ImageProtocol {
+	if p := imageProtocolByEnv(); p != ImageNone {
+		return p
+	}
+
+	if os.Getenv("TERM") != "dumb" && IsTerminal() && QuerySixelSupport() {
+		return ImageSixel
+	}
+	return ImageNone
+}
+
+// SupportImage check current terminal support display inline images
+func SupportImage() bool { return DetectImageProtocol() != ImageNone }
+
+func imageProtocolByEnv() ImageProtocol {
+	switch TerminalEmulator() {
+	case EmulatorKitty, EmulatorGhostty:
+		return ImageKitty
+	case EmulatorITerm2, EmulatorWezTerm, EmulatorVSCode:
+		return ImageITerm2
+	case EmulatorFoot, EmulatorWinTerm:
+		return ImageSixel
+	}
+
+	// eg: in tmux or over ssh from iTerm2
+	if os.Getenv("LC_TERMINAL") == "iTerm2" {
+		return ImageITerm2
+	}
+	if strings.Contains(os.Getenv("TERM"), "sixel") {
+		return ImageSixel
+	}
+	return ImageNone
+}
+
+// QuerySixelSupport query the primary device attributes(DA1), check the terminal support sixel.
+func QuerySixelSupport() bool {
+	resp, err := QueryTerminal("\x1b[c", DefaultQueryTimeout, "c")
+	if err != nil {
+		return false
+	}
+	return parseDA1HasSixel(resp)
+}
+
+// parse DA1 response like "\x1b[?62;4;22c", the attribute 4 is sixel graphics.
+func parseDA1HasSixel(resp string) bool {
+	idx := strings.Index(resp, "\x1b[?")
+	if idx < 0 || !strings.HasSuffix(resp, "c") {
+		return false
+	}
+
+	attrs := strings.Split(resp[idx+3:len(resp)-1], ";")
+	for _, attr := range attrs {
+		if attr == "4" {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintImage display the image inline on stdout. see Screen.PrintImage()
+func PrintImage(r io.Reader) error { return NewScreen(os.Stdout).PrintImage(r) }
+
+// PrintImage display the image(png, jpeg, gif) inline, the protocol is auto detected.
+//
+// Will return ErrNotSupported if the screen is disabled or the terminal not support images,
+// so that the caller can fall back to other output.
+//
+// Usage:
+//
+//	f, _ := os.Open("preview.png")
+//	defer f.Close()
+//
+//	if err := termenv.PrintImage(f); err != nil {
+//		fmt.Println("preview: preview.png")
+//	}
+func (s *Screen) PrintImage(r io.Reader) error {
+	if !s.enabled {
+		return ErrNotSupported
+	}
+	return s.WriteImage(DetectImageProtocol(), r)
+}
+
+// WriteImage display the image by the protocol
+func (s *Screen) WriteImage(proto ImageProtocol, r io.Reader) error {
+	if !s.enabled || proto == ImageNone {
+		return ErrNotSupported
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	var seq string
+	switch proto {
+	case ImageITerm2:
+		seq = iTerm2ImageSeq(data)
+	case ImageKitty:
+		seq, err = kittyImageSeq(data)
+	default:
+		seq, err = sixelImageSeq(data)
+	}
+
+	if err != nil {
+		return err
+	}
+	return s.write(seq + "\n")
+}
+
+// OSC 1337 File=inline=1;size=N:BASE64 BEL
+func iTerm2ImageSeq(data []byte) string {
+	return "\x1b]1337;File=inline=1;size=" + strconv.Itoa(len(data)) + ";preserveAspectRatio=1:" +
+		base64.StdEncoding.EncodeToString(data) + "\a"
+}
+
+// the max payload size of each kitty graphics chunk
+const kittyChunkSize = 4096
+
+// APC G a=T,f=100,m=1;BASE64 ST - transmit and display the png data by chunks.
+func kittyImageSeq(data []byte) (string, error) {
+	// the kitty protocol only support png, convert other formats.
+	if !bytes.HasPrefix(data, []byte("\x89PNG")) {
+		img, err := decodeImage(data)
+		if err != nil {
+			return "", err
+		}
+
+		var buf bytes.Buffer
+		if err = png.Encode(&buf, img); err != nil {
+			return "", err
+		}
+		data = buf.Bytes()
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var sb strings.Builder
+	for first := true; ; first = false {
+		chunk := encoded
+		if len(chunk) > kittyChunkSize {
+			chunk = chunk[:kittyChunkSize]
+		}
+		encoded = encoded[len(chunk):]
+
+		sb.WriteString("\x1b_G")
+		if first {
+			sb.WriteString("a=T,f=100,")
+		}
+		if encoded == "" {
+			sb.WriteString("m=0;")
+		} else {
+			sb.WriteString("m=1;")
+		}
+		sb.WriteString(chunk)
+		sb.WriteString("\x1b\\")
+
+		if encoded == "" {
+			break
+		}
+	}
+	return sb.String(), nil
+}
+
+// decode the png, jpeg and gif image explicitly, not register the decoders globally.
+// other formats are decoded by image.Decode(), the caller can register the decoders.
+func decodeImage(data []byte) (image.Image, error) {
+	r := bytes.NewReader(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG")):
+		return png.Decode(r)
+	case bytes.HasPrefix(data, []byte("\xff\xd8")):
+		return jpeg.Decode(r)
+	case bytes.HasPrefix(data, []byte("GIF8")):
+		return gif.Decode(r)
+	}
+
+	img, _, err := image.Decode(r)
+	return img, err
+}
+
+// encode the image to DCS sixel sequence, the colors are reduced to the web-safe palette.
+func sixelImageSeq(data []byte) (string, error) {
+	img, err := decodeImage(data)
+	if err != nil {
+		return "", err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	pal := color.Palette(palette.WebSafe)
+	pm := image.NewPaletted(image.Rect(0, 0, width, height), pal)
+	draw.FloydSteinberg.Draw(pm, pm.Rect, img, bounds.Min)
+
+	// P2=1: the pixels without color are transparent
+	var sb strings.Builder
+	sb.WriteString("\x1bP0;1;0q\"1;1;")
+	sb.WriteString(strconv.Itoa(width))
+	sb.WriteByte(';')
+	sb.WriteString(strconv.Itoa(height))
+
+	isOpaque := func(x, y int) bool {
+		_, _, _, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+		return a >= 0x8000
+	}
+
+	// only define the used colors
+	used := make([]bool, len(pal))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if isOpaque(x, y) {
+				used[pm.ColorIndexAt(x, y)] = true
+			}
+		}
+	}
+
+	for i, c := range pal {
+		if !used[i] {
+			continue
+		}
+
+		r, g, b, _ := c.RGBA()
+		sb.WriteString("#" + strconv.Itoa(i) + ";2;")
+		sb.WriteString(strconv.Itoa(int(r*100/0xffff)) + ";")
+		sb.WriteString(strconv.Itoa(int(g*100/0xffff)) + ";")
+		sb.WriteString(strconv.Itoa(int(b * 100 / 0xffff)))
+	}
+
+	// each sixel band is 6 pixel rows
+	bits := make([]byte, width)
+	for top := 0; top < height; top += 6 {
+		colors := make(map[uint8]bool)
+		for y := top; y < top+6 && y < height; y++ {
+			for x := 0; x < width; x++ {
+				if isOpaque(x, y) {
+					colors[pm.ColorIndexAt(x, y)] = true
+				}
+			}
+		}
+
+		firstColor := true
+		for ci := 0; ci < len(pal); ci++ {
+			if !colors[uint8(ci)] {
+				continue
+			}
+
+			for x := 0; x < width; x++ {
+				bits[x] = 0
+				for dy := 0; dy < 6 && top+dy < height; dy++ {
+					if pm.ColorIndexAt(x, top+dy) == uint8(ci) && isOpaque(x, top+dy) {
+						bits[x] |= 1 << dy
+					}
+				}
+			}
+
+			if !firstColor {
+				sb.WriteByte('$') // carriage return, back to band start
+			}
+			firstColor = false
+			sb.WriteString("#" + strconv.Itoa(ci))
+			writeSixelRow(&sb, bits)
+		}
+		sb.WriteByte('-') // next band
+	}
+
+	sb.WriteString("\x1b\\")
+	return sb.String(), nil
+}
+
+// write the sixel chars of a band row, with run-length encoding.
+func writeSixelRow(sb *strings.Builder, bits []byte) {
+	for x := 0; x < len(bits); {
+		n := 1
+		for x+n < len(bits) && bits[x+n] == bits[x] {
+			n++
+		}
+
+		ch := byte('?' + bits[x])
+		if n > 3 {
+			sb.WriteString("!" + strconv.Itoa(n))
+			sb.WriteByte(ch)
+		} else {
+			for i := 0; i < n; i++ {
+				sb.WriteByte(ch)
+			}
+		}
+		x += n
+	}
+}
@@ -0,0 +1,111 @@
+package termenv_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestDetectImageProtocol(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want termenv.ImageProtocol
+	}{
+		{map[string]string{"TERM": "xterm-kitty"}, termenv.ImageKitty},
+		{map[string]string{"TERM_PROGRAM": "iTerm.app"}, termenv.ImageITerm2},
+		{map[string]string{"TERM_PROGRAM": "WezTerm"}, termenv.ImageITerm2},
+		{map[string]string{"TERM": "screen", "LC_TERMINAL": "iTerm2"}, termenv.ImageITerm2},
+		{map[string]string{"TERM": "foot"}, termenv.ImageSixel},
+		{map[string]string{"TERM": "xterm-256color"}, termenv.ImageNone},
+	}
+
+	for _, tt := range tests {
+		testutil.MockCleanOsEnv(tt.env, func() {
+			assert.Eq(t, tt.want, termenv.DetectImageProtocol())
+		})
+	}
+
+	assert.Eq(t, "sixel", termenv.ImageSixel.String())
+	assert.Eq(t, "none", termenv.ImageNone.String())
+}
+
+func testPNG(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 7))
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	img.Set(0, 0, color.RGBA{}) // transparent
+
+	var buf bytes.Buffer
+	assert.NoErr(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestScreen_WriteImage(t *testing.T) {
+	data := testPNG(t)
+	buf := new(bytes.Buffer)
+	s := termenv.NewScreen(buf)
+
+	// disabled
+	assert.ErrIs(t, s.WriteImage(termenv.ImageITerm2, bytes.NewReader(data)), termenv.ErrNotSupported)
+	assert.ErrIs(t, s.PrintImage(bytes.NewReader(data)), termenv.ErrNotSupported)
+
+	s.SetEnabled(true)
+	assert.ErrIs(t, s.WriteImage(termenv.ImageNone, bytes.NewReader(data)), termenv.ErrNotSupported)
+
+	assert.NoErr(t, s.WriteImage(termenv.ImageITerm2, bytes.NewReader(data)))
+	assert.StrContains(t, buf.String(), "\x1b]1337;File=inline=1;size=")
+
+	buf.Reset()
+	assert.NoErr(t, s.WriteImage(termenv.ImageKitty, bytes.NewReader(data)))
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "\x1b_Ga=T,f=100,m=0;"))
+	assert.True(t, strings.HasSuffix(out, "\x1b\\\n"))
+
+	buf.Reset()
+	assert.NoErr(t, s.WriteImage(termenv.ImageSixel, bytes.NewReader(data)))
+	out = buf.String()
+	// red is index 180 in web-safe palette
+	assert.True(t, strings.HasPrefix(out, "\x1bP0;1;0q\"1;1;4;7#180;2;100;0;0#180"))
+	// first band: the transparent pixel at 0,0 is skipped
+	assert.StrContains(t, out, "#180}~~~-#180!4@-")
+	assert.True(t, strings.HasSuffix(out, "\x1b\\\n"))
+
+	// invalid image
+	assert.Err(t, s.WriteImage(termenv.ImageSixel, strings.NewReader("not image")))
+
+	// gif and jpeg are decoded and converted to png for kitty
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoErr(t, err)
+	var gifBuf, jpgBuf bytes.Buffer
+	assert.NoErr(t, gif.Encode(&gifBuf, img, nil))
+	assert.NoErr(t, jpeg.Encode(&jpgBuf, img, nil))
+
+	for _, bs := range [][]byte{gifBuf.Bytes(), jpgBuf.Bytes()} {
+		buf.Reset()
+		assert.NoErr(t, s.WriteImage(termenv.ImageKitty, bytes.NewReader(bs)))
+		// base64 of "\x89PNG"
+		assert.True(t, strings.HasPrefix(buf.String(), "\x1b_Ga=T,f=100,m=0;iVBORw0KGgo"))
+	}
+}
+
+func TestScreen_PrintImage(t *testing.T) {
+	buf := new(bytes.Buffer)
+	s := termenv.NewScreen(buf).SetEnabled(true)
+
+	testutil.MockCleanOsEnv(map[string]string{"TERM": "xterm-kitty"}, func() {
+		assert.NoErr(t, s.PrintImage(bytes.NewReader(testPNG(t))))
+		assert.StrContains(t, buf.String(), "\x1b_Ga=T,f=100")
+	})
+}
@@ -0,0 +1,226 @@
+package termenv
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// MouseMode the mouse tracking mode
+type MouseMode uint8
+
+// mouse tracking modes
+const (
+	// MouseModeClick report button press and release. DECSET 1000
+	MouseModeClick MouseMode = iota
+	// MouseModeDrag report press, release and motion with button pressed. DECSET 1002
+	MouseModeDrag
+	// MouseModeMotion report all events, include motion without button. DECSET 1003
+	MouseModeMotion
+)
+
+func (m MouseMode) code() string {
+	switch m {
+	case MouseModeDrag:
+		return "1002"
+	case MouseModeMotion:
+		return "1003"
+	default:
+		return "1000"
+	}
+}
+
+// EnableMouse enable the mouse reporting with the SGR extended format(DECSET 1006).
+//
+// Usage:
+//
+//	s := termenv.NewScreen(os.Stdout)
+//	s.EnableMouse(termenv.MouseModeClick)
+//	defer s.DisableMouse()
+func (s *Screen) EnableMouse(mode MouseMode) error {
+	s.mouseMode = mode
+	return s.write("\x1b[?" + mode.code() + "h\x1b[?1006h")
+}
+
+// DisableMouse disable the mouse reporting
+func (s *Screen) DisableMouse() error {
+	return s.write("\x1b[?1006l\x1b[?" + s.mouseMode.code() + "l")
+}
+
+// MouseButton the mouse button of the event
+type MouseButton uint8
+
+// mouse buttons
+const (
+	MouseNone MouseButton = iota
+	MouseLeft
+	MouseMiddle
+	MouseRight
+	MouseWheelUp
+	MouseWheelDown
+	MouseWheelLeft
+	MouseWheelRight
+	MouseBackward
+	MouseForward
+)
+
+var mouseButtonNames = []string{"none", "left", "middle", "right",
+	"wheel-up", "wheel-down", "wheel-left", "wheel-right", "backward", "forward"}
+
+// String get button name
+func (b MouseButton) String() string {
+	if int(b) < len(mouseButtonNames) {
+		return mouseButtonNames[b]
+	}
+	return "unknown"
+}
+
+// IsWheel check is a wheel button
+func (b MouseButton) IsWheel() bool {
+	return b >= MouseWheelUp && b <= MouseWheelRight
+}
+
+// MouseAction the action of mouse event
+type MouseAction uint8
+
+// mouse actions
+const (
+	MousePress MouseAction = iota
+	MouseRelease
+	MouseMotion
+)
+
+// String get action name
+func (a MouseAction) String() string {
+	switch a {
+	case MouseRelease:
+		return "release"
+	case MouseMotion:
+		return "motion"
+	default:
+		return "press"
+	}
+}
+
+// MouseEvent the decoded mouse event
+type MouseEvent struct {
+	// X, Y the 1-based column and row of the mouse position
+	X, Y   int
+	Button MouseButton
+	Action MouseAction
+	// modifier keys
+	Shift, Alt, Ctrl bool
+}
+
+// String get event string. eg: "ctrl+left press at 10,5"
+func (e MouseEvent) String() string {
+	var sb strings.Builder
+	if e.Ctrl {
+		sb.WriteString("ctrl+")
+	}
+	if e.Alt {
+		sb.WriteString("alt+")
+	}
+	if e.Shift {
+		sb.WriteString("shift+")
+	}
+
+	sb.WriteString(e.Button.String())
+	sb.WriteByte(' ')
+	sb.WriteString(e.Action.String())
+	sb.WriteString(" at ")
+	sb.WriteString(strconv.Itoa(e.X))
+	sb.WriteByte(',')
+	sb.WriteString(strconv.Itoa(e.Y))
+	return sb.String()
+}
+
+var (
+	// ErrNotMouseEvent the data is not a mouse event sequence
+	ErrNotMouseEvent = errors.New("termenv: not a mouse event sequence")
+	// ErrIncomplete the escape sequence is incomplete, need read more data
+	ErrIncomplete = errors.New("termenv: incomplete escape sequence")
+)
+
+// the SGR mouse event sequence prefix
+const mousePrefix = "\x1b[<"
+
+// IsMouseEvent check the data is start with SGR mouse event sequence. eg: "\x1b[<0;10;5M"
+func IsMouseEvent(data []byte) bool {
+	return len(data) > len(mousePrefix) && string(data[:len(mousePrefix)]) == mousePrefix
+}
+
+// ParseMouseEvent decode the SGR mouse event sequence at the start of data.
+// returns the event and the sequence length.
+//
+// Format: ESC [ < Cb ; Cx ; Cy M(press) or m(release)
+//
+// Usage:
+//
+//	ev, n, err := termenv.ParseMouseEvent(buf)
+//	if err == nil {
+//		buf = buf[n:]
+//	}
+func ParseMouseEvent(data []byte) (ev MouseEvent, n int, err error) {
+	if len(data) < len(mousePrefix) {
+		if len(data) > 0 && strings.HasPrefix(mousePrefix, string(data)) {
+			return ev, 0, ErrIncomplete
+		}
+		return ev, 0, ErrNotMouseEvent
+	}
+	if string(data[:len(mousePrefix)]) != mousePrefix {
+		return ev, 0, ErrNotMouseEvent
+	}
+
+	seqLen, complete := escapeSeqLen(data)
+	if !complete {
+		return ev, 0, ErrIncomplete
+	}
+
+	final := data[seqLen-1]
+	if final != 'M' && final != 'm' {
+		return ev, 0, ErrNotMouseEvent
+	}
+
+	nums := strings.Split(string(data[len(mousePrefix):seqLen-1]), ";")
+	if len(nums) != 3 {
+		return ev, 0, ErrNotMouseEvent
+	}
+
+	var vs [3]int
+	for i, s := range nums {
+		if vs[i], err = strconv.Atoi(s); err != nil || vs[i] < 0 {
+			return ev, 0, ErrNotMouseEvent
+		}
+	}
+
+	code := vs[0]
+	ev.X, ev.Y = vs[1], vs[2]
+	ev.Shift = code&4 != 0
+	ev.Alt = code&8 != 0
+	ev.Ctrl = code&16 != 0
+
+	switch {
+	case final == 'm':
+		ev.Action = MouseRelease
+	case code&32 != 0:
+		ev.Action = MouseMotion
+	default:
+		ev.Action = MousePress
+	}
+
+	base := code &^ (4 | 8 | 16 | 32)
+	switch {
+	case base == 128 || base == 129: // extra buttons
+		ev.Button = MouseBackward + MouseButton(base-128)
+	case base >= 64 && base <= 67: // wheel
+		ev.Button = MouseWheelUp + MouseButton(base-64)
+	case base == 3:
+		ev.Button = MouseNone
+	case base < 3:
+		ev.Button = MouseLeft + MouseButton(base)
+	default:
+		return MouseEvent{}, 0, ErrNotMouseEvent
+	}
+	return ev, seqLen, nil
+}
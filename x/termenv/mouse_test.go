@@ -0,0 +1,62 @@
+package termenv_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestScreen_EnableMouse(t *testing.T) {
+	buf := new(bytes.Buffer)
+	s := termenv.NewScreen(buf).SetEnabled(true)
+
+	assert.NoErr(t, s.EnableMouse(termenv.MouseModeDrag))
+	assert.Eq(t, "\x1b[?1002h\x1b[?1006h", buf.String())
+
+	buf.Reset()
+	assert.NoErr(t, s.DisableMouse())
+	assert.Eq(t, "\x1b[?1006l\x1b[?1002l", buf.String())
+}
+
+func TestParseMouseEvent(t *testing.T) {
+	tests := []struct {
+		in   string
+		want termenv.MouseEvent
+		str  string
+	}{
+		{"\x1b[<0;10;5M", termenv.MouseEvent{X: 10, Y: 5, Button: termenv.MouseLeft}, "left press at 10,5"},
+		{"\x1b[<2;1;1m", termenv.MouseEvent{X: 1, Y: 1, Button: termenv.MouseRight, Action: termenv.MouseRelease}, "right release at 1,1"},
+		{"\x1b[<17;3;4M", termenv.MouseEvent{X: 3, Y: 4, Button: termenv.MouseMiddle, Ctrl: true}, "ctrl+middle press at 3,4"},
+		{"\x1b[<35;7;8M", termenv.MouseEvent{X: 7, Y: 8, Button: termenv.MouseNone, Action: termenv.MouseMotion}, "none motion at 7,8"},
+		{"\x1b[<32;7;8M", termenv.MouseEvent{X: 7, Y: 8, Button: termenv.MouseLeft, Action: termenv.MouseMotion}, "left motion at 7,8"},
+		{"\x1b[<65;2;3M", termenv.MouseEvent{X: 2, Y: 3, Button: termenv.MouseWheelDown}, "wheel-down press at 2,3"},
+		{"\x1b[<76;2;3M", termenv.MouseEvent{X: 2, Y: 3, Button: termenv.MouseWheelUp, Shift: true, Alt: true}, "alt+shift+wheel-up press at 2,3"},
+		{"\x1b[<129;2;3M", termenv.MouseEvent{X: 2, Y: 3, Button: termenv.MouseForward}, "forward press at 2,3"},
+	}
+
+	for _, tt := range tests {
+		ev, n, err := termenv.ParseMouseEvent([]byte(tt.in + "rest"))
+		assert.NoErr(t, err, tt.in)
+		assert.Eq(t, len(tt.in), n)
+		assert.Eq(t, tt.want, ev)
+		assert.Eq(t, tt.str, ev.String())
+		assert.True(t, termenv.IsMouseEvent([]byte(tt.in)))
+	}
+
+	assert.True(t, termenv.MouseWheelUp.IsWheel())
+	assert.False(t, termenv.MouseLeft.IsWheel())
+
+	// incomplete
+	for _, in := range []string{"\x1b", "\x1b[", "\x1b[<0;10"} {
+		_, _, err := termenv.ParseMouseEvent([]byte(in))
+		assert.ErrIs(t, err, termenv.ErrIncomplete)
+	}
+
+	// invalid
+	for _, in := range []string{"", "abc", "\x1b[A", "\x1b[<0;10H", "\x1b[<a;1;2M", "\x1b[<131;1;2M", "\x1b[<504;1;2M"} {
+		_, _, err := termenv.ParseMouseEvent([]byte(in))
+		assert.ErrIs(t, err, termenv.ErrNotMouseEvent, in)
+	}
+}
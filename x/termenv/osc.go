@@ -0,0 +1,152 @@
+package termenv
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+)
+
+// terminal emulator names. see TerminalEmulator()
+const (
+	EmulatorUnknown   = ""
+	EmulatorITerm2    = "iterm2"
+	EmulatorWezTerm   = "wezterm"
+	EmulatorVSCode    = "vscode"
+	EmulatorWinTerm   = "windows-terminal"
+	EmulatorKitty     = "kitty"
+	EmulatorGhostty   = "ghostty"
+	EmulatorAlacritty = "alacritty"
+	EmulatorFoot      = "foot"
+	EmulatorURxvt     = "urxvt"
+	EmulatorKonsole   = "konsole"
+	EmulatorVTE       = "vte" // gnome-terminal, tilix ...
+	EmulatorApple     = "apple-terminal"
+	EmulatorXTerm     = "xterm"
+)
+
+// TerminalEmulator detect the terminal emulator name by ENV. see Emulator* constants
+func TerminalEmulator() string {
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app":
+		return EmulatorITerm2
+	case "WezTerm":
+		return EmulatorWezTerm
+	case "vscode":
+		return EmulatorVSCode
+	case "ghostty":
+		return EmulatorGhostty
+	case "Apple_Terminal":
+		return EmulatorApple
+	}
+
+	termVal := os.Getenv("TERM")
+	switch {
+	case os.Getenv("WT_SESSION") != "":
+		return EmulatorWinTerm
+	case os.Getenv("KITTY_WINDOW_ID") != "", termVal == "xterm-kitty":
+		return EmulatorKitty
+	case termVal == "xterm-ghostty":
+		return EmulatorGhostty
+	case termVal == "alacritty", os.Getenv("ALACRITTY_WINDOW_ID") != "":
+		return EmulatorAlacritty
+	case strings.HasPrefix(termVal, "foot"):
+		return EmulatorFoot
+	case strings.HasPrefix(termVal, "rxvt-unicode"):
+		return EmulatorURxvt
+	case os.Getenv("KONSOLE_VERSION") != "":
+		return EmulatorKonsole
+	case os.Getenv("VTE_VERSION") != "":
+		return EmulatorVTE
+	case os.Getenv("XTERM_VERSION") != "":
+		return EmulatorXTerm
+	}
+	return EmulatorUnknown
+}
+
+// desktop notification protocols
+const (
+	notifyOSC9   = "osc9"
+	notifyOSC777 = "osc777"
+)
+
+// get the supported desktop notification protocol of current terminal emulator
+func notifyProtocol() string {
+	switch TerminalEmulator() {
+	case EmulatorITerm2, EmulatorWezTerm, EmulatorWinTerm, EmulatorKitty, EmulatorGhostty:
+		return notifyOSC9
+	case EmulatorURxvt, EmulatorFoot:
+		return notifyOSC777
+	}
+	return ""
+}
+
+// SupportNotify check current terminal emulator support the desktop notification by OSC 9 or OSC 777.
+func SupportNotify() bool { return notifyProtocol() != "" }
+
+// SupportClipboard check current terminal emulator support write clipboard by OSC 52.
+func SupportClipboard() bool {
+	switch TerminalEmulator() {
+	case EmulatorApple, EmulatorVTE, EmulatorKonsole:
+		return false
+	}
+	return os.Getenv("TERM") != "dumb"
+}
+
+// SetTitle set the terminal window title. see Screen.SetTitle()
+func SetTitle(title string) error { return NewScreen(os.Stdout).SetTitle(title) }
+
+// Notify send a desktop notification by the terminal. see Screen.Notify()
+func Notify(title, body string) error { return NewScreen(os.Stdout).Notify(title, body) }
+
+// CopyToClipboard write text to the system clipboard by the terminal. see Screen.CopyToClipboard()
+func CopyToClipboard(text string) error { return NewScreen(os.Stdout).CopyToClipboard(text) }
+
+// SetTitle set the terminal window title by OSC 2
+func (s *Screen) SetTitle(title string) error {
+	return s.write("\x1b]2;" + oscSafe(title) + "\a")
+}
+
+// Notify send a desktop notification by OSC 9(iTerm2, WezTerm, kitty ...) or OSC 777(urxvt, foot).
+//
+// Will return ErrNotSupported if the terminal emulator not support it.
+func (s *Screen) Notify(title, body string) error {
+	if !s.enabled {
+		return nil
+	}
+
+	title, body = oscSafe(title), oscSafe(body)
+	switch notifyProtocol() {
+	case notifyOSC9: // OSC 9 only support message
+		msg := body
+		if title != "" {
+			msg = title + ": " + body
+		}
+		return s.write("\x1b]9;" + msg + "\a")
+	case notifyOSC777:
+		return s.write("\x1b]777;notify;" + strings.ReplaceAll(title, ";", ",") + ";" + body + "\a")
+	}
+	return ErrNotSupported
+}
+
+// CopyToClipboard write text to the system clipboard by OSC 52.
+//
+// Will return ErrNotSupported if the terminal emulator not support it.
+func (s *Screen) CopyToClipboard(text string) error {
+	if !s.enabled {
+		return nil
+	}
+	if !SupportClipboard() {
+		return ErrNotSupported
+	}
+	return s.write("\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a")
+}
+
+// remove the control chars, they will break the OSC sequence.
+func oscSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+}
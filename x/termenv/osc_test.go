@@ -0,0 +1,84 @@
+package termenv_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestTerminalEmulator(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want string
+	}{
+		{map[string]string{"TERM_PROGRAM": "iTerm.app"}, termenv.EmulatorITerm2},
+		{map[string]string{"TERM_PROGRAM": "WezTerm"}, termenv.EmulatorWezTerm},
+		{map[string]string{"WT_SESSION": "abc"}, termenv.EmulatorWinTerm},
+		{map[string]string{"TERM": "xterm-kitty"}, termenv.EmulatorKitty},
+		{map[string]string{"TERM": "foot"}, termenv.EmulatorFoot},
+		{map[string]string{"TERM": "rxvt-unicode-256color"}, termenv.EmulatorURxvt},
+		{map[string]string{"VTE_VERSION": "6003"}, termenv.EmulatorVTE},
+		{map[string]string{"TERM": "xterm"}, termenv.EmulatorUnknown},
+	}
+
+	for _, tt := range tests {
+		testutil.MockCleanOsEnv(tt.env, func() {
+			assert.Eq(t, tt.want, termenv.TerminalEmulator())
+		})
+	}
+}
+
+func TestScreen_SetTitle(t *testing.T) {
+	buf := new(bytes.Buffer)
+	s := termenv.NewScreen(buf)
+	assert.NoErr(t, s.SetTitle("my app"))
+	assert.Eq(t, "", buf.String())
+
+	s.SetEnabled(true)
+	assert.NoErr(t, s.SetTitle("my\x1b app\a"))
+	assert.Eq(t, "\x1b]2;my app\a", buf.String())
+}
+
+func TestScreen_Notify(t *testing.T) {
+	buf := new(bytes.Buffer)
+	s := termenv.NewScreen(buf).SetEnabled(true)
+
+	testutil.MockCleanOsEnv(map[string]string{"TERM_PROGRAM": "iTerm.app"}, func() {
+		assert.True(t, termenv.SupportNotify())
+		assert.NoErr(t, s.Notify("Build", "done"))
+		assert.Eq(t, "\x1b]9;Build: done\a", buf.String())
+	})
+
+	buf.Reset()
+	testutil.MockCleanOsEnv(map[string]string{"TERM": "foot"}, func() {
+		assert.NoErr(t, s.Notify("a;b", "done"))
+		assert.Eq(t, "\x1b]777;notify;a,b;done\a", buf.String())
+	})
+
+	buf.Reset()
+	testutil.MockCleanOsEnv(map[string]string{"VTE_VERSION": "6003"}, func() {
+		assert.False(t, termenv.SupportNotify())
+		assert.ErrIs(t, s.Notify("Build", "done"), termenv.ErrNotSupported)
+		assert.Eq(t, "", buf.String())
+	})
+}
+
+func TestScreen_CopyToClipboard(t *testing.T) {
+	buf := new(bytes.Buffer)
+	s := termenv.NewScreen(buf).SetEnabled(true)
+
+	testutil.MockCleanOsEnv(map[string]string{"TERM": "xterm-kitty"}, func() {
+		assert.True(t, termenv.SupportClipboard())
+		assert.NoErr(t, s.CopyToClipboard("hello"))
+		assert.Eq(t, "\x1b]52;c;aGVsbG8=\a", buf.String())
+	})
+
+	buf.Reset()
+	testutil.MockCleanOsEnv(map[string]string{"TERM_PROGRAM": "Apple_Terminal"}, func() {
+		assert.False(t, termenv.SupportClipboard())
+		assert.ErrIs(t, s.CopyToClipboard("hello"), termenv.ErrNotSupported)
+	})
+}
@@ -0,0 +1,132 @@
+package termenv
+
+import (
+	"bytes"
+	"io"
+)
+
+// bracketed paste markers
+var (
+	pasteStart = []byte("\x1b[200~")
+	pasteEnd   = []byte("\x1b[201~")
+)
+
+// EnableBracketedPaste enable the bracketed paste mode, the pasted text will be
+// wrapped by "ESC [200~" and "ESC [201~". use PasteReader to distinguish them.
+func (s *Screen) EnableBracketedPaste() error { return s.write("\x1b[?2004h") }
+
+// DisableBracketedPaste disable the bracketed paste mode
+func (s *Screen) DisableBracketedPaste() error { return s.write("\x1b[?2004l") }
+
+// InputChunk a chunk of the terminal input
+type InputChunk struct {
+	// Text of the input. the paste markers are removed
+	Text string
+	// Pasted is true if the text is a pasted block
+	Pasted bool
+}
+
+// PasteReader wrap the terminal input reader, distinguishes pasted blocks from typed input.
+//
+// Usage:
+//
+//	s := termenv.NewScreen(os.Stdout)
+//	s.EnableBracketedPaste()
+//	defer s.DisableBracketedPaste()
+//
+//	pr := termenv.NewPasteReader(os.Stdin)
+//	for {
+//		chunk, err := pr.ReadInput()
+//		if err != nil {
+//			break
+//		}
+//		if chunk.Pasted {
+//			// handle pasted text, eg: not execute the newlines
+//		}
+//	}
+type PasteReader struct {
+	r   io.Reader
+	buf []byte
+	err error
+	// pending data for handle
+	pending []byte
+}
+
+// NewPasteReader create a new PasteReader
+func NewPasteReader(r io.Reader) *PasteReader {
+	return &PasteReader{r: r, buf: make([]byte, 1024)}
+}
+
+// ReadInput read next input chunk. a pasted block will be returned as one chunk.
+func (pr *PasteReader) ReadInput() (*InputChunk, error) {
+	for {
+		if chunk := pr.takeChunk(); chunk != nil {
+			return chunk, nil
+		}
+
+		if pr.err != nil {
+			if len(pr.pending) == 0 {
+				return nil, pr.err
+			}
+
+			// the incomplete paste block or marker at EOF
+			data := pr.pending
+			pr.pending = nil
+			if bytes.HasPrefix(data, pasteStart) {
+				return &InputChunk{Text: string(data[len(pasteStart):]), Pasted: true}, nil
+			}
+			return &InputChunk{Text: string(data)}, nil
+		}
+
+		n, err := pr.r.Read(pr.buf)
+		pr.pending = append(pr.pending, pr.buf[:n]...)
+		pr.err = err
+	}
+}
+
+// take a chunk from pending data. return nil if need read more data.
+func (pr *PasteReader) takeChunk() *InputChunk {
+	if len(pr.pending) == 0 {
+		return nil
+	}
+
+	idx := bytes.Index(pr.pending, pasteStart)
+	if idx > 0 {
+		return pr.takeTyped(idx)
+	}
+
+	if idx == 0 {
+		end := bytes.Index(pr.pending[len(pasteStart):], pasteEnd)
+		if end < 0 {
+			return nil
+		}
+
+		text := string(pr.pending[len(pasteStart) : len(pasteStart)+end])
+		pr.pending = pr.pending[len(pasteStart)+end+len(pasteEnd):]
+		return &InputChunk{Text: text, Pasted: true}
+	}
+
+	// keep the maybe incomplete paste start marker at the end
+	keep := partialSuffix(pr.pending, pasteStart)
+	if keep == len(pr.pending) {
+		return nil
+	}
+	return pr.takeTyped(len(pr.pending) - keep)
+}
+
+func (pr *PasteReader) takeTyped(n int) *InputChunk {
+	text := string(pr.pending[:n])
+	pr.pending = pr.pending[n:]
+	return &InputChunk{Text: text}
+}
+
+// get the length of data suffix which is a prefix of the marker.
+// NOTE: a single ESC is not kept, avoid blocking the ESC key press.
+func partialSuffix(data, marker []byte) int {
+	for n := len(marker) - 1; n >= 2; n-- {
+		if len(data) >= n && bytes.HasSuffix(data, marker[:n]) {
+			return n
+		}
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package termenv_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestScreen_EnableBracketedPaste(t *testing.T) {
+	buf := new(bytes.Buffer)
+	s := termenv.NewScreen(buf).SetEnabled(true)
+
+	assert.NoErr(t, s.EnableBracketedPaste())
+	assert.Eq(t, "\x1b[?2004h", buf.String())
+
+	buf.Reset()
+	assert.NoErr(t, s.DisableBracketedPaste())
+	assert.Eq(t, "\x1b[?2004l", buf.String())
+}
+
+type chunkReader struct {
+	chunks []string
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+	return n, nil
+}
+
+func readAllChunks(t *testing.T, pr *termenv.PasteReader) []termenv.InputChunk {
+	var chunks []termenv.InputChunk
+	for {
+		chunk, err := pr.ReadInput()
+		if err != nil {
+			assert.ErrIs(t, err, io.EOF)
+			return chunks
+		}
+		chunks = append(chunks, *chunk)
+	}
+}
+
+func TestPasteReader(t *testing.T) {
+	in := "ls\x1b[200~echo a\nEOF\x1b[201~\x1b[A"
+	want := []termenv.InputChunk{
+		{Text: "ls"},
+		{Text: "echo a\nEOF", Pasted: true},
+		{Text: "\x1b[A"},
+	}
+
+	pr := termenv.NewPasteReader(strings.NewReader(in))
+	assert.Eq(t, want, readAllChunks(t, pr))
+
+	// the markers are split by reads
+	pr = termenv.NewPasteReader(&chunkReader{chunks: []string{"ls\x1b[2", "00~echo a", "\nEOF\x1b[20", "1~\x1b[A"}})
+	var typed, pasted string
+	for _, chunk := range readAllChunks(t, pr) {
+		if chunk.Pasted {
+			pasted += chunk.Text
+		} else {
+			typed += chunk.Text
+		}
+	}
+	assert.Eq(t, "ls\x1b[A", typed)
+	assert.Eq(t, "echo a\nEOF", pasted)
+
+	// single ESC key is not blocked
+	pr = termenv.NewPasteReader(strings.NewReader("\x1b"))
+	chunk, err := pr.ReadInput()
+	assert.NoErr(t, err)
+	assert.Eq(t, "\x1b", chunk.Text)
+
+	// incomplete paste block at EOF
+	pr = termenv.NewPasteReader(strings.NewReader("a\x1b[200~abc"))
+	assert.Eq(t, []termenv.InputChunk{{Text: "a"}, {Text: "abc", Pasted: true}}, readAllChunks(t, pr))
+}
@@ -0,0 +1,49 @@
+package termenv
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotSupported the operation not supported on current terminal or system
+	ErrNotSupported = errors.New("termenv: not supported on current terminal")
+	// ErrQueryTimeout read the terminal query response timeout
+	ErrQueryTimeout = errors.New("termenv: query terminal timeout")
+)
+
+// DefaultQueryTimeout for query terminal
+var DefaultQueryTimeout = 100 * time.Millisecond
+
+// the OSC response terminators: BEL or ST(ESC \)
+var oscTerminators = []string{"\a", "\x1b\\"}
+
+// readUntil read bytes one by one until got one of terminators
+func readUntil(r io.Reader, terminators []string) (string, error) {
+	var sb strings.Builder
+	buf := make([]byte, 1)
+
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			if os.IsTimeout(err) {
+				return sb.String(), ErrQueryTimeout
+			}
+			return sb.String(), err
+		}
+		if n == 0 {
+			continue
+		}
+
+		sb.WriteByte(buf[0])
+		s := sb.String()
+		for _, t := range terminators {
+			if strings.HasSuffix(s, t) {
+				return s, nil
+			}
+		}
+	}
+}
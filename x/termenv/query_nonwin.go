@@ -0,0 +1,71 @@
+//go:build !windows
+
+package termenv
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// QueryTerminal write the query sequence to the terminal, and read the response until terminator.
+// The terminal will be set to raw mode on reading.
+//
+// NOTE: if the terminal not support the query, will return error after timeout.
+func QueryTerminal(query string, timeout time.Duration, terminators ...string) (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", err
+	}
+	defer tty.Close()
+
+	fd := int(tty.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("termenv: /dev/tty is not a terminal")
+	}
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(fd, oldState)
+
+	if _, err = tty.WriteString(query); err != nil {
+		return "", err
+	}
+	return readResponse(tty, timeout, terminators)
+}
+
+// readResponse read the response with timeout.
+func readResponse(f *os.File, timeout time.Duration, terminators []string) (string, error) {
+	if len(terminators) == 0 {
+		terminators = oscTerminators
+	}
+
+	// try use read deadline, fallback to poll the fd before each read
+	deadline := time.Now().Add(timeout)
+	if err := f.SetReadDeadline(deadline); err == nil {
+		return readUntil(f, terminators)
+	}
+	return readUntil(&pollReader{f: f, deadline: deadline}, terminators)
+}
+
+// pollReader wait the file readable before read, so the read never blocks after the deadline.
+type pollReader struct {
+	f        *os.File
+	deadline time.Time
+}
+
+func (r *pollReader) Read(p []byte) (int, error) {
+	remain := time.Until(r.deadline)
+	if remain <= 0 {
+		return 0, os.ErrDeadlineExceeded
+	}
+
+	if err := waitReadable(r.f.Fd(), remain); err != nil {
+		return 0, err
+	}
+	return r.f.Read(p)
+}
@@ -0,0 +1,31 @@
+//go:build !windows
+
+package termenv
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gookit/goutil/testutil/assert"
+)
+
+func TestPollReader(t *testing.T) {
+	r, w, err := os.Pipe()
+	assert.NoErr(t, err)
+	defer r.Close()
+	defer w.Close()
+
+	// timeout without response
+	start := time.Now()
+	s, err := readUntil(&pollReader{f: r, deadline: time.Now().Add(20 * time.Millisecond)}, oscTerminators)
+	assert.Eq(t, ErrQueryTimeout, err)
+	assert.Eq(t, "", s)
+	assert.True(t, time.Since(start) < time.Second)
+
+	_, err = w.WriteString("\x1b]11;rgb:ff/ff/ff\a")
+	assert.NoErr(t, err)
+	s, err = readUntil(&pollReader{f: r, deadline: time.Now().Add(time.Second)}, oscTerminators)
+	assert.NoErr(t, err)
+	assert.Eq(t, "\x1b]11;rgb:ff/ff/ff\a", s)
+}
@@ -0,0 +1,9 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !zos && !windows
+
+package termenv
+
+import "time"
+
+func waitReadable(_ uintptr, _ time.Duration) error {
+	return ErrNotSupported
+}
@@ -0,0 +1,34 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || zos
+
+package termenv
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// waitReadable wait the fd is readable by poll, will return os.ErrDeadlineExceeded on timeout.
+func waitReadable(fd uintptr, timeout time.Duration) error {
+	ms := int(timeout / time.Millisecond)
+	if ms < 1 {
+		ms = 1
+	}
+
+	fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+	for {
+		n, err := unix.Poll(fds, ms)
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return os.ErrDeadlineExceeded
+		}
+		return nil
+	}
+}
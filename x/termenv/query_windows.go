@@ -0,0 +1,12 @@
+package termenv
+
+import (
+	"time"
+)
+
+// QueryTerminal write the query sequence to the terminal, and read the response until terminator.
+//
+// NOTE: not support on Windows, will always return ErrNotSupported.
+func QueryTerminal(_ string, _ time.Duration, _ ...string) (string, error) {
+	return "", ErrNotSupported
+}
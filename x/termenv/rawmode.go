@@ -0,0 +1,82 @@
+package termenv
+
+import (
+	"golang.org/x/term"
+)
+
+// State the terminal state, use for restore the terminal by Restore()
+type State struct {
+	st *term.State
+}
+
+// GetState get the current state of the terminal
+func GetState(fd uintptr) (*State, error) {
+	st, err := term.GetState(int(fd))
+	if err != nil {
+		return nil, err
+	}
+	return &State{st: st}, nil
+}
+
+// MakeRaw put the terminal into raw mode: input is available character by character,
+// echo is disabled and special keys(eg: Ctrl+C) are not processed. returns the previous state.
+//
+// Usage:
+//
+//	state, err := termenv.MakeRaw(os.Stdin.Fd())
+//	if err != nil {
+//		return err
+//	}
+//	defer termenv.Restore(os.Stdin.Fd(), state)
+func MakeRaw(fd uintptr) (*State, error) {
+	st, err := term.MakeRaw(int(fd))
+	if err != nil {
+		return nil, err
+	}
+	return &State{st: st}, nil
+}
+
+// MakeCbreak put the terminal into cbreak mode: input is available character by character
+// and echo is disabled, but the signal keys(eg: Ctrl+C) are still processed. returns the previous state.
+func MakeCbreak(fd uintptr) (*State, error) {
+	st, err := term.GetState(int(fd))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = makeCbreak(fd); err != nil {
+		return nil, err
+	}
+	return &State{st: st}, nil
+}
+
+// Restore the terminal to the previous state
+func Restore(fd uintptr, state *State) error {
+	if state == nil || state.st == nil {
+		return nil
+	}
+	return term.Restore(int(fd), state.st)
+}
+
+// WithRawMode run fn with the terminal in raw mode, then restore it.
+func WithRawMode(fd uintptr, fn func() error) error {
+	return withMode(fd, MakeRaw, fn)
+}
+
+// WithCbreakMode run fn with the terminal in cbreak mode, then restore it.
+func WithCbreakMode(fd uintptr, fn func() error) error {
+	return withMode(fd, MakeCbreak, fn)
+}
+
+func withMode(fd uintptr, makeFn func(fd uintptr) (*State, error), fn func() error) error {
+	state, err := makeFn(fd)
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	if rErr := Restore(fd, state); err == nil {
+		err = rErr
+	}
+	return err
+}
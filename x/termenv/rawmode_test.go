@@ -0,0 +1,33 @@
+package termenv_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestMakeRaw_notTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "raw-*")
+	assert.NoErr(t, err)
+	defer f.Close()
+
+	_, err = termenv.GetState(f.Fd())
+	assert.Err(t, err)
+	_, err = termenv.MakeRaw(f.Fd())
+	assert.Err(t, err)
+	_, err = termenv.MakeCbreak(f.Fd())
+	assert.Err(t, err)
+
+	var called bool
+	err = termenv.WithRawMode(f.Fd(), func() error {
+		called = true
+		return nil
+	})
+	assert.Err(t, err)
+	assert.False(t, called)
+	assert.Err(t, termenv.WithCbreakMode(f.Fd(), func() error { return nil }))
+
+	assert.NoErr(t, termenv.Restore(f.Fd(), nil))
+}
@@ -0,0 +1,20 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || zos
+
+package termenv
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+func makeCbreak(fd uintptr) error {
+	termios, err := unix.IoctlGetTermios(int(fd), ioctlReadTermios)
+	if err != nil {
+		return err
+	}
+
+	// disable echo and canonical mode, keep signal handling(ISIG)
+	termios.Lflag &^= unix.ECHO | unix.ICANON
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+	return unix.IoctlSetTermios(int(fd), ioctlWriteTermios, termios)
+}
@@ -0,0 +1,7 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !zos && !windows
+
+package termenv
+
+func makeCbreak(_ uintptr) error {
+	return ErrNotSupported
+}
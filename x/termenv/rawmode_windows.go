@@ -0,0 +1,20 @@
+//go:build windows
+
+package termenv
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+func makeCbreak(fd uintptr) error {
+	var mode uint32
+	handle := windows.Handle(fd)
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return err
+	}
+
+	// disable echo and line input, keep processed input for handle Ctrl+C
+	mode &^= windows.ENABLE_ECHO_INPUT | windows.ENABLE_LINE_INPUT
+	mode |= windows.ENABLE_PROCESSED_INPUT
+	return windows.SetConsoleMode(handle, mode)
+}
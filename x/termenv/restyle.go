@@ -0,0 +1,235 @@
+package termenv
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RestyleWriter intercepts the ANSI styled output, and re-render it to the target color level.
+//
+//   - TermColorNone: strip all ANSI escape sequences, output plain text.
+//   - TermColor16, TermColor256: downgrade the 256 or RGB colors to the nearest color of level.
+//   - TermColorTrue: output as is.
+//
+// Usage:
+//
+//	// keep color on console, but write plain text to log file
+//	w := io.MultiWriter(os.Stdout, termenv.NewPlainWriter(logFile))
+type RestyleWriter struct {
+	w     io.Writer
+	level ColorLevel
+	// pending incomplete escape sequence on last write
+	pending []byte
+}
+
+// NewRestyleWriter create a new RestyleWriter instance
+func NewRestyleWriter(w io.Writer, level ColorLevel) *RestyleWriter {
+	return &RestyleWriter{w: w, level: level}
+}
+
+// NewPlainWriter create a new RestyleWriter, will strip all ANSI escape sequences
+func NewPlainWriter(w io.Writer) *RestyleWriter {
+	return NewRestyleWriter(w, TermColorNone)
+}
+
+// Level get the target color level
+func (rw *RestyleWriter) Level() ColorLevel { return rw.level }
+
+// Write implements io.Writer. will always return len(p) on success, even if some sequences are stripped.
+func (rw *RestyleWriter) Write(p []byte) (n int, err error) {
+	if rw.level == TermColorTrue && len(rw.pending) == 0 {
+		return rw.w.Write(p)
+	}
+
+	data := p
+	if len(rw.pending) > 0 {
+		data = append(rw.pending, p...)
+		rw.pending = nil
+	}
+
+	out, rest := restyleBytes(data, rw.level, true)
+	if len(rest) > 0 {
+		rw.pending = append([]byte(nil), rest...)
+	}
+
+	if len(out) > 0 {
+		if _, err = rw.w.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// WriteString implements io.StringWriter
+func (rw *RestyleWriter) WriteString(s string) (int, error) {
+	return rw.Write([]byte(s))
+}
+
+// Flush write the pending incomplete escape sequence as is. if level is none, will discard it.
+func (rw *RestyleWriter) Flush() error {
+	if len(rw.pending) == 0 {
+		return nil
+	}
+
+	pending := rw.pending
+	rw.pending = nil
+	if rw.level == TermColorNone {
+		return nil
+	}
+
+	_, err := rw.w.Write(pending)
+	return err
+}
+
+// Restyle re-render the ANSI styled string to the target color level.
+func Restyle(s string, level ColorLevel) string {
+	if level == TermColorTrue || strings.IndexByte(s, escChar) < 0 {
+		return s
+	}
+
+	out, _ := restyleBytes([]byte(s), level, false)
+	return string(out)
+}
+
+const escChar = '\x1b'
+
+// restyleBytes handle the escape sequences in data.
+// if keepRest is true, will return the incomplete sequence at end as rest.
+func restyleBytes(data []byte, level ColorLevel, keepRest bool) (out, rest []byte) {
+	out = make([]byte, 0, len(data))
+	for len(data) > 0 {
+		idx := bytes.IndexByte(data, escChar)
+		if idx < 0 {
+			out = append(out, data...)
+			break
+		}
+
+		out = append(out, data[:idx]...)
+		data = data[idx:]
+
+		seqLen, complete := escapeSeqLen(data)
+		if !complete {
+			if keepRest {
+				return out, data
+			}
+			if level != TermColorNone {
+				out = append(out, data...)
+			}
+			break
+		}
+
+		seq := data[:seqLen]
+		data = data[seqLen:]
+		if level == TermColorNone {
+			continue
+		}
+
+		// only the SGR sequence need convert: ESC [ ... m
+		if seqLen > 2 && seq[1] == '[' && seq[seqLen-1] == 'm' {
+			out = append(out, "\x1b["...)
+			out = append(out, downgradeSGR(string(seq[2:seqLen-1]), level)...)
+			out = append(out, 'm')
+		} else {
+			out = append(out, seq...)
+		}
+	}
+	return out, nil
+}
+
+// escapeSeqLen get the escape sequence length at the start of data.
+// data must be start with ESC char.
+func escapeSeqLen(data []byte) (int, bool) {
+	if len(data) < 2 {
+		return 0, false
+	}
+
+	switch data[1] {
+	case '[': // CSI: ESC [ params intermediates final(0x40-0x7E)
+		for i := 2; i < len(data); i++ {
+			if data[i] >= 0x40 && data[i] <= 0x7E {
+				return i + 1, true
+			}
+		}
+		return 0, false
+	case ']', 'P', '_', '^': // OSC, DCS...: end by BEL or ESC \
+		for i := 2; i < len(data); i++ {
+			if data[i] == '\a' {
+				return i + 1, true
+			}
+			if data[i] == escChar {
+				if i+1 >= len(data) {
+					return 0, false
+				}
+				if data[i+1] == '\\' {
+					return i + 2, true
+				}
+			}
+		}
+		return 0, false
+	default: // two chars sequence. eg: ESC 7
+		return 2, true
+	}
+}
+
+// downgradeSGR convert the 256 and RGB color params to the target level
+func downgradeSGR(params string, level ColorLevel) string {
+	if level == TermColorTrue || !strings.Contains(params, "8;") {
+		return params
+	}
+
+	ps := strings.Split(params, ";")
+	ns := make([]string, 0, len(ps))
+	for i := 0; i < len(ps); i++ {
+		p := ps[i]
+		if (p != "38" && p != "48") || i+1 >= len(ps) {
+			ns = append(ns, p)
+			continue
+		}
+
+		isBg := p == "48"
+		switch ps[i+1] {
+		case "5": // 38;5;n
+			if i+2 >= len(ps) {
+				ns = append(ns, ps[i:]...)
+				return strings.Join(ns, ";")
+			}
+
+			idx := parseUint8(ps[i+2])
+			if level == TermColor256 {
+				ns = append(ns, ps[i:i+3]...)
+			} else {
+				ns = append(ns, strconv.Itoa(ANSICode16(Convert256To16(idx), isBg)))
+			}
+			i += 2
+		case "2": // 38;2;r;g;b
+			if i+4 >= len(ps) {
+				ns = append(ns, ps[i:]...)
+				return strings.Join(ns, ";")
+			}
+
+			r, g, b := parseUint8(ps[i+2]), parseUint8(ps[i+3]), parseUint8(ps[i+4])
+			if level == TermColor256 {
+				ns = append(ns, p, "5", strconv.Itoa(int(ConvertRGBTo256(r, g, b))))
+			} else {
+				ns = append(ns, strconv.Itoa(ANSICode16(ConvertTo16(r, g, b), isBg)))
+			}
+			i += 4
+		default:
+			ns = append(ns, p)
+		}
+	}
+	return strings.Join(ns, ";")
+}
+
+func parseUint8(s string) uint8 {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
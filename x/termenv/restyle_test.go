@@ -0,0 +1,64 @@
+package termenv_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestRestyle(t *testing.T) {
+	s := "\x1b[1;31mred\x1b[0m \x1b[38;5;196mc256\x1b[0m \x1b[48;2;0;0;255mrgb\x1b[0m"
+
+	assert.Eq(t, "red c256 rgb", termenv.Restyle(s, termenv.TermColorNone))
+	assert.Eq(t, s, termenv.Restyle(s, termenv.TermColorTrue))
+	assert.Eq(t,
+		"\x1b[1;31mred\x1b[0m \x1b[38;5;196mc256\x1b[0m \x1b[48;5;21mrgb\x1b[0m",
+		termenv.Restyle(s, termenv.TermColor256),
+	)
+	assert.Eq(t,
+		"\x1b[1;31mred\x1b[0m \x1b[91mc256\x1b[0m \x1b[44mrgb\x1b[0m",
+		termenv.Restyle(s, termenv.TermColor16),
+	)
+
+	// OSC sequence
+	assert.Eq(t, "title", termenv.Restyle("\x1b]0;my title\atitle", termenv.TermColorNone))
+	assert.Eq(t, "plain text", termenv.Restyle("plain text", termenv.TermColor16))
+}
+
+func TestRestyleWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := termenv.NewPlainWriter(buf)
+	assert.Eq(t, termenv.TermColorNone, w.Level())
+
+	// sequence split on multi writes
+	n, err := w.WriteString("hello \x1b[3")
+	assert.NoErr(t, err)
+	assert.Eq(t, 9, n)
+	_, err = w.WriteString("2mworld\x1b[0m")
+	assert.NoErr(t, err)
+	assert.Eq(t, "hello world", buf.String())
+
+	// incomplete at end
+	_, err = w.WriteString("!\x1b[")
+	assert.NoErr(t, err)
+	assert.NoErr(t, w.Flush())
+	assert.Eq(t, "hello world!", buf.String())
+
+	// use with io.MultiWriter
+	console, logs := new(bytes.Buffer), new(bytes.Buffer)
+	mw := io.MultiWriter(console, termenv.NewRestyleWriter(logs, termenv.TermColorNone))
+	_, err = mw.Write([]byte("\x1b[32mOK\x1b[0m"))
+	assert.NoErr(t, err)
+	assert.Eq(t, "\x1b[32mOK\x1b[0m", console.String())
+	assert.Eq(t, "OK", logs.String())
+
+	buf.Reset()
+	w = termenv.NewRestyleWriter(buf, termenv.TermColor256)
+	_, err = w.WriteString("\x1b[38;2;255;0;0mred\x1b[0m\x1b[")
+	assert.NoErr(t, err)
+	assert.NoErr(t, w.Flush())
+	assert.Eq(t, "\x1b[38;5;196mred\x1b[0m\x1b[", buf.String())
+}
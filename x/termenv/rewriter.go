@@ -0,0 +1,106 @@
+package termenv
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// LineRewriter rewrite the current line in place, the primitive for progress bars and spinners.
+//
+//   - terminal: move to the line start by "\r", and clear the stale chars when the new line is shorter.
+//   - pipe, file, TERM=dumb: degrade to write one line per update, the repeated line will be skipped.
+//
+// Usage:
+//
+//	lw := termenv.NewLineRewriter(os.Stderr)
+//	for i := 0; i <= 100; i += 10 {
+//		lw.Update(fmt.Sprintf("downloading... %d%%", i))
+//	}
+//	lw.Done()
+type LineRewriter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	tty bool
+	// last written line and its visible width
+	last  string
+	width int
+	dirty bool
+}
+
+// NewLineRewriter create a new LineRewriter for the writer
+func NewLineRewriter(w io.Writer) *LineRewriter {
+	return &LineRewriter{w: w, tty: IsTerminalWriter(w) && os.Getenv("TERM") != "dumb"}
+}
+
+// SetTTY set the rewrite mode manually. false: one line per update
+func (lw *LineRewriter) SetTTY(tty bool) *LineRewriter {
+	lw.tty = tty
+	return lw
+}
+
+// IsTTY check the line will be rewritten in place
+func (lw *LineRewriter) IsTTY() bool { return lw.tty }
+
+// Update rewrite the current line with s.
+//
+// If s contains "\r", only the text after the last "\r" is used. The newlines will be replaced by spaces.
+func (lw *LineRewriter) Update(s string) error {
+	s = normalizeLine(s)
+
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	if !lw.tty {
+		if lw.dirty && s == lw.last {
+			return nil
+		}
+		lw.last, lw.dirty = s, true
+		_, err := io.WriteString(lw.w, s+"\n")
+		return err
+	}
+
+	width := VisibleWidth(s)
+	buf := make([]byte, 0, len(s)+lw.width+1)
+	buf = append(buf, '\r')
+	buf = append(buf, s...)
+
+	// clear the stale chars of the previous line
+	if stale := lw.width - width; stale > 0 {
+		buf = append(buf, strings.Repeat(" ", stale)...)
+		buf = append(buf, '\r')
+		buf = append(buf, s...)
+	}
+
+	lw.last, lw.width, lw.dirty = s, width, true
+	_, err := lw.w.Write(buf)
+	return err
+}
+
+// Done finish the current line, the next Update will start on a new line.
+func (lw *LineRewriter) Done() error {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	wasDirty := lw.dirty
+	lw.last, lw.width, lw.dirty = "", 0, false
+	if !lw.tty || !wasDirty {
+		return nil
+	}
+
+	_, err := io.WriteString(lw.w, "\n")
+	return err
+}
+
+// keep the text after the last "\r", and replace newlines to spaces
+func normalizeLine(s string) string {
+	s = strings.TrimRight(s, "\r\n")
+	if pos := strings.LastIndexByte(s, '\r'); pos >= 0 {
+		s = s[pos+1:]
+	}
+	if strings.IndexByte(s, '\n') >= 0 {
+		s = strings.ReplaceAll(s, "\n", " ")
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+package termenv_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestLineRewriter_tty(t *testing.T) {
+	buf := new(bytes.Buffer)
+	lw := termenv.NewLineRewriter(buf).SetTTY(true)
+	assert.True(t, lw.IsTTY())
+
+	assert.NoErr(t, lw.Update("loading 10%"))
+	assert.Eq(t, "\rloading 10%", buf.String())
+
+	// longer
+	buf.Reset()
+	assert.NoErr(t, lw.Update("loading 100%"))
+	assert.Eq(t, "\rloading 100%", buf.String())
+
+	// shorter, clear the stale chars
+	buf.Reset()
+	assert.NoErr(t, lw.Update("done"))
+	assert.Eq(t, "\rdone        \rdone", buf.String())
+
+	// visible width ignore the ANSI codes
+	buf.Reset()
+	assert.NoErr(t, lw.Update("\x1b[32mok\x1b[0m"))
+	assert.Eq(t, "\r\x1b[32mok\x1b[0m  \r\x1b[32mok\x1b[0m", buf.String())
+
+	// carriage returns and newlines
+	buf.Reset()
+	assert.NoErr(t, lw.Update("old\rnew\n"))
+	assert.Eq(t, "\rnew", buf.String())
+
+	buf.Reset()
+	assert.NoErr(t, lw.Done())
+	assert.Eq(t, "\n", buf.String())
+
+	// nothing written after done
+	buf.Reset()
+	assert.NoErr(t, lw.Done())
+	assert.Eq(t, "", buf.String())
+
+	// new line start
+	assert.NoErr(t, lw.Update("a\nb"))
+	assert.Eq(t, "\ra b", buf.String())
+}
+
+func TestLineRewriter_noTTY(t *testing.T) {
+	buf := new(bytes.Buffer)
+	lw := termenv.NewLineRewriter(buf)
+	assert.False(t, lw.IsTTY())
+
+	assert.NoErr(t, lw.Update("step 1"))
+	assert.NoErr(t, lw.Update("step 1"))
+	assert.NoErr(t, lw.Update("\rstep 2"))
+	assert.NoErr(t, lw.Done())
+	assert.Eq(t, "step 1\nstep 2\n", buf.String())
+
+	assert.NoErr(t, lw.Update("step 2"))
+	assert.Eq(t, "step 1\nstep 2\nstep 2\n", buf.String())
+}
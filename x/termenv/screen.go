@@ -0,0 +1,140 @@
+package termenv
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// screen control sequences
+const (
+	seqAltScreenEnter = "\x1b[?1049h"
+	seqAltScreenExit  = "\x1b[?1049l"
+	seqClearScreen    = "\x1b[2J\x1b[H"
+	seqClearToEnd     = "\x1b[0J"
+	seqClearLine      = "\x1b[2K\r"
+	seqClearLineToEnd = "\x1b[0K"
+	seqResetScroll    = "\x1b[r"
+	seqHideCursor     = "\x1b[?25l"
+	seqShowCursor     = "\x1b[?25h"
+	seqSaveCursor     = "\x1b7"
+	seqRestoreCursor  = "\x1b8"
+)
+
+// Screen provide the screen control methods, write the control sequences to the writer.
+//
+// If the writer is not a terminal or TERM=dumb, all methods will do nothing.
+// Can use SetEnabled() to change it.
+//
+// Usage:
+//
+//	s := termenv.NewScreen(os.Stdout)
+//	s.EnterAltScreen()
+//	defer s.ExitAltScreen()
+//	s.ClearScreen()
+type Screen struct {
+	w       io.Writer
+	enabled bool
+	// the enabled mouse tracking mode
+	mouseMode MouseMode
+}
+
+// NewScreen create a new Screen for the writer
+func NewScreen(w io.Writer) *Screen {
+	return &Screen{w: w, enabled: IsTerminalWriter(w) && os.Getenv("TERM") != "dumb"}
+}
+
+// IsTerminalWriter check the writer is a terminal. eg: os.Stdout
+func IsTerminalWriter(w io.Writer) bool {
+	if f, ok := w.(interface{ Fd() uintptr }); ok {
+		return isTerminalFd(f.Fd())
+	}
+	return false
+}
+
+// SetEnabled set enable or disable write the control sequences
+func (s *Screen) SetEnabled(enabled bool) *Screen {
+	s.enabled = enabled
+	return s
+}
+
+// Enabled check the screen control is enabled
+func (s *Screen) Enabled() bool { return s.enabled }
+
+// Writer get the output writer
+func (s *Screen) Writer() io.Writer { return s.w }
+
+func (s *Screen) write(seq string) error {
+	if !s.enabled {
+		return nil
+	}
+
+	_, err := io.WriteString(s.w, seq)
+	return err
+}
+
+// EnterAltScreen switch to the alternate screen buffer
+func (s *Screen) EnterAltScreen() error { return s.write(seqAltScreenEnter) }
+
+// ExitAltScreen switch back to the main screen buffer
+func (s *Screen) ExitAltScreen() error { return s.write(seqAltScreenExit) }
+
+// WithAltScreen run fn on the alternate screen buffer, then switch back.
+func (s *Screen) WithAltScreen(fn func() error) error {
+	if err := s.EnterAltScreen(); err != nil {
+		return err
+	}
+
+	err := fn()
+	if exErr := s.ExitAltScreen(); err == nil {
+		err = exErr
+	}
+	return err
+}
+
+// ClearScreen clear the whole screen and move cursor to top-left
+func (s *Screen) ClearScreen() error { return s.write(seqClearScreen) }
+
+// ClearToEnd clear from cursor to the end of screen
+func (s *Screen) ClearToEnd() error { return s.write(seqClearToEnd) }
+
+// ClearLine clear the current line and move cursor to line start
+func (s *Screen) ClearLine() error { return s.write(seqClearLine) }
+
+// ClearLineToEnd clear from cursor to the end of line
+func (s *Screen) ClearLineToEnd() error { return s.write(seqClearLineToEnd) }
+
+// SetScrollRegion set the scrolling region from top to bottom row(1-based, inclusive)
+func (s *Screen) SetScrollRegion(top, bottom int) error {
+	return s.write("\x1b[" + strconv.Itoa(top) + ";" + strconv.Itoa(bottom) + "r")
+}
+
+// ResetScrollRegion reset the scrolling region to the whole screen
+func (s *Screen) ResetScrollRegion() error { return s.write(seqResetScroll) }
+
+// ScrollUp scroll the content up n lines
+func (s *Screen) ScrollUp(n int) error {
+	return s.write("\x1b[" + strconv.Itoa(n) + "S")
+}
+
+// ScrollDown scroll the content down n lines
+func (s *Screen) ScrollDown(n int) error {
+	return s.write("\x1b[" + strconv.Itoa(n) + "T")
+}
+
+// MoveCursor move cursor to the row and col(1-based)
+func (s *Screen) MoveCursor(row, col int) error {
+	return s.write("\x1b[" + strconv.Itoa(row) + ";" + strconv.Itoa(col) + "H")
+}
+
+// HideCursor hide the cursor
+func (s *Screen) HideCursor() error { return s.write(seqHideCursor) }
+
+// ShowCursor show the cursor
+func (s *Screen) ShowCursor() error { return s.write(seqShowCursor) }
+
+// SaveCursor save the cursor position
+func (s *Screen) SaveCursor() error { return s.write(seqSaveCursor) }
+
+// RestoreCursor restore the saved cursor position
+func (s *Screen) RestoreCursor() error { return s.write(seqRestoreCursor) }
@@ -0,0 +1,63 @@
+package termenv_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestScreen_disabled(t *testing.T) {
+	buf := new(bytes.Buffer)
+	s := termenv.NewScreen(buf)
+	assert.False(t, s.Enabled())
+	assert.False(t, termenv.IsTerminalWriter(buf))
+
+	assert.NoErr(t, s.ClearScreen())
+	assert.NoErr(t, s.EnterAltScreen())
+	assert.Eq(t, "", buf.String())
+}
+
+func TestScreen_enabled(t *testing.T) {
+	buf := new(bytes.Buffer)
+	s := termenv.NewScreen(buf).SetEnabled(true)
+	assert.True(t, s.Enabled())
+	assert.Eq(t, buf, s.Writer())
+
+	tests := []struct {
+		fn   func() error
+		want string
+	}{
+		{s.EnterAltScreen, "\x1b[?1049h"},
+		{s.ExitAltScreen, "\x1b[?1049l"},
+		{s.ClearScreen, "\x1b[2J\x1b[H"},
+		{s.ClearToEnd, "\x1b[0J"},
+		{s.ClearLine, "\x1b[2K\r"},
+		{s.ClearLineToEnd, "\x1b[0K"},
+		{func() error { return s.SetScrollRegion(2, 10) }, "\x1b[2;10r"},
+		{s.ResetScrollRegion, "\x1b[r"},
+		{func() error { return s.ScrollUp(3) }, "\x1b[3S"},
+		{func() error { return s.ScrollDown(1) }, "\x1b[1T"},
+		{func() error { return s.MoveCursor(5, 8) }, "\x1b[5;8H"},
+		{s.HideCursor, "\x1b[?25l"},
+		{s.ShowCursor, "\x1b[?25h"},
+		{s.SaveCursor, "\x1b7"},
+		{s.RestoreCursor, "\x1b8"},
+	}
+
+	for _, tt := range tests {
+		buf.Reset()
+		assert.NoErr(t, tt.fn())
+		assert.Eq(t, tt.want, buf.String())
+	}
+
+	buf.Reset()
+	err := s.WithAltScreen(func() error {
+		buf.WriteString("hi")
+		return errors.New("fn error")
+	})
+	assert.ErrMsg(t, err, "fn error")
+	assert.Eq(t, "\x1b[?1049hhi\x1b[?1049l", buf.String())
+}
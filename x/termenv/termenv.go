@@ -0,0 +1,120 @@
+// Package termenv provide some terminal env detect and output util functions. eg: color level, restyle output
+package termenv
+
+import (
+	"os"
+	"sync"
+
+	"github.com/gookit/goutil/envutil"
+)
+
+// ColorLevel the color level supported by a terminal.
+type ColorLevel uint8
+
+// terminal color available levels
+const (
+	TermColorNone ColorLevel = iota // not support color
+	TermColor16                     // basic 3/4 bit color supported
+	TermColor256                    // 8-bit 256 color supported
+	TermColorTrue                   // 24-bit true color(RGB) supported
+)
+
+// String get level name
+func (l ColorLevel) String() string {
+	switch l {
+	case TermColor16:
+		return "basic"
+	case TermColor256:
+		return "256"
+	case TermColorTrue:
+		return "truecolor"
+	default:
+		return "none"
+	}
+}
+
+// NoColor check level is not support color
+func (l ColorLevel) NoColor() bool { return l == TermColorNone }
+
+var (
+	// mu guard the colorLevel, pinned and needVTP
+	mu sync.RWMutex
+	// once for the first detection
+	once sync.Once
+
+	colorLevel ColorLevel
+	// pinned the level is set by SetColorLevel()
+	pinned bool
+	// needVTP need enable the virtual terminal processing on Windows console
+	needVTP bool
+)
+
+func detectOnce() {
+	once.Do(func() {
+		level, vtp := detectEnvLevel(IsTerminal())
+
+		mu.Lock()
+		defer mu.Unlock()
+		// SetColorLevel() maybe called before the first detection
+		if !pinned {
+			colorLevel, needVTP = level, vtp
+		}
+	})
+}
+
+// get current level and need VTP, will detect on first call.
+func currentLevel() (level ColorLevel, vtp bool) {
+	detectOnce()
+
+	mu.RLock()
+	defer mu.RUnlock()
+	return colorLevel, needVTP
+}
+
+// TermColorLevel get current terminal color level, will detect on first call.
+func TermColorLevel() ColorLevel {
+	level, _ := currentLevel()
+	return level
+}
+
+// Redetect re-detect the color level by current env, and reset the level set by SetColorLevel().
+// useful for long-running processes and tests after the env changed.
+func Redetect() ColorLevel {
+	// mark the first detection done
+	once.Do(func() {})
+	level, vtp := detectEnvLevel(IsTerminal())
+
+	mu.Lock()
+	defer mu.Unlock()
+	colorLevel, needVTP, pinned = level, vtp, false
+	return level
+}
+
+// SetColorLevel pin the color level manually, the detection will be skipped.
+// It also applies to the per-writer detection, eg: NewWriter(), WriterColorLevel().
+//
+// call Redetect() for restore the detected level.
+func SetColorLevel(level ColorLevel) {
+	once.Do(func() {})
+
+	mu.Lock()
+	defer mu.Unlock()
+	colorLevel, needVTP, pinned = level, false, true
+}
+
+// NoColor current terminal not support color
+func NoColor() bool { return TermColorLevel() == TermColorNone }
+
+// SupportColor current terminal is support color
+func SupportColor() bool { return TermColorLevel() > TermColorNone }
+
+// Support256Color current terminal is support 256 color
+func Support256Color() bool { return TermColorLevel() >= TermColor256 }
+
+// SupportTrueColor current terminal is support true color(RGB)
+func SupportTrueColor() bool { return TermColorLevel() == TermColorTrue }
+
+// IsTerminal check os.Stdout is terminal
+func IsTerminal() bool { return isTerminalFd(os.Stdout.Fd()) }
+
+func isTerminalFd(fd uintptr) bool { return envutil.IsTerminal(fd) }
@@ -0,0 +1,149 @@
+package termenv_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestColorLevel_String(t *testing.T) {
+	assert.Eq(t, "none", termenv.TermColorNone.String())
+	assert.Eq(t, "basic", termenv.TermColor16.String())
+	assert.Eq(t, "256", termenv.TermColor256.String())
+	assert.Eq(t, "truecolor", termenv.TermColorTrue.String())
+	assert.True(t, termenv.TermColorNone.NoColor())
+}
+
+func TestDetectColorLevel(t *testing.T) {
+	testutil.MockEnvValues(map[string]string{
+		"NO_COLOR":    "",
+		"FORCE_COLOR": "3",
+	}, func() {
+		assert.Eq(t, termenv.TermColorTrue, termenv.DetectColorLevel())
+	})
+
+	testutil.MockEnvValues(map[string]string{
+		"NO_COLOR":    "",
+		"FORCE_COLOR": "2",
+	}, func() {
+		assert.Eq(t, termenv.TermColor256, termenv.DetectColorLevel())
+	})
+
+	testutil.MockEnvValues(map[string]string{
+		"NO_COLOR":    "1",
+		"FORCE_COLOR": "3",
+	}, func() {
+		assert.Eq(t, termenv.TermColorNone, termenv.DetectColorLevel())
+	})
+}
+
+func TestColorEnvOverride(t *testing.T) {
+	tests := []struct {
+		val  string
+		want termenv.ColorLevel
+	}{
+		{"none", termenv.TermColorNone},
+		{"16", termenv.TermColor16},
+		{"256", termenv.TermColor256},
+		{"TRUE", termenv.TermColorTrue},
+	}
+
+	for _, tt := range tests {
+		// GOUTIL_COLOR has higher priority than NO_COLOR, FORCE_COLOR
+		testutil.MockCleanOsEnv(map[string]string{
+			termenv.ColorEnvKey: tt.val,
+			"NO_COLOR":          "1",
+			"FORCE_COLOR":       "1",
+		}, func() {
+			assert.Eq(t, tt.want, termenv.DetectColorLevel())
+		})
+	}
+
+	// invalid value will be ignored
+	testutil.MockCleanOsEnv(map[string]string{termenv.ColorEnvKey: "invalid", "FORCE_COLOR": "2"}, func() {
+		assert.Eq(t, termenv.TermColor256, termenv.DetectColorLevel())
+	})
+
+	// FORCE_COLOR_LEVEL
+	testutil.MockCleanOsEnv(map[string]string{termenv.ForceLevelEnvKey: "256", "NO_COLOR": "1"}, func() {
+		assert.Eq(t, termenv.TermColor256, termenv.DetectColorLevel())
+		assert.Eq(t, termenv.TermColor256, termenv.WriterColorLevel(new(bytes.Buffer)))
+	})
+	testutil.MockCleanOsEnv(map[string]string{termenv.ColorEnvKey: "16", termenv.ForceLevelEnvKey: "true"}, func() {
+		assert.Eq(t, termenv.TermColor16, termenv.DetectColorLevel())
+	})
+}
+
+func TestParseColorLevel(t *testing.T) {
+	lv, ok := termenv.ParseColorLevel(" 24bit ")
+	assert.True(t, ok)
+	assert.Eq(t, termenv.TermColorTrue, lv)
+
+	lv, ok = termenv.ParseColorLevel("off")
+	assert.True(t, ok)
+	assert.Eq(t, termenv.TermColorNone, lv)
+
+	_, ok = termenv.ParseColorLevel("")
+	assert.False(t, ok)
+}
+
+func TestSetColorLevel(t *testing.T) {
+	defer termenv.Redetect()
+
+	termenv.SetColorLevel(termenv.TermColor256)
+	assert.Eq(t, termenv.TermColor256, termenv.TermColorLevel())
+	assert.True(t, termenv.Support256Color())
+	assert.False(t, termenv.SupportTrueColor())
+	assert.False(t, termenv.NeedVTP())
+
+	testutil.MockCleanOsEnv(map[string]string{"FORCE_COLOR": "3"}, func() {
+		// keep the level set manually
+		assert.Eq(t, termenv.TermColor256, termenv.TermColorLevel())
+
+		assert.Eq(t, termenv.TermColorTrue, termenv.Redetect())
+		assert.Eq(t, termenv.TermColorTrue, termenv.TermColorLevel())
+	})
+
+	testutil.MockCleanOsEnv(map[string]string{termenv.ColorEnvKey: "none"}, func() {
+		assert.Eq(t, termenv.TermColorNone, termenv.Redetect())
+		assert.True(t, termenv.NoColor())
+	})
+
+	// pinned level also applies to the writer
+	termenv.SetColorLevel(termenv.TermColor16)
+	testutil.MockCleanOsEnv(map[string]string{termenv.ColorEnvKey: "none"}, func() {
+		assert.Eq(t, termenv.TermColor16, termenv.DetectColorLevel())
+		assert.Eq(t, termenv.TermColor16, termenv.NewWriter(new(bytes.Buffer)).Level())
+	})
+}
+
+func TestSetColorLevel_concurrent(t *testing.T) {
+	defer termenv.Redetect()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			termenv.SetColorLevel(termenv.TermColor256)
+		}()
+		go func() {
+			defer wg.Done()
+			termenv.Redetect()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = termenv.TermColorLevel()
+			_ = termenv.NeedVTP()
+			_ = termenv.DetectColorLevel()
+		}()
+	}
+	wg.Wait()
+
+	termenv.SetColorLevel(termenv.TermColorTrue)
+	assert.Eq(t, termenv.TermColorTrue, termenv.TermColorLevel())
+}
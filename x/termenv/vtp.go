@@ -0,0 +1,18 @@
+package termenv
+
+import "os"
+
+// NeedVTP check current Windows console need enable the virtual terminal processing for render colors.
+func NeedVTP() bool {
+	_, vtp := currentLevel()
+	return vtp
+}
+
+// EnableVTPIfNeeded enable the virtual terminal processing for os.Stdout if NeedVTP() is true.
+// returns a func for restore the previous mode.
+func EnableVTPIfNeeded() (restore func(), err error) {
+	if !NeedVTP() {
+		return func() {}, nil
+	}
+	return EnableVirtualTerminal(os.Stdout.Fd())
+}
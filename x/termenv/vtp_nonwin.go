@@ -0,0 +1,10 @@
+//go:build !windows
+
+package termenv
+
+// EnableVirtualTerminal enable the virtual terminal processing mode for the Windows console.
+//
+// NOTE: on non-Windows system, the terminal always support ANSI sequences, will do nothing.
+func EnableVirtualTerminal(_ uintptr) (restore func(), err error) {
+	return func() {}, nil
+}
@@ -0,0 +1,27 @@
+package termenv_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gookit/goutil/envutil"
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestEnableVirtualTerminal(t *testing.T) {
+	if envutil.IsWin() {
+		t.Skip("skip test on Windows")
+		return
+	}
+
+	restore, err := termenv.EnableVirtualTerminal(os.Stdout.Fd())
+	assert.NoErr(t, err)
+	assert.NotNil(t, restore)
+	restore()
+
+	assert.False(t, termenv.NeedVTP())
+	restore, err = termenv.EnableVTPIfNeeded()
+	assert.NoErr(t, err)
+	restore()
+}
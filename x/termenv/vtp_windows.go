@@ -0,0 +1,38 @@
+//go:build windows
+
+package termenv
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// EnableVirtualTerminal enable the ENABLE_VIRTUAL_TERMINAL_PROCESSING mode for the Windows console,
+// then console can render the ANSI escape sequences. returns a func for restore the previous mode.
+//
+// Usage:
+//
+//	restore, err := termenv.EnableVirtualTerminal(os.Stdout.Fd())
+//	if err == nil {
+//		defer restore()
+//	}
+func EnableVirtualTerminal(fd uintptr) (restore func(), err error) {
+	handle := windows.Handle(fd)
+
+	var oldMode uint32
+	if err = windows.GetConsoleMode(handle, &oldMode); err != nil {
+		return func() {}, err
+	}
+
+	if oldMode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return func() {}, nil
+	}
+
+	newMode := oldMode | windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING
+	if err = windows.SetConsoleMode(handle, newMode); err != nil {
+		return func() {}, err
+	}
+
+	return func() {
+		_ = windows.SetConsoleMode(handle, oldMode)
+	}, nil
+}
@@ -0,0 +1,27 @@
+package termenv
+
+import "io"
+
+// NewWriter create a color level aware writer, the level is detected for w by WriterColorLevel().
+// The color sequences written through it will be downgraded or stripped automatically.
+//
+//   - terminal: keep or downgrade the colors by the terminal color level.
+//   - pipe, file, buffer: strip all escape sequences.
+//
+// Usage:
+//
+//	w := termenv.NewWriter(os.Stderr)
+//	fmt.Fprintln(w, "\x1b[38;2;255;100;0mWARN\x1b[0m something")
+func NewWriter(w io.Writer) *RestyleWriter {
+	level, needVTP := detectLevelWith(IsTerminalWriter(w))
+
+	// Windows console: enable the VTP for render colors, strip colors on fail.
+	if needVTP {
+		if f, ok := w.(interface{ Fd() uintptr }); ok {
+			if _, err := EnableVirtualTerminal(f.Fd()); err != nil {
+				level = TermColorNone
+			}
+		}
+	}
+	return NewRestyleWriter(w, level)
+}
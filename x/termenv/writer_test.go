@@ -0,0 +1,40 @@
+package termenv_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/gookit/goutil/testutil"
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/gookit/goutil/x/termenv"
+)
+
+func TestNewWriter(t *testing.T) {
+	styled := "\x1b[38;2;255;0;0mred\x1b[0m text"
+
+	testutil.MockCleanOsEnv(map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, func() {
+		buf := new(bytes.Buffer)
+		assert.Eq(t, termenv.TermColorNone, termenv.WriterColorLevel(buf))
+
+		// buffer is not terminal, will strip colors
+		w := termenv.NewWriter(buf)
+		assert.Eq(t, termenv.TermColorNone, w.Level())
+		_, err := fmt.Fprint(w, styled)
+		assert.NoErr(t, err)
+		assert.Eq(t, "red text", buf.String())
+	})
+
+	testutil.MockCleanOsEnv(map[string]string{"FORCE_COLOR": "1"}, func() {
+		buf := new(bytes.Buffer)
+		w := termenv.NewWriter(buf)
+		assert.Eq(t, termenv.TermColor16, w.Level())
+		_, err := fmt.Fprint(w, styled)
+		assert.NoErr(t, err)
+		assert.Eq(t, "\x1b[91mred\x1b[0m text", buf.String())
+	})
+
+	testutil.MockCleanOsEnv(map[string]string{"FORCE_COLOR": "3", "NO_COLOR": "1"}, func() {
+		assert.Eq(t, termenv.TermColorNone, termenv.WriterColorLevel(new(bytes.Buffer)))
+	})
+}